@@ -115,6 +115,10 @@ type GatewayRouteConditionType string
 const (
 	// GatewayRouteActive is True when the AppMesh GatewayRoute has been created or found via the API
 	GatewayRouteActive GatewayRouteConditionType = "GatewayRouteActive"
+	// GatewayRouteReconciliationPaused is True when reconciliation is paused via the appmesh.k8s.aws/paused annotation
+	GatewayRouteReconciliationPaused GatewayRouteConditionType = "ReconciliationPaused"
+	// GatewayRouteWaitingForMesh is True while reconciliation waits for the Mesh to become active
+	GatewayRouteWaitingForMesh GatewayRouteConditionType = "WaitingForMesh"
 )
 
 type GatewayRouteCondition struct {
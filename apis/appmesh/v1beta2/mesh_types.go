@@ -42,6 +42,8 @@ type MeshConditionType string
 const (
 	// MeshActive is True when the AppMesh Mesh has been created or found via the API
 	MeshActive MeshConditionType = "MeshActive"
+	// MeshReconciliationPaused is True when reconciliation is paused via the appmesh.k8s.aws/paused annotation
+	MeshReconciliationPaused MeshConditionType = "ReconciliationPaused"
 )
 
 type MeshCondition struct {
@@ -60,6 +62,14 @@ type MeshCondition struct {
 	Message *string `json:"message,omitempty"`
 }
 
+// VirtualGatewayDefaults refers to the defaults applied to virtualGateways in a mesh.
+type VirtualGatewayDefaults struct {
+	// ConnectionPool is applied to virtualGateway listeners that don't specify their own connectionPool.
+	// Settings for each of http, http2 and grpc can be specified, a listener gets the ones matching its protocol.
+	// +optional
+	ConnectionPool *VirtualGatewayConnectionPool `json:"connectionPool,omitempty"`
+}
+
 // MeshSpec defines the desired state of Mesh
 // refers to https://docs.aws.amazon.com/app-mesh/latest/APIReference/API_MeshSpec.html
 type MeshSpec struct {
@@ -81,6 +91,14 @@ type MeshSpec struct {
 	// Required if the account ID is not your own.
 	// +optional
 	MeshOwner *string `json:"meshOwner,omitempty"`
+	// BackendDefaults are applied to virtualNodes in this mesh that don't specify their own backendDefaults.
+	// Only clientPolicy is supported, a virtualNode's own backendDefaults clientPolicy takes precedence.
+	// +optional
+	BackendDefaults *BackendDefaults `json:"backendDefaults,omitempty"`
+	// VirtualGatewayDefaults are applied to virtualGateways in this mesh.
+	// Only connectionPool is supported, a virtualGateway listener's own connectionPool takes precedence.
+	// +optional
+	VirtualGatewayDefaults *VirtualGatewayDefaults `json:"virtualGatewayDefaults,omitempty"`
 }
 
 // MeshStatus defines the observed state of Mesh
@@ -26,6 +26,10 @@ type VirtualGatewayConditionType string
 const (
 	// VirtualGatewayActive is True when the AppMesh VirtualGateway has been created or found via the API
 	VirtualGatewayActive VirtualGatewayConditionType = "VirtualGatewayActive"
+	// VirtualGatewayReconciliationPaused is True when reconciliation is paused via the appmesh.k8s.aws/paused annotation
+	VirtualGatewayReconciliationPaused VirtualGatewayConditionType = "ReconciliationPaused"
+	// VirtualGatewayWaitingForMesh is True while reconciliation waits for the Mesh to become active
+	VirtualGatewayWaitingForMesh VirtualGatewayConditionType = "WaitingForMesh"
 )
 
 // +kubebuilder:validation:Enum=grpc;http;http2
@@ -78,9 +82,11 @@ type VirtualGatewayHealthCheckPolicy struct {
 	// +optional
 	Path *string `json:"path,omitempty"`
 	// The destination port for the health check request.
+	// It must match the port of the listener it belongs to.
 	// +optional
 	Port *PortNumber `json:"port,omitempty"`
-	// The protocol for the health check request
+	// The protocol for the health check request, one of http, http2 or grpc.
+	// It must be compatible with the listener protocol when the health check targets the listener port.
 	Protocol VirtualGatewayPortProtocol `json:"protocol"`
 	// The amount of time to wait when receiving a response from the health check, in milliseconds.
 	// +kubebuilder:validation:Minimum=2000
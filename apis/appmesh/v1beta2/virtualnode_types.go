@@ -138,6 +138,7 @@ type HealthCheckPolicy struct {
 	// +optional
 	Path *string `json:"path,omitempty"`
 	// The destination port for the health check request.
+	// It must match the port of the listener it belongs to.
 	// +optional
 	Port *PortNumber `json:"port,omitempty"`
 	// The protocol for the health check request
@@ -315,9 +316,17 @@ type AWSCloudMapInstanceAttribute struct {
 // AWSCloudMapServiceDiscovery refers to https://docs.aws.amazon.com/app-mesh/latest/APIReference/API_AwsCloudMapServiceDiscovery.html
 type AWSCloudMapServiceDiscovery struct {
 	// The name of the AWS Cloud Map namespace to use.
+	// Either namespaceName or namespaceID must be specified.
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=1024
-	NamespaceName string `json:"namespaceName"`
+	// +optional
+	NamespaceName string `json:"namespaceName,omitempty"`
+	// The ID of the AWS Cloud Map namespace to use.
+	// If namespaceName is specified as well, both must refer to the same namespace.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	NamespaceID string `json:"namespaceID,omitempty"`
 	// The name of the AWS Cloud Map service to use.
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=1024
@@ -325,6 +334,20 @@ type AWSCloudMapServiceDiscovery struct {
 	// A string map that contains attributes with values that you can use to filter instances by any custom attribute that you specified when you registered the instance
 	// +optional
 	Attributes []AWSCloudMapInstanceAttribute `json:"attributes,omitempty"`
+	// The health check configuration of the AWS Cloud Map service, used when the controller creates the service.
+	// If unspecified, the controller's default is used.
+	// +optional
+	HealthCheckConfig *AWSCloudMapHealthCheckConfig `json:"healthCheckConfig,omitempty"`
+}
+
+// AWSCloudMapHealthCheckConfig refers to https://docs.aws.amazon.com/cloud-map/latest/api/API_HealthCheckCustomConfig.html
+// Instances health status is updated by the controller based on pod readiness.
+type AWSCloudMapHealthCheckConfig struct {
+	// The number of 30-second intervals that AWS Cloud Map waits after an instance health status update
+	// before changing the health status of the instance.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	FailureThreshold int64 `json:"failureThreshold"`
 }
 
 // DNSServiceDiscovery refers to https://docs.aws.amazon.com/app-mesh/latest/APIReference/API_DnsServiceDiscovery.html
@@ -358,11 +381,19 @@ type AccessLog struct {
 	File *FileAccessLog `json:"file,omitempty"`
 }
 
+// EnvoyLogLevel is the log level of Envoy.
+// +kubebuilder:validation:Enum=trace;debug;info;warning;error;critical;off
+type EnvoyLogLevel string
+
 // Logging refers to https://docs.aws.amazon.com/app-mesh/latest/APIReference/API_Logging.html
 type Logging struct {
 	// The access log configuration for a virtual node.
 	// +optional
 	AccessLog *AccessLog `json:"accessLog,omitempty"`
+	// The log level of the Envoy sidecars injected into pods of the virtual node.
+	// It isn't sent to App Mesh, and defaults to the sidecar log level of the controller.
+	// +optional
+	EnvoyLogLevel *EnvoyLogLevel `json:"envoyLogLevel,omitempty"`
 }
 
 type VirtualNodeConditionType string
@@ -370,6 +401,14 @@ type VirtualNodeConditionType string
 const (
 	// VirtualNodeActive is True when the AppMesh VirtualNode has been created or found via the API
 	VirtualNodeActive VirtualNodeConditionType = "VirtualNodeActive"
+	// VirtualNodeReconciliationPaused is True when reconciliation is paused via the appmesh.k8s.aws/paused annotation
+	VirtualNodeReconciliationPaused VirtualNodeConditionType = "ReconciliationPaused"
+	// VirtualNodeWaitingForMesh is True while reconciliation waits for the Mesh to become active
+	VirtualNodeWaitingForMesh VirtualNodeConditionType = "WaitingForMesh"
+	// VirtualNodeExternallyDeleted is True when the AppMesh VirtualNode got deleted outside of the controller and isn't recreated
+	VirtualNodeExternallyDeleted VirtualNodeConditionType = "ExternallyDeleted"
+	// VirtualNodeCloudMapReconciled is True when the CloudMap resources for VirtualNode are reconciled
+	VirtualNodeCloudMapReconciled VirtualNodeConditionType = "CloudMapReconciled"
 )
 
 type VirtualNodeCondition struct {
@@ -434,6 +473,9 @@ type VirtualNodeStatus struct {
 	// VirtualNodeARN is the AppMesh VirtualNode object's Amazon Resource Name
 	// +optional
 	VirtualNodeARN *string `json:"virtualNodeARN,omitempty"`
+	// CloudMapNamespaceName is the name of the CloudMap namespace resolved for the namespaceID of AWSCloudMap service discovery.
+	// +optional
+	CloudMapNamespaceName *string `json:"cloudMapNamespaceName,omitempty"`
 	// The current VirtualNode status.
 	// +optional
 	Conditions []VirtualNodeCondition `json:"conditions,omitempty"`
@@ -224,6 +224,7 @@ type GRPCRouteMatch struct {
 	// +optional
 	MethodName *string `json:"methodName,omitempty"`
 	// The fully qualified domain name for the service to match from the request.
+	// If you omit methodName, requests for all methods of the service are matched.
 	// +optional
 	ServiceName *string `json:"serviceName,omitempty"`
 	// An object that represents the data to match from the request.
@@ -304,6 +305,10 @@ type VirtualRouterConditionType string
 const (
 	// VirtualRouterActive is True when the AppMesh VirtualRouter has been created or found via the API
 	VirtualRouterActive VirtualRouterConditionType = "VirtualRouterActive"
+	// VirtualRouterReconciliationPaused is True when reconciliation is paused via the appmesh.k8s.aws/paused annotation
+	VirtualRouterReconciliationPaused VirtualRouterConditionType = "ReconciliationPaused"
+	// VirtualRouterWaitingForMesh is True while reconciliation waits for the Mesh to become active
+	VirtualRouterWaitingForMesh VirtualRouterConditionType = "WaitingForMesh"
 )
 
 type VirtualRouterCondition struct {
@@ -56,6 +56,10 @@ type VirtualServiceConditionType string
 const (
 	// VirtualServiceActive is True when the AppMesh VirtualService has been created or found via the API
 	VirtualServiceActive VirtualServiceConditionType = "VirtualServiceActive"
+	// VirtualServiceReconciliationPaused is True when reconciliation is paused via the appmesh.k8s.aws/paused annotation
+	VirtualServiceReconciliationPaused VirtualServiceConditionType = "ReconciliationPaused"
+	// VirtualServiceWaitingForMesh is True while reconciliation waits for the Mesh to become active
+	VirtualServiceWaitingForMesh VirtualServiceConditionType = "WaitingForMesh"
 )
 
 type VirtualServiceCondition struct {
@@ -25,6 +25,21 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSCloudMapHealthCheckConfig) DeepCopyInto(out *AWSCloudMapHealthCheckConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSCloudMapHealthCheckConfig.
+func (in *AWSCloudMapHealthCheckConfig) DeepCopy() *AWSCloudMapHealthCheckConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSCloudMapHealthCheckConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSCloudMapInstanceAttribute) DeepCopyInto(out *AWSCloudMapInstanceAttribute) {
 	*out = *in
@@ -48,6 +63,11 @@ func (in *AWSCloudMapServiceDiscovery) DeepCopyInto(out *AWSCloudMapServiceDisco
 		*out = make([]AWSCloudMapInstanceAttribute, len(*in))
 		copy(*out, *in)
 	}
+	if in.HealthCheckConfig != nil {
+		in, out := &in.HealthCheckConfig, &out.HealthCheckConfig
+		*out = new(AWSCloudMapHealthCheckConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSCloudMapServiceDiscovery.
@@ -1269,6 +1289,11 @@ func (in *Logging) DeepCopyInto(out *Logging) {
 		*out = new(AccessLog)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EnvoyLogLevel != nil {
+		in, out := &in.EnvoyLogLevel, &out.EnvoyLogLevel
+		*out = new(EnvoyLogLevel)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logging.
@@ -1422,6 +1447,16 @@ func (in *MeshSpec) DeepCopyInto(out *MeshSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.BackendDefaults != nil {
+		in, out := &in.BackendDefaults, &out.BackendDefaults
+		*out = new(BackendDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VirtualGatewayDefaults != nil {
+		in, out := &in.VirtualGatewayDefaults, &out.VirtualGatewayDefaults
+		*out = new(VirtualGatewayDefaults)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshSpec.
@@ -1995,6 +2030,26 @@ func (in *VirtualGatewayConnectionPool) DeepCopy() *VirtualGatewayConnectionPool
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualGatewayDefaults) DeepCopyInto(out *VirtualGatewayDefaults) {
+	*out = *in
+	if in.ConnectionPool != nil {
+		in, out := &in.ConnectionPool, &out.ConnectionPool
+		*out = new(VirtualGatewayConnectionPool)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualGatewayDefaults.
+func (in *VirtualGatewayDefaults) DeepCopy() *VirtualGatewayDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualGatewayDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualGatewayFileAccessLog) DeepCopyInto(out *VirtualGatewayFileAccessLog) {
 	*out = *in
@@ -2735,6 +2790,11 @@ func (in *VirtualNodeStatus) DeepCopyInto(out *VirtualNodeStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.CloudMapNamespaceName != nil {
+		in, out := &in.CloudMapNamespaceName, &out.CloudMapNamespaceName
+		*out = new(string)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]VirtualNodeCondition, len(*in))
@@ -24,13 +24,13 @@ import (
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 )
 
 // CloudMapReconciler reconciles a VirtualNode pod instance to CloudMap Service
 type cloudMapReconciler struct {
 	k8sClient                   client.Client
+	cfg                         Config
 	log                         logr.Logger
 	finalizerManager            k8s.FinalizerManager
 	cloudMapResourceManager     cloudmap.ResourceManager
@@ -44,25 +44,28 @@ func NewCloudMapReconciler(
 	finalizerManager k8s.FinalizerManager,
 	cloudMapResourceManager cloudmap.ResourceManager,
 	podEventNotificationChan <-chan k8s.GenericEvent,
+	cfg Config,
+	cloudMapConfig cloudmap.Config,
 	log logr.Logger) *cloudMapReconciler {
 	return &cloudMapReconciler{
 		k8sClient:                   k8sClient,
+		cfg:                         cfg,
 		log:                         log,
 		finalizerManager:            finalizerManager,
 		cloudMapResourceManager:     cloudMapResourceManager,
-		enqueueRequestsForPodEvents: cloudmap.NewEnqueueRequestsForPodEvents(k8sClient, log),
+		enqueueRequestsForPodEvents: cloudmap.NewEnqueueRequestsForPodEvents(k8sClient, cloudMapConfig.PodAnnotationAttributes, log),
 		podEventNotificationChan:    podEventNotificationChan,
 	}
 }
 
 // +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=virtualnodes,verbs=get;list;watch
-// +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=virtualnodes/status,verbs=get
+// +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=virtualnodes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 
 func (r *cloudMapReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.log)
+	return runtime.HandleReconcileError(r.reconcile(req), r.log.WithValues("virtualNode", req.NamespacedName))
 }
 
 func (r *cloudMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -71,7 +74,7 @@ func (r *cloudMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Named("cloudMap").
 		For(&appmesh.VirtualNode{}).
 		Watches(&k8s.NotificationChannel{Source: r.podEventNotificationChan}, r.enqueueRequestsForPodEvents).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 3}).
+		WithOptions(r.cfg.controllerOptions()).
 		Complete(r)
 }
 
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	flagMaxConcurrentReconciles    = "max-concurrent-reconciles"
+	defaultMaxConcurrentReconciles = 3
+)
+
+type Config struct {
+	// MaxConcurrentReconciles is the number of reconcile workers for each App Mesh resource controller.
+	MaxConcurrentReconciles int
+}
+
+func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&cfg.MaxConcurrentReconciles, flagMaxConcurrentReconciles, defaultMaxConcurrentReconciles,
+		"Maximum number of concurrent reconciles for each App Mesh resource controller")
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.MaxConcurrentReconciles < 1 {
+		return errors.Errorf("%s must be at least 1, got %d", flagMaxConcurrentReconciles, cfg.MaxConcurrentReconciles)
+	}
+	return nil
+}
+
+// controllerOptions returns the options for the controllers built with this config.
+func (cfg *Config) controllerOptions() controller.Options {
+	return controller.Options{MaxConcurrentReconciles: cfg.MaxConcurrentReconciles}
+}
@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/cloudmap"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+func TestConfig_BindFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantOptions controller.Options
+	}{
+		{
+			name:        "default concurrency",
+			args:        []string{},
+			wantOptions: controller.Options{MaxConcurrentReconciles: 3},
+		},
+		{
+			name:        "configured concurrency",
+			args:        []string{"--max-concurrent-reconciles=10"},
+			wantOptions: controller.Options{MaxConcurrentReconciles: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{}
+			fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+			cfg.BindFlags(fs)
+			assert.NoError(t, fs.Parse(tt.args))
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.wantOptions, cfg.controllerOptions())
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{
+			name: "single worker",
+			cfg:  Config{MaxConcurrentReconciles: 1},
+		},
+		{
+			name:    "zero workers",
+			cfg:     Config{MaxConcurrentReconciles: 0},
+			wantErr: errors.New("max-concurrent-reconciles must be at least 1, got 0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// fakeManager records the controllers added by SetupWithManager without starting them.
+type fakeManager struct {
+	manager.Manager
+	scheme    *runtime.Scheme
+	runnables []manager.Runnable
+}
+
+func (m *fakeManager) GetScheme() *runtime.Scheme {
+	return m.scheme
+}
+
+func (m *fakeManager) GetConfig() *rest.Config {
+	return &rest.Config{}
+}
+
+func (m *fakeManager) GetClient() client.Client {
+	return nil
+}
+
+func (m *fakeManager) GetCache() cache.Cache {
+	return nil
+}
+
+func (m *fakeManager) GetEventRecorderFor(_ string) record.EventRecorder {
+	return record.NewFakeRecorder(0)
+}
+
+func (m *fakeManager) SetFields(_ interface{}) error {
+	return nil
+}
+
+func (m *fakeManager) Add(runnable manager.Runnable) error {
+	m.runnables = append(m.runnables, runnable)
+	return nil
+}
+
+// fakeObjectReferenceIndexer accepts any index.
+type fakeObjectReferenceIndexer struct {
+	references.ObjectReferenceIndexer
+}
+
+func (i *fakeObjectReferenceIndexer) Setup(_ runtime.Object, _ map[string]references.ObjectReferenceIndexFunc) error {
+	return nil
+}
+
+func Test_reconcilers_SetupWithManager(t *testing.T) {
+	cfg := Config{MaxConcurrentReconciles: 7}
+	tests := []struct {
+		name       string
+		reconciler interface{ SetupWithManager(mgr ctrl.Manager) error }
+	}{
+		{
+			name:       "mesh",
+			reconciler: NewMeshReconciler(nil, nil, nil, nil, cfg, &log.NullLogger{}),
+		},
+		{
+			name:       "virtualGateway",
+			reconciler: NewVirtualGatewayReconciler(nil, nil, nil, nil, cfg, &log.NullLogger{}),
+		},
+		{
+			name:       "gatewayRoute",
+			reconciler: NewGatewayRouteReconciler(nil, nil, nil, cfg, &log.NullLogger{}),
+		},
+		{
+			name:       "virtualNode",
+			reconciler: NewVirtualNodeReconciler(nil, nil, nil, cfg, virtualnode.Config{}, &log.NullLogger{}),
+		},
+		{
+			name:       "cloudMap",
+			reconciler: NewCloudMapReconciler(nil, nil, nil, nil, cfg, cloudmap.Config{}, &log.NullLogger{}),
+		},
+		{
+			name:       "virtualService",
+			reconciler: NewVirtualServiceReconciler(nil, nil, &fakeObjectReferenceIndexer{}, nil, cfg, &log.NullLogger{}),
+		},
+		{
+			name:       "virtualRouter",
+			reconciler: NewVirtualRouterReconciler(nil, nil, &fakeObjectReferenceIndexer{}, nil, cfg, &log.NullLogger{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			mgr := &fakeManager{scheme: k8sSchema}
+			err := tt.reconciler.SetupWithManager(mgr)
+			assert.NoError(t, err)
+			if assert.Len(t, mgr.runnables, 1) {
+				// the controller built by controller-runtime is internal, only its exported fields can be inspected.
+				gotMaxConcurrentReconciles := reflect.ValueOf(mgr.runnables[0]).Elem().FieldByName("MaxConcurrentReconciles").Int()
+				assert.Equal(t, int64(cfg.MaxConcurrentReconciles), gotMaxConcurrentReconciles)
+			}
+		})
+	}
+}
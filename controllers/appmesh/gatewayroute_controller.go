@@ -24,7 +24,6 @@ import (
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -36,6 +35,7 @@ func NewGatewayRouteReconciler(
 	k8sClient client.Client,
 	finalizerManager k8s.FinalizerManager,
 	grResManager gatewayroute.ResourceManager,
+	cfg Config,
 	log logr.Logger) *gatewayRouteReconciler {
 	return &gatewayRouteReconciler{
 		k8sClient:                              k8sClient,
@@ -43,6 +43,7 @@ func NewGatewayRouteReconciler(
 		grResManager:                           grResManager,
 		enqueueRequestsForMeshEvents:           gatewayroute.NewEnqueueRequestsForMeshEvents(k8sClient, log),
 		enqueueRequestsForVirtualGatewayEvents: gatewayroute.NewEnqueueRequestsForVirtualGatewayEvents(k8sClient, log),
+		cfg:                                    cfg,
 		log:                                    log,
 	}
 }
@@ -55,6 +56,7 @@ type gatewayRouteReconciler struct {
 
 	enqueueRequestsForMeshEvents           handler.EventHandler
 	enqueueRequestsForVirtualGatewayEvents handler.EventHandler
+	cfg                                    Config
 	log                                    logr.Logger
 }
 
@@ -62,7 +64,7 @@ type gatewayRouteReconciler struct {
 // +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=gatewayroutes/status,verbs=get;update;patch
 
 func (r *gatewayRouteReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.log)
+	return runtime.HandleReconcileError(r.reconcile(req), r.log.WithValues("gatewayRoute", req.NamespacedName))
 }
 
 func (r *gatewayRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -70,7 +72,7 @@ func (r *gatewayRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&appmesh.GatewayRoute{}).
 		Watches(&source.Kind{Type: &appmesh.Mesh{}}, r.enqueueRequestsForMeshEvents).
 		Watches(&source.Kind{Type: &appmesh.VirtualGateway{}}, r.enqueueRequestsForVirtualGatewayEvents).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 3}).
+		WithOptions(r.cfg.controllerOptions()).
 		Complete(r)
 }
 
@@ -33,12 +33,14 @@ func NewMeshReconciler(
 	finalizerManager k8s.FinalizerManager,
 	meshMembersFinalizer mesh.MembersFinalizer,
 	meshResManager mesh.ResourceManager,
+	cfg Config,
 	log logr.Logger) *meshReconciler {
 	return &meshReconciler{
 		k8sClient:            k8sClient,
 		finalizerManager:     finalizerManager,
 		meshMembersFinalizer: meshMembersFinalizer,
 		meshResManager:       meshResManager,
+		cfg:                  cfg,
 		log:                  log,
 	}
 }
@@ -49,6 +51,7 @@ type meshReconciler struct {
 	finalizerManager     k8s.FinalizerManager
 	meshMembersFinalizer mesh.MembersFinalizer
 	meshResManager       mesh.ResourceManager
+	cfg                  Config
 	log                  logr.Logger
 }
 
@@ -56,12 +59,13 @@ type meshReconciler struct {
 // +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=meshes/status,verbs=get;update;patch
 
 func (r *meshReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.log)
+	return runtime.HandleReconcileError(r.reconcile(req), r.log.WithValues("mesh", req.NamespacedName))
 }
 
 func (r *meshReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&appmesh.Mesh{}).
+		WithOptions(r.cfg.controllerOptions()).
 		Complete(r)
 }
 
@@ -24,7 +24,6 @@ import (
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -37,6 +36,7 @@ func NewVirtualGatewayReconciler(
 	finalizerManager k8s.FinalizerManager,
 	vgMembersFinalizer virtualgateway.MembersFinalizer,
 	vgResManager virtualgateway.ResourceManager,
+	cfg Config,
 	log logr.Logger) *virtualGatewayReconciler {
 	return &virtualGatewayReconciler{
 		k8sClient:                    k8sClient,
@@ -44,6 +44,7 @@ func NewVirtualGatewayReconciler(
 		vgMembersFinalizer:           vgMembersFinalizer,
 		vgResManager:                 vgResManager,
 		enqueueRequestsForMeshEvents: virtualgateway.NewEnqueueRequestsForMeshEvents(k8sClient, log),
+		cfg:                          cfg,
 		log:                          log,
 	}
 }
@@ -56,6 +57,7 @@ type virtualGatewayReconciler struct {
 	vgResManager       virtualgateway.ResourceManager
 
 	enqueueRequestsForMeshEvents handler.EventHandler
+	cfg                          Config
 	log                          logr.Logger
 }
 
@@ -63,14 +65,14 @@ type virtualGatewayReconciler struct {
 // +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=virtualgateways/status,verbs=get;update;patch
 
 func (r *virtualGatewayReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.log)
+	return runtime.HandleReconcileError(r.reconcile(req), r.log.WithValues("virtualGateway", req.NamespacedName))
 }
 
 func (r *virtualGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&appmesh.VirtualGateway{}).
 		Watches(&source.Kind{Type: &appmesh.Mesh{}}, r.enqueueRequestsForMeshEvents).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 3}).
+		WithOptions(r.cfg.controllerOptions()).
 		Complete(r)
 }
 
@@ -18,9 +18,9 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -28,13 +28,17 @@ import (
 )
 
 // NewVirtualNodeReconciler constructs new virtualNodeReconciler
-func NewVirtualNodeReconciler(k8sClient client.Client, finalizerManager k8s.FinalizerManager, vnResManager virtualnode.ResourceManager, log logr.Logger) *virtualNodeReconciler {
+func NewVirtualNodeReconciler(k8sClient client.Client, finalizerManager k8s.FinalizerManager, vnResManager virtualnode.ResourceManager, cfg Config, vnConfig virtualnode.Config, log logr.Logger) *virtualNodeReconciler {
 	return &virtualNodeReconciler{
-		k8sClient:                    k8sClient,
-		finalizerManager:             finalizerManager,
-		vnResManager:                 vnResManager,
-		enqueueRequestsForMeshEvents: virtualnode.NewEnqueueRequestsForMeshEvents(k8sClient, log),
-		log:                          log,
+		k8sClient:                       k8sClient,
+		finalizerManager:                finalizerManager,
+		vnResManager:                    vnResManager,
+		enqueueRequestsForMeshEvents:    virtualnode.NewEnqueueRequestsForMeshEvents(k8sClient, log),
+		enqueueRequestsForPodEvents:     virtualnode.NewEnqueueRequestsForPodEvents(k8sClient, log),
+		enqueueRequestsForServiceEvents: virtualnode.NewEnqueueRequestsForServiceEvents(k8sClient, log),
+		cfg:                             cfg,
+		vnConfig:                        vnConfig,
+		log:                             log,
 	}
 }
 
@@ -44,22 +48,35 @@ type virtualNodeReconciler struct {
 	finalizerManager k8s.FinalizerManager
 	vnResManager     virtualnode.ResourceManager
 
-	enqueueRequestsForMeshEvents handler.EventHandler
-	log                          logr.Logger
+	enqueueRequestsForMeshEvents    handler.EventHandler
+	enqueueRequestsForPodEvents     handler.EventHandler
+	enqueueRequestsForServiceEvents handler.EventHandler
+	cfg                             Config
+	vnConfig                        virtualnode.Config
+	log                             logr.Logger
 }
 
 // +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=virtualnodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=virtualnodes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 
 func (r *virtualNodeReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.log)
+	return runtime.HandleReconcileError(r.reconcile(req), r.log.WithValues("virtualNode", req.NamespacedName))
 }
 
 func (r *virtualNodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&appmesh.VirtualNode{}).
 		Watches(&source.Kind{Type: &appmesh.Mesh{}}, r.enqueueRequestsForMeshEvents).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 3}).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, r.enqueueRequestsForPodEvents)
+	// services are only watched when VirtualNodes track deletion of their k8s Service.
+	if r.vnConfig.IsServiceDeletionCheckEnabled() {
+		builder = builder.Watches(&source.Kind{Type: &corev1.Service{}}, r.enqueueRequestsForServiceEvents)
+	}
+	return builder.
+		WithOptions(r.cfg.controllerOptions()).
 		Complete(r)
 }
 
@@ -22,7 +22,6 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualrouter"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -34,7 +33,7 @@ import (
 )
 
 // NewVirtualRouterReconciler constructs new virtualRouterReconciler
-func NewVirtualRouterReconciler(k8sClient client.Client, finalizerManager k8s.FinalizerManager, referencesIndexer references.ObjectReferenceIndexer, vrResManager virtualrouter.ResourceManager, log logr.Logger) *virtualRouterReconciler {
+func NewVirtualRouterReconciler(k8sClient client.Client, finalizerManager k8s.FinalizerManager, referencesIndexer references.ObjectReferenceIndexer, vrResManager virtualrouter.ResourceManager, cfg Config, log logr.Logger) *virtualRouterReconciler {
 	return &virtualRouterReconciler{
 		k8sClient:                           k8sClient,
 		finalizerManager:                    finalizerManager,
@@ -42,6 +41,7 @@ func NewVirtualRouterReconciler(k8sClient client.Client, finalizerManager k8s.Fi
 		vrResManager:                        vrResManager,
 		enqueueRequestsForMeshEvents:        virtualrouter.NewEnqueueRequestsForMeshEvents(k8sClient, log),
 		enqueueRequestsForVirtualNodeEvents: virtualrouter.NewEnqueueRequestsForVirtualNodeEvents(referencesIndexer, log),
+		cfg:                                 cfg,
 		log:                                 log,
 	}
 }
@@ -55,6 +55,7 @@ type virtualRouterReconciler struct {
 
 	enqueueRequestsForMeshEvents        handler.EventHandler
 	enqueueRequestsForVirtualNodeEvents handler.EventHandler
+	cfg                                 Config
 	log                                 logr.Logger
 }
 
@@ -62,7 +63,7 @@ type virtualRouterReconciler struct {
 // +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=virtualrouters/status,verbs=get;update;patch
 
 func (r *virtualRouterReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.log)
+	return runtime.HandleReconcileError(r.reconcile(req), r.log.WithValues("virtualRouter", req.NamespacedName))
 }
 
 func (r *virtualRouterReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -75,7 +76,7 @@ func (r *virtualRouterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&appmesh.VirtualRouter{}).
 		Watches(&source.Kind{Type: &appmesh.Mesh{}}, r.enqueueRequestsForMeshEvents).
 		Watches(&source.Kind{Type: &appmesh.VirtualNode{}}, r.enqueueRequestsForVirtualNodeEvents).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 3}).
+		WithOptions(r.cfg.controllerOptions()).
 		Complete(r)
 }
 
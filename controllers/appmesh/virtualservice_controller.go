@@ -22,7 +22,6 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualservice"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -34,7 +33,7 @@ import (
 )
 
 // NewVirtualServiceReconciler constructs new virtualServiceReconciler
-func NewVirtualServiceReconciler(k8sClient client.Client, finalizerManager k8s.FinalizerManager, referencesIndexer references.ObjectReferenceIndexer, vsResManager virtualservice.ResourceManager, log logr.Logger) *virtualServiceReconciler {
+func NewVirtualServiceReconciler(k8sClient client.Client, finalizerManager k8s.FinalizerManager, referencesIndexer references.ObjectReferenceIndexer, vsResManager virtualservice.ResourceManager, cfg Config, log logr.Logger) *virtualServiceReconciler {
 	return &virtualServiceReconciler{
 		k8sClient:                             k8sClient,
 		finalizerManager:                      finalizerManager,
@@ -43,6 +42,7 @@ func NewVirtualServiceReconciler(k8sClient client.Client, finalizerManager k8s.F
 		enqueueRequestsForMeshEvents:          virtualservice.NewEnqueueRequestsForMeshEvents(k8sClient, log),
 		enqueueRequestsForVirtualNodeEvents:   virtualservice.NewEnqueueRequestsForVirtualNodeEvents(referencesIndexer, log),
 		enqueueRequestsForVirtualRouterEvents: virtualservice.NewEnqueueRequestsForVirtualRouterEvents(referencesIndexer, log),
+		cfg:                                   cfg,
 		log:                                   log,
 	}
 }
@@ -57,6 +57,7 @@ type virtualServiceReconciler struct {
 	enqueueRequestsForMeshEvents          handler.EventHandler
 	enqueueRequestsForVirtualNodeEvents   handler.EventHandler
 	enqueueRequestsForVirtualRouterEvents handler.EventHandler
+	cfg                                   Config
 	log                                   logr.Logger
 }
 
@@ -64,7 +65,7 @@ type virtualServiceReconciler struct {
 // +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=virtualservices/status,verbs=get;update;patch
 
 func (r *virtualServiceReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.log)
+	return runtime.HandleReconcileError(r.reconcile(req), r.log.WithValues("virtualService", req.NamespacedName))
 }
 
 func (r *virtualServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -79,7 +80,7 @@ func (r *virtualServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&source.Kind{Type: &appmesh.Mesh{}}, r.enqueueRequestsForMeshEvents).
 		Watches(&source.Kind{Type: &appmesh.VirtualNode{}}, r.enqueueRequestsForVirtualNodeEvents).
 		Watches(&source.Kind{Type: &appmesh.VirtualRouter{}}, r.enqueueRequestsForVirtualRouterEvents).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 3}).
+		WithOptions(r.cfg.controllerOptions()).
 		Complete(r)
 }
 
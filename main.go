@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/throttle"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/cloudmap"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/tagging"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/version"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualrouter"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualservice"
@@ -33,6 +35,7 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
 
 	zapraw "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -65,6 +68,11 @@ const (
 	defaultHealthProbePort = 61779
 )
 
+const (
+	logFormatJSON    = "json"
+	logFormatConsole = "console"
+)
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -83,11 +91,17 @@ func main() {
 	var enableLeaderElection bool
 	var enableCustomHealthCheck bool
 	var logLevel string
+	var logFormat string
 	var listPageLimit int64
 	var healthProbePort int
 	awsCloudConfig := aws.CloudConfig{ThrottleConfig: throttle.NewDefaultServiceOperationsThrottleConfig()}
 	injectConfig := inject.Config{}
 	cloudMapConfig := cloudmap.Config{}
+	vnConfig := virtualnode.Config{}
+	vgConfig := virtualgateway.Config{}
+	meshConfig := mesh.Config{}
+	taggingConfig := tagging.Config{}
+	controllerConfig := appmeshcontroller.Config{}
 	fs := pflag.NewFlagSet("", pflag.ExitOnError)
 	fs.DurationVar(&syncPeriod, "sync-period", 10*time.Hour, "SyncPeriod determines the minimum frequency at which watched resources are reconciled.")
 	fs.StringVar(&metricsAddr, "metrics-addr", "0.0.0.0:8080", "The address the metric endpoint binds to.")
@@ -97,6 +111,7 @@ func main() {
 	fs.BoolVar(&enableCustomHealthCheck, "enable-custom-health-check", false,
 		"Enable custom healthCheck when using cloudMap serviceDiscovery")
 	fs.StringVar(&logLevel, "log-level", "info", "Set the controller log level - info(default), debug")
+	fs.StringVar(&logFormat, "log-format", logFormatJSON, "Set the controller log format - json(default), console")
 	fs.Int64Var(&listPageLimit, "page-limit", 100,
 		"The page size limiting the number of response for list operation to API Server")
 	fs.IntVar(&healthProbePort, flagHealthProbePort, defaultHealthProbePort,
@@ -105,20 +120,63 @@ func main() {
 	awsCloudConfig.BindFlags(fs)
 	injectConfig.BindFlags(fs)
 	cloudMapConfig.BindFlags(fs)
+	vnConfig.BindFlags(fs)
+	vgConfig.BindFlags(fs)
+	meshConfig.BindFlags(fs)
+	taggingConfig.BindFlags(fs)
+	controllerConfig.BindFlags(fs)
 	if err := fs.Parse(os.Args); err != nil {
 		setupLog.Error(err, "invalid flags")
 		os.Exit(1)
 	}
+	if err := awsCloudConfig.Validate(); err != nil {
+		setupLog.Error(err, "invalid flags")
+		os.Exit(1)
+	}
 	if err := injectConfig.Validate(); err != nil {
 		setupLog.Error(err, "invalid flags")
 		os.Exit(1)
 	}
+	if err := cloudMapConfig.Validate(); err != nil {
+		setupLog.Error(err, "invalid flags")
+		os.Exit(1)
+	}
+	if err := vnConfig.Validate(); err != nil {
+		setupLog.Error(err, "invalid flags")
+		os.Exit(1)
+	}
+	if err := vgConfig.Validate(); err != nil {
+		setupLog.Error(err, "invalid flags")
+		os.Exit(1)
+	}
+	if err := meshConfig.Validate(); err != nil {
+		setupLog.Error(err, "invalid flags")
+		os.Exit(1)
+	}
+	if err := taggingConfig.Validate(); err != nil {
+		setupLog.Error(err, "invalid flags")
+		os.Exit(1)
+	}
+	if err := controllerConfig.Validate(); err != nil {
+		setupLog.Error(err, "invalid flags")
+		os.Exit(1)
+	}
 
 	lvl := zapraw.NewAtomicLevelAt(0)
 	if logLevel == "debug" {
 		lvl = zapraw.NewAtomicLevelAt(-1)
 	}
-	ctrl.SetLogger(zap.New(zap.UseDevMode(false), zap.Level(&lvl)))
+	var logEncoder zapcore.Encoder
+	switch logFormat {
+	case logFormatJSON:
+		logEncoder = zapcore.NewJSONEncoder(zapraw.NewProductionEncoderConfig())
+	case logFormatConsole:
+		logEncoder = zapcore.NewConsoleEncoder(zapraw.NewProductionEncoderConfig())
+	default:
+		setupLog.Error(fmt.Errorf("unsupported log format %s, must be %s or %s", logFormat, logFormatJSON, logFormatConsole), "invalid flags")
+		os.Exit(1)
+	}
+	ctrl.SetLogger(zap.New(zap.UseDevMode(false), zap.Level(&lvl), zap.Encoder(logEncoder)))
 	setupLog.Info("version",
 		"GitVersion", version.GitVersion,
 		"GitCommit", version.GitCommit,
@@ -174,29 +232,32 @@ func main() {
 	meshMembersFinalizer := mesh.NewPendingMembersFinalizer(mgr.GetClient(), mgr.GetEventRecorderFor("mesh-members"), ctrl.Log)
 	vgMembersFinalizer := virtualgateway.NewPendingMembersFinalizer(mgr.GetClient(), mgr.GetEventRecorderFor("virtualgateway-members"), ctrl.Log)
 	referencesResolver := references.NewDefaultResolver(mgr.GetClient(), ctrl.Log)
-	virtualNodeEndpointResolver := cloudmap.NewDefaultVirtualNodeEndpointResolver(podsRepository, ctrl.Log)
-	cloudMapInstancesReconciler := cloudmap.NewDefaultInstancesReconciler(mgr.GetClient(), cloud.CloudMap(), ctrl.Log, stopChan)
-	meshResManager := mesh.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), cloud.AccountID(), ctrl.Log)
-	vgResManager := virtualgateway.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), referencesResolver, cloud.AccountID(), ctrl.Log)
-	grResManager := gatewayroute.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), referencesResolver, cloud.AccountID(), ctrl.Log)
-	vnResManager := virtualnode.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), referencesResolver, cloud.AccountID(), ctrl.Log)
-	vsResManager := virtualservice.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), referencesResolver, cloud.AccountID(), ctrl.Log)
-	vrResManager := virtualrouter.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), referencesResolver, cloud.AccountID(), ctrl.Log)
-	cloudMapResManager := cloudmap.NewDefaultResourceManager(mgr.GetClient(), cloud.CloudMap(), referencesResolver, virtualNodeEndpointResolver, cloudMapInstancesReconciler, enableCustomHealthCheck, ctrl.Log, cloudMapConfig)
-	msReconciler := appmeshcontroller.NewMeshReconciler(mgr.GetClient(), finalizerManager, meshMembersFinalizer, meshResManager, ctrl.Log.WithName("controllers").WithName("Mesh"))
-	vgReconciler := appmeshcontroller.NewVirtualGatewayReconciler(mgr.GetClient(), finalizerManager, vgMembersFinalizer, vgResManager, ctrl.Log.WithName("controllers").WithName("VirtualGateway"))
-	grReconciler := appmeshcontroller.NewGatewayRouteReconciler(mgr.GetClient(), finalizerManager, grResManager, ctrl.Log.WithName("controllers").WithName("GatewayRoute"))
-	vnReconciler := appmeshcontroller.NewVirtualNodeReconciler(mgr.GetClient(), finalizerManager, vnResManager, ctrl.Log.WithName("controllers").WithName("VirtualNode"))
+	virtualNodeEndpointResolver := cloudmap.NewDefaultVirtualNodeEndpointResolver(podsRepository, mgr.GetClient(), vnConfig, ctrl.Log)
+	cloudMapInstancesReconciler := cloudmap.NewDefaultInstancesReconciler(mgr.GetClient(), cloud.CloudMap(), mgr.GetEventRecorderFor("cloudmap-instances"), ctrl.Log, stopChan, cloudMapConfig)
+	tagsManager := tagging.NewDefaultManager(cloud.AppMesh(), taggingConfig, ctrl.Log.WithName("tagging"))
+	meshResManager := mesh.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), cloud.AccountID(), meshConfig, tagsManager, ctrl.Log)
+	vgResManager := virtualgateway.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), referencesResolver, cloud.AccountID(), tagsManager, ctrl.Log)
+	grResManager := gatewayroute.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), referencesResolver, cloud.AccountID(), tagsManager, ctrl.Log)
+	vnResManager := virtualnode.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), referencesResolver, cloud.AccountID(), vnConfig, mgr.GetEventRecorderFor("virtualnode"), tagsManager, ctrl.Log)
+	vsResManager := virtualservice.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), referencesResolver, cloud.AccountID(), tagsManager, ctrl.Log)
+	vrResManager := virtualrouter.NewDefaultResourceManager(mgr.GetClient(), cloud.AppMesh(), referencesResolver, cloud.AccountID(), tagsManager, ctrl.Log)
+	cloudMapResManager := cloudmap.NewDefaultResourceManager(mgr.GetClient(), cloud.CloudMap(), referencesResolver, virtualNodeEndpointResolver, cloudMapInstancesReconciler, enableCustomHealthCheck, mgr.GetEventRecorderFor("cloudmap"), ctrl.Log, cloudMapConfig)
+	msReconciler := appmeshcontroller.NewMeshReconciler(mgr.GetClient(), finalizerManager, meshMembersFinalizer, meshResManager, controllerConfig, ctrl.Log.WithName("controllers").WithName("Mesh"))
+	vgReconciler := appmeshcontroller.NewVirtualGatewayReconciler(mgr.GetClient(), finalizerManager, vgMembersFinalizer, vgResManager, controllerConfig, ctrl.Log.WithName("controllers").WithName("VirtualGateway"))
+	grReconciler := appmeshcontroller.NewGatewayRouteReconciler(mgr.GetClient(), finalizerManager, grResManager, controllerConfig, ctrl.Log.WithName("controllers").WithName("GatewayRoute"))
+	vnReconciler := appmeshcontroller.NewVirtualNodeReconciler(mgr.GetClient(), finalizerManager, vnResManager, controllerConfig, vnConfig, ctrl.Log.WithName("controllers").WithName("VirtualNode"))
 
 	cloudMapReconciler := appmeshcontroller.NewCloudMapReconciler(
 		mgr.GetClient(),
 		finalizerManager,
 		cloudMapResManager,
 		eventNotificationChan,
+		controllerConfig,
+		cloudMapConfig,
 		ctrl.Log.WithName("controllers").WithName("CloudMap"))
 
-	vsReconciler := appmeshcontroller.NewVirtualServiceReconciler(mgr.GetClient(), finalizerManager, referencesIndexer, vsResManager, ctrl.Log.WithName("controllers").WithName("VirtualService"))
-	vrReconciler := appmeshcontroller.NewVirtualRouterReconciler(mgr.GetClient(), finalizerManager, referencesIndexer, vrResManager, ctrl.Log.WithName("controllers").WithName("VirtualRouter"))
+	vsReconciler := appmeshcontroller.NewVirtualServiceReconciler(mgr.GetClient(), finalizerManager, referencesIndexer, vsResManager, controllerConfig, ctrl.Log.WithName("controllers").WithName("VirtualService"))
+	vrReconciler := appmeshcontroller.NewVirtualRouterReconciler(mgr.GetClient(), finalizerManager, referencesIndexer, vrResManager, controllerConfig, ctrl.Log.WithName("controllers").WithName("VirtualRouter"))
 	if err = msReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Mesh")
 		os.Exit(1)
@@ -228,22 +289,24 @@ func main() {
 		os.Exit(1)
 	}
 
-	meshMembershipDesignator := mesh.NewMembershipDesignator(mgr.GetClient())
-	vgMembershipDesignator := virtualgateway.NewMembershipDesignator(mgr.GetClient())
-	vnMembershipDesignator := virtualnode.NewMembershipDesignator(mgr.GetClient())
-	sidecarInjector := inject.NewSidecarInjector(injectConfig, cloud.AccountID(), cloud.Region(), mgr.GetClient(), referencesResolver, vnMembershipDesignator, vgMembershipDesignator)
+	meshMembershipDesignator := mesh.NewMembershipDesignator(mgr.GetClient(), meshConfig)
+	meshMembershipValidator := mesh.NewMembershipValidator(mgr.GetClient(), meshConfig)
+	vgMembershipDesignator := virtualgateway.NewMembershipDesignator(mgr.GetClient(), vgConfig)
+	vnMembershipDesignator := virtualnode.NewMembershipDesignator(mgr.GetClient(), vnConfig, ctrl.Log.WithName("virtualnode-membership-designator"))
+	sidecarInjector := inject.NewSidecarInjector(injectConfig, cloud.AccountID(), cloud.Region(), mgr.GetClient(), mgr.GetAPIReader(), referencesResolver, vnMembershipDesignator, vgMembershipDesignator,
+		mgr.GetEventRecorderFor("sidecar-injector"))
 	appmeshwebhook.NewMeshMutator().SetupWithManager(mgr)
 	appmeshwebhook.NewMeshValidator().SetupWithManager(mgr)
 	appmeshwebhook.NewVirtualGatewayMutator(meshMembershipDesignator).SetupWithManager(mgr)
-	appmeshwebhook.NewVirtualGatewayValidator().SetupWithManager(mgr)
+	appmeshwebhook.NewVirtualGatewayValidator(meshMembershipValidator).SetupWithManager(mgr)
 	appmeshwebhook.NewGatewayRouteMutator(meshMembershipDesignator, vgMembershipDesignator).SetupWithManager(mgr)
-	appmeshwebhook.NewGatewayRouteValidator().SetupWithManager(mgr)
-	appmeshwebhook.NewVirtualNodeMutator(meshMembershipDesignator).SetupWithManager(mgr)
-	appmeshwebhook.NewVirtualNodeValidator().SetupWithManager(mgr)
+	appmeshwebhook.NewGatewayRouteValidator(meshMembershipValidator).SetupWithManager(mgr)
+	appmeshwebhook.NewVirtualNodeMutator(meshMembershipDesignator, vnConfig).SetupWithManager(mgr)
+	appmeshwebhook.NewVirtualNodeValidator(meshMembershipValidator).SetupWithManager(mgr)
 	appmeshwebhook.NewVirtualServiceMutator(meshMembershipDesignator).SetupWithManager(mgr)
-	appmeshwebhook.NewVirtualServiceValidator().SetupWithManager(mgr)
+	appmeshwebhook.NewVirtualServiceValidator(meshMembershipValidator).SetupWithManager(mgr)
 	appmeshwebhook.NewVirtualRouterMutator(meshMembershipDesignator).SetupWithManager(mgr)
-	appmeshwebhook.NewVirtualRouterValidator().SetupWithManager(mgr)
+	appmeshwebhook.NewVirtualRouterValidator(meshMembershipValidator).SetupWithManager(mgr)
 	corewebhook.NewPodMutator(sidecarInjector).SetupWithManager(mgr)
 
 	// Add liveness probe
@@ -254,6 +317,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Add readiness probe
+	err = mgr.AddReadyzCheck("appmesh-api", aws.NewAppMeshReadinessChecker(cloud.AppMesh(), awsCloudConfig.ReadinessFailureThreshold, ctrl.Log.WithName("readiness")))
+	setupLog.Info("adding readiness check for controller")
+	if err != nil {
+		setupLog.Error(err, "unable add a readiness check")
+		os.Exit(1)
+	}
+
 	// Only start the controller when the leader election is won
 	mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
 		setupLog.Info("starting custom controller")
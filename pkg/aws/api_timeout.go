@@ -0,0 +1,27 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// injectAPITimeout will bound each awsSDK call including its retries by timeout, so a hung call is cancelled
+// and surfaces as an error instead of blocking the caller indefinitely. No timeout is applied if it's zero.
+func injectAPITimeout(handlers *request.Handlers, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	handlers.Build.PushFrontNamed(request.NamedHandler{
+		Name: fmt.Sprintf("%s/api-timeout", appName),
+		Fn: func(r *request.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			r.SetContext(ctx)
+			r.Handlers.Complete.PushBack(func(_ *request.Request) {
+				cancel()
+			})
+		},
+	})
+}
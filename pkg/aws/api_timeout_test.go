@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_injectAPITimeout(t *testing.T) {
+	// server blocks every call until the client gives up.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		ctxTimeout  time.Duration
+		wantErrCode string
+	}{
+		{
+			name:        "hung call is cancelled after api timeout",
+			timeout:     50 * time.Millisecond,
+			ctxTimeout:  5 * time.Second,
+			wantErrCode: request.CanceledErrorCode,
+		},
+		{
+			name:        "caller's shorter deadline is still honored",
+			timeout:     5 * time.Second,
+			ctxTimeout:  50 * time.Millisecond,
+			wantErrCode: request.CanceledErrorCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sess := session.Must(session.NewSession(aws.NewConfig().
+				WithEndpoint(server.URL).
+				WithRegion("us-west-2").
+				WithCredentials(credentials.NewStaticCredentials("AKID", "SECRET", "")).
+				WithMaxRetries(0)))
+			injectAPITimeout(&sess.Handlers, tt.timeout)
+			appMeshSDK := appmeshsdk.New(sess)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.ctxTimeout)
+			defer cancel()
+			start := time.Now()
+			_, err := appMeshSDK.ListMeshesWithContext(ctx, &appmeshsdk.ListMeshesInput{})
+			assert.Less(t, int64(time.Since(start)), int64(time.Second))
+			awsErr, ok := err.(awserr.Error)
+			if assert.True(t, ok, "expects awserr.Error, got %v", err) {
+				assert.Equal(t, tt.wantErrCode, awsErr.Code())
+			}
+		})
+	}
+}
@@ -28,7 +28,8 @@ type Cloud interface {
 // NewCloud constructs new Cloud implementation.
 func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud, error) {
 	sess := session.Must(session.NewSession(aws.NewConfig()))
-	injectUserAgent(&sess.Handlers)
+	injectUserAgent(&sess.Handlers, cfg.UserAgentSuffix)
+	injectAPITimeout(&sess.Handlers, cfg.APITimeout)
 	if cfg.ThrottleConfig != nil {
 		throttler := throttle.NewThrottler(cfg.ThrottleConfig)
 		throttler.InjectHandlers(&sess.Handlers)
@@ -1,6 +1,9 @@
 package aws
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/throttle"
 	"github.com/spf13/pflag"
 )
@@ -9,6 +12,13 @@ const (
 	flagAWSRegion      = "aws-region"
 	flagAWSAccountID   = "aws-account-id"
 	flagAWSAPIThrottle = "aws-api-throttle"
+
+	flagUserAgentSuffix = "user-agent-suffix"
+
+	flagAWSAPITimeout = "aws-api-timeout"
+
+	flagAWSAPIReadinessFailureThreshold    = "aws-api-readiness-failure-threshold"
+	defaultAWSAPIReadinessFailureThreshold = 3
 )
 
 type CloudConfig struct {
@@ -18,10 +28,32 @@ type CloudConfig struct {
 	AccountID string
 	// Throttle settings for aws APIs
 	ThrottleConfig *throttle.ServiceOperationsThrottleConfig
+	// Free-form suffix appended to the User-Agent of AWS API calls
+	UserAgentSuffix string
+	// Number of consecutive failed AppMesh API calls before the controller reports not-ready
+	ReadinessFailureThreshold int
+	// Deadline for each AWS API call including its retries, no deadline if zero
+	APITimeout time.Duration
 }
 
 func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&cfg.Region, flagAWSRegion, "", "AWS Region for the kubernetes cluster")
 	fs.StringVar(&cfg.AccountID, flagAWSAccountID, "", "AWS AccountID for the kubernetes cluster")
 	fs.Var(cfg.ThrottleConfig, flagAWSAPIThrottle, "throttle settings for AWS APIs, format: serviceID1:operationRegex1=rate:burst,serviceID2:operationRegex2=rate:burst")
+	fs.StringVar(&cfg.UserAgentSuffix, flagUserAgentSuffix, "", "Suffix appended to the User-Agent of AWS API calls, such as the cluster name")
+	fs.IntVar(&cfg.ReadinessFailureThreshold, flagAWSAPIReadinessFailureThreshold, defaultAWSAPIReadinessFailureThreshold,
+		"Number of consecutive failed AppMesh API calls before the controller reports not-ready on /readyz. "+
+			"The AppMesh API is called at most every 10 seconds, and throttled calls count as successful")
+	fs.DurationVar(&cfg.APITimeout, flagAWSAPITimeout, 0,
+		"Deadline for each AWS API call including its retries, after which the call is cancelled and reconciliation retried. Set to 0 for no deadline")
+}
+
+func (cfg *CloudConfig) Validate() error {
+	if cfg.ReadinessFailureThreshold < 1 {
+		return fmt.Errorf("%s must be at least 1", flagAWSAPIReadinessFailureThreshold)
+	}
+	if cfg.APITimeout < 0 {
+		return fmt.Errorf("%s must not be negative", flagAWSAPITimeout)
+	}
+	return nil
 }
@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// defaultReadinessCheckInterval is the minimum interval between AppMesh API calls of the readiness checker,
+// probes within it get the last result, so frequent probes don't consume the AppMesh API rate limit.
+const defaultReadinessCheckInterval = 10 * time.Second
+
+// NewAppMeshReadinessChecker constructs a readiness checker that reports not-ready
+// once failureThreshold consecutive AppMesh API calls have failed.
+func NewAppMeshReadinessChecker(appMeshSDK services.AppMesh, failureThreshold int, log logr.Logger) healthz.Checker {
+	checker := &appMeshReadinessChecker{
+		appMeshSDK:       appMeshSDK,
+		failureThreshold: failureThreshold,
+		minCheckInterval: defaultReadinessCheckInterval,
+		now:              time.Now,
+		log:              log,
+	}
+	return checker.check
+}
+
+type appMeshReadinessChecker struct {
+	appMeshSDK       services.AppMesh
+	failureThreshold int
+	minCheckInterval time.Duration
+	now              func() time.Time
+	log              logr.Logger
+
+	mutex               sync.Mutex
+	consecutiveFailures int
+	lastCheckTime       time.Time
+	lastErr             error
+}
+
+func (c *appMeshReadinessChecker) check(req *http.Request) error {
+	// concurrent probes wait for the ongoing AppMesh API call and share its result
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	if !c.lastCheckTime.IsZero() && now.Sub(c.lastCheckTime) < c.minCheckInterval {
+		return c.lastErr
+	}
+	c.lastCheckTime = now
+	c.lastErr = c.checkAppMeshAPI(req.Context())
+	return c.lastErr
+}
+
+func (c *appMeshReadinessChecker) checkAppMeshAPI(ctx context.Context) error {
+	_, err := c.appMeshSDK.ListMeshesWithContext(ctx, &appmeshsdk.ListMeshesInput{
+		Limit: aws.Int64(1),
+	})
+	// a throttled call still reached the AppMesh API
+	if err == nil || request.IsErrorThrottle(err) {
+		c.consecutiveFailures = 0
+		return nil
+	}
+	c.consecutiveFailures++
+	c.log.Error(err, "failed to reach AppMesh API",
+		"consecutiveFailures", c.consecutiveFailures,
+		"failureThreshold", c.failureThreshold)
+	if c.consecutiveFailures < c.failureThreshold {
+		return nil
+	}
+	return errors.Wrapf(err, "AppMesh API unreachable for %d consecutive checks", c.consecutiveFailures)
+}
@@ -0,0 +1,126 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
+	"github.com/aws/aws-sdk-go/service/appmesh/appmeshiface"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type fakeAppMesh struct {
+	appmeshiface.AppMeshAPI
+	listMeshesErrs []error
+	calls          int
+}
+
+func (f *fakeAppMesh) ListMeshesWithContext(_ context.Context, _ *appmeshsdk.ListMeshesInput, _ ...request.Option) (*appmeshsdk.ListMeshesOutput, error) {
+	err := f.listMeshesErrs[f.calls]
+	f.calls++
+	if err != nil {
+		return nil, err
+	}
+	return &appmeshsdk.ListMeshesOutput{}, nil
+}
+
+func Test_appMeshReadinessChecker_check(t *testing.T) {
+	apiErr := errors.New("RequestError: send request failed")
+	throttleErr := awserr.New("TooManyRequestsException", "rate exceeded", nil)
+	tests := []struct {
+		name             string
+		failureThreshold int
+		listMeshesErrs   []error
+		wantErrs         []error
+	}{
+		{
+			name:             "healthy AppMesh API",
+			failureThreshold: 3,
+			listMeshesErrs:   []error{nil, nil, nil},
+			wantErrs:         []error{nil, nil, nil},
+		},
+		{
+			name:             "AppMesh API down reaches failure threshold",
+			failureThreshold: 3,
+			listMeshesErrs:   []error{apiErr, apiErr, apiErr, apiErr},
+			wantErrs: []error{
+				nil,
+				nil,
+				errors.New("AppMesh API unreachable for 3 consecutive checks: RequestError: send request failed"),
+				errors.New("AppMesh API unreachable for 4 consecutive checks: RequestError: send request failed"),
+			},
+		},
+		{
+			name:             "AppMesh API recovers before failure threshold",
+			failureThreshold: 2,
+			listMeshesErrs:   []error{apiErr, nil, apiErr, nil},
+			wantErrs:         []error{nil, nil, nil, nil},
+		},
+		{
+			name:             "throttled AppMesh API is reachable",
+			failureThreshold: 2,
+			listMeshesErrs:   []error{apiErr, throttleErr, apiErr, throttleErr},
+			wantErrs:         []error{nil, nil, nil, nil},
+		},
+		{
+			name:             "AppMesh API recovers after failure threshold",
+			failureThreshold: 1,
+			listMeshesErrs:   []error{apiErr, nil},
+			wantErrs: []error{
+				errors.New("AppMesh API unreachable for 1 consecutive checks: RequestError: send request failed"),
+				nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &appMeshReadinessChecker{
+				appMeshSDK:       &fakeAppMesh{listMeshesErrs: tt.listMeshesErrs},
+				failureThreshold: tt.failureThreshold,
+				now:              time.Now,
+				log:              &log.NullLogger{},
+			}
+			for _, wantErr := range tt.wantErrs {
+				err := checker.check(httptest.NewRequest("GET", "/readyz", nil))
+				if wantErr != nil {
+					assert.EqualError(t, err, wantErr.Error())
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
+
+func Test_appMeshReadinessChecker_check_minCheckInterval(t *testing.T) {
+	apiErr := errors.New("RequestError: send request failed")
+	wantErr := errors.New("AppMesh API unreachable for 1 consecutive checks: RequestError: send request failed")
+	appMeshSDK := &fakeAppMesh{listMeshesErrs: []error{apiErr, nil}}
+	now := time.Now()
+	checker := &appMeshReadinessChecker{
+		appMeshSDK:       appMeshSDK,
+		failureThreshold: 1,
+		minCheckInterval: 10 * time.Second,
+		now:              func() time.Time { return now },
+		log:              &log.NullLogger{},
+	}
+	req := httptest.NewRequest("GET", "/readyz", nil)
+
+	assert.EqualError(t, checker.check(req), wantErr.Error())
+	assert.Equal(t, 1, appMeshSDK.calls)
+
+	// probes within the interval get the last result without calling AppMesh API
+	now = now.Add(5 * time.Second)
+	assert.EqualError(t, checker.check(req), wantErr.Error())
+	assert.Equal(t, 1, appMeshSDK.calls)
+
+	now = now.Add(5 * time.Second)
+	assert.NoError(t, checker.check(req))
+	assert.Equal(t, 2, appMeshSDK.calls)
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/appmesh"
 	"github.com/aws/aws-sdk-go/service/appmesh/appmeshiface"
@@ -20,3 +21,12 @@ func NewAppMesh(session *session.Session) AppMesh {
 type defaultAppMesh struct {
 	appmeshiface.AppMeshAPI
 }
+
+// IgnoreAppMeshNotFound returns nil on AppMesh NotFoundException errors, otherwise err.
+// AppMesh resources deleted out-of-band after we found them are gone already, which shouldn't fail their deletion.
+func IgnoreAppMeshNotFound(err error) error {
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == appmesh.ErrCodeNotFoundException {
+		return nil
+	}
+	return err
+}
@@ -8,10 +8,16 @@ import (
 
 const appName = "appmesh.k8s.aws"
 
-// injectUserAgent will inject app specific user-agent into awsSDK
-func injectUserAgent(handlers *request.Handlers) {
+// injectUserAgent will inject app specific user-agent into awsSDK, followed by userAgentSuffix if it's not empty
+func injectUserAgent(handlers *request.Handlers, userAgentSuffix string) {
 	handlers.Build.PushFrontNamed(request.NamedHandler{
 		Name: fmt.Sprintf("%s/user-agent", appName),
 		Fn:   request.MakeAddToUserAgentHandler(appName, version.GitVersion),
 	})
+	if userAgentSuffix != "" {
+		handlers.Build.PushBackNamed(request.NamedHandler{
+			Name: fmt.Sprintf("%s/user-agent-suffix", appName),
+			Fn:   request.MakeAddToUserAgentFreeFormHandler(userAgentSuffix),
+		})
+	}
 }
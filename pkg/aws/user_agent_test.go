@@ -0,0 +1,40 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/version"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_injectUserAgent(t *testing.T) {
+	tests := []struct {
+		name            string
+		userAgentSuffix string
+		wantUserAgent   string
+	}{
+		{
+			name:            "without user agent suffix",
+			userAgentSuffix: "",
+			wantUserAgent:   "aws-sdk-go/1.0 appmesh.k8s.aws/" + version.GitVersion,
+		},
+		{
+			name:            "with user agent suffix",
+			userAgentSuffix: "cluster/my-cluster",
+			wantUserAgent:   "aws-sdk-go/1.0 appmesh.k8s.aws/" + version.GitVersion + " cluster/my-cluster",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := request.Handlers{}
+			injectUserAgent(&handlers, tt.userAgentSuffix)
+			req := request.New(aws.Config{}, metadata.ClientInfo{}, handlers, nil, &request.Operation{Name: "ListMeshes"}, nil, nil)
+			req.HTTPRequest.Header.Set("User-Agent", "aws-sdk-go/1.0")
+			req.Build()
+			assert.Equal(t, tt.wantUserAgent, req.HTTPRequest.Header.Get("User-Agent"))
+		})
+	}
+}
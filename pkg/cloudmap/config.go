@@ -1,21 +1,39 @@
 package cloudmap
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 )
 
 const (
-	flagSetCloudMapTTL = "cloudmap-dns-ttl"
+	flagSetCloudMapTTL          = "cloudmap-dns-ttl"
+	flagCreateCloudMapNamespace = "create-cloudmap-namespace"
+	flagCloudMapNamespaceVPCID  = "cloudmap-namespace-vpc-id"
+
+	flagCloudMapPodAnnotationAttributes = "cloudmap-pod-annotation-attributes"
 )
 
 type Config struct {
 	//Specifies the DNS TTL value to be used while creating CloudMap services.
 	CloudMapServiceTTL int64
+	//Specifies whether missing CloudMap namespaces are created as private DNS namespaces.
+	CreateNamespace bool
+	//Specifies the VPC for CloudMap namespaces created by the controller.
+	NamespaceVPCID string
+	//Specifies the pod annotations copied onto CloudMap instances as attributes.
+	PodAnnotationAttributes []string
 }
 
 func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
 	fs.Int64Var(&cfg.CloudMapServiceTTL, flagSetCloudMapTTL, defaultServiceDNSConfigTTL,
 		`CloudMap Service DNS TTL value`)
+	fs.BoolVar(&cfg.CreateNamespace, flagCreateCloudMapNamespace, false,
+		`If enabled, a private DNS namespace is created in the VPC of cloudmap-namespace-vpc-id when the CloudMap namespace of a VirtualNode doesn't exist. `+
+			`Its SOA TTL can't be set and keeps the CloudMap default, cloudmap-dns-ttl only applies to the services created in it`)
+	fs.StringVar(&cfg.NamespaceVPCID, flagCloudMapNamespaceVPCID, "",
+		`The VPC ID for CloudMap namespaces created by the controller`)
+	fs.StringSliceVar(&cfg.PodAnnotationAttributes, flagCloudMapPodAnnotationAttributes, nil,
+		`Comma separated pod annotations copied onto CloudMap instances as attributes, e.g. app.kubernetes.io/version. Pods without an annotation omit its attribute`)
 }
 
 func (cfg *Config) BindEnv() error {
@@ -23,5 +41,8 @@ func (cfg *Config) BindEnv() error {
 }
 
 func (cfg *Config) Validate() error {
+	if cfg.CreateNamespace && cfg.NamespaceVPCID == "" {
+		return errors.Errorf("%s is required when %s is enabled", flagCloudMapNamespaceVPCID, flagCreateCloudMapNamespace)
+	}
 	return nil
 }
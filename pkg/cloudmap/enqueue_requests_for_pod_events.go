@@ -15,10 +15,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 )
 
-func NewEnqueueRequestsForPodEvents(k8sClient client.Client, log logr.Logger) *enqueueRequestsForPodEvents {
+func NewEnqueueRequestsForPodEvents(k8sClient client.Client, podAnnotationAttributes []string, log logr.Logger) *enqueueRequestsForPodEvents {
 	return &enqueueRequestsForPodEvents{
-		k8sClient: k8sClient,
-		log:       log,
+		k8sClient:               k8sClient,
+		podAnnotationAttributes: podAnnotationAttributes,
+		log:                     log,
 	}
 }
 
@@ -26,7 +27,9 @@ var _ handler.EventHandler = (*enqueueRequestsForPodEvents)(nil)
 
 type enqueueRequestsForPodEvents struct {
 	k8sClient client.Client
-	log       logr.Logger
+	// pod annotations copied onto CloudMap instances, changes to them require instances to be re-registered
+	podAnnotationAttributes []string
+	log                     logr.Logger
 }
 
 // Create is called in response to an create event
@@ -43,11 +46,23 @@ func (h *enqueueRequestsForPodEvents) Update(e event.UpdateEvent, queue workqueu
 
 	oldPodIsReady := ArePodContainersReady(oldPod)
 	newPodIsReady := ArePodContainersReady(newPod)
-	if oldPodIsReady != newPodIsReady || newPod.DeletionTimestamp != nil {
+	if oldPodIsReady != newPodIsReady || newPod.DeletionTimestamp != nil || h.podAnnotationAttributesChanged(oldPod, newPod) {
 		h.enqueueVirtualNodesForPods(context.Background(), queue, e.ObjectNew.(*corev1.Pod))
 	}
 }
 
+// podAnnotationAttributesChanged checks whether any pod annotation copied onto CloudMap instances changed.
+func (h *enqueueRequestsForPodEvents) podAnnotationAttributesChanged(oldPod *corev1.Pod, newPod *corev1.Pod) bool {
+	for _, annotation := range h.podAnnotationAttributes {
+		oldValue, oldOK := oldPod.Annotations[annotation]
+		newValue, newOK := newPod.Annotations[annotation]
+		if oldOK != newOK || oldValue != newValue {
+			return true
+		}
+	}
+	return false
+}
+
 // Delete is called in response to a delete event
 func (h *enqueueRequestsForPodEvents) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
 	h.enqueueVirtualNodesForPods(context.Background(), queue, e.Object.(*corev1.Pod))
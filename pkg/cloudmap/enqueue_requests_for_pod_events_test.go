@@ -374,3 +374,59 @@ func Test_enqueueRequestsForPodEvents_Delete(t *testing.T) {
 func compareReconcileRequest(a reconcile.Request, b reconcile.Request) bool {
 	return a.String() < b.String()
 }
+
+func Test_enqueueRequestsForPodEvents_podAnnotationAttributesChanged(t *testing.T) {
+	tests := []struct {
+		name                    string
+		podAnnotationAttributes []string
+		oldAnnotations          map[string]string
+		newAnnotations          map[string]string
+		want                    bool
+	}{
+		{
+			name:                    "configured annotation unchanged",
+			podAnnotationAttributes: []string{"app.kubernetes.io/version"},
+			oldAnnotations:          map[string]string{"app.kubernetes.io/version": "v1"},
+			newAnnotations:          map[string]string{"app.kubernetes.io/version": "v1", "other": "changed"},
+			want:                    false,
+		},
+		{
+			name:                    "configured annotation value changed",
+			podAnnotationAttributes: []string{"app.kubernetes.io/version"},
+			oldAnnotations:          map[string]string{"app.kubernetes.io/version": "v1"},
+			newAnnotations:          map[string]string{"app.kubernetes.io/version": "v2"},
+			want:                    true,
+		},
+		{
+			name:                    "configured annotation added",
+			podAnnotationAttributes: []string{"app.kubernetes.io/version"},
+			oldAnnotations:          nil,
+			newAnnotations:          map[string]string{"app.kubernetes.io/version": "v1"},
+			want:                    true,
+		},
+		{
+			name:                    "configured annotation removed",
+			podAnnotationAttributes: []string{"app.kubernetes.io/version"},
+			oldAnnotations:          map[string]string{"app.kubernetes.io/version": "v1"},
+			newAnnotations:          nil,
+			want:                    true,
+		},
+		{
+			name:                    "no annotation configured",
+			podAnnotationAttributes: nil,
+			oldAnnotations:          map[string]string{"app.kubernetes.io/version": "v1"},
+			newAnnotations:          map[string]string{"app.kubernetes.io/version": "v2"},
+			want:                    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &enqueueRequestsForPodEvents{
+				podAnnotationAttributes: tt.podAnnotationAttributes,
+			}
+			oldPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tt.oldAnnotations}}
+			newPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tt.newAnnotations}}
+			assert.Equal(t, tt.want, h.podAnnotationAttributesChanged(oldPod, newPod))
+		})
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/retry"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/servicediscovery"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoretry "k8s.io/client-go/util/retry"
 	"sync"
 	"time"
 )
@@ -20,6 +22,11 @@ const (
 	defaultOperationPollInterval = 3 * time.Second
 	// we want to retry higher times for getOperation than other normal AWS API :D
 	defaultOperationPollMaxRetries = 10
+
+	// register/deregister calls that are throttled are retried with exponential backoff, on top of the SDK's own retries.
+	defaultOperationRetryAttempts = 5
+	defaultOperationRetryDelay    = 1 * time.Second
+	defaultOperationRetryMaxDelay = 30 * time.Second
 )
 
 // instancesCache is an abstraction around cloudMap's asynchronous instances API.
@@ -44,6 +51,13 @@ func newDefaultInstancesCache(cloudMapSDK services.CloudMap) *defaultInstancesCa
 		instancesAttrsCacheTTL:   defaultInstanceAttrsCacheTTL,
 		operationPollInterval:    defaultOperationPollInterval,
 		operationPollMaxRetries:  defaultOperationPollMaxRetries,
+		operationRetryBackoff: wait.Backoff{
+			Steps:    defaultOperationRetryAttempts,
+			Duration: defaultOperationRetryDelay,
+			Factor:   2.0,
+			Jitter:   0.1,
+			Cap:      defaultOperationRetryMaxDelay,
+		},
 	}
 }
 
@@ -60,6 +74,8 @@ type defaultInstancesCache struct {
 	operationPollInterval time.Duration
 	// maximum retries per getOperation call
 	operationPollMaxRetries int
+	// backoff for retrying throttled registerInstance/deregisterInstance calls
+	operationRetryBackoff wait.Backoff
 }
 
 type instancesAttrsCacheItem struct {
@@ -97,12 +113,16 @@ func (c *defaultInstancesCache) ListInstances(ctx context.Context, serviceID str
 }
 
 func (c *defaultInstancesCache) RegisterInstance(ctx context.Context, serviceID string, instanceID string, attrs instanceAttributes) error {
-	optResp, err := c.cloudMapSDK.RegisterInstanceWithContext(ctx, &servicediscovery.RegisterInstanceInput{
-		ServiceId:  aws.String(serviceID),
-		InstanceId: aws.String(instanceID),
-		Attributes: aws.StringMap(attrs),
-	})
-	if err != nil {
+	var optResp *servicediscovery.RegisterInstanceOutput
+	if err := clientgoretry.OnError(c.operationRetryBackoff, isRetryableOperationError, func() error {
+		var err error
+		optResp, err = c.cloudMapSDK.RegisterInstanceWithContext(ctx, &servicediscovery.RegisterInstanceInput{
+			ServiceId:  aws.String(serviceID),
+			InstanceId: aws.String(instanceID),
+			Attributes: aws.StringMap(attrs),
+		})
+		return err
+	}); err != nil {
 		return err
 	}
 	return wait.PollUntil(c.operationPollInterval, func() (done bool, err error) {
@@ -125,11 +145,15 @@ func (c *defaultInstancesCache) RegisterInstance(ctx context.Context, serviceID
 }
 
 func (c *defaultInstancesCache) DeregisterInstance(ctx context.Context, serviceID string, instanceID string) error {
-	optResp, err := c.cloudMapSDK.DeregisterInstanceWithContext(ctx, &servicediscovery.DeregisterInstanceInput{
-		ServiceId:  aws.String(serviceID),
-		InstanceId: aws.String(instanceID),
-	})
-	if err != nil {
+	var optResp *servicediscovery.DeregisterInstanceOutput
+	if err := clientgoretry.OnError(c.operationRetryBackoff, isRetryableOperationError, func() error {
+		var err error
+		optResp, err = c.cloudMapSDK.DeregisterInstanceWithContext(ctx, &servicediscovery.DeregisterInstanceInput{
+			ServiceId:  aws.String(serviceID),
+			InstanceId: aws.String(instanceID),
+		})
+		return err
+	}); err != nil {
 		return err
 	}
 
@@ -207,3 +231,8 @@ func (c *defaultInstancesCache) cloneInstanceAttributesByID(instanceAttrsByID ma
 	}
 	return instanceAttrsByIDClone
 }
+
+// isRetryableOperationError checks whether a failed registerInstance/deregisterInstance call is worth retrying.
+func isRetryableOperationError(err error) bool {
+	return request.IsErrorThrottle(err) || request.IsErrorRetryable(err)
+}
@@ -2,9 +2,15 @@ package cloudmap
 
 import (
 	"context"
+	"errors"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/servicediscovery"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"testing"
 	"time"
 )
@@ -415,3 +421,142 @@ func Test_defaultInstancesCache_recordSuccessfulDeregisterInstanceOperation(t *t
 		})
 	}
 }
+
+// flakyCloudMap fails register/deregister calls with err for the first failures calls, then succeeds.
+type flakyCloudMap struct {
+	services.CloudMap
+	failures int
+	err      error
+
+	registerCalls   int
+	deregisterCalls int
+}
+
+func (c *flakyCloudMap) RegisterInstanceWithContext(ctx aws.Context, input *servicediscovery.RegisterInstanceInput, opts ...request.Option) (*servicediscovery.RegisterInstanceOutput, error) {
+	c.registerCalls++
+	if c.registerCalls <= c.failures {
+		return nil, c.err
+	}
+	return &servicediscovery.RegisterInstanceOutput{OperationId: aws.String("register-op")}, nil
+}
+
+func (c *flakyCloudMap) DeregisterInstanceWithContext(ctx aws.Context, input *servicediscovery.DeregisterInstanceInput, opts ...request.Option) (*servicediscovery.DeregisterInstanceOutput, error) {
+	c.deregisterCalls++
+	if c.deregisterCalls <= c.failures {
+		return nil, c.err
+	}
+	return &servicediscovery.DeregisterInstanceOutput{OperationId: aws.String("deregister-op")}, nil
+}
+
+func (c *flakyCloudMap) GetOperationWithContext(ctx aws.Context, input *servicediscovery.GetOperationInput, opts ...request.Option) (*servicediscovery.GetOperationOutput, error) {
+	return &servicediscovery.GetOperationOutput{
+		Operation: &servicediscovery.Operation{
+			Id:         input.OperationId,
+			Status:     aws.String(servicediscovery.OperationStatusSuccess),
+			UpdateDate: aws.Time(time.Now()),
+		},
+	}, nil
+}
+
+func Test_defaultInstancesCache_RegisterInstance_retry(t *testing.T) {
+	throttlingErr := awserr.New("ThrottlingException", "Rate exceeded", nil)
+	tests := []struct {
+		name      string
+		cloudMap  *flakyCloudMap
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "succeeds without retry",
+			cloudMap:  &flakyCloudMap{},
+			wantCalls: 1,
+		},
+		{
+			name:      "throttled calls eventually succeed",
+			cloudMap:  &flakyCloudMap{failures: 2, err: throttlingErr},
+			wantCalls: 3,
+		},
+		{
+			name:      "persistently throttled calls give up after bounded attempts",
+			cloudMap:  &flakyCloudMap{failures: 10, err: throttlingErr},
+			wantCalls: 3,
+			wantErr:   throttlingErr,
+		},
+		{
+			name:      "non-retryable errors are not retried",
+			cloudMap:  &flakyCloudMap{failures: 1, err: errors.New("some error")},
+			wantCalls: 1,
+			wantErr:   errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &defaultInstancesCache{
+				cloudMapSDK:             tt.cloudMap,
+				instancesAttrsCache:     cache.NewLRUExpireCache(defaultInstanceAttrsCacheSize),
+				instancesAttrsCacheTTL:  defaultInstanceAttrsCacheTTL,
+				operationPollInterval:   time.Millisecond,
+				operationPollMaxRetries: defaultOperationPollMaxRetries,
+				operationRetryBackoff: wait.Backoff{
+					Steps:    3,
+					Duration: time.Millisecond,
+					Factor:   2.0,
+				},
+			}
+			err := c.RegisterInstance(context.Background(), "service-A", "192.168.1.1", instanceAttributes{
+				AttrAWSInstanceIPV4: "192.168.1.1",
+			})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, tt.cloudMap.registerCalls)
+		})
+	}
+}
+
+func Test_defaultInstancesCache_DeregisterInstance_retry(t *testing.T) {
+	throttlingErr := awserr.New("ThrottlingException", "Rate exceeded", nil)
+	tests := []struct {
+		name      string
+		cloudMap  *flakyCloudMap
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "throttled calls eventually succeed",
+			cloudMap:  &flakyCloudMap{failures: 2, err: throttlingErr},
+			wantCalls: 3,
+		},
+		{
+			name:      "persistently throttled calls give up after bounded attempts",
+			cloudMap:  &flakyCloudMap{failures: 10, err: throttlingErr},
+			wantCalls: 3,
+			wantErr:   throttlingErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &defaultInstancesCache{
+				cloudMapSDK:             tt.cloudMap,
+				instancesAttrsCache:     cache.NewLRUExpireCache(defaultInstanceAttrsCacheSize),
+				instancesAttrsCacheTTL:  defaultInstanceAttrsCacheTTL,
+				operationPollInterval:   time.Millisecond,
+				operationPollMaxRetries: defaultOperationPollMaxRetries,
+				operationRetryBackoff: wait.Backoff{
+					Steps:    3,
+					Duration: time.Millisecond,
+					Factor:   2.0,
+				},
+			}
+			err := c.DeregisterInstance(context.Background(), "service-A", "192.168.1.1")
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, tt.cloudMap.deregisterCalls)
+		})
+	}
+}
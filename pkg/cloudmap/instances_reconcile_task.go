@@ -15,6 +15,9 @@ const (
 	attrAWSInitHealthStatus = "AWS_INIT_HEALTH_STATUS"
 )
 
+// errCancelledByNewDesiredState is returned for a reconcile request superseded by a newer one.
+var errCancelledByNewDesiredState = errors.New("cancelled by new desired state")
+
 // newInstancesReconcileTask constructs new instancesReconcileTask for specific subset of cloudMap service.
 func newInstancesReconcileTask(cloudMapSDK services.CloudMap, instancesCache instancesCache, log logr.Logger, done chan struct{}) *instancesReconcileTask {
 	return &instancesReconcileTask{
@@ -72,7 +75,7 @@ func (t *instancesReconcileTask) Run(ctx context.Context) {
 			close(request.resultChan)
 			return
 		case newRequest := <-t.instancesReconcileRequestChan:
-			request.resultChan <- errors.New("cancelled by new desired state")
+			request.resultChan <- errCancelledByNewDesiredState
 			close(request.resultChan)
 			request = newRequest
 		case operationResult := <-t.instanceOperationCompletionChan:
@@ -4,12 +4,16 @@ import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/servicediscovery"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sync"
 	"time"
 )
 
@@ -35,14 +39,20 @@ const (
 	// how long to requeue a instances reconcile operation
 	defaultInstancesReconcileRequeueDuration = 10 * time.Second
 	defaultInstancesHealthProbeTimeout       = 30 * time.Minute
+	// how many consecutive instances reconcile failures of a virtualNode before a warning event is emitted
+	defaultInstancesReconcileFailureEventThreshold = 3
 )
 
 type InstancesReconciler interface {
 	Reconcile(ctx context.Context, ms *appmesh.Mesh, vn *appmesh.VirtualNode, service serviceSummary,
 		readyPods []*corev1.Pod, notReadyPods []*corev1.Pod, nodeInfoByName map[string]nodeAttributes) error
+	// Cleanup forgets the instances reconcile state kept for VirtualNode, once its CloudMap resources are deleted.
+	Cleanup(vn *appmesh.VirtualNode)
 }
 
-func NewDefaultInstancesReconciler(k8sClient client.Client, cloudMapSDK services.CloudMap, log logr.Logger, stopChan <-chan struct{}) *defaultInstancesReconciler {
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func NewDefaultInstancesReconciler(k8sClient client.Client, cloudMapSDK services.CloudMap, eventRecorder record.EventRecorder, log logr.Logger, stopChan <-chan struct{}, cloudMapConfig Config) *defaultInstancesReconciler {
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
 		select {
@@ -57,6 +67,10 @@ func NewDefaultInstancesReconciler(k8sClient client.Client, cloudMapSDK services
 		cloudMapSDK:               cloudMapSDK,
 		instancesReconcileReactor: instancesReconcileReactor,
 		instancesHealthProber:     instancesHealthProber,
+		podAnnotationAttributes:   cloudMapConfig.PodAnnotationAttributes,
+		eventRecorder:             eventRecorder,
+		failuresByVN:              make(map[types.NamespacedName]int),
+		failureEventThreshold:     defaultInstancesReconcileFailureEventThreshold,
 		log:                       log,
 	}
 }
@@ -67,7 +81,15 @@ type defaultInstancesReconciler struct {
 	cloudMapSDK               services.CloudMap
 	instancesReconcileReactor instancesReconcileReactor
 	instancesHealthProber     instancesHealthProber
+	podAnnotationAttributes   []string
+	eventRecorder             record.EventRecorder
 	log                       logr.Logger
+
+	// consecutive instances reconcile failures indexed by virtualNode.
+	failuresByVN      map[types.NamespacedName]int
+	failuresByVNMutex sync.Mutex
+	// number of consecutive failures after which a warning event is emitted.
+	failureEventThreshold int
 }
 
 func (r *defaultInstancesReconciler) Reconcile(ctx context.Context, ms *appmesh.Mesh, vn *appmesh.VirtualNode, service serviceSummary,
@@ -89,8 +111,12 @@ func (r *defaultInstancesReconciler) Reconcile(ctx context.Context, ms *appmesh.
 		return runtime.NewRequeueAfterError(nil, defaultInstancesReconcileRequeueDuration)
 	case err := <-resultChan:
 		if err != nil {
+			if err != errCancelledByNewDesiredState {
+				r.recordInstancesReconcileFailure(vn, err)
+			}
 			return runtime.NewRequeueError(err)
 		}
+		r.resetInstancesReconcileFailures(vn)
 	}
 	if customHealthCheckEnabled {
 		if err := r.reconcileCustomHealthCheck(ctx, service, readyInstanceInfoByID, notReadyInstanceInfoByID); err != nil {
@@ -125,6 +151,39 @@ func (r *defaultInstancesReconciler) reconcileCustomHealthCheck(ctx context.Cont
 	return nil
 }
 
+func (r *defaultInstancesReconciler) Cleanup(vn *appmesh.VirtualNode) {
+	r.resetInstancesReconcileFailures(vn)
+}
+
+// recordInstancesReconcileFailure counts a failed instances reconcile for vn,
+// and emits a warning event on vn every failureEventThreshold consecutive failures.
+func (r *defaultInstancesReconciler) recordInstancesReconcileFailure(vn *appmesh.VirtualNode, err error) {
+	r.failuresByVNMutex.Lock()
+	defer r.failuresByVNMutex.Unlock()
+	vnKey := k8s.NamespacedName(vn)
+	r.failuresByVN[vnKey]++
+	failures := r.failuresByVN[vnKey]
+	if failures%r.failureEventThreshold != 0 {
+		return
+	}
+	r.log.Info("cloudMap instances reconcile keeps failing",
+		"virtualNode", vnKey,
+		"failures", failures,
+		"error", err.Error(),
+	)
+	if r.eventRecorder != nil {
+		r.eventRecorder.Eventf(vn, corev1.EventTypeWarning, "CloudMapInstancesReconcileFailed",
+			"failed to reconcile cloudMap instances %d times in a row: %v", failures, err)
+	}
+}
+
+// resetInstancesReconcileFailures clears the failure count of vn after a successful instances reconcile.
+func (r *defaultInstancesReconciler) resetInstancesReconcileFailures(vn *appmesh.VirtualNode) {
+	r.failuresByVNMutex.Lock()
+	defer r.failuresByVNMutex.Unlock()
+	delete(r.failuresByVN, k8s.NamespacedName(vn))
+}
+
 // buildInstanceInfoByID build instances info indexed by instanceID
 func (r *defaultInstancesReconciler) buildInstanceInfoByID(ms *appmesh.Mesh, vn *appmesh.VirtualNode,
 	pods []*corev1.Pod, nodeInfoByName map[string]nodeAttributes) map[string]instanceInfo {
@@ -146,6 +205,11 @@ func (r *defaultInstancesReconciler) buildInstanceAttributes(ms *appmesh.Mesh, v
 	for label, v := range pod.Labels {
 		attr[label] = v
 	}
+	for _, annotation := range r.podAnnotationAttributes {
+		if v, ok := pod.Annotations[annotation]; ok {
+			attr[annotation] = v
+		}
+	}
 	for _, cmAttr := range vn.Spec.ServiceDiscovery.AWSCloudMap.Attributes {
 		attr[cmAttr.Key] = cmAttr.Value
 	}
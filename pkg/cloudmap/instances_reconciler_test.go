@@ -1,11 +1,15 @@
 package cloudmap
 
 import (
+	"errors"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -229,6 +233,92 @@ func Test_defaultInstancesReconciler_buildInstanceAttributes(t *testing.T) {
 	}
 }
 
+func Test_defaultInstancesReconciler_buildInstanceAttributes_podAnnotationAttributes(t *testing.T) {
+	ms := &appmesh.Mesh{
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+	}
+	vn := &appmesh.VirtualNode{
+		Spec: appmesh.VirtualNodeSpec{
+			AWSName: aws.String("my-vn"),
+			ServiceDiscovery: &appmesh.ServiceDiscovery{
+				AWSCloudMap: &appmesh.AWSCloudMapServiceDiscovery{},
+			},
+		},
+	}
+	tests := []struct {
+		name                    string
+		podAnnotationAttributes []string
+		podAnnotations          map[string]string
+		want                    instanceAttributes
+	}{
+		{
+			name:                    "configured annotation present on pod",
+			podAnnotationAttributes: []string{"app.kubernetes.io/version"},
+			podAnnotations: map[string]string{
+				"app.kubernetes.io/version": "v1.2.0",
+				"other-annotation":          "other-value",
+			},
+			want: instanceAttributes{
+				"app.kubernetes.io/version":   "v1.2.0",
+				"AWS_INSTANCE_IPV4":           "192.168.1.42",
+				"k8s.io/pod":                  "pod-name",
+				"k8s.io/namespace":            "pod-ns",
+				"appmesh.k8s.aws/mesh":        "my-mesh",
+				"appmesh.k8s.aws/virtualNode": "my-vn",
+			},
+		},
+		{
+			name:                    "configured annotation absent on pod",
+			podAnnotationAttributes: []string{"app.kubernetes.io/version"},
+			podAnnotations: map[string]string{
+				"other-annotation": "other-value",
+			},
+			want: instanceAttributes{
+				"AWS_INSTANCE_IPV4":           "192.168.1.42",
+				"k8s.io/pod":                  "pod-name",
+				"k8s.io/namespace":            "pod-ns",
+				"appmesh.k8s.aws/mesh":        "my-mesh",
+				"appmesh.k8s.aws/virtualNode": "my-vn",
+			},
+		},
+		{
+			name:                    "no annotation configured",
+			podAnnotationAttributes: nil,
+			podAnnotations: map[string]string{
+				"app.kubernetes.io/version": "v1.2.0",
+			},
+			want: instanceAttributes{
+				"AWS_INSTANCE_IPV4":           "192.168.1.42",
+				"k8s.io/pod":                  "pod-name",
+				"k8s.io/namespace":            "pod-ns",
+				"appmesh.k8s.aws/mesh":        "my-mesh",
+				"appmesh.k8s.aws/virtualNode": "my-vn",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &defaultInstancesReconciler{
+				podAnnotationAttributes: tt.podAnnotationAttributes,
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "pod-ns",
+					Name:        "pod-name",
+					Annotations: tt.podAnnotations,
+				},
+				Status: corev1.PodStatus{
+					PodIP: "192.168.1.42",
+				},
+			}
+			got := r.buildInstanceAttributes(ms, vn, pod, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_defaultInstancesReconciler_buildInstanceID(t *testing.T) {
 	type args struct {
 		pod *corev1.Pod
@@ -421,3 +511,96 @@ func Test_defaultInstancesReconciler_buildInstanceInfoByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultInstancesReconciler_recordInstancesReconcileFailure(t *testing.T) {
+	vn := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "my-vn",
+		},
+	}
+	tests := []struct {
+		name       string
+		failures   int
+		resetAfter int
+		wantEvents []string
+	}{
+		{
+			name:       "no event below threshold",
+			failures:   2,
+			wantEvents: nil,
+		},
+		{
+			name:     "event emitted once threshold reached",
+			failures: 4,
+			wantEvents: []string{
+				"Warning CloudMapInstancesReconcileFailed failed to reconcile cloudMap instances 3 times in a row: ThrottlingException",
+			},
+		},
+		{
+			name:     "event emitted every threshold failures",
+			failures: 6,
+			wantEvents: []string{
+				"Warning CloudMapInstancesReconcileFailed failed to reconcile cloudMap instances 3 times in a row: ThrottlingException",
+				"Warning CloudMapInstancesReconcileFailed failed to reconcile cloudMap instances 6 times in a row: ThrottlingException",
+			},
+		},
+		{
+			name:       "success resets failures",
+			failures:   4,
+			resetAfter: 2,
+			wantEvents: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &defaultInstancesReconciler{
+				eventRecorder:         eventRecorder,
+				failuresByVN:          make(map[types.NamespacedName]int),
+				failureEventThreshold: 3,
+				log:                   &log.NullLogger{},
+			}
+			for i := 1; i <= tt.failures; i++ {
+				r.recordInstancesReconcileFailure(vn, errors.New("ThrottlingException"))
+				if i == tt.resetAfter {
+					r.resetInstancesReconcileFailures(vn)
+				}
+			}
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_defaultInstancesReconciler_Cleanup(t *testing.T) {
+	vn := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "my-vn",
+		},
+	}
+	otherVN := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "other-vn",
+		},
+	}
+	r := &defaultInstancesReconciler{
+		eventRecorder:         record.NewFakeRecorder(10),
+		failuresByVN:          make(map[types.NamespacedName]int),
+		failureEventThreshold: 3,
+		log:                   &log.NullLogger{},
+	}
+	r.recordInstancesReconcileFailure(vn, errors.New("ThrottlingException"))
+	r.recordInstancesReconcileFailure(otherVN, errors.New("ThrottlingException"))
+
+	r.Cleanup(vn)
+	assert.Equal(t, map[types.NamespacedName]int{
+		{Namespace: "my-ns", Name: "other-vn"}: 1,
+	}, r.failuresByVN)
+}
@@ -2,8 +2,12 @@ package cloudmap
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/servicediscovery"
@@ -12,6 +16,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"time"
@@ -27,6 +32,7 @@ const (
 	defaultNamespaceCacheTTL               = 2 * time.Minute
 	defaultServiceCacheMaxSize             = 1024
 	defaultServiceCacheTTL                 = 2 * time.Minute
+	namespaceCreationRequeueDelay          = 10 * time.Second
 
 	nodeRegionLabelKey1           = "failure-domain.beta.kubernetes.io/region"
 	nodeRegionLabelKey2           = "topology.kubernetes.io/region"
@@ -36,6 +42,22 @@ const (
 	cloudMapServiceAnnotation = "cloudMapServiceARN"
 )
 
+const (
+	reasonMeshDependencyNotResolved      = "MeshDependencyNotResolved"
+	reasonCloudMapNamespaceNotReady      = "CloudMapNamespaceNotReady"
+	reasonCloudMapServiceNotReady        = "CloudMapServiceNotReady"
+	reasonCloudMapInstancesNotReconciled = "CloudMapInstancesNotReconciled"
+)
+
+// cloudMapReconciledMessageByReason has the CloudMapReconciled condition message for each failure reason.
+// the message is kept stable across retries so that failures don't update the condition, the error itself is reported in events.
+var cloudMapReconciledMessageByReason = map[string]string{
+	reasonMeshDependencyNotResolved:      "mesh of virtualNode isn't resolved",
+	reasonCloudMapNamespaceNotReady:      "cloudMap namespace isn't ready",
+	reasonCloudMapServiceNotReady:        "cloudMap service isn't ready",
+	reasonCloudMapInstancesNotReconciled: "cloudMap instances aren't reconciled",
+}
+
 type ResourceManager interface {
 	// Reconcile will create/update AppMesh CloudMap Resources
 	Reconcile(ctx context.Context, vn *appmesh.VirtualNode) error
@@ -51,6 +73,7 @@ func NewDefaultResourceManager(
 	virtualNodeEndpointResolver VirtualNodeEndpointResolver,
 	instancesReconciler InstancesReconciler,
 	enableCustomHealthCheck bool,
+	eventRecorder record.EventRecorder,
 	log logr.Logger,
 	cfg Config) ResourceManager {
 
@@ -62,6 +85,7 @@ func NewDefaultResourceManager(
 		virtualNodeEndpointResolver: virtualNodeEndpointResolver,
 		instancesReconciler:         instancesReconciler,
 		enableCustomHealthCheck:     enableCustomHealthCheck,
+		eventRecorder:               eventRecorder,
 		namespaceSummaryCache:       cache.NewLRUExpireCache(defaultNamespaceCacheMaxSize),
 		serviceSummaryCache:         cache.NewLRUExpireCache(defaultServiceCacheMaxSize),
 		log:                         log,
@@ -77,6 +101,7 @@ type defaultResourceManager struct {
 	virtualNodeEndpointResolver VirtualNodeEndpointResolver
 	instancesReconciler         InstancesReconciler
 	enableCustomHealthCheck     bool
+	eventRecorder               record.EventRecorder
 
 	namespaceSummaryCache *cache.LRUExpireCache
 	serviceSummaryCache   *cache.LRUExpireCache
@@ -84,37 +109,62 @@ type defaultResourceManager struct {
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, vn *appmesh.VirtualNode) error {
+	reason, err := m.reconcileCloudMapResources(ctx, vn)
+	if updateErr := m.updateCRDVirtualNodeCloudMapReconciled(ctx, vn, reason, err); updateErr != nil && err == nil {
+		return updateErr
+	}
+	return err
+}
+
+// reconcileCloudMapResources reconciles the CloudMap namespace, service and instances for virtualNode.
+// returns the reason of the sub-step that failed along with the error.
+func (m *defaultResourceManager) reconcileCloudMapResources(ctx context.Context, vn *appmesh.VirtualNode) (string, error) {
 	ms, err := m.findMeshDependency(ctx, vn)
 	if err != nil {
-		return err
+		return reasonMeshDependencyNotResolved, err
 	}
 	cloudMapConfig := vn.Spec.ServiceDiscovery.AWSCloudMap
-	nsSummary, err := m.findCloudMapNamespace(ctx, cloudMapConfig.NamespaceName)
+	nsSummary, err := m.resolveCloudMapNamespace(ctx, cloudMapConfig)
 	if err != nil {
-		return err
+		return reasonCloudMapNamespaceNotReady, err
 	}
 	if nsSummary == nil {
-		return fmt.Errorf("cloudMap namespace not found: %v", cloudMapConfig.NamespaceName)
+		// namespaces referenced by ID must exist, the ID is assigned by CloudMap on creation
+		if cloudMapConfig.NamespaceID != "" {
+			return reasonCloudMapNamespaceNotReady, fmt.Errorf("cloudMap namespace not found: %v", cloudMapConfig.NamespaceID)
+		}
+		if !m.config.CreateNamespace {
+			return reasonCloudMapNamespaceNotReady, fmt.Errorf("cloudMap namespace not found: %v", cloudMapConfig.NamespaceName)
+		}
+		nsSummary, err = m.createCloudMapNamespace(ctx, cloudMapConfig.NamespaceName)
+		if err != nil {
+			return reasonCloudMapNamespaceNotReady, err
+		}
+	}
+	if err := m.updateCRDVirtualNodeCloudMapNamespace(ctx, vn, nsSummary); err != nil {
+		return reasonCloudMapNamespaceNotReady, err
 	}
 	svcSummary, err := m.findCloudMapService(ctx, nsSummary, cloudMapConfig.ServiceName)
 	if err != nil {
-		return err
+		return reasonCloudMapServiceNotReady, err
 	}
 	if svcSummary == nil {
 		svcSummary, err = m.createCloudMapService(ctx, vn, nsSummary, cloudMapConfig.ServiceName, m.config.CloudMapServiceTTL)
 		if err != nil {
-			return err
+			return reasonCloudMapServiceNotReady, err
 		}
+	} else {
+		m.checkCloudMapServiceHealthCheckConfig(vn, svcSummary)
 	}
 
 	if err := m.updateCRDVirtualNode(ctx, vn, svcSummary); err != nil {
-		return err
+		return reasonCloudMapServiceNotReady, err
 	}
 
 	if vn.Spec.PodSelector != nil {
 		readyPods, notReadyPods, _, err := m.virtualNodeEndpointResolver.Resolve(ctx, vn)
 		if err != nil {
-			return err
+			return reasonCloudMapInstancesNotReconciled, err
 		}
 		m.log.V(1).Info("resolved VirtualNode endpoints",
 			"readyPods", len(readyPods),
@@ -122,20 +172,30 @@ func (m *defaultResourceManager) Reconcile(ctx context.Context, vn *appmesh.Virt
 		)
 		nodeInfoByName := m.getClusterNodeInfo(ctx)
 		if err := m.instancesReconciler.Reconcile(ctx, ms, vn, *svcSummary, readyPods, notReadyPods, nodeInfoByName); err != nil {
-			return err
+			return reasonCloudMapInstancesNotReconciled, err
 		}
 	}
 
-	return nil
+	return "", nil
 }
 
 func (m *defaultResourceManager) Cleanup(ctx context.Context, vn *appmesh.VirtualNode) error {
+	if err := m.cleanupCloudMapResources(ctx, vn); err != nil {
+		return err
+	}
+	// the virtualNode is gone once its finalizer is removed, so no more instances reconcile failures can be reported.
+	m.instancesReconciler.Cleanup(vn)
+	return nil
+}
+
+// cleanupCloudMapResources deregisters the CloudMap instances of virtualNode and deletes its CloudMap service.
+func (m *defaultResourceManager) cleanupCloudMapResources(ctx context.Context, vn *appmesh.VirtualNode) error {
 	ms, err := m.findMeshDependency(ctx, vn)
 	if err != nil {
 		return err
 	}
 	cloudMapConfig := vn.Spec.ServiceDiscovery.AWSCloudMap
-	nsSummary, err := m.findCloudMapNamespace(ctx, cloudMapConfig.NamespaceName)
+	nsSummary, err := m.resolveCloudMapNamespace(ctx, cloudMapConfig)
 	if err != nil {
 		if !m.isCloudMapServiceCreated(ctx, vn) {
 			return nil
@@ -176,31 +236,82 @@ func (m *defaultResourceManager) findMeshDependency(ctx context.Context, vn *app
 	return ms, nil
 }
 
-// findCloudMapNamespaceFromAWS will try to find CloudMapNamespace from cache and AWS(if cache miss). returns nil if not found
+// resolveCloudMapNamespace finds the CloudMap namespace referenced by namespaceID or namespaceName of cloudMapConfig. returns nil if not found
+// when both are specified, namespaceID takes precedence and the namespace found must have namespaceName as well.
+func (m *defaultResourceManager) resolveCloudMapNamespace(ctx context.Context, cloudMapConfig *appmesh.AWSCloudMapServiceDiscovery) (*servicediscovery.NamespaceSummary, error) {
+	if cloudMapConfig.NamespaceID == "" {
+		return m.findCloudMapNamespace(ctx, cloudMapConfig.NamespaceName)
+	}
+	nsSummary, err := m.findCloudMapNamespaceByID(ctx, cloudMapConfig.NamespaceID)
+	if err != nil || nsSummary == nil {
+		return nsSummary, err
+	}
+	if cloudMapConfig.NamespaceName != "" && awssdk.StringValue(nsSummary.Name) != cloudMapConfig.NamespaceName {
+		return nil, errors.Errorf("cloudMap namespace %v has name %v, conflicting with namespaceName %v",
+			cloudMapConfig.NamespaceID, awssdk.StringValue(nsSummary.Name), cloudMapConfig.NamespaceName)
+	}
+	return nsSummary, nil
+}
+
+// findCloudMapNamespace will try to find CloudMapNamespace by name from cache and AWS(if cache miss). returns nil if not found
 func (m *defaultResourceManager) findCloudMapNamespace(ctx context.Context, namespaceName string) (*servicediscovery.NamespaceSummary, error) {
-	if cachedValue, exists := m.namespaceSummaryCache.Get(namespaceName); exists {
+	return m.findCloudMapNamespaceWithCache(ctx, namespaceName, func(ns *servicediscovery.NamespaceSummary) bool {
+		return awssdk.StringValue(ns.Name) == namespaceName
+	})
+}
+
+// findCloudMapNamespaceByID will try to find CloudMapNamespace by ID from cache and AWS(if cache miss). returns nil if not found
+func (m *defaultResourceManager) findCloudMapNamespaceByID(ctx context.Context, namespaceID string) (*servicediscovery.NamespaceSummary, error) {
+	// namespace names are DNS names, so an ID cache key prefixed with "/" never collides with a name
+	cacheKey := "/" + namespaceID
+	if cachedValue, exists := m.namespaceSummaryCache.Get(cacheKey); exists {
 		cacheItem := cachedValue.(*servicediscovery.NamespaceSummary)
 		return cacheItem, nil
 	}
 
-	nsSummary, err := m.findCloudMapNamespaceFromAWS(ctx, namespaceName)
+	resp, err := m.cloudMapSDK.GetNamespaceWithContext(ctx, &servicediscovery.GetNamespaceInput{
+		Id: awssdk.String(namespaceID),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == servicediscovery.ErrCodeNamespaceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	nsSummary := &servicediscovery.NamespaceSummary{
+		Arn:  resp.Namespace.Arn,
+		Id:   resp.Namespace.Id,
+		Name: resp.Namespace.Name,
+		Type: resp.Namespace.Type,
+	}
+	m.namespaceSummaryCache.Add(cacheKey, nsSummary, defaultNamespaceCacheTTL)
+	return nsSummary, nil
+}
+
+func (m *defaultResourceManager) findCloudMapNamespaceWithCache(ctx context.Context, cacheKey string, match func(ns *servicediscovery.NamespaceSummary) bool) (*servicediscovery.NamespaceSummary, error) {
+	if cachedValue, exists := m.namespaceSummaryCache.Get(cacheKey); exists {
+		cacheItem := cachedValue.(*servicediscovery.NamespaceSummary)
+		return cacheItem, nil
+	}
+
+	nsSummary, err := m.findCloudMapNamespaceFromAWS(ctx, match)
 	if err != nil {
 		return nil, err
 	}
 	if nsSummary != nil {
-		m.namespaceSummaryCache.Add(namespaceName, nsSummary, defaultNamespaceCacheTTL)
+		m.namespaceSummaryCache.Add(cacheKey, nsSummary, defaultNamespaceCacheTTL)
 	}
 	return nsSummary, nil
 }
 
-// findCloudMapNamespaceFromAWS will try to find CloudMapNamespace directly from AWS. returns nil if not found
-func (m *defaultResourceManager) findCloudMapNamespaceFromAWS(ctx context.Context, namespaceName string) (*servicediscovery.NamespaceSummary, error) {
+// findCloudMapNamespaceFromAWS will try to find CloudMapNamespace matching given predicate directly from AWS. returns nil if not found
+func (m *defaultResourceManager) findCloudMapNamespaceFromAWS(ctx context.Context, match func(ns *servicediscovery.NamespaceSummary) bool) (*servicediscovery.NamespaceSummary, error) {
 	listNamespacesInput := &servicediscovery.ListNamespacesInput{}
 	var nsSummary *servicediscovery.NamespaceSummary
 	if err := m.cloudMapSDK.ListNamespacesPagesWithContext(ctx, listNamespacesInput,
 		func(listNamespacesOutput *servicediscovery.ListNamespacesOutput, lastPage bool) bool {
 			for _, ns := range listNamespacesOutput.Namespaces {
-				if awssdk.StringValue(ns.Name) == namespaceName {
+				if match(ns) {
 					nsSummary = ns
 					return false
 				}
@@ -214,6 +325,93 @@ func (m *defaultResourceManager) findCloudMapNamespaceFromAWS(ctx context.Contex
 	return nsSummary, nil
 }
 
+// createCloudMapNamespace creates a private DNS namespace in the configured VPC.
+// It requeues until the namespace creation is completed instead of waiting for it.
+// createCloudMapNamespace creates a private DNS namespace, and requeues until its creation operation completes.
+// the creatorRequestID is derived from namespace settings, so concurrent or repeated creations of the same namespace share one operation.
+// CloudMap keeps returning that operation after the namespace it created got deleted,
+// in which case the creatorRequestID is salted with the deleted namespace ID to create the namespace again.
+func (m *defaultResourceManager) createCloudMapNamespace(ctx context.Context, namespaceName string) (*servicediscovery.NamespaceSummary, error) {
+	deletedNamespaceID := ""
+	for {
+		creatorRequestID := buildCloudMapNamespaceCreatorRequestID(namespaceName, m.config.NamespaceVPCID, deletedNamespaceID)
+		operationID, err := m.startCloudMapNamespaceCreation(ctx, namespaceName, creatorRequestID)
+		if err != nil {
+			return nil, err
+		}
+		if operationID == nil {
+			return m.findCreatedCloudMapNamespace(ctx, namespaceName)
+		}
+		getOptResp, err := m.cloudMapSDK.GetOperationWithContext(ctx, &servicediscovery.GetOperationInput{
+			OperationId: operationID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		operation := getOptResp.Operation
+		if awssdk.StringValue(operation.Status) == servicediscovery.OperationStatusFail {
+			return nil, errors.Errorf("failed to create cloudMap namespace %v: %v", namespaceName, awssdk.StringValue(operation.ErrorMessage))
+		}
+		if awssdk.StringValue(operation.Status) != servicediscovery.OperationStatusSuccess {
+			return nil, runtime.NewRequeueAfterError(errors.Errorf("cloudMap namespace %v is being created", namespaceName), namespaceCreationRequeueDelay)
+		}
+
+		namespaceID := awssdk.StringValue(operation.Targets[servicediscovery.OperationTargetTypeNamespace])
+		if namespaceID == "" {
+			return m.findCreatedCloudMapNamespace(ctx, namespaceName)
+		}
+		nsSummary, err := m.findCloudMapNamespaceByID(ctx, namespaceID)
+		if err != nil {
+			return nil, err
+		}
+		if nsSummary != nil {
+			m.log.Info("created cloudMap namespace",
+				"namespaceName", namespaceName,
+				"namespaceID", namespaceID,
+				"vpcID", m.config.NamespaceVPCID,
+			)
+			return nsSummary, nil
+		}
+		m.log.Info("cloudMap namespace created before is deleted, creating it again",
+			"namespaceName", namespaceName,
+			"deletedNamespaceID", namespaceID,
+		)
+		deletedNamespaceID = namespaceID
+	}
+}
+
+// startCloudMapNamespaceCreation starts creation of a private DNS namespace and returns its operation ID,
+// a creation already in progress under creatorRequestID is reused. returns nil if namespace already exists.
+func (m *defaultResourceManager) startCloudMapNamespaceCreation(ctx context.Context, namespaceName string, creatorRequestID string) (*string, error) {
+	resp, err := m.cloudMapSDK.CreatePrivateDnsNamespaceWithContext(ctx, &servicediscovery.CreatePrivateDnsNamespaceInput{
+		CreatorRequestId: awssdk.String(creatorRequestID),
+		Name:             awssdk.String(namespaceName),
+		Vpc:              awssdk.String(m.config.NamespaceVPCID),
+	})
+	if err != nil {
+		if duplicateErr, ok := err.(*servicediscovery.DuplicateRequest); ok {
+			return duplicateErr.DuplicateOperationId, nil
+		}
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == servicediscovery.ErrCodeNamespaceAlreadyExists {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to create cloudMap namespace %v", namespaceName)
+	}
+	return resp.OperationId, nil
+}
+
+// findCreatedCloudMapNamespace finds a CloudMap namespace that is created, and requeues if it isn't listed yet.
+func (m *defaultResourceManager) findCreatedCloudMapNamespace(ctx context.Context, namespaceName string) (*servicediscovery.NamespaceSummary, error) {
+	nsSummary, err := m.findCloudMapNamespace(ctx, namespaceName)
+	if err != nil {
+		return nil, err
+	}
+	if nsSummary == nil {
+		return nil, runtime.NewRequeueAfterError(errors.Errorf("cloudMap namespace %v is being created", namespaceName), namespaceCreationRequeueDelay)
+	}
+	return nsSummary, nil
+}
+
 func (m *defaultResourceManager) findCloudMapService(ctx context.Context, nsSummary *servicediscovery.NamespaceSummary, serviceName string) (*serviceSummary, error) {
 	cacheKey := m.buildCloudMapServiceSummaryCacheKey(nsSummary, serviceName)
 	if cachedValue, exists := m.serviceSummaryCache.Get(cacheKey); exists {
@@ -348,11 +546,7 @@ func (m *defaultResourceManager) createCloudMapServiceUnderPrivateDNSNamespace(c
 				},
 			},
 		},
-	}
-	if m.enableCustomHealthCheck {
-		createServiceInput.HealthCheckCustomConfig = &servicediscovery.HealthCheckCustomConfig{
-			FailureThreshold: awssdk.Int64(defaultServiceCustomHCFailureThreshold),
-		}
+		HealthCheckCustomConfig: m.buildCloudMapServiceHealthCheckCustomConfig(vn),
 	}
 
 	resp, err := m.cloudMapSDK.CreateServiceWithContext(ctx, createServiceInput)
@@ -366,14 +560,10 @@ func (m *defaultResourceManager) createCloudMapServiceUnderHTTPNamespace(ctx con
 	nsSummary *servicediscovery.NamespaceSummary, serviceName string) (*servicediscovery.Service, error) {
 	creatorRequestID := string(vn.UID)
 	createServiceInput := &servicediscovery.CreateServiceInput{
-		CreatorRequestId: awssdk.String(creatorRequestID),
-		NamespaceId:      nsSummary.Id,
-		Name:             awssdk.String(serviceName),
-	}
-	if m.enableCustomHealthCheck {
-		createServiceInput.HealthCheckCustomConfig = &servicediscovery.HealthCheckCustomConfig{
-			FailureThreshold: awssdk.Int64(defaultServiceCustomHCFailureThreshold),
-		}
+		CreatorRequestId:        awssdk.String(creatorRequestID),
+		NamespaceId:             nsSummary.Id,
+		Name:                    awssdk.String(serviceName),
+		HealthCheckCustomConfig: m.buildCloudMapServiceHealthCheckCustomConfig(vn),
 	}
 	resp, err := m.cloudMapSDK.CreateServiceWithContext(ctx, createServiceInput)
 	if err != nil {
@@ -382,6 +572,40 @@ func (m *defaultResourceManager) createCloudMapServiceUnderHTTPNamespace(ctx con
 	return resp.Service, nil
 }
 
+// buildCloudMapServiceHealthCheckCustomConfig builds the custom health check config of the CloudMap service for VirtualNode.
+// the healthCheckConfig of VirtualNode takes precedence over the enable-custom-health-check default.
+func (m *defaultResourceManager) buildCloudMapServiceHealthCheckCustomConfig(vn *appmesh.VirtualNode) *servicediscovery.HealthCheckCustomConfig {
+	if hcConfig := vn.Spec.ServiceDiscovery.AWSCloudMap.HealthCheckConfig; hcConfig != nil {
+		return &servicediscovery.HealthCheckCustomConfig{
+			FailureThreshold: awssdk.Int64(hcConfig.FailureThreshold),
+		}
+	}
+	if m.enableCustomHealthCheck {
+		return &servicediscovery.HealthCheckCustomConfig{
+			FailureThreshold: awssdk.Int64(defaultServiceCustomHCFailureThreshold),
+		}
+	}
+	return nil
+}
+
+// checkCloudMapServiceHealthCheckConfig logs when an existing CloudMap service doesn't use the healthCheckConfig of VirtualNode.
+// CloudMap doesn't allow changing the custom health check config of a service, it must be recreated to use it.
+func (m *defaultResourceManager) checkCloudMapServiceHealthCheckConfig(vn *appmesh.VirtualNode, svcSummary *serviceSummary) {
+	if vn.Spec.ServiceDiscovery.AWSCloudMap.HealthCheckConfig == nil {
+		return
+	}
+	desired := m.buildCloudMapServiceHealthCheckCustomConfig(vn)
+	if svcSummary.healthCheckCustomConfig != nil &&
+		awssdk.Int64Value(svcSummary.healthCheckCustomConfig.FailureThreshold) == awssdk.Int64Value(desired.FailureThreshold) {
+		return
+	}
+	m.log.Info("cloudMap service health check config doesn't match VirtualNode healthCheckConfig, recreate the service to apply it",
+		"virtualNode", k8s.NamespacedName(vn),
+		"serviceID", svcSummary.serviceID,
+		"failureThreshold", awssdk.Int64Value(desired.FailureThreshold),
+	)
+}
+
 func (m *defaultResourceManager) addCloudMapServiceToServiceSummaryCache(nsSummary *servicediscovery.NamespaceSummary, service *servicediscovery.Service) *serviceSummary {
 	cacheKey := m.buildCloudMapServiceSummaryCacheKey(nsSummary, awssdk.StringValue(service.Name))
 	svcSummary := &serviceSummary{
@@ -408,6 +632,17 @@ func (m *defaultResourceManager) buildCloudMapServiceSummaryCacheKey(nsSummary *
 	return fmt.Sprintf("%s/%s", awssdk.StringValue(nsSummary.Id), serviceName)
 }
 
+// buildCloudMapNamespaceCreatorRequestID builds an idempotency token that fits the 64 characters limit of creatorRequestId.
+// deletedNamespaceID salts the token once the namespace created by the unsalted one is deleted.
+func buildCloudMapNamespaceCreatorRequestID(namespaceName string, vpcID string, deletedNamespaceID string) string {
+	key := fmt.Sprintf("%s/%s", vpcID, namespaceName)
+	if deletedNamespaceID != "" {
+		key = fmt.Sprintf("%s/%s", key, deletedNamespaceID)
+	}
+	checksum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("appmesh-controller-%x", checksum[:16])
+}
+
 func (m *defaultResourceManager) getClusterNodeInfo(ctx context.Context) map[string]nodeAttributes {
 	nodeList := &corev1.NodeList{}
 	if err := m.k8sClient.List(ctx, nodeList); err != nil {
@@ -466,3 +701,35 @@ func (m *defaultResourceManager) updateCRDVirtualNode(ctx context.Context, vn *a
 	vn.Annotations[cloudMapServiceAnnotation] = *svcSummary.serviceARN
 	return m.k8sClient.Patch(ctx, vn, client.MergeFrom(oldVN))
 }
+
+// updateCRDVirtualNodeCloudMapNamespace records the name of the CloudMap namespace of vn referenced by namespaceID only into its status,
+// which is needed to create the AppMesh VirtualNode for vn.
+func (m *defaultResourceManager) updateCRDVirtualNodeCloudMapNamespace(ctx context.Context, vn *appmesh.VirtualNode, nsSummary *servicediscovery.NamespaceSummary) error {
+	if vn.Spec.ServiceDiscovery.AWSCloudMap.NamespaceName != "" {
+		return nil
+	}
+	if awssdk.StringValue(vn.Status.CloudMapNamespaceName) == awssdk.StringValue(nsSummary.Name) {
+		return nil
+	}
+	oldVN := vn.DeepCopy()
+	vn.Status.CloudMapNamespaceName = nsSummary.Name
+	return m.k8sClient.Status().Patch(ctx, vn, client.MergeFrom(oldVN))
+}
+
+// updateCRDVirtualNodeCloudMapReconciled records the result of CloudMap reconciliation into virtualNode's CloudMapReconciled condition.
+// other status such as the VirtualNodeActive condition is owned by the virtualNode reconciler and is left untouched.
+func (m *defaultResourceManager) updateCRDVirtualNodeCloudMapReconciled(ctx context.Context, vn *appmesh.VirtualNode, reason string, reconcileErr error) error {
+	oldVN := vn.DeepCopy()
+	var needsUpdate bool
+	if reconcileErr == nil {
+		needsUpdate = virtualnode.UpdateCondition(vn, appmesh.VirtualNodeCloudMapReconciled, corev1.ConditionTrue, nil, nil)
+	} else {
+		m.eventRecorder.Event(vn, corev1.EventTypeWarning, reason, reconcileErr.Error())
+		message := cloudMapReconciledMessageByReason[reason]
+		needsUpdate = virtualnode.UpdateCondition(vn, appmesh.VirtualNodeCloudMapReconciled, corev1.ConditionFalse, &reason, &message)
+	}
+	if !needsUpdate {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, vn, client.MergeFrom(oldVN))
+}
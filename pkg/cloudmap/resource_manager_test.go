@@ -3,14 +3,26 @@ package cloudmap
 import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/equality"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
+	appmeshruntime "github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/servicediscovery"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/cache"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
@@ -161,3 +173,653 @@ func Test_defaultResourceManager_isCloudMapServiceCreated(t *testing.T) {
 		})
 	}
 }
+
+// fakeCloudMap serves CloudMap namespaces and records namespace creations.
+// namespaceOperations are the namespace creation operations by creatorRequestId.
+type fakeCloudMap struct {
+	services.CloudMap
+	namespaces                  []*servicediscovery.NamespaceSummary
+	listNamespacesErr           error
+	createNamespaceErr          error
+	namespaceOperations         map[string]*servicediscovery.Operation
+	createPrivateDNSNamespaceIn []*servicediscovery.CreatePrivateDnsNamespaceInput
+	getOperationIn              []string
+	createServiceIn             []*servicediscovery.CreateServiceInput
+}
+
+func (f *fakeCloudMap) ListNamespacesPagesWithContext(_ context.Context, _ *servicediscovery.ListNamespacesInput, fn func(*servicediscovery.ListNamespacesOutput, bool) bool, _ ...request.Option) error {
+	if f.listNamespacesErr != nil {
+		return f.listNamespacesErr
+	}
+	fn(&servicediscovery.ListNamespacesOutput{Namespaces: f.namespaces}, true)
+	return nil
+}
+
+func (f *fakeCloudMap) GetNamespaceWithContext(_ context.Context, input *servicediscovery.GetNamespaceInput, _ ...request.Option) (*servicediscovery.GetNamespaceOutput, error) {
+	for _, ns := range f.namespaces {
+		if aws.StringValue(ns.Id) == aws.StringValue(input.Id) {
+			return &servicediscovery.GetNamespaceOutput{
+				Namespace: &servicediscovery.Namespace{
+					Arn:  ns.Arn,
+					Id:   ns.Id,
+					Name: ns.Name,
+					Type: ns.Type,
+				},
+			}, nil
+		}
+	}
+	return nil, awserr.New(servicediscovery.ErrCodeNamespaceNotFound, "namespace not found", nil)
+}
+
+func (f *fakeCloudMap) CreatePrivateDnsNamespaceWithContext(_ context.Context, input *servicediscovery.CreatePrivateDnsNamespaceInput, _ ...request.Option) (*servicediscovery.CreatePrivateDnsNamespaceOutput, error) {
+	f.createPrivateDNSNamespaceIn = append(f.createPrivateDNSNamespaceIn, input)
+	if f.createNamespaceErr != nil {
+		return nil, f.createNamespaceErr
+	}
+	operation, ok := f.namespaceOperations[aws.StringValue(input.CreatorRequestId)]
+	if !ok {
+		return nil, awserr.New(servicediscovery.ErrCodeInvalidInput, "unexpected creatorRequestId", nil)
+	}
+	return &servicediscovery.CreatePrivateDnsNamespaceOutput{OperationId: operation.Id}, nil
+}
+
+func (f *fakeCloudMap) CreateServiceWithContext(_ context.Context, input *servicediscovery.CreateServiceInput, _ ...request.Option) (*servicediscovery.CreateServiceOutput, error) {
+	f.createServiceIn = append(f.createServiceIn, input)
+	return &servicediscovery.CreateServiceOutput{
+		Service: &servicediscovery.Service{
+			Id:                      aws.String("srv-1"),
+			Arn:                     aws.String("arn:aws:servicediscovery:us-west-2:000000000000:service/srv-1"),
+			Name:                    input.Name,
+			NamespaceId:             input.NamespaceId,
+			HealthCheckCustomConfig: input.HealthCheckCustomConfig,
+		},
+	}, nil
+}
+
+func (f *fakeCloudMap) GetOperationWithContext(_ context.Context, input *servicediscovery.GetOperationInput, _ ...request.Option) (*servicediscovery.GetOperationOutput, error) {
+	f.getOperationIn = append(f.getOperationIn, aws.StringValue(input.OperationId))
+	for _, operation := range f.namespaceOperations {
+		if aws.StringValue(operation.Id) == aws.StringValue(input.OperationId) {
+			return &servicediscovery.GetOperationOutput{Operation: operation}, nil
+		}
+	}
+	return nil, awserr.New(servicediscovery.ErrCodeOperationNotFound, "operation not found", nil)
+}
+
+func Test_defaultResourceManager_createCloudMapNamespace(t *testing.T) {
+	nsSummary := &servicediscovery.NamespaceSummary{
+		Id:   aws.String("ns-1"),
+		Name: aws.String("my-ns.local"),
+		Type: aws.String(servicediscovery.NamespaceTypeDnsPrivate),
+	}
+	creatorRequestID := buildCloudMapNamespaceCreatorRequestID("my-ns.local", "vpc-1", "")
+	saltedCreatorRequestID := buildCloudMapNamespaceCreatorRequestID("my-ns.local", "vpc-1", "ns-0")
+	wantCreateInput := &servicediscovery.CreatePrivateDnsNamespaceInput{
+		CreatorRequestId: aws.String(creatorRequestID),
+		Name:             aws.String("my-ns.local"),
+		Vpc:              aws.String("vpc-1"),
+	}
+	wantSaltedCreateInput := &servicediscovery.CreatePrivateDnsNamespaceInput{
+		CreatorRequestId: aws.String(saltedCreatorRequestID),
+		Name:             aws.String("my-ns.local"),
+		Vpc:              aws.String("vpc-1"),
+	}
+	operation := func(id string, status string, namespaceID string) *servicediscovery.Operation {
+		return &servicediscovery.Operation{
+			Id:           aws.String(id),
+			Status:       aws.String(status),
+			ErrorMessage: aws.String("vpc not found"),
+			Targets: map[string]*string{
+				servicediscovery.OperationTargetTypeNamespace: aws.String(namespaceID),
+			},
+		}
+	}
+	tests := []struct {
+		name               string
+		cloudMapSDK        *fakeCloudMap
+		want               *servicediscovery.NamespaceSummary
+		wantRequeue        bool
+		wantErr            error
+		wantCreateInput    []*servicediscovery.CreatePrivateDnsNamespaceInput
+		wantGetOperationIn []string
+	}{
+		{
+			name: "namespace is created",
+			cloudMapSDK: &fakeCloudMap{
+				namespaces: []*servicediscovery.NamespaceSummary{nsSummary},
+				namespaceOperations: map[string]*servicediscovery.Operation{
+					creatorRequestID: operation("op-1", servicediscovery.OperationStatusSuccess, "ns-1"),
+				},
+			},
+			want:               nsSummary,
+			wantCreateInput:    []*servicediscovery.CreatePrivateDnsNamespaceInput{wantCreateInput},
+			wantGetOperationIn: []string{"op-1"},
+		},
+		{
+			name: "namespace creation is in progress",
+			cloudMapSDK: &fakeCloudMap{
+				namespaceOperations: map[string]*servicediscovery.Operation{
+					creatorRequestID: operation("op-1", servicediscovery.OperationStatusPending, ""),
+				},
+			},
+			wantRequeue:        true,
+			wantErr:            errors.New("cloudMap namespace my-ns.local is being created"),
+			wantCreateInput:    []*servicediscovery.CreatePrivateDnsNamespaceInput{wantCreateInput},
+			wantGetOperationIn: []string{"op-1"},
+		},
+		{
+			name: "namespace creation failed",
+			cloudMapSDK: &fakeCloudMap{
+				namespaceOperations: map[string]*servicediscovery.Operation{
+					creatorRequestID: operation("op-1", servicediscovery.OperationStatusFail, ""),
+				},
+			},
+			wantErr:            errors.New("failed to create cloudMap namespace my-ns.local: vpc not found"),
+			wantCreateInput:    []*servicediscovery.CreatePrivateDnsNamespaceInput{wantCreateInput},
+			wantGetOperationIn: []string{"op-1"},
+		},
+		{
+			name: "namespace creation in progress is polled on duplicate request",
+			cloudMapSDK: &fakeCloudMap{
+				namespaces: []*servicediscovery.NamespaceSummary{nsSummary},
+				createNamespaceErr: &servicediscovery.DuplicateRequest{
+					Message_:             aws.String("duplicate request"),
+					DuplicateOperationId: aws.String("op-1"),
+				},
+				namespaceOperations: map[string]*servicediscovery.Operation{
+					creatorRequestID: operation("op-1", servicediscovery.OperationStatusSuccess, "ns-1"),
+				},
+			},
+			want:               nsSummary,
+			wantCreateInput:    []*servicediscovery.CreatePrivateDnsNamespaceInput{wantCreateInput},
+			wantGetOperationIn: []string{"op-1"},
+		},
+		{
+			name: "namespace deleted after creation is created again with salted creatorRequestId",
+			cloudMapSDK: &fakeCloudMap{
+				namespaces: []*servicediscovery.NamespaceSummary{nsSummary},
+				namespaceOperations: map[string]*servicediscovery.Operation{
+					creatorRequestID:       operation("op-0", servicediscovery.OperationStatusSuccess, "ns-0"),
+					saltedCreatorRequestID: operation("op-1", servicediscovery.OperationStatusSuccess, "ns-1"),
+				},
+			},
+			want:               nsSummary,
+			wantCreateInput:    []*servicediscovery.CreatePrivateDnsNamespaceInput{wantCreateInput, wantSaltedCreateInput},
+			wantGetOperationIn: []string{"op-0", "op-1"},
+		},
+		{
+			name: "namespace already exists",
+			cloudMapSDK: &fakeCloudMap{
+				namespaces:         []*servicediscovery.NamespaceSummary{nsSummary},
+				createNamespaceErr: awserr.New(servicediscovery.ErrCodeNamespaceAlreadyExists, "namespace already exists", nil),
+			},
+			want:            nsSummary,
+			wantCreateInput: []*servicediscovery.CreatePrivateDnsNamespaceInput{wantCreateInput},
+		},
+		{
+			name: "namespace already exists but isn't listed yet",
+			cloudMapSDK: &fakeCloudMap{
+				createNamespaceErr: awserr.New(servicediscovery.ErrCodeNamespaceAlreadyExists, "namespace already exists", nil),
+			},
+			wantRequeue:     true,
+			wantErr:         errors.New("cloudMap namespace my-ns.local is being created"),
+			wantCreateInput: []*servicediscovery.CreatePrivateDnsNamespaceInput{wantCreateInput},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				config: Config{
+					CreateNamespace: true,
+					NamespaceVPCID:  "vpc-1",
+				},
+				cloudMapSDK:           tt.cloudMapSDK,
+				namespaceSummaryCache: cache.NewLRUExpireCache(defaultNamespaceCacheMaxSize),
+				log:                   log.NullLogger{},
+			}
+			got, err := m.createCloudMapNamespace(context.Background(), "my-ns.local")
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				var requeueAfterErr *appmeshruntime.RequeueAfterError
+				assert.Equal(t, tt.wantRequeue, errors.As(err, &requeueAfterErr))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Equal(t, tt.wantCreateInput, tt.cloudMapSDK.createPrivateDNSNamespaceIn)
+			assert.Equal(t, tt.wantGetOperationIn, tt.cloudMapSDK.getOperationIn)
+		})
+	}
+}
+
+func Test_defaultResourceManager_resolveCloudMapNamespace(t *testing.T) {
+	nsSummary := &servicediscovery.NamespaceSummary{
+		Id:   aws.String("ns-1"),
+		Name: aws.String("my-ns.local"),
+		Type: aws.String(servicediscovery.NamespaceTypeDnsPrivate),
+	}
+	otherNSSummary := &servicediscovery.NamespaceSummary{
+		Id:   aws.String("ns-2"),
+		Name: aws.String("other-ns.local"),
+		Type: aws.String(servicediscovery.NamespaceTypeDnsPrivate),
+	}
+	tests := []struct {
+		name           string
+		cloudMapConfig *appmesh.AWSCloudMapServiceDiscovery
+		want           *servicediscovery.NamespaceSummary
+		wantErr        error
+	}{
+		{
+			name: "namespace resolved by name",
+			cloudMapConfig: &appmesh.AWSCloudMapServiceDiscovery{
+				NamespaceName: "my-ns.local",
+			},
+			want: nsSummary,
+		},
+		{
+			name: "namespace resolved by ID",
+			cloudMapConfig: &appmesh.AWSCloudMapServiceDiscovery{
+				NamespaceID: "ns-1",
+			},
+			want: nsSummary,
+		},
+		{
+			name: "namespace resolved by ID and matching name",
+			cloudMapConfig: &appmesh.AWSCloudMapServiceDiscovery{
+				NamespaceID:   "ns-1",
+				NamespaceName: "my-ns.local",
+			},
+			want: nsSummary,
+		},
+		{
+			name: "namespace resolved by ID conflicts with name",
+			cloudMapConfig: &appmesh.AWSCloudMapServiceDiscovery{
+				NamespaceID:   "ns-1",
+				NamespaceName: "other-ns.local",
+			},
+			wantErr: errors.New("cloudMap namespace ns-1 has name my-ns.local, conflicting with namespaceName other-ns.local"),
+		},
+		{
+			name: "namespace not found by name",
+			cloudMapConfig: &appmesh.AWSCloudMapServiceDiscovery{
+				NamespaceName: "ns-1",
+			},
+			want: nil,
+		},
+		{
+			name: "namespace not found by ID",
+			cloudMapConfig: &appmesh.AWSCloudMapServiceDiscovery{
+				NamespaceID: "my-ns.local",
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				cloudMapSDK: &fakeCloudMap{
+					namespaces: []*servicediscovery.NamespaceSummary{nsSummary, otherNSSummary},
+				},
+				namespaceSummaryCache: cache.NewLRUExpireCache(defaultNamespaceCacheMaxSize),
+				log:                   log.NullLogger{},
+			}
+			got, err := m.resolveCloudMapNamespace(context.Background(), tt.cloudMapConfig)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultResourceManager_updateCRDVirtualNodeCloudMapNamespace(t *testing.T) {
+	nsSummary := &servicediscovery.NamespaceSummary{
+		Id:   aws.String("ns-1"),
+		Name: aws.String("my-ns.local"),
+	}
+	tests := []struct {
+		name                      string
+		cloudMapConfig            *appmesh.AWSCloudMapServiceDiscovery
+		wantCloudMapNamespaceName *string
+	}{
+		{
+			name: "namespace referenced by name isn't recorded",
+			cloudMapConfig: &appmesh.AWSCloudMapServiceDiscovery{
+				NamespaceName: "my-ns.local",
+				ServiceName:   "my-svc",
+			},
+			wantCloudMapNamespaceName: nil,
+		},
+		{
+			name: "name of namespace referenced by ID is recorded",
+			cloudMapConfig: &appmesh.AWSCloudMapServiceDiscovery{
+				NamespaceID: "ns-1",
+				ServiceName: "my-svc",
+			},
+			wantCloudMapNamespaceName: aws.String("my-ns.local"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			m := &defaultResourceManager{
+				k8sClient: k8sClient,
+				log:       log.NullLogger{},
+			}
+			vn := &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "my-vn",
+				},
+				Spec: appmesh.VirtualNodeSpec{
+					ServiceDiscovery: &appmesh.ServiceDiscovery{
+						AWSCloudMap: tt.cloudMapConfig,
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, vn.DeepCopy())
+			assert.NoError(t, err)
+
+			err = m.updateCRDVirtualNodeCloudMapNamespace(ctx, vn, nsSummary)
+			assert.NoError(t, err)
+			gotVN := &appmesh.VirtualNode{}
+			err = k8sClient.Get(ctx, k8s.NamespacedName(vn), gotVN)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCloudMapNamespaceName, gotVN.Status.CloudMapNamespaceName)
+		})
+	}
+}
+
+func Test_buildCloudMapNamespaceCreatorRequestID(t *testing.T) {
+	id := buildCloudMapNamespaceCreatorRequestID("my-ns.local", "vpc-1", "")
+	assert.Equal(t, id, buildCloudMapNamespaceCreatorRequestID("my-ns.local", "vpc-1", ""))
+	assert.NotEqual(t, id, buildCloudMapNamespaceCreatorRequestID("my-ns.local", "vpc-2", ""))
+	assert.NotEqual(t, id, buildCloudMapNamespaceCreatorRequestID("my-ns.local", "vpc-1", "ns-0"))
+	assert.LessOrEqual(t, len(id), 64)
+}
+
+func Test_defaultResourceManager_createCloudMapService(t *testing.T) {
+	privateDNSNSSummary := &servicediscovery.NamespaceSummary{
+		Id:   aws.String("ns-1"),
+		Name: aws.String("my-ns.local"),
+		Type: aws.String(servicediscovery.NamespaceTypeDnsPrivate),
+	}
+	httpNSSummary := &servicediscovery.NamespaceSummary{
+		Id:   aws.String("ns-2"),
+		Name: aws.String("my-http-ns"),
+		Type: aws.String(servicediscovery.NamespaceTypeHttp),
+	}
+	vnWithHealthCheckConfig := func(hcConfig *appmesh.AWSCloudMapHealthCheckConfig) *appmesh.VirtualNode {
+		return &appmesh.VirtualNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-ns",
+				Name:      "my-vn",
+				UID:       "vn-uid",
+			},
+			Spec: appmesh.VirtualNodeSpec{
+				ServiceDiscovery: &appmesh.ServiceDiscovery{
+					AWSCloudMap: &appmesh.AWSCloudMapServiceDiscovery{
+						NamespaceName:     "my-ns.local",
+						ServiceName:       "my-svc",
+						HealthCheckConfig: hcConfig,
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name                    string
+		enableCustomHealthCheck bool
+		nsSummary               *servicediscovery.NamespaceSummary
+		vn                      *appmesh.VirtualNode
+		wantCreateInput         *servicediscovery.CreateServiceInput
+		want                    *serviceSummary
+	}{
+		{
+			name:      "private DNS namespace with default health check",
+			nsSummary: privateDNSNSSummary,
+			vn:        vnWithHealthCheckConfig(nil),
+			wantCreateInput: &servicediscovery.CreateServiceInput{
+				CreatorRequestId: aws.String("vn-uid"),
+				NamespaceId:      aws.String("ns-1"),
+				Name:             aws.String("my-svc"),
+				DnsConfig: &servicediscovery.DnsConfig{
+					RoutingPolicy: aws.String(servicediscovery.RoutingPolicyMultivalue),
+					DnsRecords: []*servicediscovery.DnsRecord{
+						{
+							Type: aws.String(servicediscovery.RecordTypeA),
+							TTL:  aws.Int64(300),
+						},
+					},
+				},
+			},
+			want: &serviceSummary{
+				serviceID:  "srv-1",
+				serviceARN: aws.String("arn:aws:servicediscovery:us-west-2:000000000000:service/srv-1"),
+			},
+		},
+		{
+			name:      "private DNS namespace with custom health check",
+			nsSummary: privateDNSNSSummary,
+			vn: vnWithHealthCheckConfig(&appmesh.AWSCloudMapHealthCheckConfig{
+				FailureThreshold: 3,
+			}),
+			wantCreateInput: &servicediscovery.CreateServiceInput{
+				CreatorRequestId: aws.String("vn-uid"),
+				NamespaceId:      aws.String("ns-1"),
+				Name:             aws.String("my-svc"),
+				DnsConfig: &servicediscovery.DnsConfig{
+					RoutingPolicy: aws.String(servicediscovery.RoutingPolicyMultivalue),
+					DnsRecords: []*servicediscovery.DnsRecord{
+						{
+							Type: aws.String(servicediscovery.RecordTypeA),
+							TTL:  aws.Int64(300),
+						},
+					},
+				},
+				HealthCheckCustomConfig: &servicediscovery.HealthCheckCustomConfig{
+					FailureThreshold: aws.Int64(3),
+				},
+			},
+			want: &serviceSummary{
+				serviceID:  "srv-1",
+				serviceARN: aws.String("arn:aws:servicediscovery:us-west-2:000000000000:service/srv-1"),
+				healthCheckCustomConfig: &servicediscovery.HealthCheckCustomConfig{
+					FailureThreshold: aws.Int64(3),
+				},
+			},
+		},
+		{
+			name:                    "HTTP namespace with custom health check enabled by default",
+			enableCustomHealthCheck: true,
+			nsSummary:               httpNSSummary,
+			vn:                      vnWithHealthCheckConfig(nil),
+			wantCreateInput: &servicediscovery.CreateServiceInput{
+				CreatorRequestId: aws.String("vn-uid"),
+				NamespaceId:      aws.String("ns-2"),
+				Name:             aws.String("my-svc"),
+				HealthCheckCustomConfig: &servicediscovery.HealthCheckCustomConfig{
+					FailureThreshold: aws.Int64(1),
+				},
+			},
+			want: &serviceSummary{
+				serviceID:  "srv-1",
+				serviceARN: aws.String("arn:aws:servicediscovery:us-west-2:000000000000:service/srv-1"),
+				healthCheckCustomConfig: &servicediscovery.HealthCheckCustomConfig{
+					FailureThreshold: aws.Int64(1),
+				},
+			},
+		},
+		{
+			name:                    "HTTP namespace with custom health check overriding the default",
+			enableCustomHealthCheck: true,
+			nsSummary:               httpNSSummary,
+			vn: vnWithHealthCheckConfig(&appmesh.AWSCloudMapHealthCheckConfig{
+				FailureThreshold: 5,
+			}),
+			wantCreateInput: &servicediscovery.CreateServiceInput{
+				CreatorRequestId: aws.String("vn-uid"),
+				NamespaceId:      aws.String("ns-2"),
+				Name:             aws.String("my-svc"),
+				HealthCheckCustomConfig: &servicediscovery.HealthCheckCustomConfig{
+					FailureThreshold: aws.Int64(5),
+				},
+			},
+			want: &serviceSummary{
+				serviceID:  "srv-1",
+				serviceARN: aws.String("arn:aws:servicediscovery:us-west-2:000000000000:service/srv-1"),
+				healthCheckCustomConfig: &servicediscovery.HealthCheckCustomConfig{
+					FailureThreshold: aws.Int64(5),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cloudMapSDK := &fakeCloudMap{}
+			m := &defaultResourceManager{
+				cloudMapSDK:             cloudMapSDK,
+				enableCustomHealthCheck: tt.enableCustomHealthCheck,
+				serviceSummaryCache:     cache.NewLRUExpireCache(defaultServiceCacheMaxSize),
+				log:                     log.NullLogger{},
+			}
+			got, err := m.createCloudMapService(context.Background(), tt.vn, tt.nsSummary, "my-svc", defaultServiceDNSConfigTTL)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, []*servicediscovery.CreateServiceInput{tt.wantCreateInput}, cloudMapSDK.createServiceIn)
+		})
+	}
+}
+
+func Test_defaultResourceManager_Reconcile(t *testing.T) {
+	mesh := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+			UID:  "mesh-uid",
+		},
+	}
+	vnARN := "arn:aws:appmesh:us-west-2:000000000000:mesh/my-mesh/virtualNode/my-vn_my-ns"
+	vn := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "my-vn",
+		},
+		Spec: appmesh.VirtualNodeSpec{
+			MeshRef: &appmesh.MeshReference{
+				Name: "my-mesh",
+				UID:  "mesh-uid",
+			},
+			ServiceDiscovery: &appmesh.ServiceDiscovery{
+				AWSCloudMap: &appmesh.AWSCloudMapServiceDiscovery{
+					NamespaceName: "my-ns.local",
+					ServiceName:   "my-svc",
+				},
+			},
+		},
+		Status: appmesh.VirtualNodeStatus{
+			VirtualNodeARN: aws.String(vnARN),
+			Conditions: []appmesh.VirtualNodeCondition{
+				{
+					Type:   appmesh.VirtualNodeActive,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name            string
+		cloudMapSDK     *fakeCloudMap
+		createNamespace bool
+		wantErr         error
+		wantReason      string
+		wantMessage     string
+	}{
+		{
+			name: "failed to list CloudMap namespaces",
+			cloudMapSDK: &fakeCloudMap{
+				listNamespacesErr: errors.New("throttled"),
+			},
+			wantErr:     errors.New("throttled"),
+			wantReason:  reasonCloudMapNamespaceNotReady,
+			wantMessage: "cloudMap namespace isn't ready",
+		},
+		{
+			name:        "CloudMap namespace not found",
+			cloudMapSDK: &fakeCloudMap{},
+			wantErr:     errors.New("cloudMap namespace not found: my-ns.local"),
+			wantReason:  reasonCloudMapNamespaceNotReady,
+			wantMessage: "cloudMap namespace isn't ready",
+		},
+		{
+			name: "failed to create CloudMap namespace",
+			cloudMapSDK: &fakeCloudMap{
+				createNamespaceErr: errors.New("access denied"),
+			},
+			createNamespace: true,
+			wantErr:         errors.New("failed to create cloudMap namespace my-ns.local: access denied"),
+			wantReason:      reasonCloudMapNamespaceNotReady,
+			wantMessage:     "cloudMap namespace isn't ready",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			eventRecorder := record.NewFakeRecorder(10)
+			m := &defaultResourceManager{
+				config: Config{
+					CreateNamespace: tt.createNamespace,
+					NamespaceVPCID:  "vpc-1",
+				},
+				k8sClient:             k8sClient,
+				cloudMapSDK:           tt.cloudMapSDK,
+				referencesResolver:    references.NewDefaultResolver(k8sClient, log.NullLogger{}),
+				eventRecorder:         eventRecorder,
+				namespaceSummaryCache: cache.NewLRUExpireCache(defaultNamespaceCacheMaxSize),
+				serviceSummaryCache:   cache.NewLRUExpireCache(defaultServiceCacheMaxSize),
+				log:                   log.NullLogger{},
+			}
+
+			err := k8sClient.Create(ctx, mesh.DeepCopy())
+			assert.NoError(t, err)
+			err = k8sClient.Create(ctx, vn.DeepCopy())
+			assert.NoError(t, err)
+			gotVN := &appmesh.VirtualNode{}
+			err = k8sClient.Get(ctx, k8s.NamespacedName(vn), gotVN)
+			assert.NoError(t, err)
+
+			err = m.Reconcile(ctx, gotVN)
+			assert.EqualError(t, err, tt.wantErr.Error())
+
+			// App Mesh progress recorded by the virtualNode reconciler must be kept after CloudMap failures.
+			err = k8sClient.Get(ctx, k8s.NamespacedName(vn), gotVN)
+			assert.NoError(t, err)
+			assert.Equal(t, vnARN, aws.StringValue(gotVN.Status.VirtualNodeARN))
+			activeCondition := virtualnode.GetCondition(gotVN, appmesh.VirtualNodeActive)
+			if assert.NotNil(t, activeCondition) {
+				assert.Equal(t, corev1.ConditionTrue, activeCondition.Status)
+			}
+			cloudMapCondition := virtualnode.GetCondition(gotVN, appmesh.VirtualNodeCloudMapReconciled)
+			if assert.NotNil(t, cloudMapCondition) {
+				assert.Equal(t, corev1.ConditionFalse, cloudMapCondition.Status)
+				assert.Equal(t, tt.wantReason, aws.StringValue(cloudMapCondition.Reason))
+				assert.Equal(t, tt.wantMessage, aws.StringValue(cloudMapCondition.Message))
+			}
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, []string{"Warning " + tt.wantReason + " " + tt.wantErr.Error()}, gotEvents)
+		})
+	}
+}
@@ -5,9 +5,12 @@ import (
 
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -15,10 +18,12 @@ type VirtualNodeEndpointResolver interface {
 	Resolve(ctx context.Context, vn *appmesh.VirtualNode) ([]*corev1.Pod, []*corev1.Pod, []*corev1.Pod, error)
 }
 
-func NewDefaultVirtualNodeEndpointResolver(podsRepository k8s.PodsRepository, log logr.Logger) *defaultVirtualNodeEndpointResolver {
+func NewDefaultVirtualNodeEndpointResolver(podsRepository k8s.PodsRepository, k8sClient client.Client, vnConfig virtualnode.Config, log logr.Logger) *defaultVirtualNodeEndpointResolver {
 	return &defaultVirtualNodeEndpointResolver{
-		podsRepository: podsRepository,
-		log:            log,
+		podsRepository:     podsRepository,
+		k8sClient:          k8sClient,
+		podSelectionPolicy: vnConfig.PodSelectionPolicy,
+		log:                log,
 	}
 }
 
@@ -26,7 +31,10 @@ var _ VirtualNodeEndpointResolver = &defaultVirtualNodeEndpointResolver{}
 
 type defaultVirtualNodeEndpointResolver struct {
 	podsRepository k8s.PodsRepository
-	log            logr.Logger
+	k8sClient      client.Client
+	// how pods matched by multiple VirtualNodes are designated, must be consistent with the virtualNode membership designator.
+	podSelectionPolicy string
+	log                logr.Logger
 }
 
 func (e *defaultVirtualNodeEndpointResolver) Resolve(ctx context.Context, vNode *appmesh.VirtualNode) ([]*corev1.Pod, []*corev1.Pod, []*corev1.Pod, error) {
@@ -39,6 +47,10 @@ func (e *defaultVirtualNodeEndpointResolver) Resolve(ctx context.Context, vNode
 	if podsList, err = e.podsRepository.ListPodsWithMatchingLabels(listOptions); err != nil {
 		return nil, nil, nil, err
 	}
+	moreSpecificSelectors, err := e.findMoreSpecificPodSelectors(ctx, vNode)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	var readyPods []*corev1.Pod
 	var notReadyPods []*corev1.Pod
@@ -55,6 +67,11 @@ func (e *defaultVirtualNodeEndpointResolver) Resolve(ctx context.Context, vNode
 			continue
 		}
 
+		if matchesAnySelector(pod, moreSpecificSelectors) {
+			ignoredPods = append(ignoredPods, pod)
+			continue
+		}
+
 		if ArePodContainersReady(pod) {
 			readyPods = append(readyPods, pod)
 		} else if ShouldPodBeInEndpoints(pod) {
@@ -65,3 +82,39 @@ func (e *defaultVirtualNodeEndpointResolver) Resolve(ctx context.Context, vNode
 	}
 	return readyPods, notReadyPods, ignoredPods, nil
 }
+
+// findMoreSpecificPodSelectors returns the podSelectors of other VirtualNodes in the namespace of vNode that are more specific than the podSelector of vNode.
+// with the most-specific pod selection policy, pods matched by any of them belong to the other VirtualNode and mustn't be registered for vNode.
+func (e *defaultVirtualNodeEndpointResolver) findMoreSpecificPodSelectors(ctx context.Context, vNode *appmesh.VirtualNode) ([]labels.Selector, error) {
+	if e.podSelectionPolicy != virtualnode.PodSelectionPolicyMostSpecific {
+		return nil, nil
+	}
+	vnList := &appmesh.VirtualNodeList{}
+	if err := e.k8sClient.List(ctx, vnList, client.InNamespace(vNode.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list VirtualNodes")
+	}
+	specificity := virtualnode.PodSelectorSpecificity(vNode.Spec.PodSelector)
+	var selectors []labels.Selector
+	for i := range vnList.Items {
+		vn := &vnList.Items[i]
+		if vn.UID == vNode.UID || vn.Spec.PodSelector == nil || virtualnode.PodSelectorSpecificity(vn.Spec.PodSelector) <= specificity {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(vn.Spec.PodSelector)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+	}
+	return selectors, nil
+}
+
+// matchesAnySelector tests whether pod is matched by any of the selectors.
+func matchesAnySelector(pod *corev1.Pod, selectors []labels.Selector) bool {
+	for _, selector := range selectors {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package cloudmap
+
+import (
+	"context"
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+// fakePodsRepository serves pods matching the label selector of list options.
+type fakePodsRepository struct {
+	k8s.PodsRepository
+	pods []corev1.Pod
+}
+
+func (r *fakePodsRepository) ListPodsWithMatchingLabels(opts client.ListOptions) (*corev1.PodList, error) {
+	podList := &corev1.PodList{}
+	for _, pod := range r.pods {
+		if pod.Namespace == opts.Namespace && opts.LabelSelector.Matches(labels.Set(pod.Labels)) {
+			podList.Items = append(podList.Items, pod)
+		}
+	}
+	return podList, nil
+}
+
+func Test_defaultVirtualNodeEndpointResolver_Resolve(t *testing.T) {
+	appVN := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "app",
+			UID:       types.UID("uid-app"),
+		},
+		Spec: appmesh.VirtualNodeSpec{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "my-app"},
+			},
+		},
+	}
+	canaryVN := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "canary",
+			UID:       types.UID("uid-canary"),
+		},
+		Spec: appmesh.VirtualNodeSpec{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "my-app", "track": "canary"},
+			},
+		},
+	}
+	readyPod := func(name string, podLabels map[string]string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-ns",
+				Name:      name,
+				Labels:    podLabels,
+			},
+			Status: corev1.PodStatus{
+				PodIP: "192.168.0.1",
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.ContainersReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+	stablePod := readyPod("stable", map[string]string{"app": "my-app"})
+	canaryPod := readyPod("canary", map[string]string{"app": "my-app", "track": "canary"})
+	tests := []struct {
+		name               string
+		podSelectionPolicy string
+		vn                 *appmesh.VirtualNode
+		wantReadyPods      []string
+		wantIgnoredPods    []string
+	}{
+		{
+			name:               "deny policy resolves every pod matching podSelector",
+			podSelectionPolicy: virtualnode.PodSelectionPolicyDeny,
+			vn:                 appVN,
+			wantReadyPods:      []string{"stable", "canary"},
+		},
+		{
+			name:               "most-specific policy ignores pods of more specific virtualNodes",
+			podSelectionPolicy: virtualnode.PodSelectionPolicyMostSpecific,
+			vn:                 appVN,
+			wantReadyPods:      []string{"stable"},
+			wantIgnoredPods:    []string{"canary"},
+		},
+		{
+			name:               "most-specific policy resolves pods of the most specific virtualNode",
+			podSelectionPolicy: virtualnode.PodSelectionPolicyMostSpecific,
+			vn:                 canaryVN,
+			wantReadyPods:      []string{"canary"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, vn := range []*appmesh.VirtualNode{appVN, canaryVN} {
+				err := k8sClient.Create(ctx, vn.DeepCopy())
+				assert.NoError(t, err)
+			}
+			podsRepository := &fakePodsRepository{pods: []corev1.Pod{stablePod, canaryPod}}
+			vnConfig := virtualnode.Config{PodSelectionPolicy: tt.podSelectionPolicy}
+			e := NewDefaultVirtualNodeEndpointResolver(podsRepository, k8sClient, vnConfig, log.NullLogger{})
+
+			readyPods, notReadyPods, ignoredPods, err := e.Resolve(ctx, tt.vn)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReadyPods, podNames(readyPods))
+			assert.Empty(t, notReadyPods)
+			assert.Equal(t, tt.wantIgnoredPods, podNames(ignoredPods))
+		})
+	}
+}
+
+func podNames(pods []*corev1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
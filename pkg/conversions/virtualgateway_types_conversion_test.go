@@ -806,6 +806,56 @@ func TestConvert_CRD_VirtualGatewayHealthCheckPolicy_To_SDK_VirtualGatewayHealth
 				UnhealthyThreshold: aws.Int64(2),
 			},
 		},
+		{
+			name: "http2 protocol",
+			args: args{
+				crdObj: &appmesh.VirtualGatewayHealthCheckPolicy{
+					HealthyThreshold:   3,
+					IntervalMillis:     60,
+					Path:               aws.String("/health"),
+					Port:               &port80,
+					Protocol:           appmesh.VirtualGatewayPortProtocolHTTP2,
+					TimeoutMillis:      30,
+					UnhealthyThreshold: 2,
+				},
+				sdkObj: &appmeshsdk.VirtualGatewayHealthCheckPolicy{},
+				scope:  nil,
+			},
+			wantSDKObj: &appmeshsdk.VirtualGatewayHealthCheckPolicy{
+				HealthyThreshold:   aws.Int64(3),
+				IntervalMillis:     aws.Int64(60),
+				Path:               aws.String("/health"),
+				Port:               aws.Int64(80),
+				Protocol:           aws.String("http2"),
+				TimeoutMillis:      aws.Int64(30),
+				UnhealthyThreshold: aws.Int64(2),
+			},
+		},
+		{
+			name: "grpc protocol",
+			args: args{
+				crdObj: &appmesh.VirtualGatewayHealthCheckPolicy{
+					HealthyThreshold:   3,
+					IntervalMillis:     60,
+					Path:               nil,
+					Port:               &port80,
+					Protocol:           appmesh.VirtualGatewayPortProtocolGRPC,
+					TimeoutMillis:      30,
+					UnhealthyThreshold: 2,
+				},
+				sdkObj: &appmeshsdk.VirtualGatewayHealthCheckPolicy{},
+				scope:  nil,
+			},
+			wantSDKObj: &appmeshsdk.VirtualGatewayHealthCheckPolicy{
+				HealthyThreshold:   aws.Int64(3),
+				IntervalMillis:     aws.Int64(60),
+				Path:               nil,
+				Port:               aws.Int64(80),
+				Protocol:           aws.String("grpc"),
+				TimeoutMillis:      aws.Int64(30),
+				UnhealthyThreshold: aws.Int64(2),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
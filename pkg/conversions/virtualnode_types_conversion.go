@@ -73,6 +73,8 @@ func Convert_CRD_TLSValidationContext_To_SDK_TLSValidationContext(crdObj *appmes
 		if err := Convert_CRD_TLSValidationContextSubjectAlternativeNames_To_SDK_TLSValidationContextSubjectAlternativeNames(crdObj.SubjectAlternativeNames, sdkObj.SubjectAlternativeNames, scope); err != nil {
 			return err
 		}
+	} else {
+		sdkObj.SubjectAlternativeNames = nil
 	}
 	return nil
 }
@@ -120,6 +122,8 @@ func Convert_CRD_ClientPolicyTLS_To_SDK_ClientPolicyTLS(crdObj *appmesh.ClientPo
 		if err := Convert_CRD_ClientTLSCertificate_To_SDK_ClientTLSCertificate(crdObj.Certificate, sdkObj.Certificate, scope); err != nil {
 			return err
 		}
+	} else {
+		sdkObj.Certificate = nil
 	}
 	return nil
 }
@@ -662,7 +666,8 @@ func Convert_CRD_VirtualNodeSpec_To_SDK_VirtualNodeSpec(crdObj *appmesh.VirtualN
 		sdkObj.BackendDefaults = nil
 	}
 
-	if crdObj.Logging != nil {
+	// envoyLogLevel is only consumed by the sidecar injector, logging without accessLog has nothing to send to App Mesh.
+	if crdObj.Logging != nil && crdObj.Logging.AccessLog != nil {
 		sdkObj.Logging = &appmeshsdk.Logging{}
 		if err := Convert_CRD_Logging_To_SDK_Logging(crdObj.Logging, sdkObj.Logging, scope); err != nil {
 			return err
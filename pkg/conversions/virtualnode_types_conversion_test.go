@@ -1562,6 +1562,46 @@ func TestConvert_CRD_ListenerTimeoutHttp_To_SDK_ListenerTimeoutHttp(t *testing.T
 				},
 			},
 		},
+		{
+			name: "only idle timeout specified",
+			args: args{
+				crdObj: &appmesh.HTTPTimeout{
+					Idle: &appmesh.Duration{
+						Unit:  "s",
+						Value: int64(600),
+					},
+				},
+				sdkObj: &appmeshsdk.HttpTimeout{},
+				scope:  nil,
+			},
+			wantSDKObj: &appmeshsdk.HttpTimeout{
+				PerRequest: nil,
+				Idle: &appmeshsdk.Duration{
+					Unit:  aws.String("s"),
+					Value: aws.Int64(600),
+				},
+			},
+		},
+		{
+			name: "only perRequest timeout specified",
+			args: args{
+				crdObj: &appmesh.HTTPTimeout{
+					PerRequest: &appmesh.Duration{
+						Unit:  "ms",
+						Value: int64(500),
+					},
+				},
+				sdkObj: &appmeshsdk.HttpTimeout{},
+				scope:  nil,
+			},
+			wantSDKObj: &appmeshsdk.HttpTimeout{
+				PerRequest: &appmeshsdk.Duration{
+					Unit:  aws.String("ms"),
+					Value: aws.Int64(500),
+				},
+				Idle: nil,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2328,6 +2368,41 @@ func TestConvert_CRD_Listener_To_SDK_Listener(t *testing.T) {
 				ConnectionPool:   nil,
 			},
 		},
+		{
+			name: "tcp listener with idle timeout",
+			args: args{
+				crdObj: &appmesh.Listener{
+					PortMapping: appmesh.PortMapping{
+						Port:     port80,
+						Protocol: appmesh.PortProtocolTCP,
+					},
+					Timeout: &appmesh.ListenerTimeout{
+						TCP: &appmesh.TCPTimeout{
+							Idle: &appmesh.Duration{
+								Unit:  "s",
+								Value: int64(600),
+							},
+						},
+					},
+				},
+				sdkObj: &appmeshsdk.Listener{},
+				scope:  nil,
+			},
+			wantSDKObj: &appmeshsdk.Listener{
+				PortMapping: &appmeshsdk.PortMapping{
+					Port:     aws.Int64(80),
+					Protocol: aws.String("tcp"),
+				},
+				Timeout: &appmeshsdk.ListenerTimeout{
+					Tcp: &appmeshsdk.TcpTimeout{
+						Idle: &appmeshsdk.Duration{
+							Unit:  aws.String("s"),
+							Value: aws.Int64(600),
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2759,6 +2834,7 @@ func TestConvert_CRD_VirtualNodeSpec_To_SDK_VirtualNodeSpec(t *testing.T) {
 	port443 := appmesh.PortNumber(443)
 	protocolHTTP := appmesh.PortProtocolHTTP
 	protocolHTTP2 := appmesh.PortProtocolHTTP2
+	envoyLogLevelDebug := appmesh.EnvoyLogLevel("debug")
 	type args struct {
 		crdObj           *appmesh.VirtualNodeSpec
 		sdkObj           *appmeshsdk.VirtualNodeSpec
@@ -3742,6 +3818,45 @@ func TestConvert_CRD_VirtualNodeSpec_To_SDK_VirtualNodeSpec(t *testing.T) {
 				Logging: nil,
 			},
 		},
+		{
+			name: "logging with envoyLogLevel only",
+			args: args{
+				crdObj: &appmesh.VirtualNodeSpec{
+					Logging: &appmesh.Logging{
+						EnvoyLogLevel: &envoyLogLevelDebug,
+					},
+				},
+				sdkObj: &appmeshsdk.VirtualNodeSpec{},
+			},
+			wantSDKObj: &appmeshsdk.VirtualNodeSpec{
+				Logging: nil,
+			},
+		},
+		{
+			name: "logging with accessLog and envoyLogLevel",
+			args: args{
+				crdObj: &appmesh.VirtualNodeSpec{
+					Logging: &appmesh.Logging{
+						AccessLog: &appmesh.AccessLog{
+							File: &appmesh.FileAccessLog{
+								Path: "/dev/stdout",
+							},
+						},
+						EnvoyLogLevel: &envoyLogLevelDebug,
+					},
+				},
+				sdkObj: &appmeshsdk.VirtualNodeSpec{},
+			},
+			wantSDKObj: &appmeshsdk.VirtualNodeSpec{
+				Logging: &appmeshsdk.Logging{
+					AccessLog: &appmeshsdk.AccessLog{
+						File: &appmeshsdk.FileAccessLog{
+							Path: aws.String("/dev/stdout"),
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
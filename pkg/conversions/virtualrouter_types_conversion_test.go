@@ -720,6 +720,54 @@ func TestConvert_CRD_HTTPRetryPolicy_To_SDK_HttpRetryPolicy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "all http retry events",
+			args: args{
+				crdObj: &appmesh.HTTPRetryPolicy{
+					HTTPRetryEvents: []appmesh.HTTPRetryPolicyEvent{"server-error", "gateway-error", "client-error", "stream-error"},
+					MaxRetries:      int64(3),
+					PerRetryTimeout: appmesh.Duration{
+						Unit:  "s",
+						Value: int64(1),
+					},
+				},
+				sdkObj: &appmeshsdk.HttpRetryPolicy{},
+				scope:  nil,
+			},
+			wantSDKObj: &appmeshsdk.HttpRetryPolicy{
+				HttpRetryEvents: []*string{aws.String("server-error"), aws.String("gateway-error"), aws.String("client-error"), aws.String("stream-error")},
+				TcpRetryEvents:  nil,
+				MaxRetries:      aws.Int64(3),
+				PerRetryTimeout: &appmeshsdk.Duration{
+					Unit:  aws.String("s"),
+					Value: aws.Int64(1),
+				},
+			},
+		},
+		{
+			name: "tcp retry events only",
+			args: args{
+				crdObj: &appmesh.HTTPRetryPolicy{
+					TCPRetryEvents: []appmesh.TCPRetryPolicyEvent{"connection-error"},
+					MaxRetries:     int64(3),
+					PerRetryTimeout: appmesh.Duration{
+						Unit:  "s",
+						Value: int64(1),
+					},
+				},
+				sdkObj: &appmeshsdk.HttpRetryPolicy{},
+				scope:  nil,
+			},
+			wantSDKObj: &appmeshsdk.HttpRetryPolicy{
+				HttpRetryEvents: nil,
+				TcpRetryEvents:  []*string{aws.String("connection-error")},
+				MaxRetries:      aws.Int64(3),
+				PerRetryTimeout: &appmeshsdk.Duration{
+					Unit:  aws.String("s"),
+					Value: aws.Int64(1),
+				},
+			},
+		},
 		{
 			name: "normal case + empty retry events",
 			args: args{
@@ -1350,6 +1398,53 @@ func TestConvert_CRD_TCPRoute_To_SDK_TcpRoute(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "tcp route with idle timeout",
+			args: args{
+				crdObj: &appmesh.TCPRoute{
+					Action: appmesh.TCPRouteAction{
+						WeightedTargets: []appmesh.WeightedTarget{
+							{
+								VirtualNodeRef: &appmesh.VirtualNodeReference{
+									Namespace: aws.String("ns-1"),
+									Name:      "vn-1",
+								},
+								Weight: int64(100),
+							},
+						},
+					},
+					Timeout: &appmesh.TCPTimeout{
+						Idle: &appmesh.Duration{
+							Unit:  "s",
+							Value: 60,
+						},
+					},
+				},
+				sdkObj: &appmeshsdk.TcpRoute{},
+				scopeConvertFunc: func(src, dest interface{}, flags conversion.FieldMatchingFlags) error {
+					vnRef := src.(*appmesh.VirtualNodeReference)
+					vnNamePtr := dest.(*string)
+					*vnNamePtr = fmt.Sprintf("%s.%s", vnRef.Name, aws.StringValue(vnRef.Namespace))
+					return nil
+				},
+			},
+			wantSDKObj: &appmeshsdk.TcpRoute{
+				Action: &appmeshsdk.TcpRouteAction{
+					WeightedTargets: []*appmeshsdk.WeightedTarget{
+						{
+							VirtualNode: aws.String("vn-1.ns-1"),
+							Weight:      aws.Int64(100),
+						},
+					},
+				},
+				Timeout: &appmeshsdk.TcpTimeout{
+					Idle: &appmeshsdk.Duration{
+						Unit:  aws.String("s"),
+						Value: aws.Int64(60),
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1751,6 +1846,22 @@ func TestConvert_CRD_GRPCRouteMatch_To_SDK_GrpcRouteMatch(t *testing.T) {
 				ServiceName: aws.String("foo.foodomain.local"),
 			},
 		},
+		{
+			name: "service name without method name",
+			args: args{
+				crdObj: &appmesh.GRPCRouteMatch{
+					ServiceName: aws.String("foo.foodomain.local"),
+				},
+
+				sdkObj: &appmeshsdk.GrpcRouteMatch{},
+				scope:  nil,
+			},
+			wantSDKObj: &appmeshsdk.GrpcRouteMatch{
+				Metadata:    nil,
+				MethodName:  nil,
+				ServiceName: aws.String("foo.foodomain.local"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,31 @@
+package equality
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// CompareOptionForRetryPolicyEvents ignores the order of retry events, App Mesh treats them as a set.
+func CompareOptionForRetryPolicyEvents() cmp.Option {
+	return cmp.FilterPath(func(path cmp.Path) bool {
+		sf, ok := path.Last().(cmp.StructField)
+		if !ok {
+			return false
+		}
+		switch sf.Name() {
+		case "HttpRetryEvents", "TcpRetryEvents", "GrpcRetryEvents":
+			return true
+		}
+		return false
+	}, cmpopts.SortSlices(func(lhs *string, rhs *string) bool {
+		return aws.StringValue(lhs) < aws.StringValue(rhs)
+	}))
+}
+
+func CompareOptionForRouteSpec() cmp.Option {
+	return cmp.Options{
+		cmpopts.EquateEmpty(),
+		CompareOptionForRetryPolicyEvents(),
+	}
+}
@@ -0,0 +1,102 @@
+package equality
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCompareOptionForRouteSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		argLeft    *appmeshsdk.RouteSpec
+		argRight   *appmeshsdk.RouteSpec
+		wantEquals bool
+	}{
+		{
+			name: "when retry events equals",
+			argLeft: &appmeshsdk.RouteSpec{
+				HttpRoute: &appmeshsdk.HttpRoute{
+					RetryPolicy: &appmeshsdk.HttpRetryPolicy{
+						HttpRetryEvents: aws.StringSlice([]string{"server-error", "gateway-error"}),
+						TcpRetryEvents:  aws.StringSlice([]string{"connection-error"}),
+					},
+				},
+			},
+			argRight: &appmeshsdk.RouteSpec{
+				HttpRoute: &appmeshsdk.HttpRoute{
+					RetryPolicy: &appmeshsdk.HttpRetryPolicy{
+						HttpRetryEvents: aws.StringSlice([]string{"server-error", "gateway-error"}),
+						TcpRetryEvents:  aws.StringSlice([]string{"connection-error"}),
+					},
+				},
+			},
+			wantEquals: true,
+		},
+		{
+			name: "when retry events only differs in order",
+			argLeft: &appmeshsdk.RouteSpec{
+				HttpRoute: &appmeshsdk.HttpRoute{
+					RetryPolicy: &appmeshsdk.HttpRetryPolicy{
+						HttpRetryEvents: aws.StringSlice([]string{"server-error", "gateway-error", "client-error", "stream-error"}),
+					},
+				},
+			},
+			argRight: &appmeshsdk.RouteSpec{
+				HttpRoute: &appmeshsdk.HttpRoute{
+					RetryPolicy: &appmeshsdk.HttpRetryPolicy{
+						HttpRetryEvents: aws.StringSlice([]string{"stream-error", "client-error", "gateway-error", "server-error"}),
+					},
+				},
+			},
+			wantEquals: true,
+		},
+		{
+			name: "when retry events differs",
+			argLeft: &appmeshsdk.RouteSpec{
+				Http2Route: &appmeshsdk.HttpRoute{
+					RetryPolicy: &appmeshsdk.HttpRetryPolicy{
+						HttpRetryEvents: aws.StringSlice([]string{"server-error"}),
+					},
+				},
+			},
+			argRight: &appmeshsdk.RouteSpec{
+				Http2Route: &appmeshsdk.HttpRoute{
+					RetryPolicy: &appmeshsdk.HttpRetryPolicy{
+						HttpRetryEvents: aws.StringSlice([]string{"gateway-error"}),
+					},
+				},
+			},
+			wantEquals: false,
+		},
+		{
+			name: "when left hand retry events is empty",
+			argLeft: &appmeshsdk.RouteSpec{
+				HttpRoute: &appmeshsdk.HttpRoute{
+					RetryPolicy: &appmeshsdk.HttpRetryPolicy{
+						HttpRetryEvents: []*string{},
+						TcpRetryEvents:  aws.StringSlice([]string{"connection-error"}),
+					},
+				},
+			},
+			argRight: &appmeshsdk.RouteSpec{
+				HttpRoute: &appmeshsdk.HttpRoute{
+					RetryPolicy: &appmeshsdk.HttpRetryPolicy{
+						HttpRetryEvents: nil,
+						TcpRetryEvents:  aws.StringSlice([]string{"connection-error"}),
+					},
+				},
+			},
+			wantEquals: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := CompareOptionForRouteSpec()
+			gotEquals := cmp.Equal(tt.argLeft, tt.argRight, opts)
+			assert.Equal(t, tt.wantEquals, gotEquals)
+		})
+	}
+}
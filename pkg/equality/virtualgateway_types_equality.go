@@ -14,5 +14,6 @@ func CompareOptionForVirtualGatewaySpec() cmp.Option {
 	return cmp.Options{
 		cmpopts.EquateEmpty(),
 		CompareOptionForVirtualGatewayHealthCheckPolicy(),
+		CompareOptionForClientPolicyTLSPorts(),
 	}
 }
@@ -57,6 +57,18 @@ func TestCompareOptionForVirtualGatewayHealthCheckPolicy(t *testing.T) {
 			},
 			wantEquals: false,
 		},
+		{
+			name: "when protocol differs",
+			argLeft: &appmeshsdk.VirtualGatewayHealthCheckPolicy{
+				Port:     aws.Int64(80),
+				Protocol: aws.String("grpc"),
+			},
+			argRight: &appmeshsdk.VirtualGatewayHealthCheckPolicy{
+				Port:     aws.Int64(80),
+				Protocol: aws.String("http"),
+			},
+			wantEquals: false,
+		},
 		{
 			name:       "nil left hand arg",
 			argLeft:    nil,
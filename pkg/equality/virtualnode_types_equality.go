@@ -1,6 +1,9 @@
 package equality
 
 import (
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/aws"
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -10,9 +13,42 @@ func CompareOptionForHealthCheckPolicy() cmp.Option {
 	return IgnoreLeftHandUnset(appmeshsdk.HealthCheckPolicy{}, "Port")
 }
 
+// CompareOptionForVirtualNodeBackends ignores the order of backends, App Mesh treats them as a set.
+func CompareOptionForVirtualNodeBackends() cmp.Option {
+	return cmpopts.SortSlices(func(lhs *appmeshsdk.Backend, rhs *appmeshsdk.Backend) bool {
+		return backendVirtualServiceName(lhs) < backendVirtualServiceName(rhs)
+	})
+}
+
+func backendVirtualServiceName(backend *appmeshsdk.Backend) string {
+	if backend == nil || backend.VirtualService == nil {
+		return ""
+	}
+	return aws.StringValue(backend.VirtualService.VirtualServiceName)
+}
+
+// CompareOptionForClientPolicyTLSPorts ignores the order of ports a TLS client policy is enforced for, App Mesh treats them as a set.
+func CompareOptionForClientPolicyTLSPorts() cmp.Option {
+	return cmp.FilterPath(func(path cmp.Path) bool {
+		sf, ok := path.Last().(cmp.StructField)
+		if !ok || sf.Name() != "Ports" {
+			return false
+		}
+		switch path.Index(-2).Type() {
+		case reflect.TypeOf(appmeshsdk.ClientPolicyTls{}), reflect.TypeOf(appmeshsdk.VirtualGatewayClientPolicyTls{}):
+			return true
+		}
+		return false
+	}, cmpopts.SortSlices(func(lhs *int64, rhs *int64) bool {
+		return aws.Int64Value(lhs) < aws.Int64Value(rhs)
+	}))
+}
+
 func CompareOptionForVirtualNodeSpec() cmp.Option {
 	return cmp.Options{
 		cmpopts.EquateEmpty(),
 		CompareOptionForHealthCheckPolicy(),
+		CompareOptionForVirtualNodeBackends(),
+		CompareOptionForClientPolicyTLSPorts(),
 	}
 }
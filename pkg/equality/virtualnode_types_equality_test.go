@@ -140,12 +140,137 @@ func TestCompareOptionForVirtualNodeSpec(t *testing.T) {
 			},
 			wantEquals: false,
 		},
+		{
+			name: "when backends are reordered",
+			argLeft: &appmeshsdk.VirtualNodeSpec{
+				Backends: []*appmeshsdk.Backend{
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-a.my-ns"),
+						},
+					},
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-b.my-ns"),
+						},
+					},
+				},
+			},
+			argRight: &appmeshsdk.VirtualNodeSpec{
+				Backends: []*appmeshsdk.Backend{
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-b.my-ns"),
+						},
+					},
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-a.my-ns"),
+						},
+					},
+				},
+			},
+			wantEquals: true,
+		},
+		{
+			name: "when backends are reordered, but a backend is added",
+			argLeft: &appmeshsdk.VirtualNodeSpec{
+				Backends: []*appmeshsdk.Backend{
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-a.my-ns"),
+						},
+					},
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-b.my-ns"),
+						},
+					},
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-c.my-ns"),
+						},
+					},
+				},
+			},
+			argRight: &appmeshsdk.VirtualNodeSpec{
+				Backends: []*appmeshsdk.Backend{
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-b.my-ns"),
+						},
+					},
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-a.my-ns"),
+						},
+					},
+				},
+			},
+			wantEquals: false,
+		},
+		{
+			name: "when backends are reordered, but a backend is replaced",
+			argLeft: &appmeshsdk.VirtualNodeSpec{
+				Backends: []*appmeshsdk.Backend{
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-a.my-ns"),
+						},
+					},
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-c.my-ns"),
+						},
+					},
+				},
+			},
+			argRight: &appmeshsdk.VirtualNodeSpec{
+				Backends: []*appmeshsdk.Backend{
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-b.my-ns"),
+						},
+					},
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("svc-a.my-ns"),
+						},
+					},
+				},
+			},
+			wantEquals: false,
+		},
 		{
 			name:       "nil left hand arg",
 			argLeft:    nil,
 			argRight:   &appmeshsdk.VirtualNodeSpec{},
 			wantEquals: false,
 		},
+		{
+			name:       "listener http idle timeout differs while perRequest timeout equals",
+			argLeft:    buildVirtualNodeSpecWithHTTPTimeout(aws.Int64(500), aws.Int64(600000)),
+			argRight:   buildVirtualNodeSpecWithHTTPTimeout(aws.Int64(500), aws.Int64(300000)),
+			wantEquals: false,
+		},
+		{
+			name:       "listener http perRequest timeout differs while idle timeout equals",
+			argLeft:    buildVirtualNodeSpecWithHTTPTimeout(aws.Int64(1000), aws.Int64(600000)),
+			argRight:   buildVirtualNodeSpecWithHTTPTimeout(aws.Int64(500), aws.Int64(600000)),
+			wantEquals: false,
+		},
+		{
+			name:       "listener http idle timeout set while perRequest timeout unset on both sides",
+			argLeft:    buildVirtualNodeSpecWithHTTPTimeout(nil, aws.Int64(600000)),
+			argRight:   buildVirtualNodeSpecWithHTTPTimeout(nil, aws.Int64(600000)),
+			wantEquals: true,
+		},
+		{
+			name:       "listener http perRequest timeout unset on left hand arg only",
+			argLeft:    buildVirtualNodeSpecWithHTTPTimeout(nil, aws.Int64(600000)),
+			argRight:   buildVirtualNodeSpecWithHTTPTimeout(aws.Int64(500), aws.Int64(600000)),
+			wantEquals: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -155,3 +280,79 @@ func TestCompareOptionForVirtualNodeSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareOptionForClientPolicyTLSPorts(t *testing.T) {
+	specWithPorts := func(ports ...int64) *appmeshsdk.VirtualNodeSpec {
+		return &appmeshsdk.VirtualNodeSpec{
+			BackendDefaults: &appmeshsdk.BackendDefaults{
+				ClientPolicy: &appmeshsdk.ClientPolicy{
+					Tls: &appmeshsdk.ClientPolicyTls{
+						Ports: aws.Int64Slice(ports),
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name       string
+		argLeft    *appmeshsdk.VirtualNodeSpec
+		argRight   *appmeshsdk.VirtualNodeSpec
+		wantEquals bool
+	}{
+		{
+			name:       "when ports are reordered",
+			argLeft:    specWithPorts(443, 8443),
+			argRight:   specWithPorts(8443, 443),
+			wantEquals: true,
+		},
+		{
+			name:       "when ports differ",
+			argLeft:    specWithPorts(443, 8443),
+			argRight:   specWithPorts(443, 9443),
+			wantEquals: false,
+		},
+		{
+			name:       "when left hand arg enforces for all ports",
+			argLeft:    specWithPorts(),
+			argRight:   specWithPorts(443),
+			wantEquals: false,
+		},
+		{
+			name:       "when both enforce for all ports",
+			argLeft:    specWithPorts(),
+			argRight:   &appmeshsdk.VirtualNodeSpec{BackendDefaults: &appmeshsdk.BackendDefaults{ClientPolicy: &appmeshsdk.ClientPolicy{Tls: &appmeshsdk.ClientPolicyTls{}}}},
+			wantEquals: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := CompareOptionForVirtualNodeSpec()
+			gotEquals := cmp.Equal(tt.argLeft, tt.argRight, opts)
+			assert.Equal(t, tt.wantEquals, gotEquals)
+		})
+	}
+}
+
+// buildVirtualNodeSpecWithHTTPTimeout builds a VirtualNodeSpec whose http listener has given perRequest and idle timeouts in milliseconds.
+func buildVirtualNodeSpecWithHTTPTimeout(perRequestMS *int64, idleMS *int64) *appmeshsdk.VirtualNodeSpec {
+	timeout := &appmeshsdk.HttpTimeout{}
+	if perRequestMS != nil {
+		timeout.PerRequest = &appmeshsdk.Duration{Unit: aws.String("ms"), Value: perRequestMS}
+	}
+	if idleMS != nil {
+		timeout.Idle = &appmeshsdk.Duration{Unit: aws.String("ms"), Value: idleMS}
+	}
+	return &appmeshsdk.VirtualNodeSpec{
+		Listeners: []*appmeshsdk.Listener{
+			{
+				PortMapping: &appmeshsdk.PortMapping{
+					Port:     aws.Int64(8080),
+					Protocol: aws.String("http"),
+				},
+				Timeout: &appmeshsdk.ListenerTimeout{
+					Http: timeout,
+				},
+			},
+		},
+	}
+}
@@ -2,6 +2,7 @@ package gatewayroute
 
 import (
 	"context"
+	"fmt"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/conversions"
@@ -9,6 +10,7 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/tagging"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualgateway"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualservice"
 	"github.com/aws/aws-sdk-go/aws"
@@ -38,6 +40,7 @@ func NewDefaultResourceManager(
 	appMeshSDK services.AppMesh,
 	referencesResolver references.Resolver,
 	accountID string,
+	tagsManager tagging.Manager,
 	log logr.Logger) ResourceManager {
 
 	return &defaultResourceManager{
@@ -45,6 +48,7 @@ func NewDefaultResourceManager(
 		appMeshSDK:         appMeshSDK,
 		referencesResolver: referencesResolver,
 		accountID:          accountID,
+		tagsManager:        tagsManager,
 		log:                log,
 	}
 }
@@ -55,15 +59,19 @@ type defaultResourceManager struct {
 	appMeshSDK         services.AppMesh
 	referencesResolver references.Resolver
 	accountID          string
+	tagsManager        tagging.Manager
 	log                logr.Logger
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, gr *appmesh.GatewayRoute) error {
+	if k8s.IsReconciliationPaused(gr) {
+		return m.updateCRDGatewayRouteReconciliationPaused(ctx, gr)
+	}
 	ms, err := m.findMeshDependency(ctx, gr)
 	if err != nil {
 		return err
 	}
-	if err := m.validateMeshDependency(ctx, ms); err != nil {
+	if err := m.validateMeshDependency(ctx, ms, gr); err != nil {
 		return err
 	}
 	vg, err := m.findVirtualGatewayDependency(ctx, gr)
@@ -95,6 +103,9 @@ func (m *defaultResourceManager) Reconcile(ctx context.Context, gr *appmesh.Gate
 		if err != nil {
 			return err
 		}
+		if err := m.updateSDKGatewayRouteTags(ctx, sdkGR, gr); err != nil {
+			return err
+		}
 	}
 
 	return m.updateCRDGatewayRoute(ctx, gr, sdkGR)
@@ -119,6 +130,10 @@ func (m *defaultResourceManager) Cleanup(ctx context.Context, gr *appmesh.Gatewa
 	if sdkGR == nil {
 		return nil
 	}
+	if k8s.IsDeletionPolicyOrphan(gr) {
+		m.sdkGatewayRouteLogger(sdkGR, gr).V(1).Info("skip gatewayRoute deletion since its deletionPolicy is orphan")
+		return nil
+	}
 
 	return m.deleteSDKGatewayRoute(ctx, sdkGR, ms, vg, gr)
 }
@@ -136,8 +151,12 @@ func (m *defaultResourceManager) findMeshDependency(ctx context.Context, gr *app
 }
 
 // validateMeshDependency validate the Mesh dependency for this gatewayRoute.
-func (m *defaultResourceManager) validateMeshDependency(ctx context.Context, ms *appmesh.Mesh) error {
+// gatewayRoute is marked as WaitingForMesh and requeued with backoff until the mesh becomes active.
+func (m *defaultResourceManager) validateMeshDependency(ctx context.Context, ms *appmesh.Mesh, gr *appmesh.GatewayRoute) error {
 	if !mesh.IsMeshActive(ms) {
+		if err := m.updateCRDGatewayRouteWaitingForMesh(ctx, gr, ms); err != nil {
+			return err
+		}
 		return runtime.NewRequeueError(errors.New("mesh is not active yet"))
 	}
 	return nil
@@ -225,6 +244,7 @@ func (m *defaultResourceManager) createSDKGatewayRoute(ctx context.Context, ms *
 		Spec:               sdkGRSpec,
 		VirtualGatewayName: vg.Spec.AWSName,
 		GatewayRouteName:   gr.Spec.AWSName,
+		Tags:               m.buildSDKGatewayRouteTags(ctx, gr),
 	})
 	if err != nil {
 		return nil, err
@@ -244,16 +264,12 @@ func (m *defaultResourceManager) updateSDKGatewayRoute(ctx context.Context, sdkG
 		return sdkGR, nil
 	}
 	if !m.isSDKGatewayRouteControlledByCRDGatewayRoute(ctx, sdkGR, gr) {
-		m.log.V(2).Info("skip gatewayRoute update since it's not controlled",
-			"gatewayRoute", k8s.NamespacedName(gr),
-			"gatewayRouteARN", aws.StringValue(sdkGR.Metadata.Arn),
-		)
+		m.sdkGatewayRouteLogger(sdkGR, gr).V(2).Info("skip gatewayRoute update since it's not controlled")
 		return sdkGR, nil
 	}
 
 	diff := cmp.Diff(desiredSDKGRSpec, actualSDKGRSpec, opts)
-	m.log.V(2).Info("gatewayRouteSpec changed",
-		"gatewayRoute", k8s.NamespacedName(gr),
+	m.sdkGatewayRouteLogger(sdkGR, gr).V(2).Info("gatewayRouteSpec changed",
 		"actualSDKGRSpec", actualSDKGRSpec,
 		"desiredSDKGRSpec", desiredSDKGRSpec,
 		"diff", diff,
@@ -273,10 +289,7 @@ func (m *defaultResourceManager) updateSDKGatewayRoute(ctx context.Context, sdkG
 
 func (m *defaultResourceManager) deleteSDKGatewayRoute(ctx context.Context, sdkGR *appmeshsdk.GatewayRouteData, ms *appmesh.Mesh, vg *appmesh.VirtualGateway, gr *appmesh.GatewayRoute) error {
 	if !m.isSDKGatewayRouteOwnedByCRDGatewayRoute(ctx, sdkGR, gr) {
-		m.log.V(2).Info("skip mesh gatewayRoute since its not owned",
-			"gatewayRoute", k8s.NamespacedName(gr),
-			"gatewayRouteARN", aws.StringValue(sdkGR.Metadata.Arn),
-		)
+		m.sdkGatewayRouteLogger(sdkGR, gr).V(2).Info("skip mesh gatewayRoute since its not owned")
 		return nil
 	}
 
@@ -286,10 +299,7 @@ func (m *defaultResourceManager) deleteSDKGatewayRoute(ctx context.Context, sdkG
 		VirtualGatewayName: vg.Spec.AWSName,
 		GatewayRouteName:   sdkGR.GatewayRouteName,
 	})
-	if err != nil {
-		return err
-	}
-	return nil
+	return services.IgnoreAppMeshNotFound(err)
 }
 
 func (m *defaultResourceManager) updateCRDGatewayRoute(ctx context.Context, gr *appmesh.GatewayRoute, sdkGR *appmeshsdk.GatewayRouteData) error {
@@ -312,6 +322,14 @@ func (m *defaultResourceManager) updateCRDGatewayRoute(ctx context.Context, gr *
 	if updateCondition(gr, appmesh.GatewayRouteActive, grActiveConditionStatus, nil, nil) {
 		needsUpdate = true
 	}
+	if getCondition(gr, appmesh.GatewayRouteReconciliationPaused) != nil &&
+		updateCondition(gr, appmesh.GatewayRouteReconciliationPaused, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
+	if getCondition(gr, appmesh.GatewayRouteWaitingForMesh) != nil &&
+		updateCondition(gr, appmesh.GatewayRouteWaitingForMesh, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
 
 	if !needsUpdate {
 		return nil
@@ -319,9 +337,44 @@ func (m *defaultResourceManager) updateCRDGatewayRoute(ctx context.Context, gr *
 	return m.k8sClient.Status().Patch(ctx, gr, client.MergeFrom(oldGR))
 }
 
+// updateCRDGatewayRouteReconciliationPaused records the ReconciliationPaused condition without touching the AppMesh GatewayRoute.
+func (m *defaultResourceManager) updateCRDGatewayRouteReconciliationPaused(ctx context.Context, gr *appmesh.GatewayRoute) error {
+	oldGR := gr.DeepCopy()
+	if !updateCondition(gr, appmesh.GatewayRouteReconciliationPaused, corev1.ConditionTrue, nil, nil) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, gr, client.MergeFrom(oldGR))
+}
+
+// updateCRDGatewayRouteWaitingForMesh records the WaitingForMesh condition while the mesh of gr isn't active.
+func (m *defaultResourceManager) updateCRDGatewayRouteWaitingForMesh(ctx context.Context, gr *appmesh.GatewayRoute, ms *appmesh.Mesh) error {
+	oldGR := gr.DeepCopy()
+	message := fmt.Sprintf("mesh %s is not active yet", ms.Name)
+	if !updateCondition(gr, appmesh.GatewayRouteWaitingForMesh, corev1.ConditionTrue, nil, aws.String(message)) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, gr, client.MergeFrom(oldGR))
+}
+
 func (m *defaultResourceManager) buildSDKGatewayRouteTags(ctx context.Context, gr *appmesh.GatewayRoute) []*appmeshsdk.TagRef {
-	// TODO, support tags
-	return nil
+	return m.tagsManager.BuildSDKTags(gr)
+}
+
+// sdkGatewayRouteLogger returns a logger carrying the identity of gr and its AppMesh GatewayRoute.
+func (m *defaultResourceManager) sdkGatewayRouteLogger(sdkGR *appmeshsdk.GatewayRouteData, gr *appmesh.GatewayRoute) logr.Logger {
+	return m.log.WithValues(
+		"meshName", aws.StringValue(sdkGR.MeshName),
+		"gatewayRoute", k8s.NamespacedName(gr),
+		"arn", aws.StringValue(sdkGR.Metadata.Arn),
+	)
+}
+
+// updateSDKGatewayRouteTags corrects drift of the tags propagated from labels of gr on its AppMesh GatewayRoute.
+func (m *defaultResourceManager) updateSDKGatewayRouteTags(ctx context.Context, sdkGR *appmeshsdk.GatewayRouteData, gr *appmesh.GatewayRoute) error {
+	if !m.isSDKGatewayRouteControlledByCRDGatewayRoute(ctx, sdkGR, gr) {
+		return nil
+	}
+	return m.tagsManager.ReconcileSDKTags(ctx, aws.StringValue(sdkGR.Metadata.Arn), gr)
 }
 
 // isSDKGatewayRouteControlledByCRDGatewayRoute checks whether an AppMesh gatewayRoute is controlled by CRD gatewayRoute
@@ -4,9 +4,13 @@ import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	mock_resolver "github.com/aws/aws-app-mesh-controller-for-k8s/mocks/aws-app-mesh-controller-for-k8s/pkg/references"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/equality"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	appmeshruntime "github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
@@ -108,6 +112,55 @@ func Test_defaultResourceManager_updateCRDGatewayRoute(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "gatewayRoute resumed after pause needs patch ReconciliationPaused condition",
+			args: args{
+				gr: &appmesh.GatewayRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "gr-1",
+					},
+					Status: appmesh.GatewayRouteStatus{
+						GatewayRouteARN: aws.String("arn-1"),
+						Conditions: []appmesh.GatewayRouteCondition{
+							{
+								Type:   appmesh.GatewayRouteActive,
+								Status: corev1.ConditionTrue,
+							},
+							{
+								Type:   appmesh.GatewayRouteReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+				sdkGR: &appmeshsdk.GatewayRouteData{
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn: aws.String("arn-1"),
+					},
+					Status: &appmeshsdk.GatewayRouteStatus{
+						Status: aws.String(appmeshsdk.GatewayRouteStatusCodeActive),
+					},
+				},
+			},
+			wantGR: &appmesh.GatewayRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gr-1",
+				},
+				Status: appmesh.GatewayRouteStatus{
+					GatewayRouteARN: aws.String("arn-1"),
+					Conditions: []appmesh.GatewayRouteCondition{
+						{
+							Type:   appmesh.GatewayRouteActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.GatewayRouteReconciliationPaused,
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -141,6 +194,136 @@ func Test_defaultResourceManager_updateCRDGatewayRoute(t *testing.T) {
 	}
 }
 
+func Test_defaultResourceManager_Reconcile_paused(t *testing.T) {
+	type args struct {
+		gr *appmesh.GatewayRoute
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantGR  *appmesh.GatewayRoute
+		wantErr error
+	}{
+		{
+			name: "paused gatewayRoute records ReconciliationPaused condition",
+			args: args{
+				gr: &appmesh.GatewayRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "gr-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.GatewayRouteStatus{
+						GatewayRouteARN: aws.String("arn-1"),
+						Conditions: []appmesh.GatewayRouteCondition{
+							{
+								Type:   appmesh.GatewayRouteActive,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantGR: &appmesh.GatewayRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gr-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.GatewayRouteStatus{
+					GatewayRouteARN: aws.String("arn-1"),
+					Conditions: []appmesh.GatewayRouteCondition{
+						{
+							Type:   appmesh.GatewayRouteActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.GatewayRouteReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "paused gatewayRoute already has ReconciliationPaused condition",
+			args: args{
+				gr: &appmesh.GatewayRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "gr-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.GatewayRouteStatus{
+						GatewayRouteARN: aws.String("arn-1"),
+						Conditions: []appmesh.GatewayRouteCondition{
+							{
+								Type:   appmesh.GatewayRouteReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantGR: &appmesh.GatewayRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gr-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.GatewayRouteStatus{
+					GatewayRouteARN: aws.String("arn-1"),
+					Conditions: []appmesh.GatewayRouteCondition{
+						{
+							Type:   appmesh.GatewayRouteReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			// neither referencesResolver nor appMeshSDK expect any calls while paused.
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			m := &defaultResourceManager{
+				k8sClient:          k8sClient,
+				referencesResolver: resolver,
+				log:                log.NullLogger{},
+			}
+
+			err := k8sClient.Create(ctx, tt.args.gr.DeepCopy())
+			assert.NoError(t, err)
+			err = m.Reconcile(ctx, tt.args.gr)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				gotGR := &appmesh.GatewayRoute{}
+				err = k8sClient.Get(ctx, k8s.NamespacedName(tt.args.gr), gotGR)
+				assert.NoError(t, err)
+				opts := cmp.Options{
+					equality.IgnoreFakeClientPopulatedFields(),
+					cmpopts.IgnoreTypes((*metav1.Time)(nil)),
+				}
+				assert.True(t, cmp.Equal(tt.wantGR, gotGR, opts), "diff", cmp.Diff(tt.wantGR, gotGR, opts))
+			}
+		})
+	}
+}
+
 func Test_defaultResourceManager_isSDKGatewayRouteControlledByCRDGatewayRoute(t *testing.T) {
 	type fields struct {
 		accountID string
@@ -436,15 +619,38 @@ func Test_defaultResourceManager_validateMeshDependency(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
 			m := &defaultResourceManager{
-				log: log.NullLogger{},
+				k8sClient: k8sClient,
+				log:       log.NullLogger{},
+			}
+			gr := &appmesh.GatewayRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "my-gr",
+				},
 			}
+			err := k8sClient.Create(ctx, gr.DeepCopy())
+			assert.NoError(t, err)
 
-			err := m.validateMeshDependency(ctx, tt.args.mesh)
+			err = m.validateMeshDependency(ctx, tt.args.mesh, gr)
+			gotGR := &appmesh.GatewayRoute{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(gr), gotGR))
+			waitingForMesh := getCondition(gotGR, appmesh.GatewayRouteWaitingForMesh)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
+				var requeueErr *appmeshruntime.RequeueError
+				assert.True(t, errors.As(err, &requeueErr), "gr must be requeued while the mesh isn't active")
+				if assert.NotNil(t, waitingForMesh) {
+					assert.Equal(t, corev1.ConditionTrue, waitingForMesh.Status)
+					assert.Equal(t, "mesh my-mesh is not active yet", aws.StringValue(waitingForMesh.Message))
+				}
 			} else {
 				assert.NoError(t, err)
+				assert.Nil(t, waitingForMesh)
 			}
 		})
 	}
@@ -554,3 +760,61 @@ func Test_defaultResourceManager_findVirtualServiceDependencies(t *testing.T) {
 		})
 	}
 }
+
+// fakeAppMesh fails AppMesh deletions with deleteErr.
+type fakeAppMesh struct {
+	services.AppMesh
+	deleteErr error
+}
+
+func (f *fakeAppMesh) DeleteGatewayRouteWithContext(_ context.Context, _ *appmeshsdk.DeleteGatewayRouteInput, _ ...request.Option) (*appmeshsdk.DeleteGatewayRouteOutput, error) {
+	if f.deleteErr != nil {
+		return nil, f.deleteErr
+	}
+	return &appmeshsdk.DeleteGatewayRouteOutput{}, nil
+}
+
+func Test_defaultResourceManager_deleteSDKGatewayRoute(t *testing.T) {
+	sdkGatewayRoute := &appmeshsdk.GatewayRouteData{
+		MeshName:           aws.String("my-mesh"),
+		GatewayRouteName:   aws.String("my-gatewayroute"),
+		VirtualGatewayName: aws.String("my-virtualgateway"),
+		Metadata: &appmeshsdk.ResourceMetadata{
+			MeshOwner:     aws.String("222222222"),
+			ResourceOwner: aws.String("222222222"),
+		},
+	}
+	tests := []struct {
+		name      string
+		deleteErr error
+		wantErr   error
+	}{
+		{
+			name: "AppMesh gatewayRoute deleted",
+		},
+		{
+			name:      "AppMesh gatewayRoute deleted out-of-band",
+			deleteErr: awserr.New("NotFoundException", "gatewayRoute not found", nil),
+		},
+		{
+			name:      "AppMesh gatewayRoute deletion fails",
+			deleteErr: awserr.New("ResourceInUseException", "gatewayRoute in use", nil),
+			wantErr:   errors.New("ResourceInUseException: gatewayRoute in use"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				appMeshSDK: &fakeAppMesh{deleteErr: tt.deleteErr},
+				accountID:  "222222222",
+				log:        &log.NullLogger{},
+			}
+			err := m.deleteSDKGatewayRoute(context.Background(), sdkGatewayRoute, &appmesh.Mesh{}, &appmesh.VirtualGateway{}, &appmesh.GatewayRoute{})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
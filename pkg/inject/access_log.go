@@ -0,0 +1,89 @@
+package inject
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	accessLogVolumeName = "envoy-access-log"
+	// writableTmpDir is world-writable in the Envoy image, so the non-root Envoy user can write to it without a volume
+	writableTmpDir = "/tmp"
+	// devDir holds the device files of the container, such as /dev/stdout, a volume there would mask them
+	devDir = "/dev"
+	// accessLogFileStdout is the special access log file for writing the Envoy access log to container stdout
+	accessLogFileStdout = "stdout"
+	stdoutDevice        = "/dev/stdout"
+)
+
+// resolveAccessLogFile returns the path Envoy writes its access log to, resolving the special stdout access log file.
+func resolveAccessLogFile(accessLogFile string) string {
+	if accessLogFile == accessLogFileStdout {
+		return stdoutDevice
+	}
+	return accessLogFile
+}
+
+// mutateAccessLogMounts mounts an emptyDir for the directory of the Envoy access log file if it isn't writable already.
+// access log files under /tmp, device files such as /dev/stdout or files under a writable volume mount of envoy container
+// are left as is, while access log files under a read-only volume mount are rejected since Envoy would fail to open them.
+func mutateAccessLogMounts(pod *corev1.Pod, envoyContainer *corev1.Container, accessLogFile string) error {
+	if accessLogFile == "" {
+		return nil
+	}
+	accessLogDir := path.Dir(path.Clean(accessLogFile))
+	if isUnderDir(accessLogDir, devDir) {
+		return nil
+	}
+	if mount, ok := findVolumeMountForPath(envoyContainer, accessLogDir); ok {
+		if mount.ReadOnly {
+			return errors.Errorf("envoy access log file %s is under read-only volume mount %s", accessLogFile, mount.MountPath)
+		}
+		return nil
+	}
+	if isUnderDir(accessLogDir, writableTmpDir) {
+		return nil
+	}
+
+	volume := corev1.Volume{
+		Name: accessLogVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      accessLogVolumeName,
+		MountPath: accessLogDir,
+	}
+	envoyContainer.VolumeMounts = append(envoyContainer.VolumeMounts, volumeMount)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+	return nil
+}
+
+// findVolumeMountForPath returns the innermost volume mount of container that contains dir.
+func findVolumeMountForPath(container *corev1.Container, dir string) (corev1.VolumeMount, bool) {
+	var found corev1.VolumeMount
+	ok := false
+	for _, mount := range container.VolumeMounts {
+		mountPath := path.Clean(mount.MountPath)
+		if !isUnderDir(dir, mountPath) {
+			continue
+		}
+		if !ok || len(mountPath) > len(path.Clean(found.MountPath)) {
+			found = mount
+			ok = true
+		}
+	}
+	return found, ok
+}
+
+// isUnderDir checks whether p equals dir or is nested within it.
+func isUnderDir(p string, dir string) bool {
+	if dir == "/" {
+		return true
+	}
+	return p == dir || strings.HasPrefix(p, dir+"/")
+}
@@ -0,0 +1,261 @@
+package inject
+
+import (
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func Test_mutateAccessLogMounts(t *testing.T) {
+	type args struct {
+		pod           *corev1.Pod
+		container     *corev1.Container
+		accessLogFile string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantPod       *corev1.Pod
+		wantContainer *corev1.Container
+		wantErr       error
+	}{
+		{
+			name: "access log file under /tmp",
+			args: args{
+				pod: &corev1.Pod{},
+				container: &corev1.Container{
+					Name: "envoy",
+				},
+				accessLogFile: "/tmp/envoy_admin_access.log",
+			},
+			wantPod: &corev1.Pod{},
+			wantContainer: &corev1.Container{
+				Name: "envoy",
+			},
+		},
+		{
+			name: "access log file is stdout",
+			args: args{
+				pod: &corev1.Pod{},
+				container: &corev1.Container{
+					Name: "envoy",
+				},
+				accessLogFile: "/dev/stdout",
+			},
+			wantPod: &corev1.Pod{},
+			wantContainer: &corev1.Container{
+				Name: "envoy",
+			},
+		},
+		{
+			name: "access log file under an existing writable mount",
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{
+							{
+								Name: "logs",
+								VolumeSource: corev1.VolumeSource{
+									EmptyDir: &corev1.EmptyDirVolumeSource{},
+								},
+							},
+						},
+					},
+				},
+				container: &corev1.Container{
+					Name: "envoy",
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "logs",
+							MountPath: "/var/log",
+						},
+					},
+				},
+				accessLogFile: "/var/log/envoy/admin_access.log",
+			},
+			wantPod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "logs",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+			wantContainer: &corev1.Container{
+				Name: "envoy",
+				VolumeMounts: []corev1.VolumeMount{
+					{
+						Name:      "logs",
+						MountPath: "/var/log",
+					},
+				},
+			},
+		},
+		{
+			name: "access log file outside of any mount",
+			args: args{
+				pod: &corev1.Pod{},
+				container: &corev1.Container{
+					Name: "envoy",
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "appmesh-ca-bundle",
+							MountPath: "/etc/appmesh/ca-bundle",
+							ReadOnly:  true,
+						},
+					},
+				},
+				accessLogFile: "/var/log/envoy/admin_access.log",
+			},
+			wantPod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "envoy-access-log",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+			wantContainer: &corev1.Container{
+				Name: "envoy",
+				VolumeMounts: []corev1.VolumeMount{
+					{
+						Name:      "appmesh-ca-bundle",
+						MountPath: "/etc/appmesh/ca-bundle",
+						ReadOnly:  true,
+					},
+					{
+						Name:      "envoy-access-log",
+						MountPath: "/var/log/envoy",
+					},
+				},
+			},
+		},
+		{
+			name: "access log file under a read-only mount",
+			args: args{
+				pod: &corev1.Pod{},
+				container: &corev1.Container{
+					Name: "envoy",
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "config",
+							MountPath: "/etc/envoy",
+							ReadOnly:  true,
+						},
+					},
+				},
+				accessLogFile: "/etc/envoy/logs/admin_access.log",
+			},
+			wantErr: errors.New("envoy access log file /etc/envoy/logs/admin_access.log is under read-only volume mount /etc/envoy"),
+		},
+		{
+			name: "access log file under a writable mount nested in a read-only mount",
+			args: args{
+				pod: &corev1.Pod{},
+				container: &corev1.Container{
+					Name: "envoy",
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "config",
+							MountPath: "/etc/envoy",
+							ReadOnly:  true,
+						},
+						{
+							Name:      "logs",
+							MountPath: "/etc/envoy/logs",
+						},
+					},
+				},
+				accessLogFile: "/etc/envoy/logs/admin_access.log",
+			},
+			wantPod: &corev1.Pod{},
+			wantContainer: &corev1.Container{
+				Name: "envoy",
+				VolumeMounts: []corev1.VolumeMount{
+					{
+						Name:      "config",
+						MountPath: "/etc/envoy",
+						ReadOnly:  true,
+					},
+					{
+						Name:      "logs",
+						MountPath: "/etc/envoy/logs",
+					},
+				},
+			},
+		},
+		{
+			name: "empty access log file",
+			args: args{
+				pod: &corev1.Pod{},
+				container: &corev1.Container{
+					Name: "envoy",
+				},
+				accessLogFile: "",
+			},
+			wantPod: &corev1.Pod{},
+			wantContainer: &corev1.Container{
+				Name: "envoy",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := tt.args.pod.DeepCopy()
+			container := tt.args.container.DeepCopy()
+			err := mutateAccessLogMounts(pod, container, tt.args.accessLogFile)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, cmp.Equal(tt.wantPod, pod), "diff", cmp.Diff(tt.wantPod, pod))
+				assert.True(t, cmp.Equal(tt.wantContainer, container), "diff", cmp.Diff(tt.wantContainer, container))
+			}
+		})
+	}
+}
+
+func Test_resolveAccessLogFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		accessLogFile string
+		want          string
+	}{
+		{
+			name:          "stdout",
+			accessLogFile: "stdout",
+			want:          "/dev/stdout",
+		},
+		{
+			name:          "stdout device",
+			accessLogFile: "/dev/stdout",
+			want:          "/dev/stdout",
+		},
+		{
+			name:          "access log file",
+			accessLogFile: "/tmp/envoy_admin_access.log",
+			want:          "/tmp/envoy_admin_access.log",
+		},
+		{
+			name:          "no access log file",
+			accessLogFile: "",
+			want:          "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveAccessLogFile(tt.accessLogFile)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package inject
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	caBundleVolumeName = "appmesh-ca-bundle"
+	caBundleMountPath  = "/etc/appmesh/ca-bundle"
+	// caBundleEnvName is honored by the App Mesh agent inside the Envoy image when connecting to the control plane
+	caBundleEnvName = "AWS_CA_BUNDLE"
+
+	caBundleSourceKindConfigMap = "configmap"
+	caBundleSourceKindSecret    = "secret"
+)
+
+// caBundleSource references the key of a ConfigMap or Secret that holds a PEM encoded CA bundle.
+type caBundleSource struct {
+	kind string
+	name string
+	key  string
+}
+
+// parseCABundleSource parses a CA bundle reference in the format of <configmap|secret>/<name>/<key>
+func parseCABundleSource(ref string) (caBundleSource, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return caBundleSource{}, errors.Errorf("malformed CA bundle reference %s, expected format: %s", ref, "<configmap|secret>/<name>/<key>")
+	}
+	kind := strings.ToLower(parts[0])
+	if kind != caBundleSourceKindConfigMap && kind != caBundleSourceKindSecret {
+		return caBundleSource{}, errors.Errorf("unsupported CA bundle source kind %s, must be %s or %s", parts[0], caBundleSourceKindConfigMap, caBundleSourceKindSecret)
+	}
+	return caBundleSource{
+		kind: kind,
+		name: parts[1],
+		key:  parts[2],
+	}, nil
+}
+
+// mutateCABundleMounts mounts the CA bundle into envoy container and points the control plane client at it.
+func mutateCABundleMounts(pod *corev1.Pod, envoyContainer *corev1.Container, source caBundleSource) {
+	volume := corev1.Volume{Name: caBundleVolumeName}
+	switch source.kind {
+	case caBundleSourceKindConfigMap:
+		volume.VolumeSource = corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: source.name},
+			},
+		}
+	case caBundleSourceKindSecret:
+		volume.VolumeSource = corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: source.name,
+			},
+		}
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      caBundleVolumeName,
+		MountPath: caBundleMountPath,
+		ReadOnly:  true,
+	}
+
+	caBundlePath := caBundleMountPath + "/" + source.key
+	envOverridden := false
+	for idx, env := range envoyContainer.Env {
+		if env.Name == caBundleEnvName {
+			envoyContainer.Env[idx] = envVar(caBundleEnvName, caBundlePath)
+			envOverridden = true
+		}
+	}
+	if !envOverridden {
+		envoyContainer.Env = append(envoyContainer.Env, envVar(caBundleEnvName, caBundlePath))
+	}
+	envoyContainer.VolumeMounts = append(envoyContainer.VolumeMounts, volumeMount)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+}
@@ -0,0 +1,197 @@
+package inject
+
+import (
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func Test_parseCABundleSource(t *testing.T) {
+	type args struct {
+		ref string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    caBundleSource
+		wantErr error
+	}{
+		{
+			name: "configMap reference",
+			args: args{
+				ref: "configmap/corp-ca/ca.crt",
+			},
+			want: caBundleSource{
+				kind: "configmap",
+				name: "corp-ca",
+				key:  "ca.crt",
+			},
+		},
+		{
+			name: "secret reference",
+			args: args{
+				ref: "Secret/corp-ca/ca.pem",
+			},
+			want: caBundleSource{
+				kind: "secret",
+				name: "corp-ca",
+				key:  "ca.pem",
+			},
+		},
+		{
+			name: "missing key",
+			args: args{
+				ref: "configmap/corp-ca",
+			},
+			wantErr: errors.New("malformed CA bundle reference configmap/corp-ca, expected format: <configmap|secret>/<name>/<key>"),
+		},
+		{
+			name: "unsupported kind",
+			args: args{
+				ref: "pod/corp-ca/ca.crt",
+			},
+			wantErr: errors.New("unsupported CA bundle source kind pod, must be configmap or secret"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCABundleSource(tt.args.ref)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_mutateCABundleMounts(t *testing.T) {
+	type args struct {
+		pod       *corev1.Pod
+		container *corev1.Container
+		source    caBundleSource
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantPod       *corev1.Pod
+		wantContainer *corev1.Container
+	}{
+		{
+			name: "configMap source",
+			args: args{
+				pod: &corev1.Pod{},
+				container: &corev1.Container{
+					Name: "envoy",
+					Env: []corev1.EnvVar{
+						{
+							Name:  "AWS_REGION",
+							Value: "us-west-2",
+						},
+					},
+				},
+				source: caBundleSource{
+					kind: "configmap",
+					name: "corp-ca",
+					key:  "ca.crt",
+				},
+			},
+			wantPod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "appmesh-ca-bundle",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "corp-ca"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantContainer: &corev1.Container{
+				Name: "envoy",
+				Env: []corev1.EnvVar{
+					{
+						Name:  "AWS_REGION",
+						Value: "us-west-2",
+					},
+					{
+						Name:  "AWS_CA_BUNDLE",
+						Value: "/etc/appmesh/ca-bundle/ca.crt",
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{
+						Name:      "appmesh-ca-bundle",
+						MountPath: "/etc/appmesh/ca-bundle",
+						ReadOnly:  true,
+					},
+				},
+			},
+		},
+		{
+			name: "secret source overrides existing env",
+			args: args{
+				pod: &corev1.Pod{},
+				container: &corev1.Container{
+					Name: "envoy",
+					Env: []corev1.EnvVar{
+						{
+							Name:  "AWS_CA_BUNDLE",
+							Value: "/some/other/path",
+						},
+					},
+				},
+				source: caBundleSource{
+					kind: "secret",
+					name: "corp-ca",
+					key:  "ca.pem",
+				},
+			},
+			wantPod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "appmesh-ca-bundle",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: "corp-ca",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantContainer: &corev1.Container{
+				Name: "envoy",
+				Env: []corev1.EnvVar{
+					{
+						Name:  "AWS_CA_BUNDLE",
+						Value: "/etc/appmesh/ca-bundle/ca.pem",
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{
+						Name:      "appmesh-ca-bundle",
+						MountPath: "/etc/appmesh/ca-bundle",
+						ReadOnly:  true,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := tt.args.pod.DeepCopy()
+			container := tt.args.container.DeepCopy()
+			mutateCABundleMounts(pod, container, tt.args.source)
+			assert.True(t, cmp.Equal(tt.wantPod, pod), "diff", cmp.Diff(tt.wantPod, pod))
+			assert.True(t, cmp.Equal(tt.wantContainer, container), "diff", cmp.Diff(tt.wantContainer, container))
+		})
+	}
+}
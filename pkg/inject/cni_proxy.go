@@ -28,6 +28,9 @@ func (m *cniProxyMutator) mutate(pod *corev1.Pod) error {
 		AppMeshIgnoredUIDAnnotation:         fmt.Sprintf("%d", m.proxyConfig.proxyUID),
 		AppMeshSidecarInjectAnnotation:      "enabled",
 	}
+	if m.proxyConfig.proxyGID > 0 {
+		cniAnnotations[AppMeshIgnoredGIDAnnotation] = fmt.Sprintf("%d", m.proxyConfig.proxyGID)
+	}
 	annotations := algorithm.MergeStringMap(cniAnnotations, pod.GetAnnotations())
 	pod.SetAnnotations(annotations)
 	return nil
@@ -2,8 +2,16 @@ package inject
 
 import (
 	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strings"
 
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -11,8 +19,14 @@ const (
 	flagEnableECRSecret             = "enable-ecr-secret"
 	flagEnableSDS                   = "enable-sds"
 	flagSdsUdsPath                  = "sds-uds-path"
+	flagEnableVNReadinessGate       = "enable-virtual-node-readiness-gate"
+	flagInjectionLabelSelector      = "injection-label-selector"
 
 	flagSidecarImage               = "sidecar-image"
+	flagSidecarImageAmd64          = "sidecar-image-amd64"
+	flagSidecarImageArm64          = "sidecar-image-arm64"
+	flagGatewaySidecarImage        = "gateway-sidecar-image"
+	flagSidecarContainerName       = "sidecar-container-name"
 	flagSidecarCpuRequests         = "sidecar-cpu-requests"
 	flagSidecarMemoryRequests      = "sidecar-memory-requests"
 	flagSidecarCpuLimits           = "sidecar-cpu-limits"
@@ -20,13 +34,23 @@ const (
 	flagPreview                    = "preview"
 	flagLogLevel                   = "sidecar-log-level"
 	flagPreStopDelay               = "prestop-delay"
+	flagEnableGracefulDrain        = "enable-graceful-drain"
+	flagGracefulDrainTimeout       = "graceful-drain-timeout"
 	flagReadinessProbeInitialDelay = "readiness-probe-initial-delay"
 	flagReadinessProbePeriod       = "readiness-probe-period"
 	flagEnvoyAdminAccessPort       = "envoy-admin-access-port"
 	flagEnvoyAdminAccessLogFile    = "envoy-admin-access-log-file"
+	flagEnvoyAccessLogVolume       = "envoy-access-log-volume"
+	flagPSACompatible              = "psa-compatible"
+	flagSidecarCABundle            = "sidecar-ca-bundle"
+	flagSidecarRunAsUser           = "sidecar-run-as-user"
+	flagSidecarRunAsGroup          = "sidecar-run-as-group"
+	flagRequireImageDigest         = "require-image-digest"
+	flagRequireSidecarLimits       = "require-sidecar-limits"
 
-	flagInitImage  = "init-image"
-	flagIgnoredIPs = "ignored-ips"
+	flagInitImage              = "init-image"
+	flagProxyInitContainerName = "proxyinit-container-name"
+	flagIgnoredIPs             = "ignored-ips"
 
 	flagEnableJaegerTracing  = "enable-jaeger-tracing"
 	flagJaegerAddress        = "jaeger-address"
@@ -34,6 +58,9 @@ const (
 	flagEnableDatadogTracing = "enable-datadog-tracing"
 	flagDatadogAddress       = "datadog-address"
 	flagDatadogPort          = "datadog-port"
+	flagDatadogEnvLabel      = "datadog-env-label"
+	flagDatadogServiceLabel  = "datadog-service-label"
+	flagDatadogVersionLabel  = "datadog-version-label"
 	flagEnableXrayTracing    = "enable-xray-tracing"
 	flagXrayDaemonPort       = "xray-daemon-port"
 	flagEnableStatsTags      = "enable-stats-tags"
@@ -41,6 +68,40 @@ const (
 	flagStatsDAddress        = "statsd-address"
 	flagStatsDPort           = "statsd-port"
 	flagXRayImage            = "xray-image"
+	flagXRayInjectDaemon     = "xray-inject-daemon"
+	flagXRayRequireIRSA      = "xray-require-irsa"
+	flagXRayCpuRequests      = "xray-cpu-requests"
+	flagXRayMemoryRequests   = "xray-memory-requests"
+	flagXRayCpuLimits        = "xray-cpu-limits"
+	flagXRayMemoryLimits     = "xray-memory-limits"
+
+	flagTracingConfigVolumeSizeLimit = "tracing-config-volume-size-limit"
+
+	flagEnableEnvoyWarmup  = "enable-envoy-warmup"
+	flagEnvoyWarmupImage   = "envoy-warmup-image"
+	flagEnvoyWarmupTimeout = "envoy-warmup-timeout"
+
+	flagDumpInjectedSpec          = "dump-injected-spec"
+	flagDumpInjectedSpecRedactEnv = "dump-injected-spec-redact-env"
+
+	flagSidecarEnvOverrideAllowlist = "sidecar-env-override-allowlist"
+)
+
+const (
+	flagK8sLookupRetries         = "k8s-lookup-retries"
+	flagInjectExcludedNamespaces = "inject-excluded-namespaces"
+	flagSidecarPreviewImage      = "sidecar-preview-image"
+
+	flagSidecarTerminationMessagePolicy = "sidecar-termination-message-policy"
+	flagEnablePrometheusScrape          = "enable-prometheus-scrape-annotations"
+	flagPrometheusScrapePath            = "prometheus-scrape-path"
+	flagPodDNSOptions                   = "pod-dns-options"
+	flagPodDNSSearches                  = "pod-dns-searches"
+	flagHostNetworkPolicy               = "host-network-policy"
+	flagMinEnvoyVersion                 = "min-envoy-version"
+	flagMinEnvoyVersionAction           = "min-envoy-version-action"
+	flagInjectFailOnMissingVirtualNode  = "inject-fail-on-missing-virtual-node"
+	flagEnablePodMetadataEnv            = "enable-pod-metadata-env"
 )
 
 type Config struct {
@@ -53,9 +114,19 @@ type Config struct {
 	EnableSDS bool
 	// Contains the Unix Domain Socket Path for SDS provider.
 	SdsUdsPath string
+	// If enabled, pods will not become ready until their VirtualNode is active in AppMesh.
+	EnableVNReadinessGate bool
+	// If set, only pods matching this label selector are injected, in addition to the namespace gating.
+	InjectionLabelSelector string
+	// Pods in these namespaces are never injected, regardless of namespace labels and pod annotations.
+	InjectExcludedNamespaces []string
 
 	// Sidecar settings
 	SidecarImage               string
+	SidecarImageAmd64          string
+	SidecarImageArm64          string
+	GatewaySidecarImage        string
+	SidecarContainerName       string
 	SidecarCpuRequests         string
 	SidecarMemoryRequests      string
 	SidecarCpuLimits           string
@@ -63,14 +134,52 @@ type Config struct {
 	Preview                    bool
 	LogLevel                   string
 	PreStopDelay               string
+	EnableGracefulDrain        bool
+	GracefulDrainTimeout       int32
 	ReadinessProbeInitialDelay int32
 	ReadinessProbePeriod       int32
 	EnvoyAdminAcessPort        int32
 	EnvoyAdminAccessLogFile    string
+	EnvoyAccessLogVolume       bool
+	PSACompatible              bool
+	SidecarCABundle            string
+	SidecarRunAsUser           int64
+	SidecarRunAsGroup          int64
+	// If enabled, pods are denied unless their Envoy image is pinned by digest.
+	RequireImageDigest bool
+	// Resource limits(cpu, memory) the Envoy container must declare, pods are denied otherwise.
+	RequireSidecarLimits []string
+	// Controller managed Envoy env that the appmesh.k8s.aws/sidecarEnv annotation of pods may override.
+	SidecarEnvOverrideAllowlist []string
+	// Number of retries of the Kubernetes API lookups during injection on transient errors.
+	K8sLookupRetries int
+	// Envoy image of pods connecting to the App Mesh preview channel, defaults to the image of sidecars.
+	SidecarPreviewImage string
+	// TerminationMessagePolicy of the injected Envoy, proxyinit and X-Ray containers, left unset if empty.
+	SidecarTerminationMessagePolicy string
+	// If enabled, injected pods are annotated for Prometheus to scrape Envoy stats from the admin port.
+	EnablePrometheusScrape bool
+	// Path of the Envoy admin interface Prometheus scrapes stats from.
+	PrometheusScrapePath string
+	// dnsConfig options, such as ndots:1, merged into injected pods.
+	PodDNSOptions []string
+	// dnsConfig search domains merged into injected pods.
+	PodDNSSearches []string
+	// How meshed pods using hostNetwork are handled - skip or deny.
+	HostNetworkPolicy string
+	// If set, the Envoy image of injected pods must be no older than this version, such as 1.17.2.0.
+	MinEnvoyVersion string
+	// How pods with an Envoy image older than MinEnvoyVersion are handled - deny or warn.
+	MinEnvoyVersionAction string
+	// If enabled, pods without a virtualNode with a meshRef are denied instead of admitted without a sidecar.
+	InjectFailOnMissingVirtualNode bool
+	// If enabled, Envoy gets the name and namespace of its pod via the downward API, so traces can be correlated with pods.
+	EnablePodMetadataEnv bool
 
 	// Init container settings
-	InitImage  string
-	IgnoredIPs string
+	InitImage              string
+	ProxyInitContainerName string
+	IgnoredIPs             string
 
 	// Observability settings
 	EnableJaegerTracing  bool
@@ -79,6 +188,9 @@ type Config struct {
 	EnableDatadogTracing bool
 	DatadogAddress       string
 	DatadogPort          int32
+	DatadogEnvLabel      string
+	DatadogServiceLabel  string
+	DatadogVersionLabel  string
 	EnableXrayTracing    bool
 	XrayDaemonPort       int32
 	EnableStatsTags      bool
@@ -86,6 +198,24 @@ type Config struct {
 	StatsDAddress        string
 	StatsDPort           int32
 	XRayImage            string
+	XRayInjectDaemon     bool
+	XRayRequireIRSA      bool
+	XRayCpuRequests      string
+	XRayMemoryRequests   string
+	XRayCpuLimits        string
+	XRayMemoryLimits     string
+
+	// Size limit of the emptyDir volume holding the Envoy tracing config
+	TracingConfigVolumeSizeLimit string
+
+	// Envoy warm-up init container settings
+	EnableEnvoyWarmup  bool
+	EnvoyWarmupImage   string
+	EnvoyWarmupTimeout int32
+
+	// Debug settings for logging the injected pod spec of pods opting in with appmesh.k8s.aws/debugInjection
+	DumpInjectedSpec          bool
+	DumpInjectedSpecRedactEnv bool
 }
 
 // MultipleTracer checks if more than one tracer is configured.
@@ -104,11 +234,28 @@ func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
 		"If enabled, 'appmesh-ecr-secret' secret will be injected in the absence of it within pod imagePullSecrets")
 	fs.BoolVar(&cfg.EnableSDS, flagEnableSDS, false,
 		"If enabled, mTLS support via SDS will be enabled")
+	fs.BoolVar(&cfg.EnableVNReadinessGate, flagEnableVNReadinessGate, false,
+		"If enabled, 'appmesh.k8s.aws/virtual-node-ready' readiness gate will be injected and only turns true once the VirtualNode is active")
+	fs.StringVar(&cfg.InjectionLabelSelector, flagInjectionLabelSelector, "",
+		"If set, only pods matching this label selector are injected, e.g. appmesh.k8s.aws/mesh=enabled. Pods without matching labels are left untouched")
+	fs.StringSliceVar(&cfg.InjectExcludedNamespaces, flagInjectExcludedNamespaces, []string{"kube-system", "kube-node-lease"},
+		"Comma separated namespaces whose pods are never injected, regardless of namespace labels and pod annotations")
 	//Set to the SPIRE Agent's default UDS path for now as App Mesh only supports SPIRE as SDS provider for preview.
 	fs.StringVar(&cfg.SdsUdsPath, flagSdsUdsPath, "/run/spire/sockets/agent.sock",
 		"Unix Domain Socket path for SDS provider")
 	fs.StringVar(&cfg.SidecarImage, flagSidecarImage, "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-envoy:v1.17.2.0-prod",
 		"Envoy sidecar container image.")
+	fs.StringVar(&cfg.SidecarImageAmd64, flagSidecarImageAmd64, "",
+		"Envoy sidecar container image for pods constrained to amd64 nodes by nodeSelector or nodeAffinity on kubernetes.io/arch. Defaults to sidecar-image")
+	fs.StringVar(&cfg.SidecarImageArm64, flagSidecarImageArm64, "",
+		"Envoy sidecar container image for pods constrained to arm64 nodes by nodeSelector or nodeAffinity on kubernetes.io/arch. Defaults to sidecar-image")
+	fs.StringVar(&cfg.GatewaySidecarImage, flagGatewaySidecarImage, "",
+		"Envoy container image for VirtualGateway pods. Defaults to the image of sidecars")
+	fs.StringVar(&cfg.SidecarPreviewImage, flagSidecarPreviewImage, "",
+		"Envoy container image for pods connecting to the App Mesh preview channel, by preview or the appmesh.k8s.aws/preview pod annotation. "+
+			"Defaults to the image of sidecars")
+	fs.StringVar(&cfg.SidecarContainerName, flagSidecarContainerName, envoyContainerName,
+		"Name of the Envoy sidecar container. VirtualGateway pods must use the same name for their Envoy container")
 	fs.StringVar(&cfg.SidecarCpuRequests, flagSidecarCpuRequests, "10m",
 		"Sidecar CPU resources requests.")
 	fs.StringVar(&cfg.SidecarMemoryRequests, flagSidecarMemoryRequests, "32Mi",
@@ -124,15 +271,73 @@ func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
 	fs.Int32Var(&cfg.EnvoyAdminAcessPort, flagEnvoyAdminAccessPort, 9901,
 		"AWS App Mesh envoy admin access port")
 	fs.StringVar(&cfg.EnvoyAdminAccessLogFile, flagEnvoyAdminAccessLogFile, "/tmp/envoy_admin_access.log",
-		"AWS App Mesh envoy access log path")
+		"AWS App Mesh envoy access log path, must be an absolute path. Set to stdout to write the access log to the envoy container stdout")
+	fs.BoolVar(&cfg.EnvoyAccessLogVolume, flagEnvoyAccessLogVolume, true,
+		"If enabled, an emptyDir volume is mounted for the directory of the envoy access log file unless it's under /tmp or an existing writable volume mount")
+	fs.BoolVar(&cfg.PSACompatible, flagPSACompatible, false,
+		"If enabled, every injected container(Envoy, X-Ray daemon and init containers) gets a security context compatible with the restricted Pod Security Standard, "+
+			"with the runtime/default seccomp profile requested by container annotation. The proxyinit container still requires root and NET_ADMIN, use App Mesh CNI in restricted namespaces")
+	fs.StringVar(&cfg.SidecarCABundle, flagSidecarCABundle, "",
+		"CA bundle mounted into Envoy sidecar to validate the App Mesh control plane, in the format of <configmap|secret>/<name>/<key>. "+
+			"The ConfigMap or Secret must exist in the namespace of the injected pod")
+	fs.Int64Var(&cfg.SidecarRunAsUser, flagSidecarRunAsUser, defaultProxyUID,
+		"UID the Envoy sidecar runs as. Traffic from this UID is exempted from redirection")
+	fs.Int64Var(&cfg.SidecarRunAsGroup, flagSidecarRunAsGroup, 0,
+		"GID the Envoy sidecar runs as. Traffic from this GID is exempted from redirection. Leave as 0 to not set a GID")
+	fs.BoolVar(&cfg.RequireImageDigest, flagRequireImageDigest, false,
+		"If enabled, pods are denied unless their Envoy image is pinned by digest, such as <repository>@sha256:<digest>. "+
+			`Pods annotated with appmesh.k8s.aws/allowUnpinnedSidecarImage: "true" are exempted`)
+	fs.StringSliceVar(&cfg.RequireSidecarLimits, flagRequireSidecarLimits, nil,
+		"Comma separated resources(cpu, memory) the Envoy sidecar must declare limits for, from annotations or defaults. "+
+			"Pods are denied otherwise. Leave empty to not require limits")
+	fs.StringSliceVar(&cfg.SidecarEnvOverrideAllowlist, flagSidecarEnvOverrideAllowlist, []string{"ENVOY_LOG_LEVEL"},
+		"Comma separated controller managed Envoy env that the appmesh.k8s.aws/sidecarEnv pod annotation may override. "+
+			"Env identifying Envoy to App Mesh, such as APPMESH_VIRTUAL_NODE_NAME, can't be allowlisted")
+	fs.IntVar(&cfg.K8sLookupRetries, flagK8sLookupRetries, 2,
+		"Number of retries of the namespace, ConfigMap, Secret and ServiceAccount lookups during injection on transient Kubernetes API errors. "+
+			"Pods are rejected with a retriable error once all attempts failed")
+	fs.StringVar(&cfg.SidecarTerminationMessagePolicy, flagSidecarTerminationMessagePolicy, "",
+		"terminationMessagePolicy of the injected Envoy, proxyinit and X-Ray containers - File or FallbackToLogsOnError. "+
+			"Leave empty to use the Kubernetes default")
+	fs.BoolVar(&cfg.EnablePrometheusScrape, flagEnablePrometheusScrape, false,
+		"If enabled, injected pods are annotated with prometheus.io/scrape, prometheus.io/port and prometheus.io/path for the Envoy admin interface. "+
+			"Annotations already set on pods are kept")
+	fs.StringVar(&cfg.PrometheusScrapePath, flagPrometheusScrapePath, defaultPrometheusScrapePath,
+		"Path of the Envoy admin interface set as prometheus.io/path on injected pods")
+	fs.StringSliceVar(&cfg.PodDNSOptions, flagPodDNSOptions, nil,
+		"Comma separated dnsConfig options merged into injected pods, in the format of name or name:value such as ndots:1. "+
+			"Options already set on pods are kept, the appmesh.k8s.aws/dnsOptions pod annotation replaces this list")
+	fs.StringSliceVar(&cfg.PodDNSSearches, flagPodDNSSearches, nil,
+		"Comma separated dnsConfig search domains merged into injected pods. "+
+			"The appmesh.k8s.aws/dnsSearches pod annotation replaces this list")
+	fs.StringVar(&cfg.HostNetworkPolicy, flagHostNetworkPolicy, hostNetworkPolicySkip,
+		"How meshed pods using hostNetwork are handled - skip leaves them uninjected with a warning event on their VirtualNode or VirtualGateway, "+
+			"deny rejects them")
+	fs.StringVar(&cfg.MinEnvoyVersion, flagMinEnvoyVersion, "",
+		"If set, such as 1.17.2.0, the vX.Y.Z.N-prod tagged Envoy image of injected pods must be no older than this version. "+
+			"Images with other tags are not checked")
+	fs.StringVar(&cfg.MinEnvoyVersionAction, flagMinEnvoyVersionAction, minEnvoyVersionActionDeny,
+		"How pods with an Envoy image older than min-envoy-version are handled - deny rejects them, warn admits them with a warning log")
+	fs.BoolVar(&cfg.InjectFailOnMissingVirtualNode, flagInjectFailOnMissingVirtualNode, false,
+		"If enabled, pods are denied when no virtualNode with a meshRef matches them, "+
+			"instead of being admitted without a sidecar")
+	fs.BoolVar(&cfg.EnablePodMetadataEnv, flagEnablePodMetadataEnv, true,
+		"If enabled, Envoy gets APPMESH_PLATFORM_K8S_POD_NAME and APPMESH_PLATFORM_K8S_POD_NAMESPACE from the downward API")
 	fs.StringVar(&cfg.PreStopDelay, flagPreStopDelay, "20",
 		"AWS App Mesh envoy preStop hook sleep duration")
+	fs.BoolVar(&cfg.EnableGracefulDrain, flagEnableGracefulDrain, false,
+		"If enabled, the Envoy preStop hook gracefully drains listeners and waits for active connections to close instead of sleeping for prestop-delay")
+	fs.Int32Var(&cfg.GracefulDrainTimeout, flagGracefulDrainTimeout, 30,
+		"How long (in seconds) the Envoy preStop hook waits for active connections to close before exiting regardless. "+
+			"terminationGracePeriodSeconds of injected pods is raised to accommodate it")
 	fs.Int32Var(&cfg.ReadinessProbeInitialDelay, flagReadinessProbeInitialDelay, 1,
 		"Number of seconds after Envoy has started before readiness probes are initiated")
 	fs.Int32Var(&cfg.ReadinessProbePeriod, flagReadinessProbePeriod, 10,
 		"How often (in seconds) to perform the readiness probe on Envoy container")
 	fs.StringVar(&cfg.InitImage, flagInitImage, "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-proxy-route-manager:v3-prod",
 		"Init container image.")
+	fs.StringVar(&cfg.ProxyInitContainerName, flagProxyInitContainerName, proxyInitContainerName,
+		"Name of the proxyinit init container that sets up traffic redirection.")
 	fs.StringVar(&cfg.IgnoredIPs, flagIgnoredIPs, "169.254.169.254",
 		"Init container ignored IPs.")
 	fs.BoolVar(&cfg.EnableJaegerTracing, flagEnableJaegerTracing, false,
@@ -141,18 +346,40 @@ func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
 		"Jaeger address")
 	fs.StringVar(&cfg.JaegerPort, flagJaegerPort, "9411",
 		"Jaeger port")
+	fs.StringVar(&cfg.TracingConfigVolumeSizeLimit, flagTracingConfigVolumeSizeLimit, "10Mi",
+		"Size limit of the emptyDir volume used to share the Envoy tracing config. Set to empty for no limit")
 	fs.BoolVar(&cfg.EnableDatadogTracing, flagEnableDatadogTracing, false,
 		"Enable Envoy Datadog tracing")
 	fs.StringVar(&cfg.DatadogAddress, flagDatadogAddress, "datadog.appmesh-system",
 		"Datadog Agent address")
 	fs.Int32Var(&cfg.DatadogPort, flagDatadogPort, 8126,
 		"Datadog Agent tracing port")
+	fs.StringVar(&cfg.DatadogEnvLabel, flagDatadogEnvLabel, "tags.datadoghq.com/env",
+		"Pod label used to populate DD_ENV on Envoy when Datadog tracing is enabled")
+	fs.StringVar(&cfg.DatadogServiceLabel, flagDatadogServiceLabel, "tags.datadoghq.com/service",
+		"Pod label used to populate DD_SERVICE on Envoy when Datadog tracing is enabled")
+	fs.StringVar(&cfg.DatadogVersionLabel, flagDatadogVersionLabel, "tags.datadoghq.com/version",
+		"Pod label used to populate DD_VERSION on Envoy when Datadog tracing is enabled")
 	fs.BoolVar(&cfg.EnableXrayTracing, flagEnableXrayTracing, false,
 		"Enable Envoy X-Ray tracing integration and injects xray-daemon as sidecar")
 	fs.Int32Var(&cfg.XrayDaemonPort, flagXrayDaemonPort, 2000,
 		"Datadog Agent tracing port")
 	fs.StringVar(&cfg.XRayImage, flagXRayImage, "amazon/aws-xray-daemon",
 		"X-Ray daemon container image")
+	fs.BoolVar(&cfg.XRayInjectDaemon, flagXRayInjectDaemon, true,
+		"If enabled, the X-Ray daemon is injected as sidecar when X-Ray tracing is enabled. "+
+			"Disable it to run your own xray-daemon container")
+	fs.BoolVar(&cfg.XRayRequireIRSA, flagXRayRequireIRSA, false,
+		"If enabled, pods running the X-Ray daemon are denied unless their service account is annotated with eks.amazonaws.com/role-arn, "+
+			"instead of only logging a warning")
+	fs.StringVar(&cfg.XRayCpuRequests, flagXRayCpuRequests, "10m",
+		"X-Ray daemon CPU resources requests.")
+	fs.StringVar(&cfg.XRayMemoryRequests, flagXRayMemoryRequests, "32Mi",
+		"X-Ray daemon memory resources requests.")
+	fs.StringVar(&cfg.XRayCpuLimits, flagXRayCpuLimits, "100m",
+		"X-Ray daemon CPU resources limits. Set to empty for no limit")
+	fs.StringVar(&cfg.XRayMemoryLimits, flagXRayMemoryLimits, "128Mi",
+		"X-Ray daemon memory resources limits. Set to empty for no limit")
 	fs.BoolVar(&cfg.EnableStatsTags, flagEnableStatsTags, false,
 		"Enable Envoy to tag stats")
 	fs.BoolVar(&cfg.EnableStatsD, flagEnableStatsD, false,
@@ -161,6 +388,16 @@ func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
 		"Datadog Agent address")
 	fs.Int32Var(&cfg.StatsDPort, flagStatsDPort, 8125,
 		"Datadog Agent tracing port")
+	fs.BoolVar(&cfg.EnableEnvoyWarmup, flagEnableEnvoyWarmup, false,
+		"If enabled, an init container reaching the App Mesh envoy management endpoint will be injected to warm up DNS and TLS before Envoy starts")
+	fs.StringVar(&cfg.EnvoyWarmupImage, flagEnvoyWarmupImage, "busybox",
+		"Envoy warm-up init container image")
+	fs.Int32Var(&cfg.EnvoyWarmupTimeout, flagEnvoyWarmupTimeout, 10,
+		"How long (in seconds) the Envoy warm-up init container waits for the App Mesh envoy management endpoint")
+	fs.BoolVar(&cfg.DumpInjectedSpec, flagDumpInjectedSpec, false,
+		`If enabled, the injected spec of pods annotated with appmesh.k8s.aws/debugInjection: "true" is logged at debug level`)
+	fs.BoolVar(&cfg.DumpInjectedSpecRedactEnv, flagDumpInjectedSpecRedactEnv, true,
+		"If enabled, environment variable values are redacted from the dumped pod spec")
 }
 
 func (cfg *Config) BindEnv() error {
@@ -171,5 +408,141 @@ func (cfg *Config) Validate() error {
 	if multipleTracer(cfg) {
 		return errors.New("Envoy only supports a single tracer instance. Please choose between Jaeger, Datadog or X-Ray.")
 	}
+	if errs := validation.IsDNS1123Label(cfg.SidecarContainerName); len(errs) != 0 {
+		return fmt.Errorf("invalid %s: %s, %s", flagSidecarContainerName, cfg.SidecarContainerName, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsDNS1123Label(cfg.ProxyInitContainerName); len(errs) != 0 {
+		return fmt.Errorf("invalid %s: %s, %s", flagProxyInitContainerName, cfg.ProxyInitContainerName, strings.Join(errs, ", "))
+	}
+	if cfg.ProxyInitContainerName == cfg.SidecarContainerName {
+		return fmt.Errorf("%s and %s must be different", flagProxyInitContainerName, flagSidecarContainerName)
+	}
+	if cfg.SidecarRunAsUser <= 0 {
+		return fmt.Errorf("invalid %s: %d, must be a positive integer", flagSidecarRunAsUser, cfg.SidecarRunAsUser)
+	}
+	if cfg.SidecarRunAsGroup < 0 {
+		return fmt.Errorf("invalid %s: %d, must be a positive integer", flagSidecarRunAsGroup, cfg.SidecarRunAsGroup)
+	}
+	if err := cfg.validateSidecarImageDigest(); err != nil {
+		return err
+	}
+	if err := validateRequiredSidecarLimits(cfg.RequireSidecarLimits); err != nil {
+		return err
+	}
+	if err := validateSidecarEnvOverrideAllowlist(cfg.SidecarEnvOverrideAllowlist); err != nil {
+		return err
+	}
+	if cfg.K8sLookupRetries < 0 {
+		return fmt.Errorf("invalid %s: %d, must be a non-negative integer", flagK8sLookupRetries, cfg.K8sLookupRetries)
+	}
+	switch corev1.TerminationMessagePolicy(cfg.SidecarTerminationMessagePolicy) {
+	case "", corev1.TerminationMessageReadFile, corev1.TerminationMessageFallbackToLogsOnError:
+	default:
+		return fmt.Errorf("invalid %s: %s, must be %s or %s", flagSidecarTerminationMessagePolicy, cfg.SidecarTerminationMessagePolicy,
+			corev1.TerminationMessageReadFile, corev1.TerminationMessageFallbackToLogsOnError)
+	}
+	if cfg.EnablePrometheusScrape && !strings.HasPrefix(cfg.PrometheusScrapePath, "/") {
+		return fmt.Errorf("invalid %s: %s, must be an absolute path such as %s", flagPrometheusScrapePath, cfg.PrometheusScrapePath, defaultPrometheusScrapePath)
+	}
+	if _, err := parseDNSOptions(cfg.PodDNSOptions); err != nil {
+		return fmt.Errorf("invalid %s: %v", flagPodDNSOptions, err)
+	}
+	if err := validateDNSSearches(cfg.PodDNSSearches); err != nil {
+		return fmt.Errorf("invalid %s: %v", flagPodDNSSearches, err)
+	}
+	switch cfg.HostNetworkPolicy {
+	case hostNetworkPolicySkip, hostNetworkPolicyDeny:
+	default:
+		return fmt.Errorf("invalid %s: %s, must be %s or %s", flagHostNetworkPolicy, cfg.HostNetworkPolicy,
+			hostNetworkPolicySkip, hostNetworkPolicyDeny)
+	}
+	if cfg.MinEnvoyVersion != "" {
+		if _, err := parseEnvoyVersion(cfg.MinEnvoyVersion); err != nil {
+			return fmt.Errorf("invalid %s: %v", flagMinEnvoyVersion, err)
+		}
+	}
+	switch cfg.MinEnvoyVersionAction {
+	case minEnvoyVersionActionDeny, minEnvoyVersionActionWarn:
+	default:
+		return fmt.Errorf("invalid %s: %s, must be %s or %s", flagMinEnvoyVersionAction, cfg.MinEnvoyVersionAction,
+			minEnvoyVersionActionDeny, minEnvoyVersionActionWarn)
+	}
+	if cfg.SidecarCABundle != "" {
+		if _, err := parseCABundleSource(cfg.SidecarCABundle); err != nil {
+			return fmt.Errorf("invalid %s: %v", flagSidecarCABundle, err)
+		}
+	}
+	if _, err := labels.Parse(cfg.InjectionLabelSelector); err != nil {
+		return fmt.Errorf("invalid %s: %v", flagInjectionLabelSelector, err)
+	}
+	if cfg.EnvoyAdminAccessLogFile != "" && cfg.EnvoyAdminAccessLogFile != accessLogFileStdout && !path.IsAbs(cfg.EnvoyAdminAccessLogFile) {
+		return fmt.Errorf("invalid %s: %s, must be an absolute path or %s", flagEnvoyAdminAccessLogFile, cfg.EnvoyAdminAccessLogFile, accessLogFileStdout)
+	}
+	if cfg.GracefulDrainTimeout <= 0 {
+		return fmt.Errorf("invalid %s: %d, must be a positive integer", flagGracefulDrainTimeout, cfg.GracefulDrainTimeout)
+	}
+	if cfg.EnvoyWarmupTimeout <= 0 {
+		return fmt.Errorf("invalid %s: %d, must be a positive integer", flagEnvoyWarmupTimeout, cfg.EnvoyWarmupTimeout)
+	}
+	if cfg.EnableDatadogTracing {
+		if err := validateTracerAddress(flagDatadogAddress, cfg.DatadogAddress); err != nil {
+			return err
+		}
+	}
+	if cfg.TracingConfigVolumeSizeLimit != "" {
+		if _, err := resource.ParseQuantity(cfg.TracingConfigVolumeSizeLimit); err != nil {
+			return fmt.Errorf("invalid %s: %v", flagTracingConfigVolumeSizeLimit, err)
+		}
+	}
+	return nil
+}
+
+// validateTracerAddress makes sure address is an IP, a DNS hostname or a reference to the IP of the node.
+// Envoy resolves hostnames itself, so agents running out of cluster can be reached by their DNS name.
+func validateTracerAddress(flag string, address string) error {
+	if address == hostIPRef || net.ParseIP(address) != nil {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(address); len(errs) != 0 {
+		return fmt.Errorf("invalid %s: %s, must be an IP, a DNS hostname or %s", flag, address, hostIPRef)
+	}
+	return nil
+}
+
+func (cfg *Config) validateSidecarImageDigest() error {
+	if err := cfg.validateImageDigest(flagSidecarImage, cfg.SidecarImage); err != nil {
+		return err
+	}
+	if cfg.SidecarImageAmd64 != "" {
+		if err := cfg.validateImageDigest(flagSidecarImageAmd64, cfg.SidecarImageAmd64); err != nil {
+			return err
+		}
+	}
+	if cfg.SidecarImageArm64 != "" {
+		if err := cfg.validateImageDigest(flagSidecarImageArm64, cfg.SidecarImageArm64); err != nil {
+			return err
+		}
+	}
+	if cfg.GatewaySidecarImage != "" {
+		if err := cfg.validateImageDigest(flagGatewaySidecarImage, cfg.GatewaySidecarImage); err != nil {
+			return err
+		}
+	}
+	if cfg.SidecarPreviewImage != "" {
+		if err := cfg.validateImageDigest(flagSidecarPreviewImage, cfg.SidecarPreviewImage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cfg *Config) validateImageDigest(flag string, image string) error {
+	digest, err := parseImageDigest(image)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", flag, err)
+	}
+	if cfg.RequireImageDigest && digest == "" {
+		return fmt.Errorf("invalid %s: %s, must be pinned by digest when %s is enabled", flag, image, flagRequireImageDigest)
+	}
 	return nil
 }
@@ -0,0 +1,56 @@
+package inject
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_validateTracerAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		wantErr error
+	}{
+		{
+			name:    "in cluster service",
+			address: "datadog.appmesh-system",
+		},
+		{
+			name:    "out of cluster hostname",
+			address: "datadog-agent.example.com",
+		},
+		{
+			name:    "IPv4 address",
+			address: "10.0.0.10",
+		},
+		{
+			name:    "IPv6 address",
+			address: "fd00::10",
+		},
+		{
+			name:    "host IP reference",
+			address: "ref:status.hostIP",
+		},
+		{
+			name:    "URL instead of hostname",
+			address: "http://datadog-agent.example.com",
+			wantErr: errors.New("invalid datadog-address: http://datadog-agent.example.com, must be an IP, a DNS hostname or ref:status.hostIP"),
+		},
+		{
+			name:    "hostname with port",
+			address: "datadog-agent.example.com:8126",
+			wantErr: errors.New("invalid datadog-address: datadog-agent.example.com:8126, must be an IP, a DNS hostname or ref:status.hostIP"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTracerAddress(flagDatadogAddress, tt.address)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
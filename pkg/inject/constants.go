@@ -10,6 +10,14 @@ const (
 	AppMeshCPULimitAnnotation = "appmesh.k8s.aws/cpuLimit"
 	//AppMeshMemoryLimitAnnotation specifies the memory limits for proxy
 	AppMeshMemoryLimitAnnotation = "appmesh.k8s.aws/memoryLimit"
+	//AppMeshXRayCPURequestAnnotation specifies the CPU requests for the X-Ray daemon
+	AppMeshXRayCPURequestAnnotation = "appmesh.k8s.aws/xrayCpuRequest"
+	//AppMeshXRayMemoryRequestAnnotation specifies the memory requests for the X-Ray daemon
+	AppMeshXRayMemoryRequestAnnotation = "appmesh.k8s.aws/xrayMemoryRequest"
+	//AppMeshXRayCPULimitAnnotation specifies the CPU limits for the X-Ray daemon
+	AppMeshXRayCPULimitAnnotation = "appmesh.k8s.aws/xrayCpuLimit"
+	//AppMeshXRayMemoryLimitAnnotation specifies the memory limits for the X-Ray daemon
+	AppMeshXRayMemoryLimitAnnotation = "appmesh.k8s.aws/xrayMemoryLimit"
 
 	// === begin proxy settings annotations ===
 	//AppMeshCNIAnnotation specifies that CNI will be used to configure traffic interception
@@ -36,12 +44,58 @@ const (
 	AppMeshSidecarInjectAnnotation = "appmesh.k8s.aws/sidecarInjectorWebhook"
 	//AppMeshSecretMountsAnnotation specifies the list of Secret that need to be mounted to the proxy as a volume
 	AppMeshSecretMountsAnnotation = "appmesh.k8s.aws/secretMounts"
+	//AppMeshSidecarVolumeMountsAnnotation specifies the list of pod volumes that need to be mounted to the proxy, e.g. "shared-uds:/var/run/uds,config:/etc/app:ro"
+	AppMeshSidecarVolumeMountsAnnotation = "appmesh.k8s.aws/sidecarVolumeMounts"
 	//AppMeshGatewaySkipImageOverride specifies if Virtual Gateway sidecar image override needs to be skipped for customers
 	//to use their own sidecare image for Virtual Gateway
 	AppMeshGatewaySkipImageOverride = "appmesh.k8s.aws/virtualGatewaySkipImageOverride"
+	//AppMeshGatewaySidecarImageAnnotation specifies the Envoy image a Virtual Gateway pod is overridden with,
+	//instead of the one configured on the controller
+	AppMeshGatewaySidecarImageAnnotation = "appmesh.k8s.aws/virtualGatewaySidecarImage"
 	//AppMeshSDSAnnotation is used if SDS is enabled at the controller level but needs to be disabled
 	//for a particular VirtualNode.
 	AppMeshSDSAnnotation = "appmesh.k8s.aws/sds"
+	//AppMeshInitialFetchTimeoutAnnotation specifies how long Envoy waits for its initial config from App Mesh,
+	//in the format of a duration such as "30s".
+	AppMeshInitialFetchTimeoutAnnotation = "appmesh.k8s.aws/initialFetchTimeout"
+	//AppMeshEnvoyWarmupAnnotation enables or disables the init container warming up the connection to App Mesh
+	//for a particular pod, overriding the controller level setting. Accepts "enabled" or "disabled".
+	AppMeshEnvoyWarmupAnnotation = "appmesh.k8s.aws/envoyWarmup"
+	//AppMeshEnvoyClusterAnnotation overrides the Envoy node.cluster used in metrics and traces, which defaults to the
+	//VirtualNode resource name.
+	AppMeshEnvoyClusterAnnotation = "appmesh.k8s.aws/envoyCluster"
+	//AppMeshAdminAccessSocketAnnotation asks for the Envoy admin interface on a Unix socket, which the App Mesh Envoy image
+	//doesn't support. Pods carrying it are rejected.
+	AppMeshAdminAccessSocketAnnotation = "appmesh.k8s.aws/adminAccessSocket"
+	//AppMeshAWSRegionAnnotation overrides the AWS region of the App Mesh control plane Envoy connects to,
+	//which defaults to the region of the controller.
+	AppMeshAWSRegionAnnotation = "appmesh.k8s.aws/awsRegion"
+	//AppMeshReadinessProbeTimeoutAnnotation specifies the timeout in seconds of the proxy readiness probe
+	AppMeshReadinessProbeTimeoutAnnotation = "appmesh.k8s.aws/readinessProbeTimeout"
+	//AppMeshReadinessProbeSuccessThresholdAnnotation specifies the success threshold of the proxy readiness probe
+	AppMeshReadinessProbeSuccessThresholdAnnotation = "appmesh.k8s.aws/readinessProbeSuccessThreshold"
+	//AppMeshReadinessProbeFailureThresholdAnnotation specifies the failure threshold of the proxy readiness probe
+	AppMeshReadinessProbeFailureThresholdAnnotation = "appmesh.k8s.aws/readinessProbeFailureThreshold"
+	//AppMeshReadinessProbeTolerateDrainingAnnotation lets the proxy readiness probe pass while Envoy is DRAINING during hot restarts,
+	//accepts "true".
+	AppMeshReadinessProbeTolerateDrainingAnnotation = "appmesh.k8s.aws/readinessProbeTolerateDraining"
+	//AppMeshReadinessProbeHostAnnotation specifies the host the proxy readiness probe queries the Envoy admin interface on,
+	//accepts localhost or 127.0.0.1.
+	AppMeshReadinessProbeHostAnnotation = "appmesh.k8s.aws/readinessProbeHost"
+	//AppMeshDebugInjectionAnnotation specifies the injected pod spec should be logged, when the injector runs with dump-injected-spec.
+	AppMeshDebugInjectionAnnotation = "appmesh.k8s.aws/debugInjection"
+	//AppMeshAllowUnpinnedSidecarImageAnnotation exempts a pod from the image digest requirement when the injector runs with
+	//require-image-digest. It's meant as a break-glass for emergencies, accepts "true".
+	AppMeshAllowUnpinnedSidecarImageAnnotation = "appmesh.k8s.aws/allowUnpinnedSidecarImage"
+	//AppMeshInjectionConfigVersionAnnotation is stamped by the injector onto injected pods, with a hash of the injector config used.
+	//Pods whose value differs from the one of newly injected pods carry a stale sidecar.
+	AppMeshInjectionConfigVersionAnnotation = "appmesh.k8s.aws/injectionConfigVersion"
+	//AppMeshDNSOptionsAnnotation specifies comma separated dnsConfig options merged into the pod, such as "ndots:1",
+	//replacing the ones configured on the controller.
+	AppMeshDNSOptionsAnnotation = "appmesh.k8s.aws/dnsOptions"
+	//AppMeshDNSSearchesAnnotation specifies comma separated dnsConfig search domains merged into the pod,
+	//replacing the ones configured on the controller.
+	AppMeshDNSSearchesAnnotation = "appmesh.k8s.aws/dnsSearches"
 
 	// AppMeshEnvAnnotation specifies the list of enviornment variables that need to be programmed on Envoy sidecars
 	// This allow passing tags like DataDog environment `DD_ENV` to Envoy to help correlate observability data
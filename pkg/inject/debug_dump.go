@@ -0,0 +1,50 @@
+package inject
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	debugInjectionEnabled = "true"
+	redactedEnvValue      = "<redacted>"
+)
+
+// dumpInjectedSpec logs the injected pod spec at debug level for pods opting in with the debugInjection annotation.
+func (m *SidecarInjector) dumpInjectedSpec(pod *corev1.Pod) {
+	if !m.config.DumpInjectedSpec || pod.Annotations[AppMeshDebugInjectionAnnotation] != debugInjectionEnabled {
+		return
+	}
+	podSpec := pod.Spec.DeepCopy()
+	if m.config.DumpInjectedSpecRedactEnv {
+		redactPodSpecEnv(podSpec)
+	}
+	podSpecJSON, err := json.Marshal(podSpec)
+	if err != nil {
+		m.log.Error(err, "failed to marshal injected pod spec")
+		return
+	}
+	m.log.V(1).Info("injected pod spec",
+		"namespace", pod.Namespace,
+		"name", pod.Name,
+		"generateName", pod.GenerateName,
+		"spec", string(podSpecJSON),
+	)
+}
+
+// redactPodSpecEnv replaces the literal env values of all containers, valueFrom only references a source and is kept.
+func redactPodSpecEnv(podSpec *corev1.PodSpec) {
+	redactContainersEnv(podSpec.InitContainers)
+	redactContainersEnv(podSpec.Containers)
+}
+
+func redactContainersEnv(containers []corev1.Container) {
+	for i := range containers {
+		for j := range containers[i].Env {
+			if containers[i].Env[j].Value != "" {
+				containers[i].Env[j].Value = redactedEnvValue
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package inject
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type logEntry struct {
+	level         int
+	msg           string
+	keysAndValues []interface{}
+}
+
+// recordingLogger records the entries logged through it.
+type recordingLogger struct {
+	level   int
+	entries *[]logEntry
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	*l.entries = append(*l.entries, logEntry{level: l.level, msg: msg, keysAndValues: keysAndValues})
+}
+
+func (l *recordingLogger) Enabled() bool {
+	return true
+}
+
+func (l *recordingLogger) Error(_ error, msg string, keysAndValues ...interface{}) {
+	*l.entries = append(*l.entries, logEntry{level: l.level, msg: msg, keysAndValues: keysAndValues})
+}
+
+func (l *recordingLogger) V(level int) logr.InfoLogger {
+	return &recordingLogger{level: level, entries: l.entries}
+}
+
+func (l *recordingLogger) WithValues(_ ...interface{}) logr.Logger {
+	return l
+}
+
+func (l *recordingLogger) WithName(_ string) logr.Logger {
+	return l
+}
+
+func TestSidecarInjector_dumpInjectedSpec(t *testing.T) {
+	annotatedPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    "my-ns",
+			GenerateName: "my-pod-",
+			Annotations: map[string]string{
+				"appmesh.k8s.aws/debugInjection": "true",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "envoy",
+					Env: []corev1.EnvVar{
+						{Name: "APPMESH_VIRTUAL_NODE_NAME", Value: "mesh/my-mesh/virtualNode/my-vn"},
+						{Name: "POD_IP", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}}},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		config    Config
+		pod       *corev1.Pod
+		wantEntry *logEntry
+	}{
+		{
+			name:   "dump disabled",
+			config: Config{DumpInjectedSpec: false},
+			pod:    annotatedPod,
+		},
+		{
+			name:   "pod without debugInjection annotation",
+			config: Config{DumpInjectedSpec: true},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "my-pod",
+				},
+			},
+		},
+		{
+			name:   "dump with env values",
+			config: Config{DumpInjectedSpec: true, DumpInjectedSpecRedactEnv: false},
+			pod:    annotatedPod,
+			wantEntry: &logEntry{
+				level: 1,
+				msg:   "injected pod spec",
+				keysAndValues: []interface{}{
+					"namespace", "my-ns",
+					"name", "",
+					"generateName", "my-pod-",
+					"spec", `{"containers":[{"name":"envoy","env":[{"name":"APPMESH_VIRTUAL_NODE_NAME","value":"mesh/my-mesh/virtualNode/my-vn"},{"name":"POD_IP","valueFrom":{"fieldRef":{"fieldPath":"status.podIP"}}}],"resources":{}}]}`,
+				},
+			},
+		},
+		{
+			name:   "dump with redacted env values",
+			config: Config{DumpInjectedSpec: true, DumpInjectedSpecRedactEnv: true},
+			pod:    annotatedPod,
+			wantEntry: &logEntry{
+				level: 1,
+				msg:   "injected pod spec",
+				keysAndValues: []interface{}{
+					"namespace", "my-ns",
+					"name", "",
+					"generateName", "my-pod-",
+					"spec", `{"containers":[{"name":"envoy","env":[{"name":"APPMESH_VIRTUAL_NODE_NAME","value":"<redacted>"},{"name":"POD_IP","valueFrom":{"fieldRef":{"fieldPath":"status.podIP"}}}],"resources":{}}]}`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entries []logEntry
+			m := &SidecarInjector{
+				config: tt.config,
+				log:    &recordingLogger{entries: &entries},
+			}
+			pod := tt.pod.DeepCopy()
+			m.dumpInjectedSpec(pod)
+			if tt.wantEntry == nil {
+				assert.Empty(t, entries)
+			} else {
+				assert.Equal(t, []logEntry{*tt.wantEntry}, entries)
+			}
+			// the dump never changes the pod itself.
+			assert.Equal(t, tt.pod, pod)
+		})
+	}
+}
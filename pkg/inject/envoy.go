@@ -6,13 +6,18 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const envoyTracingConfigVolumeName = "envoy-tracing-config"
 const envoyContainerName = "envoy"
 
+// awsRegionPattern matches region names such as us-west-2 or us-gov-east-1
+var awsRegionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
 type EnvoyTemplateVariables struct {
 	AWSRegion                    string
 	MeshName                     string
@@ -25,6 +30,7 @@ type EnvoyTemplateVariables struct {
 	AdminAccessLogFile           string
 	PreStopDelay                 string
 	SidecarImage                 string
+	SidecarContainerName         string
 	EnvoyTracingConfigVolumeName string
 	EnableXrayTracing            bool
 	XrayDaemonPort               int32
@@ -32,10 +38,18 @@ type EnvoyTemplateVariables struct {
 	EnableDatadogTracing         bool
 	DatadogTracerPort            int32
 	DatadogTracerAddress         string
+	DatadogEnv                   string
+	DatadogService               string
+	DatadogVersion               string
+	SidecarRunAsUser             int64
+	SidecarRunAsGroup            int64
 	EnableStatsTags              bool
+	EnablePodMetadataEnv         bool
 	EnableStatsD                 bool
 	StatsDPort                   int32
 	StatsDAddress                string
+	InitialFetchTimeout          string
+	Cluster                      string
 }
 
 type envoyMutatorConfig struct {
@@ -47,10 +61,12 @@ type envoyMutatorConfig struct {
 	logLevel                   string
 	adminAccessPort            int32
 	adminAccessLogFile         string
+	accessLogVolume            bool
 	preStopDelay               string
 	readinessProbeInitialDelay int32
 	readinessProbePeriod       int32
 	sidecarImage               string
+	sidecarContainerName       string
 	sidecarCPURequests         string
 	sidecarMemoryRequests      string
 	sidecarCPULimits           string
@@ -61,10 +77,23 @@ type envoyMutatorConfig struct {
 	enableDatadogTracing       bool
 	datadogTracerPort          int32
 	datadogTracerAddress       string
+	datadogEnvLabel            string
+	datadogServiceLabel        string
+	datadogVersionLabel        string
 	enableStatsTags            bool
+	enablePodMetadataEnv       bool
 	enableStatsD               bool
 	statsDPort                 int32
 	statsDAddress              string
+	caBundle                   string
+	sidecarRunAsUser           int64
+	sidecarRunAsGroup          int64
+	enableGracefulDrain        bool
+	gracefulDrainTimeout       int32
+	// controller managed env that pod annotations are allowed to override
+	envOverrideAllowlist []string
+	// terminationMessagePolicy of the Envoy container, left unset if empty
+	terminationMessagePolicy corev1.TerminationMessagePolicy
 }
 
 func newEnvoyMutator(mutatorConfig envoyMutatorConfig, ms *appmesh.Mesh, vn *appmesh.VirtualNode) *envoyMutator {
@@ -82,22 +111,42 @@ type envoyMutator struct {
 }
 
 func (m *envoyMutator) mutate(pod *corev1.Pod) error {
-	if ok, _ := containsEnvoyContainer(pod); ok {
+	if ok, _ := containsEnvoyContainer(pod, envoyContainerNameOrDefault(m.mutatorConfig.sidecarContainerName)); ok {
 		return nil
 	}
 	secretMounts, err := m.getSecretMounts(pod)
 	if err != nil {
 		return err
 	}
+	sidecarVolumeMounts, err := getSidecarVolumeMounts(pod)
+	if err != nil {
+		return err
+	}
 
 	variables := m.buildTemplateVariables(pod)
+	variables.AWSRegion, err = getAWSRegion(pod, m.mutatorConfig.awsRegion)
+	if err != nil {
+		return err
+	}
+	variables.InitialFetchTimeout, err = m.getInitialFetchTimeout(pod)
+	if err != nil {
+		return err
+	}
+	if err := m.checkAdminAccessSocket(pod); err != nil {
+		return err
+	}
+	variables.Cluster, err = m.getCluster(pod)
+	if err != nil {
+		return err
+	}
 
 	customEnv, err := m.getCustomEnv(pod)
 	if err != nil {
 		return err
 	}
 
-	container := buildEnvoySidecar(variables, customEnv)
+	container := buildEnvoySidecar(variables, customEnv, m.mutatorConfig.envOverrideAllowlist)
+	container.TerminationMessagePolicy = m.mutatorConfig.terminationMessagePolicy
 
 	// add resource requests and limits
 	container.Resources, err = sidecarResources(getSidecarCPURequest(m.mutatorConfig.sidecarCPURequests, pod),
@@ -109,13 +158,43 @@ func (m *envoyMutator) mutate(pod *corev1.Pod) error {
 	}
 
 	// add readiness probe
+	readinessProbeHost, err := getReadinessProbeHost(pod)
+	if err != nil {
+		return err
+	}
 	container.ReadinessProbe = envoyReadinessProbe(m.mutatorConfig.readinessProbeInitialDelay,
-		m.mutatorConfig.readinessProbePeriod, strconv.Itoa(int(m.mutatorConfig.adminAccessPort)))
+		m.mutatorConfig.readinessProbePeriod, readinessProbeHost, strconv.Itoa(int(m.mutatorConfig.adminAccessPort)),
+		isReadinessProbeDrainingTolerated(pod))
+	if err := mutateReadinessProbeOverrides(pod, container.ReadinessProbe); err != nil {
+		return err
+	}
+
+	if m.mutatorConfig.enableGracefulDrain {
+		container.Lifecycle.PreStop.Exec.Command = gracefulDrainPreStopCommand(strconv.Itoa(int(m.mutatorConfig.adminAccessPort)),
+			m.mutatorConfig.gracefulDrainTimeout)
+		mutateGracefulDrainTerminationGracePeriod(pod, m.mutatorConfig.gracefulDrainTimeout)
+	}
+
+	if m.mutatorConfig.caBundle != "" {
+		caBundle, err := parseCABundleSource(m.mutatorConfig.caBundle)
+		if err != nil {
+			return err
+		}
+		mutateCABundleMounts(pod, &container, caBundle)
+	}
 
 	m.mutateSecretMounts(pod, &container, secretMounts)
 	if m.mutatorConfig.enableSDS && !isSDSDisabled(pod) {
 		mutateSDSMounts(pod, &container, m.mutatorConfig.sdsUdsPath)
 	}
+	if m.mutatorConfig.accessLogVolume {
+		if err := mutateAccessLogMounts(pod, &container, m.mutatorConfig.adminAccessLogFile); err != nil {
+			return err
+		}
+	}
+	if err := mutateSidecarVolumeMounts(pod, &container, sidecarVolumeMounts); err != nil {
+		return err
+	}
 	pod.Spec.Containers = append(pod.Spec.Containers, container)
 	return nil
 }
@@ -136,11 +215,12 @@ func (m *envoyMutator) buildTemplateVariables(pod *corev1.Pod) EnvoyTemplateVari
 		Preview:                      preview,
 		EnableSDS:                    sdsEnabled,
 		SdsUdsPath:                   m.mutatorConfig.sdsUdsPath,
-		LogLevel:                     m.mutatorConfig.logLevel,
+		LogLevel:                     m.getEnvoyLogLevel(),
 		AdminAccessPort:              m.mutatorConfig.adminAccessPort,
 		AdminAccessLogFile:           m.mutatorConfig.adminAccessLogFile,
 		PreStopDelay:                 m.mutatorConfig.preStopDelay,
 		SidecarImage:                 m.mutatorConfig.sidecarImage,
+		SidecarContainerName:         envoyContainerNameOrDefault(m.mutatorConfig.sidecarContainerName),
 		EnvoyTracingConfigVolumeName: envoyTracingConfigVolumeName,
 		EnableXrayTracing:            m.mutatorConfig.enableXrayTracing,
 		XrayDaemonPort:               m.mutatorConfig.xrayDaemonPort,
@@ -148,13 +228,28 @@ func (m *envoyMutator) buildTemplateVariables(pod *corev1.Pod) EnvoyTemplateVari
 		EnableDatadogTracing:         m.mutatorConfig.enableDatadogTracing,
 		DatadogTracerPort:            m.mutatorConfig.datadogTracerPort,
 		DatadogTracerAddress:         m.mutatorConfig.datadogTracerAddress,
+		DatadogEnv:                   getPodLabel(pod, m.mutatorConfig.datadogEnvLabel),
+		DatadogService:               getPodLabel(pod, m.mutatorConfig.datadogServiceLabel),
+		DatadogVersion:               getPodLabel(pod, m.mutatorConfig.datadogVersionLabel),
+		SidecarRunAsUser:             proxyUIDOrDefault(m.mutatorConfig.sidecarRunAsUser),
+		SidecarRunAsGroup:            m.mutatorConfig.sidecarRunAsGroup,
 		EnableStatsTags:              m.mutatorConfig.enableStatsTags,
+		EnablePodMetadataEnv:         m.mutatorConfig.enablePodMetadataEnv,
 		EnableStatsD:                 m.mutatorConfig.enableStatsD,
 		StatsDPort:                   m.mutatorConfig.statsDPort,
 		StatsDAddress:                m.mutatorConfig.statsDAddress,
 	}
 }
 
+// getEnvoyLogLevel returns the Envoy log level set on virtualNode, or the default log level if it isn't set.
+// the ENVOY_LOG_LEVEL of the sidecarEnv annotation takes precedence over both when it's allowlisted.
+func (m *envoyMutator) getEnvoyLogLevel() string {
+	if m.vn.Spec.Logging != nil && m.vn.Spec.Logging.EnvoyLogLevel != nil {
+		return string(*m.vn.Spec.Logging.EnvoyLogLevel)
+	}
+	return m.mutatorConfig.logLevel
+}
+
 func (m *envoyMutator) getAugmentedMeshName() string {
 	meshName := aws.StringValue(m.ms.Spec.AWSName)
 	if m.ms.Spec.MeshOwner != nil && aws.StringValue(m.ms.Spec.MeshOwner) != m.mutatorConfig.accountID {
@@ -164,10 +259,8 @@ func (m *envoyMutator) getAugmentedMeshName() string {
 }
 
 func (m *envoyMutator) getPreview(pod *corev1.Pod) string {
-	preview := m.mutatorConfig.preview
-	if v, ok := pod.ObjectMeta.Annotations[AppMeshPreviewAnnotation]; ok {
-		preview = strings.ToLower(v) == "enabled"
-	}
+	// malformed annotation is rejected before pod is mutated
+	preview, _ := isPreviewEnabled(m.mutatorConfig.preview, pod)
 	if preview {
 		return "1"
 	}
@@ -226,6 +319,57 @@ func (m *envoyMutator) getCustomEnv(pod *corev1.Pod) (map[string]string, error)
 	return customEnv, nil
 }
 
+// getInitialFetchTimeout returns the initial fetch timeout in seconds from pod annotation, or empty if not set.
+func (m *envoyMutator) getInitialFetchTimeout(pod *corev1.Pod) (string, error) {
+	v, ok := pod.ObjectMeta.Annotations[AppMeshInitialFetchTimeoutAnnotation]
+	if !ok {
+		return "", nil
+	}
+	timeout, err := time.ParseDuration(v)
+	if err != nil {
+		return "", errors.Errorf("malformed annotation %s: %s, expected a duration such as 30s", AppMeshInitialFetchTimeoutAnnotation, v)
+	}
+	if timeout < time.Second {
+		return "", errors.Errorf("invalid annotation %s: %s, must be a positive duration of at least 1s", AppMeshInitialFetchTimeoutAnnotation, v)
+	}
+	return strconv.FormatInt(int64(timeout/time.Second), 10), nil
+}
+
+// checkAdminAccessSocket rejects pods asking for the Envoy admin interface on a Unix socket,
+// the App Mesh Envoy image only serves it on the TCP port of ENVOY_ADMIN_ACCESS_PORT.
+func (m *envoyMutator) checkAdminAccessSocket(pod *corev1.Pod) error {
+	if v, ok := pod.ObjectMeta.Annotations[AppMeshAdminAccessSocketAnnotation]; ok {
+		return errors.Errorf("unsupported annotation %s: %s, the App Mesh Envoy image only serves its admin interface on TCP port %d",
+			AppMeshAdminAccessSocketAnnotation, v, m.mutatorConfig.adminAccessPort)
+	}
+	return nil
+}
+
+// getCluster returns the Envoy cluster override from pod annotation, or empty if not set.
+func (m *envoyMutator) getCluster(pod *corev1.Pod) (string, error) {
+	v, ok := pod.ObjectMeta.Annotations[AppMeshEnvoyClusterAnnotation]
+	if !ok {
+		return "", nil
+	}
+	if strings.TrimSpace(v) == "" {
+		return "", errors.Errorf("invalid annotation %s: must be a non-empty string", AppMeshEnvoyClusterAnnotation)
+	}
+	return strings.TrimSpace(v), nil
+}
+
+// getAWSRegion returns the AWS region from pod annotation, or defaultRegion if not set.
+func getAWSRegion(pod *corev1.Pod, defaultRegion string) (string, error) {
+	v, ok := pod.ObjectMeta.Annotations[AppMeshAWSRegionAnnotation]
+	if !ok {
+		return defaultRegion, nil
+	}
+	region := strings.TrimSpace(v)
+	if !awsRegionPattern.MatchString(region) {
+		return "", errors.Errorf("malformed annotation %s: %s, expected a region such as us-west-2", AppMeshAWSRegionAnnotation, v)
+	}
+	return region, nil
+}
+
 // containsEnvoyTracingConfigVolume checks whether pod already contains "envoy-tracing-config" volume
 func containsEnvoyTracingConfigVolume(pod *corev1.Pod) bool {
 	for _, volume := range pod.Spec.Volumes {
@@ -52,6 +52,44 @@ func Test_envoyMutator_mutate(t *testing.T) {
 		},
 	}
 
+	datadogLabelsPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "my-pod",
+			Labels: map[string]string{
+				"tags.datadoghq.com/env":     "prod",
+				"tags.datadoghq.com/service": "my-app",
+				"tags.datadoghq.com/version": "v1.2.3",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "app",
+					Image: "app/v1",
+				},
+			},
+		},
+	}
+
+	datadogPartialLabelsPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "my-pod",
+			Labels: map[string]string{
+				"tags.datadoghq.com/service": "my-app",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "app",
+					Image: "app/v1",
+				},
+			},
+		},
+	}
+
 	envPod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: "my-ns",
@@ -668,7 +706,7 @@ func Test_envoyMutator_mutate(t *testing.T) {
 			},
 		},
 		{
-			name: "no tracing + enable Stats tags",
+			name: "no tracing + enable Datadog tracing with unified service tags",
 			fields: fields{
 				vn: vn,
 				ms: ms,
@@ -683,16 +721,26 @@ func Test_envoyMutator_mutate(t *testing.T) {
 					sidecarImage:               "envoy:v2",
 					sidecarCPURequests:         cpuRequests.String(),
 					sidecarMemoryRequests:      memoryRequests.String(),
-					enableStatsTags:            true,
+					enableDatadogTracing:       true,
+					datadogTracerPort:          8126,
+					datadogTracerAddress:       "127.0.0.1",
+					datadogEnvLabel:            "tags.datadoghq.com/env",
+					datadogServiceLabel:        "tags.datadoghq.com/service",
+					datadogVersionLabel:        "tags.datadoghq.com/version",
 				},
 			},
 			args: args{
-				pod: pod,
+				pod: datadogLabelsPod,
 			},
 			wantPod: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "my-ns",
 					Name:      "my-pod",
+					Labels: map[string]string{
+						"tags.datadoghq.com/env":     "prod",
+						"tags.datadoghq.com/service": "my-app",
+						"tags.datadoghq.com/version": "v1.2.3",
+					},
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
@@ -723,7 +771,6 @@ func Test_envoyMutator_mutate(t *testing.T) {
 							},
 							ReadinessProbe: &corev1.Probe{
 								Handler: corev1.Handler{
-
 									Exec: &corev1.ExecAction{Command: []string{
 										"sh", "-c", "curl -s http://localhost:9901/server_info | grep state | grep -q LIVE",
 									}},
@@ -756,9 +803,29 @@ func Test_envoyMutator_mutate(t *testing.T) {
 									Value: "us-west-2",
 								},
 								{
-									Name:  "ENABLE_ENVOY_STATS_TAGS",
+									Name:  "ENABLE_ENVOY_DATADOG_TRACING",
 									Value: "1",
 								},
+								{
+									Name:  "DATADOG_TRACER_PORT",
+									Value: "8126",
+								},
+								{
+									Name:  "DATADOG_TRACER_ADDRESS",
+									Value: "127.0.0.1",
+								},
+								{
+									Name:  "DD_ENV",
+									Value: "prod",
+								},
+								{
+									Name:  "DD_SERVICE",
+									Value: "my-app",
+								},
+								{
+									Name:  "DD_VERSION",
+									Value: "v1.2.3",
+								},
 							},
 							Resources: corev1.ResourceRequirements{
 								Requests: corev1.ResourceList{
@@ -772,7 +839,7 @@ func Test_envoyMutator_mutate(t *testing.T) {
 			},
 		},
 		{
-			name: "no tracing + enable Stats D",
+			name: "no tracing + enable Datadog tracing with partial unified service tags",
 			fields: fields{
 				vn: vn,
 				ms: ms,
@@ -787,18 +854,24 @@ func Test_envoyMutator_mutate(t *testing.T) {
 					sidecarImage:               "envoy:v2",
 					sidecarCPURequests:         cpuRequests.String(),
 					sidecarMemoryRequests:      memoryRequests.String(),
-					enableStatsD:               true,
-					statsDAddress:              "127.0.0.1",
-					statsDPort:                 8125,
+					enableDatadogTracing:       true,
+					datadogTracerPort:          8126,
+					datadogTracerAddress:       "127.0.0.1",
+					datadogEnvLabel:            "tags.datadoghq.com/env",
+					datadogServiceLabel:        "tags.datadoghq.com/service",
+					datadogVersionLabel:        "tags.datadoghq.com/version",
 				},
 			},
 			args: args{
-				pod: pod,
+				pod: datadogPartialLabelsPod,
 			},
 			wantPod: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "my-ns",
 					Name:      "my-pod",
+					Labels: map[string]string{
+						"tags.datadoghq.com/service": "my-app",
+					},
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
@@ -829,7 +902,6 @@ func Test_envoyMutator_mutate(t *testing.T) {
 							},
 							ReadinessProbe: &corev1.Probe{
 								Handler: corev1.Handler{
-
 									Exec: &corev1.ExecAction{Command: []string{
 										"sh", "-c", "curl -s http://localhost:9901/server_info | grep state | grep -q LIVE",
 									}},
@@ -862,17 +934,21 @@ func Test_envoyMutator_mutate(t *testing.T) {
 									Value: "us-west-2",
 								},
 								{
-									Name:  "ENABLE_ENVOY_DOG_STATSD",
+									Name:  "ENABLE_ENVOY_DATADOG_TRACING",
 									Value: "1",
 								},
 								{
-									Name:  "STATSD_PORT",
-									Value: "8125",
+									Name:  "DATADOG_TRACER_PORT",
+									Value: "8126",
 								},
 								{
-									Name:  "STATSD_ADDRESS",
+									Name:  "DATADOG_TRACER_ADDRESS",
 									Value: "127.0.0.1",
 								},
+								{
+									Name:  "DD_SERVICE",
+									Value: "my-app",
+								},
 							},
 							Resources: corev1.ResourceRequirements{
 								Requests: corev1.ResourceList{
@@ -886,7 +962,7 @@ func Test_envoyMutator_mutate(t *testing.T) {
 			},
 		},
 		{
-			name: "no tracing + secretMounts",
+			name: "no tracing + enable Datadog tracing without unified service tags",
 			fields: fields{
 				vn: vn,
 				ms: ms,
@@ -901,34 +977,21 @@ func Test_envoyMutator_mutate(t *testing.T) {
 					sidecarImage:               "envoy:v2",
 					sidecarCPURequests:         cpuRequests.String(),
 					sidecarMemoryRequests:      memoryRequests.String(),
+					enableDatadogTracing:       true,
+					datadogTracerPort:          8126,
+					datadogTracerAddress:       "127.0.0.1",
+					datadogEnvLabel:            "tags.datadoghq.com/env",
+					datadogServiceLabel:        "tags.datadoghq.com/service",
+					datadogVersionLabel:        "tags.datadoghq.com/version",
 				},
 			},
 			args: args{
-				pod: &corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "my-ns",
-						Name:      "my-pod",
-						Annotations: map[string]string{
-							"appmesh.k8s.aws/secretMounts": "svc1-cert-chain-key:/certs/svc1, svc1-svc2-ca-bundle:/certs",
-						},
-					},
-					Spec: corev1.PodSpec{
-						Containers: []corev1.Container{
-							{
-								Name:  "app",
-								Image: "app/v1",
-							},
-						},
-					},
-				},
+				pod: pod,
 			},
 			wantPod: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "my-ns",
 					Name:      "my-pod",
-					Annotations: map[string]string{
-						"appmesh.k8s.aws/secretMounts": "svc1-cert-chain-key:/certs/svc1, svc1-svc2-ca-bundle:/certs",
-					},
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
@@ -959,7 +1022,6 @@ func Test_envoyMutator_mutate(t *testing.T) {
 							},
 							ReadinessProbe: &corev1.Probe{
 								Handler: corev1.Handler{
-
 									Exec: &corev1.ExecAction{Command: []string{
 										"sh", "-c", "curl -s http://localhost:9901/server_info | grep state | grep -q LIVE",
 									}},
@@ -991,41 +1053,23 @@ func Test_envoyMutator_mutate(t *testing.T) {
 									Name:  "AWS_REGION",
 									Value: "us-west-2",
 								},
-							},
-							Resources: corev1.ResourceRequirements{
-								Requests: corev1.ResourceList{
-									"cpu":    cpuRequests,
-									"memory": memoryRequests,
-								},
-							},
-							VolumeMounts: []corev1.VolumeMount{
 								{
-									Name:      "svc1-cert-chain-key",
-									MountPath: "/certs/svc1",
-									ReadOnly:  true,
+									Name:  "ENABLE_ENVOY_DATADOG_TRACING",
+									Value: "1",
 								},
 								{
-									Name:      "svc1-svc2-ca-bundle",
-									MountPath: "/certs",
-									ReadOnly:  true,
+									Name:  "DATADOG_TRACER_PORT",
+									Value: "8126",
 								},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
-						{
-							Name: "svc1-cert-chain-key",
-							VolumeSource: corev1.VolumeSource{
-								Secret: &corev1.SecretVolumeSource{
-									SecretName: "svc1-cert-chain-key",
+								{
+									Name:  "DATADOG_TRACER_ADDRESS",
+									Value: "127.0.0.1",
 								},
 							},
-						},
-						{
-							Name: "svc1-svc2-ca-bundle",
-							VolumeSource: corev1.VolumeSource{
-								Secret: &corev1.SecretVolumeSource{
-									SecretName: "svc1-svc2-ca-bundle",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									"cpu":    cpuRequests,
+									"memory": memoryRequests,
 								},
 							},
 						},
@@ -1034,40 +1078,27 @@ func Test_envoyMutator_mutate(t *testing.T) {
 			},
 		},
 		{
-			name: "enable SDS controller flag + no pod annotation",
+			name: "no tracing + enable Stats tags",
 			fields: fields{
 				vn: vn,
 				ms: ms,
 				mutatorConfig: envoyMutatorConfig{
 					awsRegion:                  "us-west-2",
-					preview:                    true,
-					enableSDS:                  true,
-					sdsUdsPath:                 "/run/spire/sockets/agent.sock",
+					preview:                    false,
 					logLevel:                   "debug",
 					adminAccessPort:            9901,
 					preStopDelay:               "20",
-					readinessProbeInitialDelay: 10,
-					readinessProbePeriod:       2,
+					readinessProbeInitialDelay: 1,
+					readinessProbePeriod:       10,
 					sidecarImage:               "envoy:v2",
 					sidecarCPURequests:         cpuRequests.String(),
 					sidecarMemoryRequests:      memoryRequests.String(),
+					enableStatsTags:            true,
 				},
 			},
 			args: args{
-				pod: &corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "my-ns",
-						Name:      "my-pod",
-					},
-					Spec: corev1.PodSpec{
-						Containers: []corev1.Container{
-							{
-								Name:  "app",
-								Image: "app/v1",
-							},
-						},
-					},
-				}},
+				pod: pod,
+			},
 			wantPod: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "my-ns",
@@ -1107,9 +1138,9 @@ func Test_envoyMutator_mutate(t *testing.T) {
 										"sh", "-c", "curl -s http://localhost:9901/server_info | grep state | grep -q LIVE",
 									}},
 								},
-								InitialDelaySeconds: 10,
+								InitialDelaySeconds: 1,
 								TimeoutSeconds:      1,
-								PeriodSeconds:       2,
+								PeriodSeconds:       10,
 								SuccessThreshold:    1,
 								FailureThreshold:    3,
 							},
@@ -1120,16 +1151,12 @@ func Test_envoyMutator_mutate(t *testing.T) {
 								},
 								{
 									Name:  "APPMESH_PREVIEW",
-									Value: "1",
+									Value: "0",
 								},
 								{
 									Name:  "ENVOY_LOG_LEVEL",
 									Value: "debug",
 								},
-								{
-									Name:  "APPMESH_SDS_SOCKET_PATH",
-									Value: "/run/spire/sockets/agent.sock",
-								},
 								{
 									Name:  "ENVOY_ADMIN_ACCESS_PORT",
 									Value: "9901",
@@ -1138,6 +1165,10 @@ func Test_envoyMutator_mutate(t *testing.T) {
 									Name:  "AWS_REGION",
 									Value: "us-west-2",
 								},
+								{
+									Name:  "ENABLE_ENVOY_STATS_TAGS",
+									Value: "1",
+								},
 							},
 							Resources: corev1.ResourceRequirements{
 								Requests: corev1.ResourceList{
@@ -1145,73 +1176,39 @@ func Test_envoyMutator_mutate(t *testing.T) {
 									"memory": memoryRequests,
 								},
 							},
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      "appmesh-sds-socket-volume",
-									MountPath: "/run/spire/sockets/agent.sock",
-								},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
-						{
-							Name: "appmesh-sds-socket-volume",
-							VolumeSource: corev1.VolumeSource{
-								HostPath: &corev1.HostPathVolumeSource{
-									Path: "/run/spire/sockets/agent.sock",
-									Type: &SDSVolumeType,
-								},
-							},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "enable SDS controller flag + pod annotation to disable sds",
+			name: "no tracing + enable Stats D",
 			fields: fields{
 				vn: vn,
 				ms: ms,
 				mutatorConfig: envoyMutatorConfig{
 					awsRegion:                  "us-west-2",
-					preview:                    true,
-					enableSDS:                  true,
-					sdsUdsPath:                 "/run/spire/sockets/agent.sock",
+					preview:                    false,
 					logLevel:                   "debug",
 					adminAccessPort:            9901,
 					preStopDelay:               "20",
-					readinessProbeInitialDelay: 10,
-					readinessProbePeriod:       2,
+					readinessProbeInitialDelay: 1,
+					readinessProbePeriod:       10,
 					sidecarImage:               "envoy:v2",
 					sidecarCPURequests:         cpuRequests.String(),
 					sidecarMemoryRequests:      memoryRequests.String(),
+					enableStatsD:               true,
+					statsDAddress:              "127.0.0.1",
+					statsDPort:                 8125,
 				},
 			},
 			args: args{
-				pod: &corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "my-ns",
-						Name:      "my-pod",
-						Annotations: map[string]string{
-							"appmesh.k8s.aws/sds": "disabled",
-						},
-					},
-					Spec: corev1.PodSpec{
-						Containers: []corev1.Container{
-							{
-								Name:  "app",
-								Image: "app/v1",
-							},
-						},
-					},
-				}},
+				pod: pod,
+			},
 			wantPod: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "my-ns",
 					Name:      "my-pod",
-					Annotations: map[string]string{
-						"appmesh.k8s.aws/sds": "disabled",
-					},
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
@@ -1247,9 +1244,9 @@ func Test_envoyMutator_mutate(t *testing.T) {
 										"sh", "-c", "curl -s http://localhost:9901/server_info | grep state | grep -q LIVE",
 									}},
 								},
-								InitialDelaySeconds: 10,
+								InitialDelaySeconds: 1,
 								TimeoutSeconds:      1,
-								PeriodSeconds:       2,
+								PeriodSeconds:       10,
 								SuccessThreshold:    1,
 								FailureThreshold:    3,
 							},
@@ -1260,7 +1257,7 @@ func Test_envoyMutator_mutate(t *testing.T) {
 								},
 								{
 									Name:  "APPMESH_PREVIEW",
-									Value: "1",
+									Value: "0",
 								},
 								{
 									Name:  "ENVOY_LOG_LEVEL",
@@ -1274,6 +1271,18 @@ func Test_envoyMutator_mutate(t *testing.T) {
 									Name:  "AWS_REGION",
 									Value: "us-west-2",
 								},
+								{
+									Name:  "ENABLE_ENVOY_DOG_STATSD",
+									Value: "1",
+								},
+								{
+									Name:  "STATSD_PORT",
+									Value: "8125",
+								},
+								{
+									Name:  "STATSD_ADDRESS",
+									Value: "127.0.0.1",
+								},
 							},
 							Resources: corev1.ResourceRequirements{
 								Requests: corev1.ResourceList{
@@ -1287,7 +1296,7 @@ func Test_envoyMutator_mutate(t *testing.T) {
 			},
 		},
 		{
-			name: "no cpu limits",
+			name: "no tracing + secretMounts",
 			fields: fields{
 				vn: vn,
 				ms: ms,
@@ -1302,7 +1311,408 @@ func Test_envoyMutator_mutate(t *testing.T) {
 					sidecarImage:               "envoy:v2",
 					sidecarCPURequests:         cpuRequests.String(),
 					sidecarMemoryRequests:      memoryRequests.String(),
-					sidecarMemoryLimits:        memoryLimits.String(),
+				},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-pod",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/secretMounts": "svc1-cert-chain-key:/certs/svc1, svc1-svc2-ca-bundle:/certs",
+						},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "app",
+								Image: "app/v1",
+							},
+						},
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "my-pod",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/secretMounts": "svc1-cert-chain-key:/certs/svc1, svc1-svc2-ca-bundle:/certs",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: "app/v1",
+						},
+						{
+							Name:  "envoy",
+							Image: "envoy:v2",
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser: aws.Int64(1337),
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "stats",
+									ContainerPort: 9901,
+									Protocol:      "TCP",
+								},
+							},
+							Lifecycle: &corev1.Lifecycle{
+								PostStart: nil,
+								PreStop: &corev1.Handler{
+									Exec: &corev1.ExecAction{Command: []string{
+										"sh", "-c", "sleep 20",
+									}},
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								Handler: corev1.Handler{
+
+									Exec: &corev1.ExecAction{Command: []string{
+										"sh", "-c", "curl -s http://localhost:9901/server_info | grep state | grep -q LIVE",
+									}},
+								},
+								InitialDelaySeconds: 1,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "APPMESH_VIRTUAL_NODE_NAME",
+									Value: "mesh/my-mesh/virtualNode/my-vn_my-ns",
+								},
+								{
+									Name:  "APPMESH_PREVIEW",
+									Value: "0",
+								},
+								{
+									Name:  "ENVOY_LOG_LEVEL",
+									Value: "debug",
+								},
+								{
+									Name:  "ENVOY_ADMIN_ACCESS_PORT",
+									Value: "9901",
+								},
+								{
+									Name:  "AWS_REGION",
+									Value: "us-west-2",
+								},
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									"cpu":    cpuRequests,
+									"memory": memoryRequests,
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "svc1-cert-chain-key",
+									MountPath: "/certs/svc1",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "svc1-svc2-ca-bundle",
+									MountPath: "/certs",
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "svc1-cert-chain-key",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: "svc1-cert-chain-key",
+								},
+							},
+						},
+						{
+							Name: "svc1-svc2-ca-bundle",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: "svc1-svc2-ca-bundle",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "enable SDS controller flag + no pod annotation",
+			fields: fields{
+				vn: vn,
+				ms: ms,
+				mutatorConfig: envoyMutatorConfig{
+					awsRegion:                  "us-west-2",
+					preview:                    true,
+					enableSDS:                  true,
+					sdsUdsPath:                 "/run/spire/sockets/agent.sock",
+					logLevel:                   "debug",
+					adminAccessPort:            9901,
+					preStopDelay:               "20",
+					readinessProbeInitialDelay: 10,
+					readinessProbePeriod:       2,
+					sidecarImage:               "envoy:v2",
+					sidecarCPURequests:         cpuRequests.String(),
+					sidecarMemoryRequests:      memoryRequests.String(),
+				},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-pod",
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "app",
+								Image: "app/v1",
+							},
+						},
+					},
+				}},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "my-pod",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: "app/v1",
+						},
+						{
+							Name:  "envoy",
+							Image: "envoy:v2",
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser: aws.Int64(1337),
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "stats",
+									ContainerPort: 9901,
+									Protocol:      "TCP",
+								},
+							},
+							Lifecycle: &corev1.Lifecycle{
+								PostStart: nil,
+								PreStop: &corev1.Handler{
+									Exec: &corev1.ExecAction{Command: []string{
+										"sh", "-c", "sleep 20",
+									}},
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								Handler: corev1.Handler{
+
+									Exec: &corev1.ExecAction{Command: []string{
+										"sh", "-c", "curl -s http://localhost:9901/server_info | grep state | grep -q LIVE",
+									}},
+								},
+								InitialDelaySeconds: 10,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       2,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "APPMESH_VIRTUAL_NODE_NAME",
+									Value: "mesh/my-mesh/virtualNode/my-vn_my-ns",
+								},
+								{
+									Name:  "APPMESH_PREVIEW",
+									Value: "1",
+								},
+								{
+									Name:  "ENVOY_LOG_LEVEL",
+									Value: "debug",
+								},
+								{
+									Name:  "APPMESH_SDS_SOCKET_PATH",
+									Value: "/run/spire/sockets/agent.sock",
+								},
+								{
+									Name:  "ENVOY_ADMIN_ACCESS_PORT",
+									Value: "9901",
+								},
+								{
+									Name:  "AWS_REGION",
+									Value: "us-west-2",
+								},
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									"cpu":    cpuRequests,
+									"memory": memoryRequests,
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "appmesh-sds-socket-volume",
+									MountPath: "/run/spire/sockets/agent.sock",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "appmesh-sds-socket-volume",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{
+									Path: "/run/spire/sockets/agent.sock",
+									Type: &SDSVolumeType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "enable SDS controller flag + pod annotation to disable sds",
+			fields: fields{
+				vn: vn,
+				ms: ms,
+				mutatorConfig: envoyMutatorConfig{
+					awsRegion:                  "us-west-2",
+					preview:                    true,
+					enableSDS:                  true,
+					sdsUdsPath:                 "/run/spire/sockets/agent.sock",
+					logLevel:                   "debug",
+					adminAccessPort:            9901,
+					preStopDelay:               "20",
+					readinessProbeInitialDelay: 10,
+					readinessProbePeriod:       2,
+					sidecarImage:               "envoy:v2",
+					sidecarCPURequests:         cpuRequests.String(),
+					sidecarMemoryRequests:      memoryRequests.String(),
+				},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-pod",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/sds": "disabled",
+						},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "app",
+								Image: "app/v1",
+							},
+						},
+					},
+				}},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "my-pod",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/sds": "disabled",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: "app/v1",
+						},
+						{
+							Name:  "envoy",
+							Image: "envoy:v2",
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser: aws.Int64(1337),
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "stats",
+									ContainerPort: 9901,
+									Protocol:      "TCP",
+								},
+							},
+							Lifecycle: &corev1.Lifecycle{
+								PostStart: nil,
+								PreStop: &corev1.Handler{
+									Exec: &corev1.ExecAction{Command: []string{
+										"sh", "-c", "sleep 20",
+									}},
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								Handler: corev1.Handler{
+
+									Exec: &corev1.ExecAction{Command: []string{
+										"sh", "-c", "curl -s http://localhost:9901/server_info | grep state | grep -q LIVE",
+									}},
+								},
+								InitialDelaySeconds: 10,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       2,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "APPMESH_VIRTUAL_NODE_NAME",
+									Value: "mesh/my-mesh/virtualNode/my-vn_my-ns",
+								},
+								{
+									Name:  "APPMESH_PREVIEW",
+									Value: "1",
+								},
+								{
+									Name:  "ENVOY_LOG_LEVEL",
+									Value: "debug",
+								},
+								{
+									Name:  "ENVOY_ADMIN_ACCESS_PORT",
+									Value: "9901",
+								},
+								{
+									Name:  "AWS_REGION",
+									Value: "us-west-2",
+								},
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									"cpu":    cpuRequests,
+									"memory": memoryRequests,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no cpu limits",
+			fields: fields{
+				vn: vn,
+				ms: ms,
+				mutatorConfig: envoyMutatorConfig{
+					awsRegion:                  "us-west-2",
+					preview:                    false,
+					logLevel:                   "debug",
+					adminAccessPort:            9901,
+					preStopDelay:               "20",
+					readinessProbeInitialDelay: 1,
+					readinessProbePeriod:       10,
+					sidecarImage:               "envoy:v2",
+					sidecarCPURequests:         cpuRequests.String(),
+					sidecarMemoryRequests:      memoryRequests.String(),
+					sidecarMemoryLimits:        memoryLimits.String(),
 					enableStatsD:               true,
 					statsDPort:                 8125,
 					statsDAddress:              "127.0.0.1",
@@ -2217,95 +2627,579 @@ func Test_envoyMutator_mutate(t *testing.T) {
 	}
 }
 
-func Test_envoyMutator_getPreview(t *testing.T) {
-	type fields struct {
-		mutatorConfig envoyMutatorConfig
-	}
+func Test_envoyMutator_getPreview(t *testing.T) {
+	type fields struct {
+		mutatorConfig envoyMutatorConfig
+	}
+	type args struct {
+		pod *corev1.Pod
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   string
+	}{
+		{
+			name: "enabled preview by annotation",
+			fields: fields{
+				mutatorConfig: envoyMutatorConfig{
+					preview: false,
+				},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/preview": "enabled",
+						},
+					},
+				},
+			},
+			want: "1",
+		},
+		{
+			name: "disable preview by annotation",
+			fields: fields{
+				mutatorConfig: envoyMutatorConfig{
+					preview: true,
+				},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/preview": "disabled",
+						},
+					},
+				},
+			},
+			want: "0",
+		},
+		{
+			name: "enabled preview by default",
+			fields: fields{
+				mutatorConfig: envoyMutatorConfig{
+					preview: true,
+				},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{},
+				},
+			},
+			want: "1",
+		},
+		{
+			name: "disable preview by default",
+			fields: fields{
+				mutatorConfig: envoyMutatorConfig{
+					preview: false,
+				},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{},
+				},
+			},
+			want: "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &envoyMutator{
+				mutatorConfig: tt.fields.mutatorConfig,
+			}
+			got := m.getPreview(tt.args.pod)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_envoyMutator_getInitialFetchTimeout(t *testing.T) {
+	type args struct {
+		pod *corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "annotation not specified",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{},
+				},
+			},
+			want: "",
+		},
+		{
+			name: "annotation specified in seconds",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/initialFetchTimeout": "30s",
+						},
+					},
+				},
+			},
+			want: "30",
+		},
+		{
+			name: "annotation specified in minutes",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/initialFetchTimeout": "2m",
+						},
+					},
+				},
+			},
+			want: "120",
+		},
+		{
+			name: "annotation is malformed",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/initialFetchTimeout": "30",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/initialFetchTimeout: 30, expected a duration such as 30s"),
+		},
+		{
+			name: "annotation is negative",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/initialFetchTimeout": "-5s",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/initialFetchTimeout: -5s, must be a positive duration of at least 1s"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &envoyMutator{}
+			got, err := m.getInitialFetchTimeout(tt.args.pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_envoyMutator_checkAdminAccessSocket(t *testing.T) {
+	type args struct {
+		pod *corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "annotation not specified",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{},
+				},
+			},
+		},
+		{
+			name: "annotation specified",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/adminAccessSocket": "/tmp/envoy_admin.sock",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("unsupported annotation appmesh.k8s.aws/adminAccessSocket: /tmp/envoy_admin.sock, the App Mesh Envoy image only serves its admin interface on TCP port 9901"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &envoyMutator{
+				mutatorConfig: envoyMutatorConfig{
+					adminAccessPort: 9901,
+				},
+			}
+			err := m.checkAdminAccessSocket(tt.args.pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_envoyMutator_getCluster(t *testing.T) {
 	type args struct {
 		pod *corev1.Pod
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   string
+		name    string
+		args    args
+		want    string
+		wantErr error
 	}{
 		{
-			name: "enabled preview by annotation",
-			fields: fields{
-				mutatorConfig: envoyMutatorConfig{
-					preview: false,
+			name: "annotation not specified",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{},
 				},
 			},
+			want: "",
+		},
+		{
+			name: "cluster specified",
 			args: args{
 				pod: &corev1.Pod{
 					ObjectMeta: metav1.ObjectMeta{
 						Annotations: map[string]string{
-							"appmesh.k8s.aws/preview": "enabled",
+							"appmesh.k8s.aws/envoyCluster": "my-service-canary",
 						},
 					},
 				},
 			},
-			want: "1",
+			want: "my-service-canary",
 		},
 		{
-			name: "disable preview by annotation",
-			fields: fields{
-				mutatorConfig: envoyMutatorConfig{
-					preview: true,
-				},
-			},
+			name: "cluster specified with surrounding spaces",
 			args: args{
 				pod: &corev1.Pod{
 					ObjectMeta: metav1.ObjectMeta{
 						Annotations: map[string]string{
-							"appmesh.k8s.aws/preview": "disabled",
+							"appmesh.k8s.aws/envoyCluster": " my-cluster ",
 						},
 					},
 				},
 			},
-			want: "0",
+			want: "my-cluster",
 		},
 		{
-			name: "enabled preview by default",
-			fields: fields{
-				mutatorConfig: envoyMutatorConfig{
-					preview: true,
-				},
-			},
+			name: "annotation is empty",
 			args: args{
 				pod: &corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{},
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/envoyCluster": "  ",
+						},
+					},
 				},
 			},
-			want: "1",
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/envoyCluster: must be a non-empty string"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &envoyMutator{}
+			got, err := m.getCluster(tt.args.pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_envoyMutator_mutate_nodeMetadata(t *testing.T) {
+	ms := &appmesh.Mesh{
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+	}
+	vn := &appmesh.VirtualNode{
+		Spec: appmesh.VirtualNodeSpec{
+			AWSName: aws.String("my-vn_my-ns"),
+		},
+	}
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		wantNodeName string
+		wantCluster  string
+	}{
+		{
+			name:         "default node metadata",
+			wantNodeName: "mesh/my-mesh/virtualNode/my-vn_my-ns",
 		},
 		{
-			name: "disable preview by default",
-			fields: fields{
-				mutatorConfig: envoyMutatorConfig{
-					preview: false,
+			name: "overridden cluster",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/envoyCluster": "my-cluster",
+			},
+			wantNodeName: "mesh/my-mesh/virtualNode/my-vn_my-ns",
+			wantCluster:  "my-cluster",
+		},
+		{
+			name: "node id can't be overridden",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/envoyNodeId": "mesh/other-mesh/virtualNode/other-vn",
+			},
+			wantNodeName: "mesh/my-mesh/virtualNode/my-vn_my-ns",
+		},
+		{
+			name: "overridden cluster isn't replaced by sidecarEnv",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/envoyCluster": "my-cluster",
+				"appmesh.k8s.aws/sidecarEnv":   "APPMESH_RESOURCE_CLUSTER=other-cluster",
+			},
+			wantNodeName: "mesh/my-mesh/virtualNode/my-vn_my-ns",
+			wantCluster:  "my-cluster",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "my-ns",
+					Name:        "my-pod",
+					Annotations: tt.annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: "app/v1",
+						},
+					},
 				},
+			}
+			m := newEnvoyMutator(envoyMutatorConfig{
+				awsRegion:                  "us-west-2",
+				logLevel:                   "debug",
+				adminAccessPort:            9901,
+				preStopDelay:               "20",
+				readinessProbeInitialDelay: 1,
+				readinessProbePeriod:       10,
+				sidecarImage:               "envoy:v2",
+			}, ms, vn)
+			assert.NoError(t, m.mutate(pod))
+
+			envoy := pod.Spec.Containers[1]
+			assert.Contains(t, envoy.Env, corev1.EnvVar{Name: "APPMESH_VIRTUAL_NODE_NAME", Value: tt.wantNodeName})
+			if tt.wantCluster == "" {
+				for _, env := range envoy.Env {
+					assert.NotEqual(t, "APPMESH_RESOURCE_CLUSTER", env.Name)
+				}
+			} else {
+				assert.Contains(t, envoy.Env, corev1.EnvVar{Name: "APPMESH_RESOURCE_CLUSTER", Value: tt.wantCluster})
+			}
+		})
+	}
+}
+
+func Test_getAWSRegion(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+		wantErr     error
+	}{
+		{
+			name: "annotation not specified",
+			want: "us-west-2",
+		},
+		{
+			name: "region specified",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/awsRegion": "eu-west-1",
 			},
-			args: args{
-				pod: &corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{},
+			want: "eu-west-1",
+		},
+		{
+			name: "partition region specified",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/awsRegion": "us-gov-west-1",
+			},
+			want: "us-gov-west-1",
+		},
+		{
+			name: "region isn't lowercase",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/awsRegion": "EU-WEST-1",
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/awsRegion: EU-WEST-1, expected a region such as us-west-2"),
+		},
+		{
+			name: "region is an endpoint",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/awsRegion": "appmesh-envoy-management.eu-west-1.amazonaws.com",
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/awsRegion: appmesh-envoy-management.eu-west-1.amazonaws.com, expected a region such as us-west-2"),
+		},
+		{
+			name: "region is empty",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/awsRegion": "",
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/awsRegion: , expected a region such as us-west-2"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
 				},
+			}
+			got, err := getAWSRegion(pod, "us-west-2")
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_envoyMutator_mutate_awsRegion(t *testing.T) {
+	ms := &appmesh.Mesh{
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+	}
+	vn := &appmesh.VirtualNode{
+		Spec: appmesh.VirtualNodeSpec{
+			AWSName: aws.String("my-vn_my-ns"),
+		},
+	}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantRegion  string
+		wantErr     error
+	}{
+		{
+			name:       "default region",
+			wantRegion: "us-west-2",
+		},
+		{
+			name: "overridden region",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/awsRegion": "eu-west-1",
 			},
-			want: "0",
+			wantRegion: "eu-west-1",
+		},
+		{
+			name: "overridden region isn't replaced by sidecarEnv",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/awsRegion":  "eu-west-1",
+				"appmesh.k8s.aws/sidecarEnv": "AWS_REGION=ap-southeast-1",
+			},
+			wantRegion: "eu-west-1",
+		},
+		{
+			name: "malformed region",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/awsRegion": "eu_west_1",
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/awsRegion: eu_west_1, expected a region such as us-west-2"),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := &envoyMutator{
-				mutatorConfig: tt.fields.mutatorConfig,
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "my-ns",
+					Name:        "my-pod",
+					Annotations: tt.annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: "app/v1",
+						},
+					},
+				},
 			}
-			got := m.getPreview(tt.args.pod)
-			assert.Equal(t, tt.want, got)
+			m := newEnvoyMutator(envoyMutatorConfig{
+				awsRegion:                  "us-west-2",
+				logLevel:                   "debug",
+				adminAccessPort:            9901,
+				preStopDelay:               "20",
+				readinessProbeInitialDelay: 1,
+				readinessProbePeriod:       10,
+				sidecarImage:               "envoy:v2",
+			}, ms, vn)
+			err := m.mutate(pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			envoy := pod.Spec.Containers[1]
+			assert.Contains(t, envoy.Env, corev1.EnvVar{Name: "AWS_REGION", Value: tt.wantRegion})
 		})
 	}
 }
 
+func Test_envoyMutator_mutate_gracefulDrain(t *testing.T) {
+	ms := &appmesh.Mesh{
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+	}
+	vn := &appmesh.VirtualNode{
+		Spec: appmesh.VirtualNodeSpec{
+			AWSName: aws.String("my-vn_my-ns"),
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "my-pod",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "app",
+					Image: "app/v1",
+				},
+			},
+		},
+	}
+	m := newEnvoyMutator(envoyMutatorConfig{
+		awsRegion:                  "us-west-2",
+		logLevel:                   "debug",
+		adminAccessPort:            9901,
+		preStopDelay:               "20",
+		readinessProbeInitialDelay: 1,
+		readinessProbePeriod:       10,
+		sidecarImage:               "envoy:v2",
+		enableGracefulDrain:        true,
+		gracefulDrainTimeout:       45,
+	}, ms, vn)
+	assert.NoError(t, m.mutate(pod))
+
+	envoy := pod.Spec.Containers[1]
+	assert.Equal(t, gracefulDrainPreStopCommand("9901", 45), envoy.Lifecycle.PreStop.Exec.Command)
+	assert.Equal(t, aws.Int64(50), pod.Spec.TerminationGracePeriodSeconds)
+}
+
 func Test_containsEnvoyTracingConfigVolume(t *testing.T) {
 	type args struct {
 		pod *corev1.Pod
@@ -2554,3 +3448,100 @@ func Test_envoyMutator_getAugmentedMeshName(t *testing.T) {
 		})
 	}
 }
+
+func Test_envoyMutator_mutate_envoyLogLevel(t *testing.T) {
+	ms := &appmesh.Mesh{
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+	}
+	envoyLogLevelTrace := appmesh.EnvoyLogLevel("trace")
+	tests := []struct {
+		name         string
+		logging      *appmesh.Logging
+		annotations  map[string]string
+		allowlist    []string
+		wantLogLevel string
+	}{
+		{
+			name:         "default log level",
+			wantLogLevel: "info",
+		},
+		{
+			name: "virtualNode without envoyLogLevel",
+			logging: &appmesh.Logging{
+				AccessLog: &appmesh.AccessLog{
+					File: &appmesh.FileAccessLog{Path: "/dev/stdout"},
+				},
+			},
+			wantLogLevel: "info",
+		},
+		{
+			name: "virtualNode envoyLogLevel overrides default log level",
+			logging: &appmesh.Logging{
+				EnvoyLogLevel: &envoyLogLevelTrace,
+			},
+			wantLogLevel: "trace",
+		},
+		{
+			name: "sidecarEnv annotation isn't applied without allowlist",
+			logging: &appmesh.Logging{
+				EnvoyLogLevel: &envoyLogLevelTrace,
+			},
+			annotations: map[string]string{
+				"appmesh.k8s.aws/sidecarEnv": "ENVOY_LOG_LEVEL=error",
+			},
+			wantLogLevel: "trace",
+		},
+		{
+			name: "allowlisted sidecarEnv annotation overrides virtualNode envoyLogLevel",
+			logging: &appmesh.Logging{
+				EnvoyLogLevel: &envoyLogLevelTrace,
+			},
+			annotations: map[string]string{
+				"appmesh.k8s.aws/sidecarEnv": "ENVOY_LOG_LEVEL=error",
+			},
+			allowlist:    []string{"ENVOY_LOG_LEVEL"},
+			wantLogLevel: "error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vn := &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("my-vn_my-ns"),
+					Logging: tt.logging,
+				},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "my-ns",
+					Name:        "my-pod",
+					Annotations: tt.annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: "app/v1",
+						},
+					},
+				},
+			}
+			m := newEnvoyMutator(envoyMutatorConfig{
+				awsRegion:                  "us-west-2",
+				logLevel:                   "info",
+				adminAccessPort:            9901,
+				preStopDelay:               "20",
+				readinessProbeInitialDelay: 1,
+				readinessProbePeriod:       10,
+				sidecarImage:               "envoy:v2",
+				envOverrideAllowlist:       tt.allowlist,
+			}, ms, vn)
+			err := m.mutate(pod)
+			assert.NoError(t, err)
+			envoy := pod.Spec.Containers[1]
+			assert.Contains(t, envoy.Env, corev1.EnvVar{Name: "ENVOY_LOG_LEVEL", Value: tt.wantLogLevel})
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package inject
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// minEnvoyVersionActionDeny rejects pods whose Envoy image is older than the minimum version.
+	minEnvoyVersionActionDeny = "deny"
+	// minEnvoyVersionActionWarn admits pods whose Envoy image is older than the minimum version with a warning log.
+	minEnvoyVersionActionWarn = "warn"
+)
+
+var (
+	// envoyImageTagPattern matches the tags of App Mesh Envoy images, such as v1.17.2.0-prod
+	envoyImageTagPattern = regexp.MustCompile(`^v(\d+)\.(\d+)\.(\d+)\.(\d+)-prod$`)
+	// envoyVersionPattern matches the versions configured as minimum Envoy version, such as 1.17.2.0 or v1.17.2.0
+	envoyVersionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)\.(\d+)$`)
+)
+
+// envoyVersion is the version of an App Mesh Envoy image, in the format of <major>.<minor>.<patch>.<build>.
+type envoyVersion [4]int
+
+func (v envoyVersion) String() string {
+	return fmt.Sprintf("v%d.%d.%d.%d", v[0], v[1], v[2], v[3])
+}
+
+// lessThan returns whether v is older than other.
+func (v envoyVersion) lessThan(other envoyVersion) bool {
+	for i := range v {
+		if v[i] != other[i] {
+			return v[i] < other[i]
+		}
+	}
+	return false
+}
+
+// parseEnvoyVersion parses a minimum Envoy version such as 1.17.2.0.
+func parseEnvoyVersion(s string) (envoyVersion, error) {
+	version, ok := matchEnvoyVersion(envoyVersionPattern, s)
+	if !ok {
+		return envoyVersion{}, errors.Errorf("malformed Envoy version %s, expected format: <major>.<minor>.<patch>.<build>", s)
+	}
+	return version, nil
+}
+
+// parseEnvoyImageVersion returns the version in the tag of an App Mesh Envoy image,
+// or false if the image isn't tagged in the format of vX.Y.Z.N-prod, such as custom builds or digest only references.
+func parseEnvoyImageVersion(image string) (envoyVersion, bool) {
+	if idx := strings.LastIndex(image, "@"); idx >= 0 {
+		image = image[:idx]
+	}
+	// the registry host might contain a port, so only a colon after the last slash separates the tag
+	idx := strings.LastIndex(image, ":")
+	if idx < 0 || idx < strings.LastIndex(image, "/") {
+		return envoyVersion{}, false
+	}
+	return matchEnvoyVersion(envoyImageTagPattern, image[idx+1:])
+}
+
+func matchEnvoyVersion(pattern *regexp.Regexp, s string) (envoyVersion, bool) {
+	matches := pattern.FindStringSubmatch(s)
+	if matches == nil {
+		return envoyVersion{}, false
+	}
+	var version envoyVersion
+	for i := range version {
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return envoyVersion{}, false
+		}
+		version[i] = n
+	}
+	return version, true
+}
+
+// validateMinEnvoyVersion makes sure the Envoy container of injected pod runs an image no older than the minimum Envoy version.
+// Images not tagged in the App Mesh format are skipped since their version can't be told.
+func (m *SidecarInjector) validateMinEnvoyVersion(pod *corev1.Pod) error {
+	if m.config.MinEnvoyVersion == "" {
+		return nil
+	}
+	minVersion, err := parseEnvoyVersion(m.config.MinEnvoyVersion)
+	if err != nil {
+		return err
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name != envoyContainerNameOrDefault(m.config.SidecarContainerName) {
+			continue
+		}
+		version, ok := parseEnvoyImageVersion(container.Image)
+		if !ok {
+			m.log.V(1).Info("skipping minimum Envoy version check of sidecar image with non-standard tag",
+				"image", container.Image,
+			)
+			continue
+		}
+		if !version.lessThan(minVersion) {
+			continue
+		}
+		if m.config.MinEnvoyVersionAction == minEnvoyVersionActionWarn {
+			m.log.Info("sidecar image is older than the minimum Envoy version",
+				"namespace", pod.Namespace,
+				"name", pod.Name,
+				"generateName", pod.GenerateName,
+				"image", container.Image,
+				"minEnvoyVersion", minVersion.String(),
+			)
+			continue
+		}
+		return errors.Errorf("sidecar image %s is older than the minimum Envoy version %s required by %s",
+			container.Image, minVersion, flagMinEnvoyVersion)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package inject
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_parseEnvoyImageVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		image     string
+		want      envoyVersion
+		wantFound bool
+	}{
+		{
+			name:      "App Mesh Envoy image",
+			image:     "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-envoy:v1.17.2.0-prod",
+			want:      envoyVersion{1, 17, 2, 0},
+			wantFound: true,
+		},
+		{
+			name:      "App Mesh Envoy image pinned by digest",
+			image:     "aws-appmesh-envoy:v1.15.1.0-prod@" + testImageDigest,
+			want:      envoyVersion{1, 15, 1, 0},
+			wantFound: true,
+		},
+		{
+			name:      "registry with port",
+			image:     "registry.example.com:5000/aws-appmesh-envoy:v1.16.1.1-prod",
+			want:      envoyVersion{1, 16, 1, 1},
+			wantFound: true,
+		},
+		{
+			name:      "registry with port but no tag",
+			image:     "registry.example.com:5000/aws-appmesh-envoy",
+			wantFound: false,
+		},
+		{
+			name:      "digest only",
+			image:     "aws-appmesh-envoy@" + testImageDigest,
+			wantFound: false,
+		},
+		{
+			name:      "custom tag",
+			image:     "aws-appmesh-envoy:latest",
+			wantFound: false,
+		},
+		{
+			name:      "upstream Envoy tag",
+			image:     "envoyproxy/envoy:v1.17.2",
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := parseEnvoyImageVersion(tt.image)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseEnvoyVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    envoyVersion
+		wantErr error
+	}{
+		{
+			name:    "version",
+			version: "1.17.2.0",
+			want:    envoyVersion{1, 17, 2, 0},
+		},
+		{
+			name:    "version with v prefix",
+			version: "v1.17.2.0",
+			want:    envoyVersion{1, 17, 2, 0},
+		},
+		{
+			name:    "image tag",
+			version: "v1.17.2.0-prod",
+			wantErr: errors.New("malformed Envoy version v1.17.2.0-prod, expected format: <major>.<minor>.<patch>.<build>"),
+		},
+		{
+			name:    "upstream Envoy version",
+			version: "1.17.2",
+			wantErr: errors.New("malformed Envoy version 1.17.2, expected format: <major>.<minor>.<patch>.<build>"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEnvoyVersion(tt.version)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSidecarInjector_validateMinEnvoyVersion(t *testing.T) {
+	getPodWithEnvoyImage := func(image string) *corev1.Pod {
+		return &corev1.Pod{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "app", Image: "app:v1.0.0.0-prod"},
+					{Name: "envoy", Image: image},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name                  string
+		sidecarContainerName  string
+		minEnvoyVersion       string
+		minEnvoyVersionAction string
+		pod                   *corev1.Pod
+		wantErr               error
+	}{
+		{
+			name:                  "minimum version not set",
+			minEnvoyVersion:       "",
+			minEnvoyVersionAction: minEnvoyVersionActionDeny,
+			pod:                   getPodWithEnvoyImage("envoy:v1.12.1.0-prod"),
+		},
+		{
+			name:                  "below minimum version, deny",
+			minEnvoyVersion:       "1.15.0.0",
+			minEnvoyVersionAction: minEnvoyVersionActionDeny,
+			pod:                   getPodWithEnvoyImage("envoy:v1.12.1.0-prod"),
+			wantErr:               errors.New("sidecar image envoy:v1.12.1.0-prod is older than the minimum Envoy version v1.15.0.0 required by min-envoy-version"),
+		},
+		{
+			name:                  "below minimum build, deny",
+			minEnvoyVersion:       "1.15.0.1",
+			minEnvoyVersionAction: minEnvoyVersionActionDeny,
+			pod:                   getPodWithEnvoyImage("envoy:v1.15.0.0-prod"),
+			wantErr:               errors.New("sidecar image envoy:v1.15.0.0-prod is older than the minimum Envoy version v1.15.0.1 required by min-envoy-version"),
+		},
+		{
+			name:                  "below minimum version, warn",
+			minEnvoyVersion:       "1.15.0.0",
+			minEnvoyVersionAction: minEnvoyVersionActionWarn,
+			pod:                   getPodWithEnvoyImage("envoy:v1.12.1.0-prod"),
+		},
+		{
+			name:                  "equal to minimum version",
+			minEnvoyVersion:       "v1.15.0.0",
+			minEnvoyVersionAction: minEnvoyVersionActionDeny,
+			pod:                   getPodWithEnvoyImage("envoy:v1.15.0.0-prod"),
+		},
+		{
+			name:                  "above minimum version",
+			minEnvoyVersion:       "1.15.0.0",
+			minEnvoyVersionAction: minEnvoyVersionActionDeny,
+			pod:                   getPodWithEnvoyImage("envoy:v1.17.2.0-prod"),
+		},
+		{
+			name:                  "below minimum version with custom sidecar container name, deny",
+			sidecarContainerName:  "proxy",
+			minEnvoyVersion:       "1.15.0.0",
+			minEnvoyVersionAction: minEnvoyVersionActionDeny,
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "app", Image: "app:v1.0.0.0-prod"},
+						{Name: "proxy", Image: "envoy:v1.12.1.0-prod"},
+					},
+				},
+			},
+			wantErr: errors.New("sidecar image envoy:v1.12.1.0-prod is older than the minimum Envoy version v1.15.0.0 required by min-envoy-version"),
+		},
+		{
+			name:                  "unparseable tag",
+			minEnvoyVersion:       "1.15.0.0",
+			minEnvoyVersionAction: minEnvoyVersionActionDeny,
+			pod:                   getPodWithEnvoyImage("envoy:custom-build"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &SidecarInjector{
+				config: Config{
+					SidecarContainerName:  tt.sidecarContainerName,
+					MinEnvoyVersion:       tt.minEnvoyVersion,
+					MinEnvoyVersionAction: tt.minEnvoyVersionAction,
+				},
+				log: &log.NullLogger{},
+			}
+			err := m.validateMinEnvoyVersion(tt.pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package inject
+
+import (
+	"encoding/json"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	"strings"
+)
+
+const envoyWarmupContainerName = "envoy-warmup"
+const envoyWarmupContainerTemplate = `
+{
+  "name": "envoy-warmup",
+  "image": "{{ .ContainerImage }}",
+  "imagePullPolicy": "IfNotPresent",
+  "command": [
+    "sh",
+    "-c",
+    "timeout {{ .TimeoutSeconds }} wget -q -T {{ .TimeoutSeconds }} -O /dev/null https://{{ .Endpoint }}/ || echo 'warm-up of {{ .Endpoint }} did not complete'"
+  ],
+  "securityContext": {
+    "runAsUser": {{ .RunAsUser }}
+  },
+  "resources": {
+    "limits": {
+      "cpu": "100m",
+      "memory": "64Mi"
+    },
+    "requests": {
+      "cpu": "10m",
+      "memory": "32Mi"
+    }
+  }
+}
+`
+
+type EnvoyWarmupTemplateVariables struct {
+	ContainerImage string
+	Endpoint       string
+	TimeoutSeconds int32
+	RunAsUser      int64
+}
+
+type envoyWarmupMutatorConfig struct {
+	awsRegion      string
+	preview        bool
+	containerImage string
+	timeoutSeconds int32
+	// UID the warm-up container runs as, it must be exempted from traffic redirection since Envoy isn't running yet
+	runAsUser int64
+}
+
+func newEnvoyWarmupMutator(mutatorConfig envoyWarmupMutatorConfig, enabled bool) *envoyWarmupMutator {
+	return &envoyWarmupMutator{
+		mutatorConfig: mutatorConfig,
+		enabled:       enabled,
+	}
+}
+
+// envoyWarmupMutator adds an init container that reaches App Mesh envoy management endpoint
+// to warm up DNS and TLS before Envoy fetches its initial config.
+type envoyWarmupMutator struct {
+	mutatorConfig envoyWarmupMutatorConfig
+	enabled       bool
+}
+
+func (m *envoyWarmupMutator) mutate(pod *corev1.Pod) error {
+	if !m.isEnabled(pod) {
+		return nil
+	}
+	if containsEnvoyWarmupContainer(pod) {
+		return nil
+	}
+	variables := m.buildTemplateVariables(pod)
+	containerJSON, err := renderTemplate("envoy-warmup", envoyWarmupContainerTemplate, variables)
+	if err != nil {
+		return err
+	}
+	container := corev1.Container{}
+	err = json.Unmarshal([]byte(containerJSON), &container)
+	if err != nil {
+		return err
+	}
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, container)
+	return nil
+}
+
+func (m *envoyWarmupMutator) isEnabled(pod *corev1.Pod) bool {
+	if v, ok := pod.ObjectMeta.Annotations[AppMeshEnvoyWarmupAnnotation]; ok {
+		return strings.ToLower(v) == "enabled"
+	}
+	return m.enabled
+}
+
+func (m *envoyWarmupMutator) buildTemplateVariables(pod *corev1.Pod) EnvoyWarmupTemplateVariables {
+	return EnvoyWarmupTemplateVariables{
+		ContainerImage: m.mutatorConfig.containerImage,
+		Endpoint:       m.getEndpoint(pod),
+		TimeoutSeconds: m.mutatorConfig.timeoutSeconds,
+		RunAsUser:      proxyUIDOrDefault(m.mutatorConfig.runAsUser),
+	}
+}
+
+// getEndpoint returns the App Mesh envoy management endpoint Envoy will connect to.
+func (m *envoyWarmupMutator) getEndpoint(pod *corev1.Pod) string {
+	// malformed annotation is rejected before pod is mutated
+	preview, _ := isPreviewEnabled(m.mutatorConfig.preview, pod)
+	region, err := getAWSRegion(pod, m.mutatorConfig.awsRegion)
+	if err != nil {
+		// the envoy mutator rejects pods with a malformed region
+		region = m.mutatorConfig.awsRegion
+	}
+	if preview {
+		return fmt.Sprintf("appmesh-preview-envoy-management.%s.amazonaws.com", region)
+	}
+	return fmt.Sprintf("appmesh-envoy-management.%s.amazonaws.com", region)
+}
+
+func containsEnvoyWarmupContainer(pod *corev1.Pod) bool {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == envoyWarmupContainerName {
+			return true
+		}
+	}
+	return false
+}
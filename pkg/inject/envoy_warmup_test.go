@@ -0,0 +1,234 @@
+package inject
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_envoyWarmupMutator_mutate(t *testing.T) {
+	cpuLimits, _ := resource.ParseQuantity("100m")
+	cpuRequests, _ := resource.ParseQuantity("10m")
+	memoryLimits, _ := resource.ParseQuantity("64Mi")
+	memoryRequests, _ := resource.ParseQuantity("32Mi")
+	warmupContainer := func(endpoint string) corev1.Container {
+		return corev1.Container{
+			Name:            "envoy-warmup",
+			Image:           "busybox",
+			ImagePullPolicy: "IfNotPresent",
+			Command: []string{
+				"sh",
+				"-c",
+				"timeout 5 wget -q -T 5 -O /dev/null https://" + endpoint + "/ || echo 'warm-up of " + endpoint + " did not complete'",
+			},
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser: aws.Int64(1337),
+			},
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					"cpu":    cpuLimits,
+					"memory": memoryLimits,
+				},
+				Requests: corev1.ResourceList{
+					"cpu":    cpuRequests,
+					"memory": memoryRequests,
+				},
+			},
+		}
+	}
+	mutatorConfig := envoyWarmupMutatorConfig{
+		awsRegion:      "us-west-2",
+		containerImage: "busybox",
+		timeoutSeconds: 5,
+	}
+	type fields struct {
+		mutatorConfig envoyWarmupMutatorConfig
+		enabled       bool
+	}
+	type args struct {
+		pod *corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantPod *corev1.Pod
+	}{
+		{
+			name: "no-op when disabled",
+			fields: fields{
+				mutatorConfig: mutatorConfig,
+				enabled:       false,
+			},
+			args: args{
+				pod: &corev1.Pod{},
+			},
+			wantPod: &corev1.Pod{},
+		},
+		{
+			name: "inject init container when enabled",
+			fields: fields{
+				mutatorConfig: mutatorConfig,
+				enabled:       true,
+			},
+			args: args{
+				pod: &corev1.Pod{},
+			},
+			wantPod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						warmupContainer("appmesh-envoy-management.us-west-2.amazonaws.com"),
+					},
+				},
+			},
+		},
+		{
+			name: "inject init container with preview endpoint",
+			fields: fields{
+				mutatorConfig: mutatorConfig,
+				enabled:       true,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							AppMeshPreviewAnnotation: "enabled",
+						},
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						AppMeshPreviewAnnotation: "enabled",
+					},
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						warmupContainer("appmesh-preview-envoy-management.us-west-2.amazonaws.com"),
+					},
+				},
+			},
+		},
+		{
+			name: "inject init container with endpoint of overridden region",
+			fields: fields{
+				mutatorConfig: mutatorConfig,
+				enabled:       true,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							AppMeshAWSRegionAnnotation: "eu-west-1",
+						},
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						AppMeshAWSRegionAnnotation: "eu-west-1",
+					},
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						warmupContainer("appmesh-envoy-management.eu-west-1.amazonaws.com"),
+					},
+				},
+			},
+		},
+		{
+			name: "inject init container when enabled by annotation",
+			fields: fields{
+				mutatorConfig: mutatorConfig,
+				enabled:       false,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							AppMeshEnvoyWarmupAnnotation: "enabled",
+						},
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						AppMeshEnvoyWarmupAnnotation: "enabled",
+					},
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						warmupContainer("appmesh-envoy-management.us-west-2.amazonaws.com"),
+					},
+				},
+			},
+		},
+		{
+			name: "no-op when disabled by annotation",
+			fields: fields{
+				mutatorConfig: mutatorConfig,
+				enabled:       true,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							AppMeshEnvoyWarmupAnnotation: "disabled",
+						},
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						AppMeshEnvoyWarmupAnnotation: "disabled",
+					},
+				},
+			},
+		},
+		{
+			name: "no-op when already contains warm-up container",
+			fields: fields{
+				mutatorConfig: mutatorConfig,
+				enabled:       true,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{
+							{
+								Name: "envoy-warmup",
+							},
+						},
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{
+							Name: "envoy-warmup",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newEnvoyWarmupMutator(tt.fields.mutatorConfig, tt.fields.enabled)
+			pod := tt.args.pod.DeepCopy()
+			err := m.mutate(pod)
+			assert.NoError(t, err)
+			assert.True(t, cmp.Equal(tt.wantPod, pod), "diff", cmp.Diff(tt.wantPod, pod))
+		})
+	}
+}
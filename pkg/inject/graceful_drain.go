@@ -0,0 +1,43 @@
+package inject
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// gracefulDrainTerminationGracePeriodPadding leaves Envoy time to exit after the PreStop hook timed out.
+	gracefulDrainTerminationGracePeriodPadding int64 = 5
+	// defaultTerminationGracePeriodSeconds is what Kubernetes uses when pod doesn't specify terminationGracePeriodSeconds.
+	defaultTerminationGracePeriodSeconds int64 = 30
+)
+
+// gracefulDrainPreStopCommand builds the Envoy PreStop command that gracefully drains listeners,
+// then polls active downstream connections once per second until there is none or timeoutSeconds elapsed.
+// The command exits regardless once timeoutSeconds elapsed, so a pod never hangs terminating on Envoy.
+func gracefulDrainPreStopCommand(adminAccessPort string, timeoutSeconds int32) []string {
+	adminCurl := "curl -s --max-time 1 http://localhost:" + adminAccessPort
+	script := fmt.Sprintf("%[1]s/drain_listeners?graceful -X POST > /dev/null; "+
+		"deadline=$(($(date +%%s) + %[2]d)); "+
+		"while [ \"$(date +%%s)\" -lt \"$deadline\" ]; do "+
+		"active=$(%[1]s/stats?filter=downstream_cx_active | grep -v admin | awk '{s+=$2} END {print s+0}'); "+
+		"[ \"$active\" -eq 0 ] && break; "+
+		"sleep 1; "+
+		"done", adminCurl, timeoutSeconds)
+	return []string{"sh", "-c", script}
+}
+
+// mutateGracefulDrainTerminationGracePeriod makes sure pod's terminationGracePeriodSeconds outlasts the graceful drain,
+// otherwise Envoy is killed before the PreStop hook times out.
+func mutateGracefulDrainTerminationGracePeriod(pod *corev1.Pod, timeoutSeconds int32) {
+	required := int64(timeoutSeconds) + gracefulDrainTerminationGracePeriodPadding
+	current := defaultTerminationGracePeriodSeconds
+	if pod.Spec.TerminationGracePeriodSeconds != nil {
+		current = *pod.Spec.TerminationGracePeriodSeconds
+	}
+	if current >= required {
+		return
+	}
+	pod.Spec.TerminationGracePeriodSeconds = &required
+}
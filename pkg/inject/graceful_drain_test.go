@@ -0,0 +1,100 @@
+package inject
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func Test_gracefulDrainPreStopCommand(t *testing.T) {
+	type args struct {
+		adminAccessPort string
+		timeoutSeconds  int32
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "admin over TCP with 30 seconds timeout",
+			args: args{
+				adminAccessPort: "9901",
+				timeoutSeconds:  30,
+			},
+			want: []string{"sh", "-c", "curl -s --max-time 1 http://localhost:9901/drain_listeners?graceful -X POST > /dev/null; " +
+				"deadline=$(($(date +%s) + 30)); " +
+				"while [ \"$(date +%s)\" -lt \"$deadline\" ]; do " +
+				"active=$(curl -s --max-time 1 http://localhost:9901/stats?filter=downstream_cx_active | grep -v admin | awk '{s+=$2} END {print s+0}'); " +
+				"[ \"$active\" -eq 0 ] && break; " +
+				"sleep 1; " +
+				"done"},
+		},
+		{
+			name: "admin over TCP with 120 seconds timeout",
+			args: args{
+				adminAccessPort: "9902",
+				timeoutSeconds:  120,
+			},
+			want: []string{"sh", "-c", "curl -s --max-time 1 http://localhost:9902/drain_listeners?graceful -X POST > /dev/null; " +
+				"deadline=$(($(date +%s) + 120)); " +
+				"while [ \"$(date +%s)\" -lt \"$deadline\" ]; do " +
+				"active=$(curl -s --max-time 1 http://localhost:9902/stats?filter=downstream_cx_active | grep -v admin | awk '{s+=$2} END {print s+0}'); " +
+				"[ \"$active\" -eq 0 ] && break; " +
+				"sleep 1; " +
+				"done"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gracefulDrainPreStopCommand(tt.args.adminAccessPort, tt.args.timeoutSeconds)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_mutateGracefulDrainTerminationGracePeriod(t *testing.T) {
+	tests := []struct {
+		name                              string
+		terminationGracePeriodSeconds     *int64
+		timeoutSeconds                    int32
+		wantTerminationGracePeriodSeconds *int64
+	}{
+		{
+			name:                              "default grace period accommodates timeout",
+			terminationGracePeriodSeconds:     nil,
+			timeoutSeconds:                    20,
+			wantTerminationGracePeriodSeconds: nil,
+		},
+		{
+			name:                              "default grace period is raised for timeout",
+			terminationGracePeriodSeconds:     nil,
+			timeoutSeconds:                    30,
+			wantTerminationGracePeriodSeconds: aws.Int64(35),
+		},
+		{
+			name:                              "explicit grace period is raised for timeout",
+			terminationGracePeriodSeconds:     aws.Int64(10),
+			timeoutSeconds:                    60,
+			wantTerminationGracePeriodSeconds: aws.Int64(65),
+		},
+		{
+			name:                              "explicit grace period accommodates timeout",
+			terminationGracePeriodSeconds:     aws.Int64(120),
+			timeoutSeconds:                    60,
+			wantTerminationGracePeriodSeconds: aws.Int64(120),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					TerminationGracePeriodSeconds: tt.terminationGracePeriodSeconds,
+				},
+			}
+			mutateGracefulDrainTerminationGracePeriod(pod, tt.timeoutSeconds)
+			assert.Equal(t, tt.wantTerminationGracePeriodSeconds, pod.Spec.TerminationGracePeriodSeconds)
+		})
+	}
+}
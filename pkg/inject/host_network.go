@@ -0,0 +1,58 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// hostNetworkPolicySkip leaves hostNetwork pods uninjected and warns on their VirtualNode or VirtualGateway.
+	hostNetworkPolicySkip = "skip"
+	// hostNetworkPolicyDeny rejects hostNetwork pods.
+	hostNetworkPolicyDeny = "deny"
+
+	reasonHostNetworkPodSkipped = "HostNetworkPodSkipped"
+)
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// handleHostNetworkPod applies the host network policy to meshed pods using hostNetwork,
+// the iptables rules of proxyinit would redirect the traffic of the whole node to Envoy otherwise.
+// It returns whether injection should be skipped.
+func (m *SidecarInjector) handleHostNetworkPod(ctx context.Context, pod *corev1.Pod, vn *appmesh.VirtualNode, vg *appmesh.VirtualGateway) (bool, error) {
+	if !pod.Spec.HostNetwork {
+		return false, nil
+	}
+	podName := m.podDisplayName(ctx, pod)
+	if m.config.HostNetworkPolicy == hostNetworkPolicyDeny {
+		return false, errors.Errorf("pod %s uses hostNetwork, sidecar injection would redirect the traffic of its node", podName)
+	}
+	message := fmt.Sprintf("skipped sidecar injection of pod %s since it uses hostNetwork", podName)
+	m.log.Info(message)
+	if m.eventRecorder != nil {
+		if vn != nil {
+			m.eventRecorder.Event(vn, corev1.EventTypeWarning, reasonHostNetworkPodSkipped, message)
+		} else if vg != nil {
+			m.eventRecorder.Event(vg, corev1.EventTypeWarning, reasonHostNetworkPodSkipped, message)
+		}
+	}
+	return true, nil
+}
+
+// podDisplayName returns namespace/name of pod, pod name might be unset on admission when generateName is used.
+func (m *SidecarInjector) podDisplayName(ctx context.Context, pod *corev1.Pod) string {
+	namespace := pod.Namespace
+	if req := webhook.ContextGetAdmissionRequest(ctx); req != nil && req.Namespace != "" {
+		namespace = req.Namespace
+	}
+	name := pod.Name
+	if name == "" {
+		name = pod.GenerateName
+	}
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
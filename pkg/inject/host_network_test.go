@@ -0,0 +1,104 @@
+package inject
+
+import (
+	"context"
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"testing"
+)
+
+func TestSidecarInjector_handleHostNetworkPod(t *testing.T) {
+	vn := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-vn"},
+	}
+	vg := &appmesh.VirtualGateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-vg"},
+	}
+	tests := []struct {
+		name              string
+		hostNetworkPolicy string
+		hostNetwork       bool
+		vn                *appmesh.VirtualNode
+		vg                *appmesh.VirtualGateway
+		wantSkip          bool
+		wantEvents        []string
+		wantErr           error
+	}{
+		{
+			name:              "pod without hostNetwork, skip policy",
+			hostNetworkPolicy: hostNetworkPolicySkip,
+			hostNetwork:       false,
+			vn:                vn,
+			wantSkip:          false,
+		},
+		{
+			name:              "pod without hostNetwork, deny policy",
+			hostNetworkPolicy: hostNetworkPolicyDeny,
+			hostNetwork:       false,
+			vn:                vn,
+			wantSkip:          false,
+		},
+		{
+			name:              "hostNetwork pod of virtualNode, skip policy",
+			hostNetworkPolicy: hostNetworkPolicySkip,
+			hostNetwork:       true,
+			vn:                vn,
+			wantSkip:          true,
+			wantEvents:        []string{"Warning HostNetworkPodSkipped skipped sidecar injection of pod default/foo-abcde since it uses hostNetwork"},
+		},
+		{
+			name:              "hostNetwork pod of virtualGateway, skip policy",
+			hostNetworkPolicy: hostNetworkPolicySkip,
+			hostNetwork:       true,
+			vg:                vg,
+			wantSkip:          true,
+			wantEvents:        []string{"Warning HostNetworkPodSkipped skipped sidecar injection of pod default/foo-abcde since it uses hostNetwork"},
+		},
+		{
+			name:              "hostNetwork pod of virtualNode, deny policy",
+			hostNetworkPolicy: hostNetworkPolicyDeny,
+			hostNetwork:       true,
+			vn:                vn,
+			wantErr:           errors.New("pod default/foo-abcde uses hostNetwork, sidecar injection would redirect the traffic of its node"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(1)
+			m := &SidecarInjector{
+				config:        Config{HostNetworkPolicy: tt.hostNetworkPolicy},
+				eventRecorder: eventRecorder,
+				log:           &log.NullLogger{},
+			}
+			// pod name and namespace are unset on admission of pods created by controllers.
+			pod := getPod(nil)
+			pod.Namespace = ""
+			pod.Name = ""
+			pod.GenerateName = "foo-abcde"
+			pod.Spec.HostNetwork = tt.hostNetwork
+			ctx := webhook.ContextWithAdmissionRequest(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{Namespace: "default"},
+			})
+			gotSkip, err := m.handleHostNetworkPod(ctx, pod, tt.vn, tt.vg)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantSkip, gotSkip)
+			}
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
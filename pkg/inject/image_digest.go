@@ -0,0 +1,59 @@
+package inject
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	allowUnpinnedSidecarImageEnabled = "true"
+)
+
+var imageDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// parseImageDigest returns the digest of an image reference in the format of <repository>[:tag]@sha256:<digest>,
+// or empty string if the image reference is tag based.
+func parseImageDigest(image string) (string, error) {
+	idx := strings.LastIndex(image, "@")
+	if idx < 0 {
+		return "", nil
+	}
+	digest := image[idx+1:]
+	if !imageDigestPattern.MatchString(digest) {
+		return "", errors.Errorf("malformed digest %s of image %s, expected format: %s", digest, image, "sha256:<64 lowercase hex characters>")
+	}
+	return digest, nil
+}
+
+// validateSidecarImageDigest makes sure the Envoy container of injected pod runs an image pinned by digest.
+// The Envoy image of a VirtualGateway pod skipping image override comes from the pod itself, so it's checked after injection.
+func (m *SidecarInjector) validateSidecarImageDigest(pod *corev1.Pod) error {
+	if !m.config.RequireImageDigest {
+		return nil
+	}
+	if strings.ToLower(pod.Annotations[AppMeshAllowUnpinnedSidecarImageAnnotation]) == allowUnpinnedSidecarImageEnabled {
+		m.log.Info("skipping sidecar image digest check with break-glass annotation",
+			"namespace", pod.Namespace,
+			"name", pod.Name,
+			"generateName", pod.GenerateName,
+		)
+		return nil
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name != m.config.SidecarContainerName {
+			continue
+		}
+		digest, err := parseImageDigest(container.Image)
+		if err != nil {
+			return err
+		}
+		if digest == "" {
+			return errors.Errorf("sidecar image %s must be pinned by digest as required by %s, or annotate the pod with %s: %q",
+				container.Image, flagRequireImageDigest, AppMeshAllowUnpinnedSidecarImageAnnotation, allowUnpinnedSidecarImageEnabled)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package inject
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+const (
+	testImageDigest = "sha256:6f9b2a3c47d2f2a0e4d0e2b5b1c3a5e7f9d1b3c5e7a9b1d3f5a7c9e1b3d5f7a9"
+)
+
+func Test_parseImageDigest(t *testing.T) {
+	tests := []struct {
+		name       string
+		image      string
+		wantDigest string
+		wantErr    error
+	}{
+		{
+			name:       "digest pinned image",
+			image:      "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-envoy@" + testImageDigest,
+			wantDigest: testImageDigest,
+		},
+		{
+			name:       "digest pinned image with tag",
+			image:      "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-envoy:v1.17.2.0-prod@" + testImageDigest,
+			wantDigest: testImageDigest,
+		},
+		{
+			name:       "tag based image",
+			image:      "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-envoy:v1.17.2.0-prod",
+			wantDigest: "",
+		},
+		{
+			name:       "image without tag",
+			image:      "envoy",
+			wantDigest: "",
+		},
+		{
+			name:    "digest with unsupported algorithm",
+			image:   "envoy@sha512:6f9b2a3c",
+			wantErr: errors.New("malformed digest sha512:6f9b2a3c of image envoy@sha512:6f9b2a3c, expected format: sha256:<64 lowercase hex characters>"),
+		},
+		{
+			name:    "truncated digest",
+			image:   "envoy@sha256:6f9b2a3c",
+			wantErr: errors.New("malformed digest sha256:6f9b2a3c of image envoy@sha256:6f9b2a3c, expected format: sha256:<64 lowercase hex characters>"),
+		},
+		{
+			name:    "empty digest",
+			image:   "envoy@",
+			wantErr: errors.New("malformed digest  of image envoy@, expected format: sha256:<64 lowercase hex characters>"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImageDigest(tt.image)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantDigest, got)
+			}
+		})
+	}
+}
+
+func TestSidecarInjector_validateSidecarImageDigest(t *testing.T) {
+	tests := []struct {
+		name               string
+		requireImageDigest bool
+		pod                *corev1.Pod
+		wantErr            error
+	}{
+		{
+			name:               "tag based image when digest isn't required",
+			requireImageDigest: false,
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "envoy", Image: "envoy:v1.17.2.0-prod"},
+					},
+				},
+			},
+		},
+		{
+			name:               "digest pinned image when digest is required",
+			requireImageDigest: true,
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "app", Image: "app:latest"},
+						{Name: "envoy", Image: "envoy@" + testImageDigest},
+					},
+				},
+			},
+		},
+		{
+			name:               "tag based image when digest is required",
+			requireImageDigest: true,
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "envoy", Image: "envoy:v1.17.2.0-prod"},
+					},
+				},
+			},
+			wantErr: errors.New("sidecar image envoy:v1.17.2.0-prod must be pinned by digest as required by require-image-digest, or annotate the pod with appmesh.k8s.aws/allowUnpinnedSidecarImage: \"true\""),
+		},
+		{
+			name:               "malformed digest when digest is required",
+			requireImageDigest: true,
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "envoy", Image: "envoy@sha256:6f9b2a3c"},
+					},
+				},
+			},
+			wantErr: errors.New("malformed digest sha256:6f9b2a3c of image envoy@sha256:6f9b2a3c, expected format: sha256:<64 lowercase hex characters>"),
+		},
+		{
+			name:               "tag based image with break-glass annotation",
+			requireImageDigest: true,
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						AppMeshAllowUnpinnedSidecarImageAnnotation: "true",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "envoy", Image: "envoy:v1.17.2.0-prod"},
+					},
+				},
+			},
+		},
+		{
+			name:               "tag based image with break-glass annotation disabled",
+			requireImageDigest: true,
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						AppMeshAllowUnpinnedSidecarImageAnnotation: "false",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "envoy", Image: "envoy:v1.17.2.0-prod"},
+					},
+				},
+			},
+			wantErr: errors.New("sidecar image envoy:v1.17.2.0-prod must be pinned by digest as required by require-image-digest, or annotate the pod with appmesh.k8s.aws/allowUnpinnedSidecarImage: \"true\""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &SidecarInjector{
+				config: Config{
+					SidecarContainerName: "envoy",
+					RequireImageDigest:   tt.requireImageDigest,
+				},
+				log: &log.NullLogger{},
+			}
+			err := m.validateSidecarImageDigest(tt.pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_validateSidecarImageDigest(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{
+			name: "tag based image when digest isn't required",
+			cfg:  Config{SidecarImage: "envoy:v1.17.2.0-prod"},
+		},
+		{
+			name: "digest pinned image when digest is required",
+			cfg:  Config{SidecarImage: "envoy@" + testImageDigest, RequireImageDigest: true},
+		},
+		{
+			name:    "tag based image when digest is required",
+			cfg:     Config{SidecarImage: "envoy:v1.17.2.0-prod", RequireImageDigest: true},
+			wantErr: errors.New("invalid sidecar-image: envoy:v1.17.2.0-prod, must be pinned by digest when require-image-digest is enabled"),
+		},
+		{
+			name:    "malformed digest",
+			cfg:     Config{SidecarImage: "envoy@sha256:6f9b2a3c"},
+			wantErr: errors.New("invalid sidecar-image: malformed digest sha256:6f9b2a3c of image envoy@sha256:6f9b2a3c, expected format: sha256:<64 lowercase hex characters>"),
+		},
+		{
+			name: "tag based arm64 image when digest is required",
+			cfg: Config{
+				SidecarImage:       "envoy@" + testImageDigest,
+				SidecarImageArm64:  "envoy:v1.17.2.0-prod-arm64",
+				RequireImageDigest: true,
+			},
+			wantErr: errors.New("invalid sidecar-image-arm64: envoy:v1.17.2.0-prod-arm64, must be pinned by digest when require-image-digest is enabled"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validateSidecarImageDigest()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -8,7 +8,7 @@ import (
 const proxyInitContainerName = "proxyinit"
 const proxyInitContainerTemplate = `
 {
-  "name": "proxyinit",
+  "name": "{{ .ContainerName }}",
   "image": "{{ .ContainerImage }}",
   "securityContext": {
     "capabilities": {
@@ -25,7 +25,11 @@ const proxyInitContainerTemplate = `
     {
       "name": "APPMESH_IGNORE_UID",
       "value": "{{ .ProxyUID }}"
-    },
+    },{{ if .ProxyGID }}
+    {
+      "name": "APPMESH_IGNORE_GID",
+      "value": "{{ .ProxyGID }}"
+    },{{ end }}
     {
       "name": "APPMESH_ENVOY_INGRESS_PORT",
       "value": "{{ .ProxyIngressPort }}"
@@ -57,15 +61,20 @@ type InitContainerTemplateVariables struct {
 	ProxyEgressPort    int64
 	ProxyIngressPort   int64
 	ProxyUID           int64
+	ProxyGID           int64
 	ContainerImage     string
+	ContainerName      string
 }
 
 type initProxyMutatorConfig struct {
 	containerImage string
+	containerName  string
 	cpuRequests    string
 	memoryRequests string
 	cpuLimits      string
 	memoryLimits   string
+	// terminationMessagePolicy of the proxyinit container, left unset if empty
+	terminationMessagePolicy corev1.TerminationMessagePolicy
 }
 
 // newInitProxyMutator constructs new initProxyMutator
@@ -83,7 +92,7 @@ type initProxyMutator struct {
 }
 
 func (m *initProxyMutator) mutate(pod *corev1.Pod) error {
-	if containsProxyInitContainer(pod) {
+	if containsProxyInitContainer(pod, proxyInitContainerNameOrDefault(m.mutatorConfig.containerName)) {
 		return nil
 	}
 	variables := m.buildTemplateVariables()
@@ -96,6 +105,7 @@ func (m *initProxyMutator) mutate(pod *corev1.Pod) error {
 	if err != nil {
 		return err
 	}
+	container.TerminationMessagePolicy = m.mutatorConfig.terminationMessagePolicy
 
 	// add resource requests and limits
 	container.Resources, err = sidecarResources(m.mutatorConfig.cpuRequests, m.mutatorConfig.memoryRequests,
@@ -116,15 +126,25 @@ func (m *initProxyMutator) buildTemplateVariables() InitContainerTemplateVariabl
 		ProxyEgressPort:    m.proxyConfig.proxyEgressPort,
 		ProxyIngressPort:   m.proxyConfig.proxyIngressPort,
 		ProxyUID:           m.proxyConfig.proxyUID,
+		ProxyGID:           m.proxyConfig.proxyGID,
 		ContainerImage:     m.mutatorConfig.containerImage,
+		ContainerName:      proxyInitContainerNameOrDefault(m.mutatorConfig.containerName),
 	}
 }
 
-func containsProxyInitContainer(pod *corev1.Pod) bool {
+func containsProxyInitContainer(pod *corev1.Pod, containerName string) bool {
 	for _, container := range pod.Spec.InitContainers {
-		if container.Name == proxyInitContainerName {
+		if container.Name == containerName {
 			return true
 		}
 	}
 	return false
 }
+
+// proxyInitContainerNameOrDefault returns the configured name of proxyinit container, or proxyInitContainerName when it's unset.
+func proxyInitContainerNameOrDefault(name string) string {
+	if name != "" {
+		return name
+	}
+	return proxyInitContainerName
+}
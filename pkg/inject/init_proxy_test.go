@@ -112,6 +112,95 @@ func Test_initProxyMutator_mutate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "normal case with custom proxy UID and GID",
+			fields: fields{
+				mutatorConfig: initProxyMutatorConfig{
+					containerImage: "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-proxy-route-manager:v3-prod",
+					cpuRequests:    cpuRequests.String(),
+					memoryRequests: memoryRequests.String(),
+					cpuLimits:      cpuLimits.String(),
+					memoryLimits:   memoryLimits.String(),
+				},
+				proxyConfig: proxyConfig{
+					appPorts:           "80,443",
+					egressIgnoredIPs:   "192.168.0.1",
+					egressIgnoredPorts: "22",
+					proxyEgressPort:    15001,
+					proxyIngressPort:   15000,
+					proxyUID:           1500,
+					proxyGID:           1600,
+				},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						InitContainers: nil,
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{
+							Name:  "proxyinit",
+							Image: "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-proxy-route-manager:v3-prod",
+							SecurityContext: &corev1.SecurityContext{
+								Capabilities: &corev1.Capabilities{
+									Add: []corev1.Capability{
+										"NET_ADMIN",
+									},
+								},
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "APPMESH_START_ENABLED",
+									Value: "1",
+								},
+								{
+									Name:  "APPMESH_IGNORE_UID",
+									Value: "1500",
+								},
+								{
+									Name:  "APPMESH_IGNORE_GID",
+									Value: "1600",
+								},
+								{
+									Name:  "APPMESH_ENVOY_INGRESS_PORT",
+									Value: "15000",
+								},
+								{
+									Name:  "APPMESH_ENVOY_EGRESS_PORT",
+									Value: "15001",
+								},
+								{
+									Name:  "APPMESH_APP_PORTS",
+									Value: "80,443",
+								},
+								{
+									Name:  "APPMESH_EGRESS_IGNORED_IP",
+									Value: "192.168.0.1",
+								},
+								{
+									Name:  "APPMESH_EGRESS_IGNORED_PORTS",
+									Value: "22",
+								},
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									"cpu":    cpuRequests,
+									"memory": memoryRequests,
+								},
+								Limits: corev1.ResourceList{
+									"cpu":    cpuLimits,
+									"memory": memoryLimits,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "normal case without resource limits",
 			fields: fields{
@@ -315,6 +404,45 @@ func Test_initProxyMutator_mutate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "no-op when already contains renamed proxyInit container",
+			fields: fields{
+				mutatorConfig: initProxyMutatorConfig{
+					containerImage: "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-proxy-route-manager:v3-prod",
+					containerName:  "appmesh-proxyinit",
+					cpuRequests:    cpuRequests.String(),
+					memoryRequests: memoryRequests.String(),
+				},
+				proxyConfig: proxyConfig{
+					appPorts:           "80,443",
+					egressIgnoredIPs:   "192.168.0.1",
+					egressIgnoredPorts: "22",
+					proxyEgressPort:    15001,
+					proxyIngressPort:   15000,
+					proxyUID:           1337,
+				},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{
+							{
+								Name: "appmesh-proxyinit",
+							},
+						},
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{
+							Name: "appmesh-proxyinit",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -375,7 +503,7 @@ func Test_containsProxyInitContainer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := containsProxyInitContainer(tt.args.pod)
+			got := containsProxyInitContainer(tt.args.pod, proxyInitContainerName)
 			assert.Equal(t, tt.want, got)
 		})
 	}
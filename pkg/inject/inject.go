@@ -9,9 +9,12 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualgateway"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -23,32 +26,51 @@ type PodMutator interface {
 }
 
 type SidecarInjector struct {
-	config                 Config
-	accountID              string
-	awsRegion              string
-	k8sClient              client.Client
+	config    Config
+	accountID string
+	awsRegion string
+	k8sClient client.Client
+	// apiReader reads from the API server directly, for objects that mustn't be cached cluster-wide such as secrets.
+	apiReader              client.Reader
 	referenceResolver      references.Resolver
 	vgMembershipDesignator virtualgateway.MembershipDesignator
 	vnMembershipDesignator virtualnode.MembershipDesignator
+	eventRecorder          record.EventRecorder
+	log                    logr.Logger
 }
 
 func NewSidecarInjector(cfg Config, accountID string, awsRegion string,
 	k8sClient client.Client,
+	apiReader client.Reader,
 	referenceResolver references.Resolver,
 	vnMembershipDesignator virtualnode.MembershipDesignator,
-	vgMembershipDesignator virtualgateway.MembershipDesignator) *SidecarInjector {
+	vgMembershipDesignator virtualgateway.MembershipDesignator,
+	eventRecorder record.EventRecorder) *SidecarInjector {
 	return &SidecarInjector{
 		config:                 cfg,
 		accountID:              accountID,
 		awsRegion:              awsRegion,
 		k8sClient:              k8sClient,
+		apiReader:              apiReader,
 		referenceResolver:      referenceResolver,
 		vgMembershipDesignator: vgMembershipDesignator,
 		vnMembershipDesignator: vnMembershipDesignator,
+		eventRecorder:          eventRecorder,
+		log:                    injectLogger,
 	}
 }
 
 func (m *SidecarInjector) Inject(ctx context.Context, pod *corev1.Pod) error {
+	if m.isNamespaceExcluded(ctx, pod) {
+		return nil
+	}
+	selected, err := m.matchesInjectionLabelSelector(pod)
+	if err != nil {
+		return errors.Wrap(err, "failed to match injection label selector")
+	}
+	if !selected {
+		return nil
+	}
 	injectMode, err := m.determineSidecarInjectMode(ctx, pod)
 	if err != nil {
 		return errors.Wrap(err, "failed to determine sidecarInject mode")
@@ -74,8 +96,14 @@ func (m *SidecarInjector) Inject(ctx context.Context, pod *corev1.Pod) error {
 		if injectMode == sidecarInjectModeEnabled {
 			return errors.New("sidecarInject enabled but no matching VirtualNode or VirtualGateway found")
 		}
+		if vg == nil && m.config.InjectFailOnMissingVirtualNode {
+			return m.buildMissingVirtualNodeError(ctx, pod, vn)
+		}
 		return nil
 	}
+	if skip, err := m.handleHostNetworkPod(ctx, pod, vn, vg); err != nil || skip {
+		return err
+	}
 
 	var msRef *appmesh.MeshReference
 	if vn != nil {
@@ -90,25 +118,113 @@ func (m *SidecarInjector) Inject(ctx context.Context, pod *corev1.Pod) error {
 	if err != nil {
 		return err
 	}
-	return m.injectAppMeshPatches(ms, vn, vg, pod)
+	if err := m.validateCABundleSource(ctx); err != nil {
+		return err
+	}
+	if err := m.injectAppMeshPatches(ms, vn, vg, pod); err != nil {
+		return err
+	}
+	if err := m.validateSidecarImageDigest(pod); err != nil {
+		return err
+	}
+	if err := m.validateSidecarLimits(pod); err != nil {
+		return err
+	}
+	if err := m.validateMinEnvoyVersion(pod); err != nil {
+		return err
+	}
+	if err := m.validateXRayServiceAccount(ctx, pod); err != nil {
+		return err
+	}
+	m.dumpInjectedSpec(pod)
+	return nil
+}
+
+// buildMissingVirtualNodeError builds the error denying pod whose virtualNode can't be resolved when fail on missing virtualNode is enabled,
+// vn is the virtualNode matching pod if any, which lacks a meshRef then.
+func (m *SidecarInjector) buildMissingVirtualNodeError(ctx context.Context, pod *corev1.Pod, vn *appmesh.VirtualNode) error {
+	if vn == nil {
+		return errors.Errorf("failed to resolve virtualNode of pod %s: no virtualNode matches it, denied as required by %s",
+			m.podDisplayName(ctx, pod), flagInjectFailOnMissingVirtualNode)
+	}
+	return errors.Errorf("failed to resolve virtualNode of pod %s: virtualNode %s has no meshRef, denied as required by %s",
+		m.podDisplayName(ctx, pod), vn.Name, flagInjectFailOnMissingVirtualNode)
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps;secrets,verbs=get
+
+// validateCABundleSource makes sure the configured CA bundle exists in pod's namespace,
+// otherwise the injected pod would never start due to the missing volume source.
+// the CA bundle is read from the API server directly, so no informer caches every configMap or secret in the cluster.
+func (m *SidecarInjector) validateCABundleSource(ctx context.Context) error {
+	if m.config.SidecarCABundle == "" {
+		return nil
+	}
+	caBundle, err := parseCABundleSource(m.config.SidecarCABundle)
+	if err != nil {
+		return err
+	}
+	req := webhook.ContextGetAdmissionRequest(ctx)
+	key := types.NamespacedName{Namespace: req.Namespace, Name: caBundle.name}
+	var data map[string][]byte
+	switch caBundle.kind {
+	case caBundleSourceKindConfigMap:
+		cm := &corev1.ConfigMap{}
+		if err := m.getFromReaderWithRetry(ctx, m.apiReader, key, cm); err != nil {
+			return errors.Wrapf(err, "failed to get CA bundle configMap %s", caBundle.name)
+		}
+		data = make(map[string][]byte)
+		for k, v := range cm.Data {
+			data[k] = []byte(v)
+		}
+		for k, v := range cm.BinaryData {
+			data[k] = v
+		}
+	case caBundleSourceKindSecret:
+		secret := &corev1.Secret{}
+		if err := m.getFromReaderWithRetry(ctx, m.apiReader, key, secret); err != nil {
+			return errors.Wrapf(err, "failed to get CA bundle secret %s", caBundle.name)
+		}
+		data = secret.Data
+	}
+	if _, ok := data[caBundle.key]; !ok {
+		return errors.Errorf("CA bundle %s %s doesn't contain key %s", caBundle.kind, caBundle.name, caBundle.key)
+	}
+	return nil
 }
 
 func (m *SidecarInjector) injectAppMeshPatches(ms *appmesh.Mesh, vn *appmesh.VirtualNode, vg *appmesh.VirtualGateway, pod *corev1.Pod) error {
 	// List out all the mutators in sequence
 	var mutators []PodMutator
 
+	preview, err := isPreviewEnabled(m.config.Preview, pod)
+	if err != nil {
+		return err
+	}
+
 	if vn != nil {
 		mutators = []PodMutator{
 			newProxyMutator(proxyMutatorConfig{
 				egressIgnoredIPs: m.config.IgnoredIPs,
+				proxyUID:         m.config.SidecarRunAsUser,
+				proxyGID:         m.config.SidecarRunAsGroup,
 				initProxyMutatorConfig: initProxyMutatorConfig{
-					containerImage: m.config.InitImage,
-					cpuRequests:    m.config.SidecarCpuRequests,
-					memoryRequests: m.config.SidecarMemoryRequests,
-					cpuLimits:      m.config.SidecarCpuLimits,
-					memoryLimits:   m.config.SidecarMemoryLimits,
+					containerImage:           m.config.InitImage,
+					containerName:            m.config.ProxyInitContainerName,
+					cpuRequests:              m.config.SidecarCpuRequests,
+					memoryRequests:           m.config.SidecarMemoryRequests,
+					cpuLimits:                m.config.SidecarCpuLimits,
+					memoryLimits:             m.config.SidecarMemoryLimits,
+					terminationMessagePolicy: corev1.TerminationMessagePolicy(m.config.SidecarTerminationMessagePolicy),
 				},
 			}, vn),
+			newEnvoyWarmupMutator(envoyWarmupMutatorConfig{
+				awsRegion:      m.awsRegion,
+				preview:        m.config.Preview,
+				containerImage: m.config.EnvoyWarmupImage,
+				timeoutSeconds: m.config.EnvoyWarmupTimeout,
+				runAsUser:      m.config.SidecarRunAsUser,
+			}, m.config.EnableEnvoyWarmup),
 			newEnvoyMutator(envoyMutatorConfig{
 				accountID:                  m.accountID,
 				awsRegion:                  m.awsRegion,
@@ -117,11 +233,13 @@ func (m *SidecarInjector) injectAppMeshPatches(ms *appmesh.Mesh, vn *appmesh.Vir
 				sdsUdsPath:                 m.config.SdsUdsPath,
 				logLevel:                   m.config.LogLevel,
 				adminAccessPort:            m.config.EnvoyAdminAcessPort,
-				adminAccessLogFile:         m.config.EnvoyAdminAccessLogFile,
+				adminAccessLogFile:         resolveAccessLogFile(m.config.EnvoyAdminAccessLogFile),
+				accessLogVolume:            m.config.EnvoyAccessLogVolume,
 				preStopDelay:               m.config.PreStopDelay,
 				readinessProbeInitialDelay: m.config.ReadinessProbeInitialDelay,
 				readinessProbePeriod:       m.config.ReadinessProbePeriod,
-				sidecarImage:               m.config.SidecarImage,
+				sidecarImage:               m.sidecarImageForPreview(pod, preview, sidecarImageForPod(m.config, pod)),
+				sidecarContainerName:       m.config.SidecarContainerName,
 				sidecarCPURequests:         m.config.SidecarCpuRequests,
 				sidecarMemoryRequests:      m.config.SidecarMemoryRequests,
 				sidecarCPULimits:           m.config.SidecarCpuLimits,
@@ -132,29 +250,62 @@ func (m *SidecarInjector) injectAppMeshPatches(ms *appmesh.Mesh, vn *appmesh.Vir
 				enableDatadogTracing:       m.config.EnableDatadogTracing,
 				datadogTracerPort:          m.config.DatadogPort,
 				datadogTracerAddress:       m.config.DatadogAddress,
+				datadogEnvLabel:            m.config.DatadogEnvLabel,
+				datadogServiceLabel:        m.config.DatadogServiceLabel,
+				datadogVersionLabel:        m.config.DatadogVersionLabel,
 				enableStatsTags:            m.config.EnableStatsTags,
+				enablePodMetadataEnv:       m.config.EnablePodMetadataEnv,
 				enableStatsD:               m.config.EnableStatsD,
 				statsDPort:                 m.config.StatsDPort,
 				statsDAddress:              m.config.StatsDAddress,
+				caBundle:                   m.config.SidecarCABundle,
+				sidecarRunAsUser:           m.config.SidecarRunAsUser,
+				sidecarRunAsGroup:          m.config.SidecarRunAsGroup,
+				enableGracefulDrain:        m.config.EnableGracefulDrain,
+				gracefulDrainTimeout:       m.config.GracefulDrainTimeout,
+				envOverrideAllowlist:       m.config.SidecarEnvOverrideAllowlist,
+				terminationMessagePolicy:   corev1.TerminationMessagePolicy(m.config.SidecarTerminationMessagePolicy),
 			}, ms, vn),
 			newXrayMutator(xrayMutatorConfig{
-				awsRegion:             m.awsRegion,
-				sidecarCPURequests:    m.config.SidecarCpuRequests,
-				sidecarMemoryRequests: m.config.SidecarMemoryRequests,
-				sidecarCPULimits:      m.config.SidecarCpuLimits,
-				sidecarMemoryLimits:   m.config.SidecarMemoryLimits,
-				xRayImage:             m.config.XRayImage,
-				xRayDaemonPort:        m.config.XrayDaemonPort,
-			}, m.config.EnableXrayTracing),
+				awsRegion:                m.awsRegion,
+				sidecarCPURequests:       m.config.XRayCpuRequests,
+				sidecarMemoryRequests:    m.config.XRayMemoryRequests,
+				sidecarCPULimits:         m.config.XRayCpuLimits,
+				sidecarMemoryLimits:      m.config.XRayMemoryLimits,
+				xRayImage:                m.config.XRayImage,
+				xRayDaemonPort:           m.config.XrayDaemonPort,
+				runAsUser:                m.config.SidecarRunAsUser,
+				terminationMessagePolicy: corev1.TerminationMessagePolicy(m.config.SidecarTerminationMessagePolicy),
+			}, m.config.EnableXrayTracing && m.config.XRayInjectDaemon),
+			newPrometheusScrapeMutator(prometheusScrapeMutatorConfig{
+				adminAccessPort: m.config.EnvoyAdminAcessPort,
+				scrapePath:      m.config.PrometheusScrapePath,
+			}, m.config.EnablePrometheusScrape),
+			newPodDNSConfigMutator(podDNSConfigMutatorConfig{
+				options:  m.config.PodDNSOptions,
+				searches: m.config.PodDNSSearches,
+			}),
 			newJaegerMutator(jaegerMutatorConfig{
-				jaegerAddress: m.config.JaegerAddress,
-				jaegerPort:    m.config.JaegerPort,
+				jaegerAddress:   m.config.JaegerAddress,
+				jaegerPort:      m.config.JaegerPort,
+				volumeSizeLimit: m.config.TracingConfigVolumeSizeLimit,
 			}, m.config.EnableJaegerTracing),
 			newCloudMapHealthyReadinessGate(vn),
+			newVirtualNodeReadyReadinessGate(m.config.EnableVNReadinessGate),
 			newIAMForServiceAccountsMutator(m.config.EnableIAMForServiceAccounts),
 			newECRSecretMutator(m.config.EnableECRSecret),
+			// restricts containers injected by all of the above
+			newPSACompatibleMutator(psaCompatibleMutatorConfig{
+				proxyInitContainerName: m.config.ProxyInitContainerName,
+				runAsUser:              m.config.SidecarRunAsUser,
+			}, pod, m.config.PSACompatible),
 		}
 	} else if vg != nil {
+		sidecarImage := virtualGatewaySidecarImageForPod(m.config, pod)
+		// the image virtual gateway pod is annotated with takes precedence over the preview image
+		if v := pod.ObjectMeta.Annotations[AppMeshGatewaySidecarImageAnnotation]; strings.TrimSpace(v) == "" {
+			sidecarImage = m.sidecarImageForPreview(pod, preview, sidecarImage)
+		}
 		mutators = []PodMutator{newVirtualGatewayEnvoyConfig(virtualGatwayEnvoyConfig{
 			accountID:                  m.accountID,
 			awsRegion:                  m.awsRegion,
@@ -163,22 +314,40 @@ func (m *SidecarInjector) injectAppMeshPatches(ms *appmesh.Mesh, vn *appmesh.Vir
 			sdsUdsPath:                 m.config.SdsUdsPath,
 			logLevel:                   m.config.LogLevel,
 			adminAccessPort:            m.config.EnvoyAdminAcessPort,
-			adminAccessLogFile:         m.config.EnvoyAdminAccessLogFile,
-			sidecarImage:               m.config.SidecarImage,
+			adminAccessLogFile:         resolveAccessLogFile(m.config.EnvoyAdminAccessLogFile),
+			accessLogVolume:            m.config.EnvoyAccessLogVolume,
+			sidecarImage:               sidecarImage,
+			sidecarContainerName:       m.config.SidecarContainerName,
 			readinessProbeInitialDelay: m.config.ReadinessProbeInitialDelay,
 			readinessProbePeriod:       m.config.ReadinessProbePeriod,
 			enableXrayTracing:          m.config.EnableXrayTracing,
 			xrayDaemonPort:             m.config.XrayDaemonPort,
+			caBundle:                   m.config.SidecarCABundle,
+			enablePodMetadataEnv:       m.config.EnablePodMetadataEnv,
 		}, ms, vg),
 			newXrayMutator(xrayMutatorConfig{
-				awsRegion:             m.awsRegion,
-				sidecarCPURequests:    m.config.SidecarCpuRequests,
-				sidecarMemoryRequests: m.config.SidecarMemoryRequests,
-				sidecarCPULimits:      m.config.SidecarCpuLimits,
-				sidecarMemoryLimits:   m.config.SidecarMemoryLimits,
-				xRayImage:             m.config.XRayImage,
-				xRayDaemonPort:        m.config.XrayDaemonPort,
-			}, m.config.EnableXrayTracing),
+				awsRegion:                m.awsRegion,
+				sidecarCPURequests:       m.config.XRayCpuRequests,
+				sidecarMemoryRequests:    m.config.XRayMemoryRequests,
+				sidecarCPULimits:         m.config.XRayCpuLimits,
+				sidecarMemoryLimits:      m.config.XRayMemoryLimits,
+				xRayImage:                m.config.XRayImage,
+				xRayDaemonPort:           m.config.XrayDaemonPort,
+				runAsUser:                m.config.SidecarRunAsUser,
+				terminationMessagePolicy: corev1.TerminationMessagePolicy(m.config.SidecarTerminationMessagePolicy),
+			}, m.config.EnableXrayTracing && m.config.XRayInjectDaemon),
+			newPrometheusScrapeMutator(prometheusScrapeMutatorConfig{
+				adminAccessPort: m.config.EnvoyAdminAcessPort,
+				scrapePath:      m.config.PrometheusScrapePath,
+			}, m.config.EnablePrometheusScrape),
+			newPodDNSConfigMutator(podDNSConfigMutatorConfig{
+				options:  m.config.PodDNSOptions,
+				searches: m.config.PodDNSSearches,
+			}),
+			// restricts containers injected by all of the above
+			newPSACompatibleMutator(psaCompatibleMutatorConfig{
+				runAsUser: m.config.SidecarRunAsUser,
+			}, pod, m.config.PSACompatible),
 		}
 	}
 
@@ -188,7 +357,7 @@ func (m *SidecarInjector) injectAppMeshPatches(ms *appmesh.Mesh, vn *appmesh.Vir
 			return err
 		}
 	}
-	return nil
+	return m.stampInjectionConfigVersion(pod)
 }
 
 type sidecarInjectMode string
@@ -202,6 +371,31 @@ const (
 	sidecarInjectModeUnspecified = "unspecified"
 )
 
+// matchesInjectionLabelSelector checks whether pod carries the labels required by the injection label selector.
+// all pods match when no selector is configured.
+func (m *SidecarInjector) matchesInjectionLabelSelector(pod *corev1.Pod) (bool, error) {
+	selector, err := labels.Parse(m.config.InjectionLabelSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(pod.Labels)), nil
+}
+
+// isNamespaceExcluded checks whether pod is in a namespace that is never injected, such as kube-system.
+func (m *SidecarInjector) isNamespaceExcluded(ctx context.Context, pod *corev1.Pod) bool {
+	// pod namespace might be unset on admission, see determineSidecarInjectMode
+	namespace := pod.Namespace
+	if req := webhook.ContextGetAdmissionRequest(ctx); req != nil && req.Namespace != "" {
+		namespace = req.Namespace
+	}
+	for _, excludedNamespace := range m.config.InjectExcludedNamespaces {
+		if namespace == excludedNamespace {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *SidecarInjector) determineSidecarInjectMode(ctx context.Context, pod *corev1.Pod) (sidecarInjectMode, error) {
 	// The injector webhook uses the namespaceSelector to filter which requests
 	// are intercepted. This makes sure all the requests sent to the injector have
@@ -220,8 +414,8 @@ func (m *SidecarInjector) determineSidecarInjectMode(ctx context.Context, pod *c
 	// see https://github.com/kubernetes/kubernetes/issues/88282 and https://github.com/kubernetes/kubernetes/issues/76680
 	req := webhook.ContextGetAdmissionRequest(ctx)
 	objectNS := &corev1.Namespace{}
-	if err := m.k8sClient.Get(ctx, types.NamespacedName{Name: req.Namespace}, objectNS); err != nil {
-		return sidecarInjectModeUnspecified, err
+	if err := m.getWithRetry(ctx, types.NamespacedName{Name: req.Namespace}, objectNS); err != nil {
+		return sidecarInjectModeUnspecified, errors.Wrapf(err, "failed to get namespace %s", req.Namespace)
 	}
 	if v, ok := objectNS.ObjectMeta.Labels[AppMeshSidecarInjectAnnotation]; ok {
 		namespaceDefaultInjectionMode = v
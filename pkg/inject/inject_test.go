@@ -2,9 +2,16 @@ package inject
 
 import (
 	"context"
+	"fmt"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	mock_mesh "github.com/aws/aws-app-mesh-controller-for-k8s/mocks/aws-app-mesh-controller-for-k8s/pkg/mesh"
+	mock_virtualgateway "github.com/aws/aws-app-mesh-controller-for-k8s/mocks/aws-app-mesh-controller-for-k8s/pkg/virtualgateway"
+	mock_virtualnode "github.com/aws/aws-app-mesh-controller-for-k8s/mocks/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	appmeshwebhook "github.com/aws/aws-app-mesh-controller-for-k8s/webhooks/appmesh"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
@@ -27,6 +34,7 @@ func getConfig(fp func(Config) Config) Config {
 		SidecarMemoryRequests:       "32Mi",
 		SidecarCpuRequests:          "10m",
 		EnableIAMForServiceAccounts: true,
+		XRayInjectDaemon:            true,
 	}
 	if fp != nil {
 		conf = fp(conf)
@@ -172,6 +180,27 @@ func Test_InjectEnvoyContainerVN(t *testing.T) {
 				proxyinit:  true,
 			},
 		},
+		{
+			name: "Inject Envoy container with xray but without xray daemon",
+			conf: getConfig(func(cnf Config) Config {
+				cnf.EnableXrayTracing = true
+				cnf.XrayDaemonPort = 2000
+				cnf.XRayImage = "amazon/aws-xray-daemon"
+				cnf.XRayInjectDaemon = false
+				return cnf
+			}),
+			args: args{
+				ms:  getMesh(),
+				vn:  getVn(nil),
+				pod: getPod(nil),
+			},
+			want: expected{
+				init:       1,
+				containers: 2,
+				xray:       false,
+				proxyinit:  true,
+			},
+		},
 		{
 			name: "AppMesh CNI Enabled",
 			conf: getConfig(nil),
@@ -252,7 +281,7 @@ func Test_InjectEnvoyContainerVN(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			inj := NewSidecarInjector(tt.conf, "000000000000", "us-west-2", nil, nil, nil, nil)
+			inj := NewSidecarInjector(tt.conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
 			pod := tt.args.pod
 			inj.injectAppMeshPatches(tt.args.ms, tt.args.vn, nil, pod)
 			assert.Equal(t, tt.want.init, len(pod.Spec.InitContainers), "Numbers of init containers mismatch")
@@ -279,6 +308,349 @@ func Test_InjectEnvoyContainerVN(t *testing.T) {
 	}
 }
 
+func Test_InjectEnvoyContainerVN_SidecarRunAsUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		conf          Config
+		wantRunAsUser int64
+		wantIgnoreUID string
+		wantIgnoreGID string
+	}{
+		{
+			name:          "default sidecar UID",
+			conf:          getConfig(nil),
+			wantRunAsUser: 1337,
+			wantIgnoreUID: "1337",
+		},
+		{
+			name: "overridden sidecar UID and GID",
+			conf: getConfig(func(cnf Config) Config {
+				cnf.SidecarRunAsUser = 1500
+				cnf.SidecarRunAsGroup = 1600
+				return cnf
+			}),
+			wantRunAsUser: 1500,
+			wantIgnoreUID: "1500",
+			wantIgnoreGID: "1600",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inj := NewSidecarInjector(tt.conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+			pod := getPod(nil)
+			err := inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+			assert.NoError(t, err)
+
+			_, envoyIdx := containsEnvoyContainer(pod, envoyContainerName)
+			assert.NotEqual(t, -1, envoyIdx, "Envoy container not found")
+			envoyContainer := pod.Spec.Containers[envoyIdx]
+			assert.Equal(t, tt.wantRunAsUser, aws.Int64Value(envoyContainer.SecurityContext.RunAsUser))
+
+			ignoreUID, ignoreGID := "", ""
+			for _, v := range pod.Spec.InitContainers {
+				if v.Name != "proxyinit" {
+					continue
+				}
+				for _, env := range v.Env {
+					switch env.Name {
+					case "APPMESH_IGNORE_UID":
+						ignoreUID = env.Value
+					case "APPMESH_IGNORE_GID":
+						ignoreGID = env.Value
+					}
+				}
+			}
+			assert.Equal(t, tt.wantIgnoreUID, ignoreUID)
+			assert.Equal(t, fmt.Sprintf("%d", aws.Int64Value(envoyContainer.SecurityContext.RunAsUser)), ignoreUID)
+			assert.Equal(t, tt.wantIgnoreGID, ignoreGID)
+		})
+	}
+}
+
+func Test_InjectEnvoyContainerVN_VirtualNodeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		vnConfig virtualnode.Config
+		want     string
+	}{
+		{
+			name:     "defaulted awsName",
+			vnConfig: virtualnode.Config{},
+			want:     "mesh/my-mesh/virtualNode/my-vn_awesome-ns",
+		},
+		{
+			name: "defaulted awsName with resource name prefix and suffix",
+			vnConfig: virtualnode.Config{
+				ResourceNamePrefix: "dev-",
+				ResourceNameSuffix: "_prod",
+			},
+			want: "mesh/my-mesh/virtualNode/dev-my-vn_awesome-ns_prod",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			meshMembershipDesignator := mock_mesh.NewMockMembershipDesignator(ctrl)
+			meshMembershipDesignator.EXPECT().Designate(gomock.Any(), gomock.Any()).Return(getMesh(), nil)
+			vn := getVn(nil)
+			vn.Spec.MeshRef = nil
+			// awsName is defaulted by the virtualNode mutator webhook when the virtualNode is created
+			mutatedVN, err := appmeshwebhook.NewVirtualNodeMutator(meshMembershipDesignator, tt.vnConfig).MutateCreate(context.Background(), vn)
+			assert.NoError(t, err)
+
+			inj := NewSidecarInjector(getConfig(nil), "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+			pod := getPod(nil)
+			err = inj.injectAppMeshPatches(getMesh(), mutatedVN.(*appmesh.VirtualNode), nil, pod)
+			assert.NoError(t, err)
+
+			_, envoyIdx := containsEnvoyContainer(pod, envoyContainerName)
+			assert.NotEqual(t, -1, envoyIdx, "Envoy container not found")
+			got := ""
+			for _, env := range pod.Spec.Containers[envoyIdx].Env {
+				if env.Name == "APPMESH_VIRTUAL_NODE_NAME" {
+					got = env.Value
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Inject_InjectFailOnMissingVirtualNode(t *testing.T) {
+	vnWithoutMeshRef := &appmesh.VirtualNode{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "my-vn"}}
+	vgWithoutMeshRef := &appmesh.VirtualGateway{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "my-vg"}}
+	tests := []struct {
+		name                           string
+		injectFailOnMissingVirtualNode bool
+		vn                             *appmesh.VirtualNode
+		vg                             *appmesh.VirtualGateway
+		wantErr                        error
+	}{
+		{
+			name:                           "lenient mode without matching virtualNode",
+			injectFailOnMissingVirtualNode: false,
+		},
+		{
+			name:                           "lenient mode with virtualNode without meshRef",
+			injectFailOnMissingVirtualNode: false,
+			vn:                             vnWithoutMeshRef,
+		},
+		{
+			name:                           "strict mode without matching virtualNode",
+			injectFailOnMissingVirtualNode: true,
+			wantErr:                        errors.New("failed to resolve virtualNode of pod awesome-ns/foo: no virtualNode matches it, denied as required by inject-fail-on-missing-virtual-node"),
+		},
+		{
+			name:                           "strict mode with virtualNode without meshRef",
+			injectFailOnMissingVirtualNode: true,
+			vn:                             vnWithoutMeshRef,
+			wantErr:                        errors.New("failed to resolve virtualNode of pod awesome-ns/foo: virtualNode my-vn has no meshRef, denied as required by inject-fail-on-missing-virtual-node"),
+		},
+		{
+			name:                           "strict mode with virtualGateway pod",
+			injectFailOnMissingVirtualNode: true,
+			vg:                             vgWithoutMeshRef,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			err := k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "awesome-ns"}})
+			assert.NoError(t, err)
+			vnMembershipDesignator := mock_virtualnode.NewMockMembershipDesignator(ctrl)
+			vnMembershipDesignator.EXPECT().Designate(gomock.Any(), gomock.Any()).Return(tt.vn, nil)
+			vgMembershipDesignator := mock_virtualgateway.NewMockMembershipDesignator(ctrl)
+			vgMembershipDesignator.EXPECT().DesignateForPod(gomock.Any(), gomock.Any()).Return(tt.vg, nil)
+			conf := getConfig(func(cnf Config) Config {
+				cnf.InjectFailOnMissingVirtualNode = tt.injectFailOnMissingVirtualNode
+				return cnf
+			})
+			inj := NewSidecarInjector(conf, "000000000000", "us-west-2", k8sClient, k8sClient, nil, vnMembershipDesignator, vgMembershipDesignator, nil)
+			ctx = webhook.ContextWithAdmissionRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{Namespace: "awesome-ns"},
+			})
+			pod := getPod(nil)
+			pod.Namespace = ""
+			wantPod := pod.DeepCopy()
+			err = inj.Inject(ctx, pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, wantPod, pod)
+		})
+	}
+}
+
+func Test_InjectEnvoyContainerVN_InitialFetchTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantEnv     bool
+		wantValue   string
+		wantErr     error
+	}{
+		{
+			name:    "annotation not specified",
+			wantEnv: false,
+		},
+		{
+			name: "annotation specified",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/initialFetchTimeout": "45s",
+			},
+			wantEnv:   true,
+			wantValue: "45",
+		},
+		{
+			name: "annotation is zero",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/initialFetchTimeout": "0s",
+			},
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/initialFetchTimeout: 0s, must be a positive duration of at least 1s"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inj := NewSidecarInjector(getConfig(nil), "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+			pod := getPod(tt.annotations)
+			err := inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+
+			_, envoyIdx := containsEnvoyContainer(pod, envoyContainerName)
+			assert.NotEqual(t, -1, envoyIdx, "Envoy container not found")
+			found, value := false, ""
+			for _, env := range pod.Spec.Containers[envoyIdx].Env {
+				if env.Name == "ENVOY_INITIAL_FETCH_TIMEOUT" {
+					found, value = true, env.Value
+				}
+			}
+			assert.Equal(t, tt.wantEnv, found)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
+
+func Test_InjectEnvoyContainerVN_AccessLogStdout(t *testing.T) {
+	conf := getConfig(func(cnf Config) Config {
+		cnf.EnvoyAdminAccessLogFile = "stdout"
+		cnf.EnvoyAccessLogVolume = true
+		return cnf
+	})
+	inj := NewSidecarInjector(conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+	pod := getPod(nil)
+	err := inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+	assert.NoError(t, err)
+
+	_, envoyIdx := containsEnvoyContainer(pod, envoyContainerName)
+	assert.NotEqual(t, -1, envoyIdx, "Envoy container not found")
+	accessLogFile := ""
+	for _, env := range pod.Spec.Containers[envoyIdx].Env {
+		if env.Name == "ENVOY_ADMIN_ACCESS_LOG_FILE" {
+			accessLogFile = env.Value
+		}
+	}
+	assert.Equal(t, "/dev/stdout", accessLogFile)
+	for _, volume := range pod.Spec.Volumes {
+		assert.NotEqual(t, accessLogVolumeName, volume.Name, "access log volume shouldn't be added for stdout")
+	}
+}
+
+func Test_InjectEnvoyContainerVN_SidecarContainerName(t *testing.T) {
+	conf := getConfig(func(cnf Config) Config {
+		cnf.SidecarContainerName = "appmesh-envoy"
+		return cnf
+	})
+	inj := NewSidecarInjector(conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+	pod := getPod(nil)
+	err := inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+	assert.NoError(t, err)
+
+	ok, _ := containsEnvoyContainer(pod, envoyContainerName)
+	assert.False(t, ok, "Envoy container with default name found")
+	_, envoyIdx := containsEnvoyContainer(pod, "appmesh-envoy")
+	assert.NotEqual(t, -1, envoyIdx, "Envoy container not found")
+	envoyContainer := pod.Spec.Containers[envoyIdx]
+	assert.NotNil(t, envoyContainer.ReadinessProbe)
+
+	ignoreUID := ""
+	for _, v := range pod.Spec.InitContainers {
+		if v.Name != "proxyinit" {
+			continue
+		}
+		for _, env := range v.Env {
+			if env.Name == "APPMESH_IGNORE_UID" {
+				ignoreUID = env.Value
+			}
+		}
+	}
+	assert.Equal(t, fmt.Sprintf("%d", aws.Int64Value(envoyContainer.SecurityContext.RunAsUser)), ignoreUID)
+
+	// injecting an already injected pod must not add another envoy container
+	containerCount := len(pod.Spec.Containers)
+	err = inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+	assert.NoError(t, err)
+	assert.Equal(t, containerCount, len(pod.Spec.Containers))
+}
+
+func Test_InjectEnvoyContainerVN_ReadinessProbeHost(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantCommand []string
+		wantErr     error
+	}{
+		{
+			name:        "default host",
+			wantCommand: []string{"sh", "-c", "curl -s http://localhost:9902/server_info | grep state | grep -q LIVE"},
+		},
+		{
+			name: "loopback IP host",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/readinessProbeHost": "127.0.0.1",
+			},
+			wantCommand: []string{"sh", "-c", "curl -s http://127.0.0.1:9902/server_info | grep state | grep -q LIVE"},
+		},
+		{
+			name: "unsupported host",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/readinessProbeHost": "0.0.0.0",
+			},
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/readinessProbeHost: 0.0.0.0, must be localhost or 127.0.0.1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := getConfig(func(cnf Config) Config {
+				cnf.EnvoyAdminAcessPort = 9902
+				return cnf
+			})
+			inj := NewSidecarInjector(conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+			pod := getPod(tt.annotations)
+			err := inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			_, envoyIdx := containsEnvoyContainer(pod, envoyContainerName)
+			assert.NotEqual(t, -1, envoyIdx, "Envoy container not found")
+			assert.Equal(t, tt.wantCommand, pod.Spec.Containers[envoyIdx].ReadinessProbe.Exec.Command)
+		})
+	}
+}
+
 func Test_InjectEnvoyContainerVG(t *testing.T) {
 	type args struct {
 		ms  *appmesh.Mesh
@@ -354,7 +726,7 @@ func Test_InjectEnvoyContainerVG(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			inj := NewSidecarInjector(tt.conf, "000000000000", "us-west-2", nil, nil, nil, nil)
+			inj := NewSidecarInjector(tt.conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
 			pod := tt.args.pod
 			err := inj.injectAppMeshPatches(tt.args.ms, nil, tt.args.vg, pod)
 			if tt.wantErr != nil {
@@ -375,6 +747,318 @@ func Test_InjectEnvoyContainerVG(t *testing.T) {
 	}
 }
 
+func Test_InjectEnvoyContainer_GatewaySidecarImage(t *testing.T) {
+	gatewayPod := func(annotations map[string]string) *corev1.Pod {
+		pod := getPod(annotations)
+		pod.Spec.Containers = []corev1.Container{{
+			Name:  "envoy",
+			Image: "envoy:custom",
+		}}
+		return pod
+	}
+	tests := []struct {
+		name      string
+		conf      Config
+		vn        *appmesh.VirtualNode
+		vg        *appmesh.VirtualGateway
+		pod       *corev1.Pod
+		wantImage string
+	}{
+		{
+			name: "virtualNode pod uses sidecar image",
+			conf: getConfig(func(cnf Config) Config {
+				cnf.GatewaySidecarImage = "envoy:gateway"
+				return cnf
+			}),
+			vn:        getVn(nil),
+			pod:       getPod(nil),
+			wantImage: "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-envoy:v1.17.2.0-prod",
+		},
+		{
+			name: "virtualGateway pod uses gateway sidecar image",
+			conf: getConfig(func(cnf Config) Config {
+				cnf.GatewaySidecarImage = "envoy:gateway"
+				return cnf
+			}),
+			vg:        getVg(nil),
+			pod:       gatewayPod(nil),
+			wantImage: "envoy:gateway",
+		},
+		{
+			name:      "virtualGateway pod falls back to sidecar image",
+			conf:      getConfig(nil),
+			vg:        getVg(nil),
+			pod:       gatewayPod(nil),
+			wantImage: "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-envoy:v1.17.2.0-prod",
+		},
+		{
+			name: "virtualGateway pod uses image from annotation",
+			conf: getConfig(func(cnf Config) Config {
+				cnf.GatewaySidecarImage = "envoy:gateway"
+				return cnf
+			}),
+			vg: getVg(nil),
+			pod: gatewayPod(map[string]string{
+				"appmesh.k8s.aws/virtualGatewaySidecarImage": "envoy:debug",
+			}),
+			wantImage: "envoy:debug",
+		},
+		{
+			name: "virtualGateway pod skipping image override keeps its image",
+			conf: getConfig(func(cnf Config) Config {
+				cnf.GatewaySidecarImage = "envoy:gateway"
+				return cnf
+			}),
+			vg: getVg(nil),
+			pod: gatewayPod(map[string]string{
+				"appmesh.k8s.aws/virtualGatewaySkipImageOverride": "enabled",
+			}),
+			wantImage: "envoy:custom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inj := NewSidecarInjector(tt.conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+			err := inj.injectAppMeshPatches(getMesh(), tt.vn, tt.vg, tt.pod)
+			assert.NoError(t, err)
+			_, envoyIdx := containsEnvoyContainer(tt.pod, envoyContainerName)
+			assert.NotEqual(t, -1, envoyIdx, "Envoy container not found")
+			assert.Equal(t, tt.wantImage, tt.pod.Spec.Containers[envoyIdx].Image)
+		})
+	}
+}
+
+func Test_InjectEnvoyContainer_SidecarPreviewImage(t *testing.T) {
+	defaultImage := "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-envoy:v1.17.2.0-prod"
+	withPreviewImage := func(cnf Config) Config {
+		cnf.SidecarPreviewImage = "envoy:preview"
+		return cnf
+	}
+	gatewayPod := func(annotations map[string]string) *corev1.Pod {
+		pod := getPod(annotations)
+		pod.Spec.Containers = []corev1.Container{{
+			Name:  "envoy",
+			Image: "envoy:custom",
+		}}
+		return pod
+	}
+	tests := []struct {
+		name      string
+		conf      Config
+		vn        *appmesh.VirtualNode
+		vg        *appmesh.VirtualGateway
+		pod       *corev1.Pod
+		wantImage string
+		wantErr   error
+	}{
+		{
+			name:      "virtualNode pod without preview uses sidecar image",
+			conf:      getConfig(withPreviewImage),
+			vn:        getVn(nil),
+			pod:       getPod(nil),
+			wantImage: defaultImage,
+		},
+		{
+			name: "virtualNode pod with preview flag uses preview image",
+			conf: getConfig(func(cnf Config) Config {
+				cnf = withPreviewImage(cnf)
+				cnf.Preview = true
+				return cnf
+			}),
+			vn:        getVn(nil),
+			pod:       getPod(nil),
+			wantImage: "envoy:preview",
+		},
+		{
+			name: "virtualNode pod with preview annotation uses preview image",
+			conf: getConfig(withPreviewImage),
+			vn:   getVn(nil),
+			pod: getPod(map[string]string{
+				AppMeshPreviewAnnotation: "enabled",
+			}),
+			wantImage: "envoy:preview",
+		},
+		{
+			name: "virtualNode pod with preview falls back to sidecar image",
+			conf: getConfig(nil),
+			vn:   getVn(nil),
+			pod: getPod(map[string]string{
+				AppMeshPreviewAnnotation: "1",
+			}),
+			wantImage: defaultImage,
+		},
+		{
+			name: "virtualNode pod with malformed preview annotation is rejected",
+			conf: getConfig(withPreviewImage),
+			vn:   getVn(nil),
+			pod: getPod(map[string]string{
+				AppMeshPreviewAnnotation: "yes",
+			}),
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/preview: yes, expected enabled/1 or disabled/0"),
+		},
+		{
+			name: "virtualGateway pod with preview uses preview image",
+			conf: getConfig(func(cnf Config) Config {
+				cnf = withPreviewImage(cnf)
+				cnf.GatewaySidecarImage = "envoy:gateway"
+				return cnf
+			}),
+			vg: getVg(nil),
+			pod: gatewayPod(map[string]string{
+				AppMeshPreviewAnnotation: "enabled",
+			}),
+			wantImage: "envoy:preview",
+		},
+		{
+			name: "virtualGateway pod with preview keeps image from annotation",
+			conf: getConfig(withPreviewImage),
+			vg:   getVg(nil),
+			pod: gatewayPod(map[string]string{
+				AppMeshPreviewAnnotation:                     "enabled",
+				"appmesh.k8s.aws/virtualGatewaySidecarImage": "envoy:debug",
+			}),
+			wantImage: "envoy:debug",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inj := NewSidecarInjector(tt.conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+			err := inj.injectAppMeshPatches(getMesh(), tt.vn, tt.vg, tt.pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			_, envoyIdx := containsEnvoyContainer(tt.pod, envoyContainerName)
+			assert.NotEqual(t, -1, envoyIdx, "Envoy container not found")
+			assert.Equal(t, tt.wantImage, tt.pod.Spec.Containers[envoyIdx].Image)
+		})
+	}
+}
+
+func Test_InjectSidecars_TerminationMessagePolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     string
+		wantPolicy corev1.TerminationMessagePolicy
+	}{
+		{
+			name:       "policy isn't configured",
+			policy:     "",
+			wantPolicy: "",
+		},
+		{
+			name:       "policy is FallbackToLogsOnError",
+			policy:     "FallbackToLogsOnError",
+			wantPolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := getConfig(func(cnf Config) Config {
+				cnf.EnableXrayTracing = true
+				cnf.XrayDaemonPort = 2000
+				cnf.XRayImage = "amazon/aws-xray-daemon"
+				cnf.SidecarTerminationMessagePolicy = tt.policy
+				return cnf
+			})
+			inj := NewSidecarInjector(conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+			pod := getPod(nil)
+			err := inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+			assert.NoError(t, err)
+
+			gotPolicies := make(map[string]corev1.TerminationMessagePolicy)
+			for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+				gotPolicies[container.Name] = container.TerminationMessagePolicy
+			}
+			for _, name := range []string{"envoy", "proxyinit", "xray-daemon"} {
+				gotPolicy, ok := gotPolicies[name]
+				assert.True(t, ok, "%s container not found", name)
+				assert.Equal(t, tt.wantPolicy, gotPolicy, "terminationMessagePolicy of %s container mismatch", name)
+			}
+		})
+	}
+}
+
+func Test_InjectPrometheusScrapeAnnotations(t *testing.T) {
+	tests := []struct {
+		name            string
+		adminAccessPort int32
+		vn              *appmesh.VirtualNode
+		vg              *appmesh.VirtualGateway
+		wantPort        string
+	}{
+		{
+			name:            "virtualNode pod scraped on default admin port",
+			adminAccessPort: 9901,
+			vn:              getVn(nil),
+			wantPort:        "9901",
+		},
+		{
+			name:            "virtualNode pod scraped on custom admin port",
+			adminAccessPort: 9902,
+			vn:              getVn(nil),
+			wantPort:        "9902",
+		},
+		{
+			name:            "virtualGateway pod scraped on custom admin port",
+			adminAccessPort: 9902,
+			vg:              getVg(nil),
+			wantPort:        "9902",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := getConfig(func(cnf Config) Config {
+				cnf.EnvoyAdminAcessPort = tt.adminAccessPort
+				cnf.EnablePrometheusScrape = true
+				cnf.PrometheusScrapePath = "/stats/prometheus"
+				return cnf
+			})
+			inj := NewSidecarInjector(conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+			pod := getPod(nil)
+			if tt.vg != nil {
+				pod.Spec.Containers = []corev1.Container{{Name: "envoy", Image: "envoy"}}
+			}
+			err := inj.injectAppMeshPatches(getMesh(), tt.vn, tt.vg, pod)
+			assert.NoError(t, err)
+			assert.Equal(t, "true", pod.Annotations["prometheus.io/scrape"])
+			assert.Equal(t, tt.wantPort, pod.Annotations["prometheus.io/port"])
+			assert.Equal(t, "/stats/prometheus", pod.Annotations["prometheus.io/path"])
+		})
+	}
+}
+
+func Test_InjectXRayDaemon_Resources(t *testing.T) {
+	conf := getConfig(func(cnf Config) Config {
+		cnf.EnableXrayTracing = true
+		cnf.XrayDaemonPort = 2000
+		cnf.XRayImage = "amazon/aws-xray-daemon"
+		cnf.XRayCpuRequests = "10m"
+		cnf.XRayMemoryRequests = "32Mi"
+		cnf.XRayCpuLimits = "100m"
+		cnf.XRayMemoryLimits = "128Mi"
+		return cnf
+	})
+	inj := NewSidecarInjector(conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+	pod := getPod(nil)
+	err := inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+	assert.NoError(t, err)
+
+	var xrayContainer *corev1.Container
+	for idx := range pod.Spec.Containers {
+		if pod.Spec.Containers[idx].Name == "xray-daemon" {
+			xrayContainer = &pod.Spec.Containers[idx]
+		}
+	}
+	if assert.NotNil(t, xrayContainer, "X-ray container not found") {
+		assert.Equal(t, "10m", xrayContainer.Resources.Requests.Cpu().String())
+		assert.Equal(t, "32Mi", xrayContainer.Resources.Requests.Memory().String())
+		assert.Equal(t, "100m", xrayContainer.Resources.Limits.Cpu().String())
+		assert.Equal(t, "128Mi", xrayContainer.Resources.Limits.Memory().String())
+	}
+}
+
 func TestSidecarInjector_determineSidecarInjectMode(t *testing.T) {
 	nsEnabledSidecarInject := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -594,3 +1278,261 @@ func TestSidecarInjector_determineSidecarInjectMode(t *testing.T) {
 		})
 	}
 }
+
+func TestSidecarInjector_validateCABundleSource(t *testing.T) {
+	caConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "corp-ca",
+		},
+		Data: map[string]string{
+			"ca.crt": "-----BEGIN CERTIFICATE-----",
+		},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "corp-ca",
+		},
+		Data: map[string][]byte{
+			"ca.pem": []byte("-----BEGIN CERTIFICATE-----"),
+		},
+	}
+
+	type env struct {
+		configMaps []*corev1.ConfigMap
+		secrets    []*corev1.Secret
+	}
+	tests := []struct {
+		name     string
+		env      env
+		caBundle string
+		wantErr  error
+	}{
+		{
+			name:     "no CA bundle configured",
+			caBundle: "",
+		},
+		{
+			name: "configMap with key exists",
+			env: env{
+				configMaps: []*corev1.ConfigMap{caConfigMap},
+			},
+			caBundle: "configmap/corp-ca/ca.crt",
+		},
+		{
+			name: "secret with key exists",
+			env: env{
+				secrets: []*corev1.Secret{caSecret},
+			},
+			caBundle: "secret/corp-ca/ca.pem",
+		},
+		{
+			name:     "configMap doesn't exist",
+			caBundle: "configmap/corp-ca/ca.crt",
+			wantErr:  errors.New("failed to get CA bundle configMap corp-ca: configmaps \"corp-ca\" not found"),
+		},
+		{
+			name: "configMap without key",
+			env: env{
+				configMaps: []*corev1.ConfigMap{caConfigMap},
+			},
+			caBundle: "configmap/corp-ca/ca.pem",
+			wantErr:  errors.New("CA bundle configmap corp-ca doesn't contain key ca.pem"),
+		},
+		{
+			name:     "secret doesn't exist",
+			caBundle: "secret/corp-ca/ca.pem",
+			wantErr:  errors.New("failed to get CA bundle secret corp-ca: secrets \"corp-ca\" not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			m := &SidecarInjector{
+				config:    Config{SidecarCABundle: tt.caBundle},
+				apiReader: k8sClient,
+			}
+			for _, cm := range tt.env.configMaps {
+				err := k8sClient.Create(ctx, cm.DeepCopy())
+				assert.NoError(t, err)
+			}
+			for _, secret := range tt.env.secrets {
+				err := k8sClient.Create(ctx, secret.DeepCopy())
+				assert.NoError(t, err)
+			}
+			ctx = webhook.ContextWithAdmissionRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{Namespace: "awesome-ns"},
+			})
+			err := m.validateCABundleSource(ctx)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_InjectEnvoyContainerVN_ProxyInitContainerName(t *testing.T) {
+	conf := getConfig(func(cnf Config) Config {
+		cnf.ProxyInitContainerName = "appmesh-proxyinit"
+		return cnf
+	})
+	inj := NewSidecarInjector(conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+	pod := getPod(nil)
+	err := inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+	assert.NoError(t, err)
+	assert.False(t, containsProxyInitContainer(pod, proxyInitContainerName), "proxyinit container with default name found")
+	assert.True(t, containsProxyInitContainer(pod, "appmesh-proxyinit"), "proxyinit container not found")
+	assert.Equal(t, 1, len(pod.Spec.InitContainers))
+
+	// re-injection must detect the renamed proxyinit container and not add another one
+	err = inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pod.Spec.InitContainers))
+	assert.Equal(t, "appmesh-proxyinit", pod.Spec.InitContainers[0].Name)
+}
+
+func TestSidecarInjector_matchesInjectionLabelSelector(t *testing.T) {
+	tests := []struct {
+		name      string
+		selector  string
+		podLabels map[string]string
+		want      bool
+		wantErr   error
+	}{
+		{
+			name:      "no selector configured",
+			selector:  "",
+			podLabels: nil,
+			want:      true,
+		},
+		{
+			name:     "labeled pod matches selector",
+			selector: "appmesh.k8s.aws/mesh=enabled",
+			podLabels: map[string]string{
+				"app":                  "my-app",
+				"appmesh.k8s.aws/mesh": "enabled",
+			},
+			want: true,
+		},
+		{
+			name:     "pod with different label value doesn't match selector",
+			selector: "appmesh.k8s.aws/mesh=enabled",
+			podLabels: map[string]string{
+				"appmesh.k8s.aws/mesh": "disabled",
+			},
+			want: false,
+		},
+		{
+			name:     "unlabeled pod doesn't match selector",
+			selector: "appmesh.k8s.aws/mesh=enabled",
+			podLabels: map[string]string{
+				"app": "my-app",
+			},
+			want: false,
+		},
+		{
+			name:     "malformed selector",
+			selector: "=enabled",
+			wantErr:  errors.New("found '=', expected: identifier"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &SidecarInjector{
+				config: Config{InjectionLabelSelector: tt.selector},
+			}
+			pod := getPod(nil)
+			pod.Labels = tt.podLabels
+			got, err := m.matchesInjectionLabelSelector(pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_Inject_InjectionLabelSelector_UnlabeledPod(t *testing.T) {
+	conf := getConfig(func(cnf Config) Config {
+		cnf.InjectionLabelSelector = "appmesh.k8s.aws/mesh=enabled"
+		return cnf
+	})
+	// unlabeled pods must be left untouched without looking up namespace, VirtualNode or VirtualGateway.
+	inj := NewSidecarInjector(conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+	pod := getPod(map[string]string{
+		"appmesh.k8s.aws/sidecarInjectorWebhook": "enabled",
+	})
+	wantPod := pod.DeepCopy()
+	err := inj.Inject(context.Background(), pod)
+	assert.NoError(t, err)
+	assert.Equal(t, wantPod, pod)
+}
+
+func TestSidecarInjector_isNamespaceExcluded(t *testing.T) {
+	excludedNamespaces := []string{"kube-system", "kube-node-lease"}
+	tests := []struct {
+		name         string
+		podNamespace string
+		reqNamespace string
+		want         bool
+	}{
+		{
+			name:         "pod in kube-system",
+			podNamespace: "kube-system",
+			want:         true,
+		},
+		{
+			name:         "pod in kube-node-lease from admission request",
+			reqNamespace: "kube-node-lease",
+			want:         true,
+		},
+		{
+			name:         "pod in non-excluded namespace",
+			podNamespace: "default",
+			reqNamespace: "default",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &SidecarInjector{
+				config: Config{InjectExcludedNamespaces: excludedNamespaces},
+			}
+			pod := getPod(nil)
+			pod.Namespace = tt.podNamespace
+			ctx := webhook.ContextWithAdmissionRequest(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{Namespace: tt.reqNamespace},
+			})
+			got := m.isNamespaceExcluded(ctx, pod)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Inject_InjectExcludedNamespaces(t *testing.T) {
+	conf := getConfig(func(cnf Config) Config {
+		cnf.InjectExcludedNamespaces = []string{"kube-system", "kube-node-lease"}
+		return cnf
+	})
+	// pods in excluded namespaces must be left untouched without looking up namespace, VirtualNode or VirtualGateway,
+	// even if they're selected by the injection label selector and request injection.
+	conf.InjectionLabelSelector = "appmesh.k8s.aws/mesh=enabled"
+	inj := NewSidecarInjector(conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+	pod := getPod(map[string]string{
+		"appmesh.k8s.aws/sidecarInjectorWebhook": "enabled",
+	})
+	pod.Namespace = "kube-system"
+	pod.Labels = map[string]string{"appmesh.k8s.aws/mesh": "enabled"}
+	wantPod := pod.DeepCopy()
+	err := inj.Inject(context.Background(), pod)
+	assert.NoError(t, err)
+	assert.Equal(t, wantPod, pod)
+}
@@ -0,0 +1,38 @@
+package inject
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// injectionConfigVersionLength is the number of hex characters kept from the config hash.
+const injectionConfigVersionLength = 10
+
+// injectionConfigVersion returns a short hash of the injector config.
+// It changes whenever the config used to inject pods does, e.g. after the sidecar image is bumped.
+func injectionConfigVersion(cfg Config) (string, error) {
+	cfgJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal injector config")
+	}
+	sum := sha256.Sum256(cfgJSON)
+	return hex.EncodeToString(sum[:])[:injectionConfigVersionLength], nil
+}
+
+// stampInjectionConfigVersion records the version of the config used to inject pod, so tooling can detect stale sidecars.
+// any existing value is overwritten, e.g. one copied from the pod template of an earlier injected pod.
+func (m *SidecarInjector) stampInjectionConfigVersion(pod *corev1.Pod) error {
+	version, err := injectionConfigVersion(m.config)
+	if err != nil {
+		return err
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[AppMeshInjectionConfigVersionAnnotation] = version
+	return nil
+}
@@ -0,0 +1,74 @@
+package inject
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_injectionConfigVersion(t *testing.T) {
+	version, err := injectionConfigVersion(getConfig(nil))
+	assert.NoError(t, err)
+	assert.Len(t, version, injectionConfigVersionLength)
+
+	sameVersion, err := injectionConfigVersion(getConfig(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, version, sameVersion, "same config must have the same version")
+
+	bumpedVersion, err := injectionConfigVersion(getConfig(func(cnf Config) Config {
+		cnf.SidecarImage = "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-envoy:v1.18.3.0-prod"
+		return cnf
+	}))
+	assert.NoError(t, err)
+	assert.NotEqual(t, version, bumpedVersion, "bumping the sidecar image must change the version")
+}
+
+func Test_InjectEnvoyContainerVN_InjectionConfigVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		conf        Config
+		annotations map[string]string
+	}{
+		{
+			name: "pod without injectionConfigVersion",
+			conf: getConfig(nil),
+		},
+		{
+			name: "pod with stale injectionConfigVersion copied from its template",
+			conf: getConfig(nil),
+			annotations: map[string]string{
+				"appmesh.k8s.aws/injectionConfigVersion": "0123456789",
+			},
+		},
+		{
+			name: "pod injected with bumped sidecar image",
+			conf: getConfig(func(cnf Config) Config {
+				cnf.SidecarImage = "840364872350.dkr.ecr.us-west-2.amazonaws.com/aws-appmesh-envoy:v1.18.3.0-prod"
+				return cnf
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inj := NewSidecarInjector(tt.conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+			pod := getPod(tt.annotations)
+			err := inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+			assert.NoError(t, err)
+
+			want, err := injectionConfigVersion(tt.conf)
+			assert.NoError(t, err)
+			assert.Equal(t, want, pod.Annotations["appmesh.k8s.aws/injectionConfigVersion"])
+		})
+	}
+}
+
+func Test_InjectEnvoyContainerVG_InjectionConfigVersion(t *testing.T) {
+	conf := getConfig(nil)
+	inj := NewSidecarInjector(conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+	pod := getPod(nil)
+	err := inj.injectAppMeshPatches(getMesh(), nil, getVg(nil), pod)
+	assert.NoError(t, err)
+
+	want, err := injectionConfigVersion(conf)
+	assert.NoError(t, err)
+	assert.Equal(t, want, pod.Annotations["appmesh.k8s.aws/injectionConfigVersion"])
+}
@@ -2,7 +2,9 @@ package inject
 
 import (
 	"encoding/json"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const jaegerEnvoyConfigTemplate = `
@@ -74,6 +76,8 @@ type JaegerInitContainerTemplateVariables struct {
 type jaegerMutatorConfig struct {
 	jaegerAddress string
 	jaegerPort    string
+	// size limit of the tracing config volume, no limit when empty
+	volumeSizeLimit string
 }
 
 func newJaegerMutator(mutatorConfig jaegerMutatorConfig, enabled bool) *jaegerMutator {
@@ -108,14 +112,31 @@ func (m *jaegerMutator) mutate(pod *corev1.Pod) error {
 	if err != nil {
 		return err
 	}
+	emptyDir, err := m.buildTracingConfigVolumeSource()
+	if err != nil {
+		return err
+	}
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, container)
 	volume := corev1.Volume{Name: envoyTracingConfigVolumeName, VolumeSource: corev1.VolumeSource{
-		EmptyDir: &corev1.EmptyDirVolumeSource{},
+		EmptyDir: emptyDir,
 	}}
 	pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
 	return nil
 }
 
+func (m *jaegerMutator) buildTracingConfigVolumeSource() (*corev1.EmptyDirVolumeSource, error) {
+	emptyDir := &corev1.EmptyDirVolumeSource{}
+	if m.mutatorConfig.volumeSizeLimit == "" {
+		return emptyDir, nil
+	}
+	sizeLimit, err := resource.ParseQuantity(m.mutatorConfig.volumeSizeLimit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid tracing config volume size limit %s", m.mutatorConfig.volumeSizeLimit)
+	}
+	emptyDir.SizeLimit = &sizeLimit
+	return emptyDir, nil
+}
+
 func (m *jaegerMutator) buildEnvoyConfigTemplateVariables() JaegerEnvoyConfigTemplateVariables {
 	return JaegerEnvoyConfigTemplateVariables{
 		JaegerAddress: m.mutatorConfig.jaegerAddress,
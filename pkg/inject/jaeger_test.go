@@ -1,6 +1,7 @@
 package inject
 
 import (
+	"errors"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -13,6 +14,7 @@ func Test_jaegerMutator_mutate(t *testing.T) {
 	cpuRequests, _ := resource.ParseQuantity("10m")
 	memoryLimits, _ := resource.ParseQuantity("64Mi")
 	memoryRequests, _ := resource.ParseQuantity("32Mi")
+	volumeSizeLimit, _ := resource.ParseQuantity("10Mi")
 	type fields struct {
 		mutatorConfig jaegerMutatorConfig
 		enabled       bool
@@ -164,6 +166,109 @@ cat /tmp/envoy/envoyconf.yaml
 				},
 			},
 		},
+		{
+			name: "inject sidecar and volume with size limit",
+			fields: fields{
+				mutatorConfig: jaegerMutatorConfig{
+					jaegerAddress:   "127.0.0.1",
+					jaegerPort:      "8080",
+					volumeSizeLimit: "10Mi",
+				},
+				enabled: true,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{},
+				},
+			},
+			wantPod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{
+							Name:            "inject-jaeger-config",
+							Image:           "busybox",
+							ImagePullPolicy: "IfNotPresent",
+							Command: []string{
+								"sh",
+								"-c",
+								`cat <<EOF >> /tmp/envoy/envoyconf.yaml
+tracing:
+ http:
+  name: envoy.tracers.zipkin
+  typed_config:
+   "@type": type.googleapis.com/envoy.config.trace.v3.ZipkinConfig
+   collector_cluster: jaeger
+   collector_endpoint: "/api/v2/spans"
+   collector_endpoint_version: HTTP_JSON
+   shared_span_context: false
+static_resources:
+  clusters:
+  - name: jaeger
+    connect_timeout: 1s
+    type: STRICT_DNS
+    lb_policy: ROUND_ROBIN
+    load_assignment:
+      cluster_name: jaeger
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+           address:
+            socket_address:
+             address: 127.0.0.1
+             port_value: 8080
+EOF
+
+cat /tmp/envoy/envoyconf.yaml
+`,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      envoyTracingConfigVolumeName,
+									MountPath: "/tmp/envoy",
+								},
+							},
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									"cpu":    cpuLimits,
+									"memory": memoryLimits,
+								},
+								Requests: corev1.ResourceList{
+									"cpu":    cpuRequests,
+									"memory": memoryRequests,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: envoyTracingConfigVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{
+									SizeLimit: &volumeSizeLimit,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "error on malformed volume size limit",
+			fields: fields{
+				mutatorConfig: jaegerMutatorConfig{
+					jaegerAddress:   "127.0.0.1",
+					jaegerPort:      "8080",
+					volumeSizeLimit: "10Mx",
+				},
+				enabled: true,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{},
+				},
+			},
+			wantErr: errors.New("invalid tracing config volume size limit 10Mx: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
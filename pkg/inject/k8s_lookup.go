@@ -0,0 +1,49 @@
+package inject
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// k8sLookupRetryInterval is the initial interval between retries of a failed lookup, doubled after each retry.
+const k8sLookupRetryInterval = 100 * time.Millisecond
+
+// getWithRetry gets the object with key, retrying transient errors since a failed lookup fails the admission of pod.
+// the error is returned as a webhook.RetriableError when all attempts failed due to transient errors.
+func (m *SidecarInjector) getWithRetry(ctx context.Context, key types.NamespacedName, obj runtime.Object) error {
+	return m.getFromReaderWithRetry(ctx, m.k8sClient, key, obj)
+}
+
+// getFromReaderWithRetry gets the object with key from reader, retrying transient errors like getWithRetry.
+func (m *SidecarInjector) getFromReaderWithRetry(ctx context.Context, reader client.Reader, key types.NamespacedName, obj runtime.Object) error {
+	backoff := wait.Backoff{
+		Duration: k8sLookupRetryInterval,
+		Factor:   2,
+		Steps:    m.config.K8sLookupRetries + 1,
+	}
+	err := retry.OnError(backoff, isTransientLookupError, func() error {
+		return reader.Get(ctx, key, obj)
+	})
+	if err != nil && isTransientLookupError(err) {
+		return webhook.NewRetriableError(err)
+	}
+	return err
+}
+
+// isTransientLookupError checks whether err might be resolved by retrying the lookup.
+// errors that aren't from the API server, such as connection errors, are considered transient.
+func isTransientLookupError(err error) bool {
+	if _, ok := err.(apierrors.APIStatus); !ok {
+		return true
+	}
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err) || apierrors.IsUnexpectedServerError(err)
+}
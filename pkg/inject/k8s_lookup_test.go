@@ -0,0 +1,117 @@
+package inject
+
+import (
+	"context"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+// flakyClient fails the first failures Get calls with err.
+type flakyClient struct {
+	client.Client
+	failures int
+	err      error
+	calls    int
+}
+
+func (c *flakyClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	c.calls++
+	if c.calls <= c.failures {
+		return c.err
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func TestSidecarInjector_getWithRetry(t *testing.T) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "awesome-ns",
+		},
+	}
+	timeoutErr := apierrors.NewTimeoutError("request timed out", 1)
+	tests := []struct {
+		name          string
+		retries       int
+		failures      int
+		err           error
+		wantCalls     int
+		wantErr       error
+		wantRetriable bool
+	}{
+		{
+			name:      "succeeds without retry",
+			retries:   2,
+			wantCalls: 1,
+		},
+		{
+			name:      "succeeds after transient errors",
+			retries:   2,
+			failures:  2,
+			err:       timeoutErr,
+			wantCalls: 3,
+		},
+		{
+			name:          "fails after persistent transient errors",
+			retries:       2,
+			failures:      5,
+			err:           timeoutErr,
+			wantCalls:     3,
+			wantErr:       errors.New("Timeout: request timed out"),
+			wantRetriable: true,
+		},
+		{
+			name:          "connection errors are retried",
+			retries:       1,
+			failures:      5,
+			err:           errors.New("connection refused"),
+			wantCalls:     2,
+			wantErr:       errors.New("connection refused"),
+			wantRetriable: true,
+		},
+		{
+			name:      "non-transient errors aren't retried",
+			retries:   2,
+			failures:  5,
+			err:       apierrors.NewForbidden(corev1.Resource("namespaces"), "awesome-ns", errors.New("rbac")),
+			wantCalls: 1,
+			wantErr:   errors.New("namespaces \"awesome-ns\" is forbidden: rbac"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := &flakyClient{
+				Client:   testclient.NewFakeClientWithScheme(k8sSchema, ns.DeepCopy()),
+				failures: tt.failures,
+				err:      tt.err,
+			}
+			m := &SidecarInjector{
+				config:    Config{K8sLookupRetries: tt.retries},
+				k8sClient: k8sClient,
+			}
+			got := &corev1.Namespace{}
+			err := m.getWithRetry(ctx, types.NamespacedName{Name: "awesome-ns"}, got)
+			assert.Equal(t, tt.wantCalls, k8sClient.calls)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				var retriableErr *webhook.RetriableError
+				assert.Equal(t, tt.wantRetriable, errors.As(err, &retriableErr))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "awesome-ns", got.Name)
+			}
+		})
+	}
+}
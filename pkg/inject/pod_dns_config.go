@@ -0,0 +1,146 @@
+package inject
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type podDNSConfigMutatorConfig struct {
+	// dnsConfig options such as ndots:1, in the format of name or name:value
+	options []string
+	// dnsConfig search domains
+	searches []string
+}
+
+func newPodDNSConfigMutator(mutatorConfig podDNSConfigMutatorConfig) *podDNSConfigMutator {
+	return &podDNSConfigMutator{
+		mutatorConfig: mutatorConfig,
+	}
+}
+
+var _ PodMutator = &podDNSConfigMutator{}
+
+// podDNSConfigMutator merges dnsConfig options and search domains into pod,
+// e.g. so Envoy can resolve the App Mesh endpoint on clusters with custom DNS.
+type podDNSConfigMutator struct {
+	mutatorConfig podDNSConfigMutatorConfig
+}
+
+func (m *podDNSConfigMutator) mutate(pod *corev1.Pod) error {
+	options, err := m.getDNSOptions(pod)
+	if err != nil {
+		return err
+	}
+	searches, err := m.getDNSSearches(pod)
+	if err != nil {
+		return err
+	}
+	if len(options) == 0 && len(searches) == 0 {
+		return nil
+	}
+	if pod.Spec.DNSConfig == nil {
+		pod.Spec.DNSConfig = &corev1.PodDNSConfig{}
+	}
+	mergeDNSConfig(pod.Spec.DNSConfig, options, searches)
+	return nil
+}
+
+// getDNSOptions returns the dnsConfig options from pod annotation, or the ones configured on the controller if not set.
+func (m *podDNSConfigMutator) getDNSOptions(pod *corev1.Pod) ([]corev1.PodDNSConfigOption, error) {
+	v, ok := pod.ObjectMeta.Annotations[AppMeshDNSOptionsAnnotation]
+	if !ok {
+		// validated on startup
+		options, _ := parseDNSOptions(m.mutatorConfig.options)
+		return options, nil
+	}
+	options, err := parseDNSOptions(splitCommaSeparated(v))
+	if err != nil {
+		return nil, errors.Errorf("malformed annotation %s: %s, %v", AppMeshDNSOptionsAnnotation, v, err)
+	}
+	return options, nil
+}
+
+// getDNSSearches returns the dnsConfig search domains from pod annotation, or the ones configured on the controller if not set.
+func (m *podDNSConfigMutator) getDNSSearches(pod *corev1.Pod) ([]string, error) {
+	v, ok := pod.ObjectMeta.Annotations[AppMeshDNSSearchesAnnotation]
+	if !ok {
+		return m.mutatorConfig.searches, nil
+	}
+	searches := splitCommaSeparated(v)
+	if err := validateDNSSearches(searches); err != nil {
+		return nil, errors.Errorf("malformed annotation %s: %s, %v", AppMeshDNSSearchesAnnotation, v, err)
+	}
+	return searches, nil
+}
+
+// mergeDNSConfig adds options and searches to dnsConfig conservatively:
+// options already set on pod keep their value, and search domains already set on pod aren't duplicated.
+func mergeDNSConfig(dnsConfig *corev1.PodDNSConfig, options []corev1.PodDNSConfigOption, searches []string) {
+	existingOptions := make(map[string]struct{}, len(dnsConfig.Options))
+	for _, option := range dnsConfig.Options {
+		existingOptions[option.Name] = struct{}{}
+	}
+	for _, option := range options {
+		if _, ok := existingOptions[option.Name]; ok {
+			continue
+		}
+		dnsConfig.Options = append(dnsConfig.Options, option)
+		existingOptions[option.Name] = struct{}{}
+	}
+	existingSearches := make(map[string]struct{}, len(dnsConfig.Searches))
+	for _, search := range dnsConfig.Searches {
+		existingSearches[search] = struct{}{}
+	}
+	for _, search := range searches {
+		if _, ok := existingSearches[search]; ok {
+			continue
+		}
+		dnsConfig.Searches = append(dnsConfig.Searches, search)
+		existingSearches[search] = struct{}{}
+	}
+}
+
+// parseDNSOptions parses dnsConfig options in the format of name or name:value, such as ndots:1 or edns0.
+func parseDNSOptions(rawOptions []string) ([]corev1.PodDNSConfigOption, error) {
+	var options []corev1.PodDNSConfigOption
+	for _, rawOption := range rawOptions {
+		name, value := rawOption, ""
+		hasValue := false
+		if idx := strings.Index(rawOption, ":"); idx != -1 {
+			name, value, hasValue = rawOption[:idx], rawOption[idx+1:], true
+		}
+		if name == "" || (hasValue && value == "") {
+			return nil, errors.Errorf("invalid option %s, expected name or name:value such as ndots:1", rawOption)
+		}
+		option := corev1.PodDNSConfigOption{Name: name}
+		if hasValue {
+			option.Value = &value
+		}
+		options = append(options, option)
+	}
+	return options, nil
+}
+
+// validateDNSSearches makes sure every search domain is a DNS subdomain.
+func validateDNSSearches(searches []string) error {
+	for _, search := range searches {
+		if errs := validation.IsDNS1123Subdomain(search); len(errs) != 0 {
+			return errors.Errorf("invalid search domain %s, %s", search, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// splitCommaSeparated splits a comma separated value, dropping empty entries and surrounding whitespace.
+func splitCommaSeparated(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
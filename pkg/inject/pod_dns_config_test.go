@@ -0,0 +1,145 @@
+package inject
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_podDNSConfigMutator_mutate(t *testing.T) {
+	tests := []struct {
+		name          string
+		mutatorConfig podDNSConfigMutatorConfig
+		pod           *corev1.Pod
+		wantDNSConfig *corev1.PodDNSConfig
+		wantErr       error
+	}{
+		{
+			name:          "no-op when nothing is configured",
+			mutatorConfig: podDNSConfigMutatorConfig{},
+			pod:           &corev1.Pod{},
+			wantDNSConfig: nil,
+		},
+		{
+			name: "pod without dnsConfig",
+			mutatorConfig: podDNSConfigMutatorConfig{
+				options:  []string{"ndots:1", "edns0"},
+				searches: []string{"corp.example.com"},
+			},
+			pod: &corev1.Pod{},
+			wantDNSConfig: &corev1.PodDNSConfig{
+				Options: []corev1.PodDNSConfigOption{
+					{Name: "ndots", Value: aws.String("1")},
+					{Name: "edns0"},
+				},
+				Searches: []string{"corp.example.com"},
+			},
+		},
+		{
+			name: "existing dnsConfig isn't clobbered",
+			mutatorConfig: podDNSConfigMutatorConfig{
+				options:  []string{"ndots:1", "timeout:2"},
+				searches: []string{"corp.example.com", "svc.cluster.local"},
+			},
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					DNSConfig: &corev1.PodDNSConfig{
+						Nameservers: []string{"10.0.0.10"},
+						Options: []corev1.PodDNSConfigOption{
+							{Name: "ndots", Value: aws.String("5")},
+						},
+						Searches: []string{"svc.cluster.local"},
+					},
+				},
+			},
+			wantDNSConfig: &corev1.PodDNSConfig{
+				Nameservers: []string{"10.0.0.10"},
+				Options: []corev1.PodDNSConfigOption{
+					{Name: "ndots", Value: aws.String("5")},
+					{Name: "timeout", Value: aws.String("2")},
+				},
+				Searches: []string{"svc.cluster.local", "corp.example.com"},
+			},
+		},
+		{
+			name: "annotations replace the configured options and searches",
+			mutatorConfig: podDNSConfigMutatorConfig{
+				options:  []string{"ndots:1"},
+				searches: []string{"corp.example.com"},
+			},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/dnsOptions":  "ndots:2, single-request-reopen",
+						"appmesh.k8s.aws/dnsSearches": "team.example.com",
+					},
+				},
+			},
+			wantDNSConfig: &corev1.PodDNSConfig{
+				Options: []corev1.PodDNSConfigOption{
+					{Name: "ndots", Value: aws.String("2")},
+					{Name: "single-request-reopen"},
+				},
+				Searches: []string{"team.example.com"},
+			},
+		},
+		{
+			name: "empty annotations opt pod out",
+			mutatorConfig: podDNSConfigMutatorConfig{
+				options:  []string{"ndots:1"},
+				searches: []string{"corp.example.com"},
+			},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/dnsOptions":  "",
+						"appmesh.k8s.aws/dnsSearches": "",
+					},
+				},
+			},
+			wantDNSConfig: nil,
+		},
+		{
+			name: "malformed options annotation",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/dnsOptions": "ndots:",
+					},
+				},
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/dnsOptions: ndots:, invalid option ndots:, expected name or name:value such as ndots:1"),
+		},
+		{
+			name: "malformed searches annotation",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/dnsSearches": "Corp_Example",
+					},
+				},
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/dnsSearches: Corp_Example, invalid search domain Corp_Example, " +
+				"a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character " +
+				"(e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newPodDNSConfigMutator(tt.mutatorConfig)
+			pod := tt.pod.DeepCopy()
+			err := m.mutate(pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, cmp.Equal(tt.wantDNSConfig, pod.Spec.DNSConfig), "diff", cmp.Diff(tt.wantDNSConfig, pod.Spec.DNSConfig))
+		})
+	}
+}
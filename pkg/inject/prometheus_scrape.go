@@ -0,0 +1,55 @@
+package inject
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	prometheusScrapeAnnotation = "prometheus.io/scrape"
+	prometheusPortAnnotation   = "prometheus.io/port"
+	prometheusPathAnnotation   = "prometheus.io/path"
+
+	defaultPrometheusScrapePath = "/stats/prometheus"
+)
+
+type prometheusScrapeMutatorConfig struct {
+	adminAccessPort int32
+	scrapePath      string
+}
+
+func newPrometheusScrapeMutator(mutatorConfig prometheusScrapeMutatorConfig, enabled bool) *prometheusScrapeMutator {
+	return &prometheusScrapeMutator{
+		mutatorConfig: mutatorConfig,
+		enabled:       enabled,
+	}
+}
+
+var _ PodMutator = &prometheusScrapeMutator{}
+
+// If enabled, pod is annotated for Prometheus to scrape Envoy stats from the admin port.
+type prometheusScrapeMutator struct {
+	mutatorConfig prometheusScrapeMutatorConfig
+	enabled       bool
+}
+
+func (m *prometheusScrapeMutator) mutate(pod *corev1.Pod) error {
+	if !m.enabled {
+		return nil
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	// annotations set by users take precedence, e.g. to scrape the application instead
+	setAnnotationIfAbsent(pod, prometheusScrapeAnnotation, "true")
+	setAnnotationIfAbsent(pod, prometheusPortAnnotation, strconv.Itoa(int(m.mutatorConfig.adminAccessPort)))
+	setAnnotationIfAbsent(pod, prometheusPathAnnotation, m.mutatorConfig.scrapePath)
+	return nil
+}
+
+func setAnnotationIfAbsent(pod *corev1.Pod, key string, value string) {
+	if _, ok := pod.Annotations[key]; !ok {
+		pod.Annotations[key] = value
+	}
+}
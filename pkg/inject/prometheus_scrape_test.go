@@ -0,0 +1,91 @@
+package inject
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_prometheusScrapeMutator_mutate(t *testing.T) {
+	type fields struct {
+		mutatorConfig prometheusScrapeMutatorConfig
+		enabled       bool
+	}
+	type args struct {
+		pod *corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantPod *corev1.Pod
+	}{
+		{
+			name: "no-op when disabled",
+			fields: fields{
+				mutatorConfig: prometheusScrapeMutatorConfig{adminAccessPort: 9901, scrapePath: "/stats/prometheus"},
+				enabled:       false,
+			},
+			args: args{
+				pod: &corev1.Pod{},
+			},
+			wantPod: &corev1.Pod{},
+		},
+		{
+			name: "annotate pod without annotations",
+			fields: fields{
+				mutatorConfig: prometheusScrapeMutatorConfig{adminAccessPort: 9901, scrapePath: "/stats/prometheus"},
+				enabled:       true,
+			},
+			args: args{
+				pod: &corev1.Pod{},
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"prometheus.io/scrape": "true",
+						"prometheus.io/port":   "9901",
+						"prometheus.io/path":   "/stats/prometheus",
+					},
+				},
+			},
+		},
+		{
+			name: "keep annotations set by users",
+			fields: fields{
+				mutatorConfig: prometheusScrapeMutatorConfig{adminAccessPort: 9901, scrapePath: "/stats/prometheus"},
+				enabled:       true,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"prometheus.io/port": "8080",
+							"prometheus.io/path": "/metrics",
+						},
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"prometheus.io/scrape": "true",
+						"prometheus.io/port":   "8080",
+						"prometheus.io/path":   "/metrics",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newPrometheusScrapeMutator(tt.fields.mutatorConfig, tt.fields.enabled)
+			pod := tt.args.pod.DeepCopy()
+			err := m.mutate(pod)
+			assert.NoError(t, err)
+			assert.True(t, cmp.Equal(tt.wantPod, pod), "diff", cmp.Diff(tt.wantPod, pod))
+		})
+	}
+}
@@ -3,7 +3,9 @@ package inject
 import (
 	"fmt"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"strconv"
 	"strings"
 )
 
@@ -14,9 +16,17 @@ const (
 	defaultEgressIgnoredPorts = "22"
 )
 
+// supportedAppPortProtocols are the container port protocols of app ports that can be intercepted.
+// traffic redirection into Envoy, by either proxyinit or AppMesh CNI, only covers TCP.
+var supportedAppPortProtocols = []corev1.Protocol{corev1.ProtocolTCP}
+
 type proxyMutatorConfig struct {
 	initProxyMutatorConfig
 	egressIgnoredIPs string
+	// UID used by proxy, defaultProxyUID will be used if unset
+	proxyUID int64
+	// GID used by proxy, no GID will be exempted if unset
+	proxyGID int64
 }
 
 func newProxyMutator(mutatorConfig proxyMutatorConfig, vn *appmesh.VirtualNode) *proxyMutator {
@@ -33,6 +43,9 @@ type proxyMutator struct {
 
 func (m *proxyMutator) mutate(pod *corev1.Pod) error {
 	proxyConfig := m.buildProxyConfig(pod)
+	if err := m.checkAppPortProtocols(pod, proxyConfig.appPorts); err != nil {
+		return err
+	}
 	var mutator PodMutator
 	if m.isAppMeshCNIEnabled(pod) {
 		mutator = newCNIProxyMutator(proxyConfig)
@@ -56,6 +69,8 @@ type proxyConfig struct {
 	proxyIngressPort int64
 	// UID used by proxy
 	proxyUID int64
+	// GID used by proxy
+	proxyGID int64
 }
 
 func (m *proxyMutator) buildProxyConfig(pod *corev1.Pod) proxyConfig {
@@ -67,7 +82,8 @@ func (m *proxyMutator) buildProxyConfig(pod *corev1.Pod) proxyConfig {
 		egressIgnoredPorts: egressIgnoredPorts,
 		proxyEgressPort:    defaultProxyEgressPort,
 		proxyIngressPort:   defaultProxyIngressPort,
-		proxyUID:           defaultProxyUID,
+		proxyUID:           proxyUIDOrDefault(m.mutatorConfig.proxyUID),
+		proxyGID:           m.mutatorConfig.proxyGID,
 	}
 }
 
@@ -87,6 +103,53 @@ func (m *proxyMutator) getAppPorts(pod *corev1.Pod) string {
 	return strings.Join(ports, ",")
 }
 
+// checkAppPortProtocols rejects pods whose containers declare an app port only with an unsupported protocol(e.g. UDP),
+// such traffic would silently bypass Envoy since it's never redirected.
+func (m *proxyMutator) checkAppPortProtocols(pod *corev1.Pod, appPorts string) error {
+	appPortSet := make(map[int32]bool)
+	for _, port := range strings.Split(appPorts, ",") {
+		portNum, err := strconv.ParseInt(strings.TrimSpace(port), 10, 32)
+		if err != nil {
+			continue
+		}
+		appPortSet[int32(portNum)] = true
+	}
+
+	// a port served over both TCP and another protocol(e.g. DNS) is fine, the TCP traffic gets intercepted.
+	tcpAppPorts := make(map[int32]bool)
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if appPortSet[containerPort.ContainerPort] && isSupportedAppPortProtocol(containerPort.Protocol) {
+				tcpAppPorts[containerPort.ContainerPort] = true
+			}
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if !appPortSet[containerPort.ContainerPort] || tcpAppPorts[containerPort.ContainerPort] {
+				continue
+			}
+			return errors.Errorf("app port %d of container %s uses unsupported protocol %s, supported protocols: %v",
+				containerPort.ContainerPort, container.Name, containerPort.Protocol, supportedAppPortProtocols)
+		}
+	}
+	return nil
+}
+
+// isSupportedAppPortProtocol checks whether traffic of given container port protocol can be intercepted.
+// an unset protocol defaults to TCP.
+func isSupportedAppPortProtocol(protocol corev1.Protocol) bool {
+	if protocol == "" {
+		return true
+	}
+	for _, supportedProtocol := range supportedAppPortProtocols {
+		if protocol == supportedProtocol {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *proxyMutator) getEgressIgnoredPorts(pod *corev1.Pod) string {
 	egressIgnoredPorts := defaultEgressIgnoredPorts
 	if v, ok := pod.ObjectMeta.Annotations[AppMeshEgressIgnoredPortsAnnotation]; ok {
@@ -107,3 +170,11 @@ func (m *proxyMutator) isAppMeshCNIEnabled(pod *corev1.Pod) bool {
 	}
 	return false
 }
+
+// proxyUIDOrDefault returns the configured UID of proxy, or defaultProxyUID when it's unset.
+func proxyUIDOrDefault(uid int64) int64 {
+	if uid > 0 {
+		return uid
+	}
+	return defaultProxyUID
+}
@@ -1,6 +1,7 @@
 package inject
 
 import (
+	"errors"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -242,6 +243,28 @@ func Test_proxyMutator_mutate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reject UDP app port",
+			fields: fields{
+				mutatorConfig: mutatorConfig,
+				vn:            vn,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+								Ports: []corev1.ContainerPort{
+									{ContainerPort: 443, Protocol: corev1.ProtocolUDP},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("app port 443 of container app uses unsupported protocol UDP, supported protocols: [TCP]"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -367,6 +390,149 @@ func Test_proxyMutator_getAppPorts(t *testing.T) {
 	}
 }
 
+func Test_proxyMutator_checkAppPortProtocols(t *testing.T) {
+	type args struct {
+		pod      *corev1.Pod
+		appPorts string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "TCP app port",
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+								Ports: []corev1.ContainerPort{
+									{ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+								},
+							},
+						},
+					},
+				},
+				appPorts: "8080",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "app port without protocol defaults to TCP",
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+								Ports: []corev1.ContainerPort{
+									{ContainerPort: 8080},
+								},
+							},
+						},
+					},
+				},
+				appPorts: "8080",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "app port not declared by any container",
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+							},
+						},
+					},
+				},
+				appPorts: "8080",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "UDP port that isn't an app port",
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+								Ports: []corev1.ContainerPort{
+									{ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+									{ContainerPort: 8125, Protocol: corev1.ProtocolUDP},
+								},
+							},
+						},
+					},
+				},
+				appPorts: "8080",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "app port served over both TCP and UDP",
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+								Ports: []corev1.ContainerPort{
+									{ContainerPort: 53, Protocol: corev1.ProtocolUDP},
+									{ContainerPort: 53, Protocol: corev1.ProtocolTCP},
+								},
+							},
+						},
+					},
+				},
+				appPorts: "53",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "UDP only app port",
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+								Ports: []corev1.ContainerPort{
+									{ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+								},
+							},
+							{
+								Name: "quic",
+								Ports: []corev1.ContainerPort{
+									{ContainerPort: 8443, Protocol: corev1.ProtocolUDP},
+								},
+							},
+						},
+					},
+				},
+				appPorts: "8080, 8443",
+			},
+			wantErr: errors.New("app port 8443 of container quic uses unsupported protocol UDP, supported protocols: [TCP]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &proxyMutator{}
+			err := m.checkAppPortProtocols(tt.args.pod, tt.args.appPorts)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_proxyMutator_getEgressIgnoredPorts(t *testing.T) {
 	type args struct {
 		pod *corev1.Pod
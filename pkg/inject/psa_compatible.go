@@ -0,0 +1,92 @@
+package inject
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type psaCompatibleMutatorConfig struct {
+	// name of the proxyinit container, proxyInitContainerName will be used if unset
+	proxyInitContainerName string
+	// UID injected containers run as when their image decides otherwise, defaultProxyUID will be used if unset
+	runAsUser int64
+}
+
+// newPSACompatibleMutator constructs new psaCompatibleMutator,
+// it must be constructed before pod is mutated since it only restricts containers added afterwards.
+func newPSACompatibleMutator(mutatorConfig psaCompatibleMutatorConfig, pod *corev1.Pod, enabled bool) *psaCompatibleMutator {
+	existingContainers := sets.NewString()
+	for _, container := range pod.Spec.InitContainers {
+		existingContainers.Insert(container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		existingContainers.Insert(container.Name)
+	}
+	return &psaCompatibleMutator{
+		mutatorConfig:      mutatorConfig,
+		existingContainers: existingContainers,
+		enabled:            enabled,
+	}
+}
+
+// psaCompatibleMutator restricts the security context of every container injected by preceding mutators,
+// so that pod can be admitted into namespaces enforcing the restricted Pod Security Standard.
+// containers pod already had are left untouched.
+type psaCompatibleMutator struct {
+	mutatorConfig      psaCompatibleMutatorConfig
+	existingContainers sets.String
+	enabled            bool
+}
+
+func (m *psaCompatibleMutator) mutate(pod *corev1.Pod) error {
+	if !m.enabled {
+		return nil
+	}
+	proxyInitContainerName := proxyInitContainerNameOrDefault(m.mutatorConfig.proxyInitContainerName)
+	for i := range pod.Spec.InitContainers {
+		container := &pod.Spec.InitContainers[i]
+		if m.existingContainers.Has(container.Name) {
+			continue
+		}
+		if container.Name == proxyInitContainerName {
+			mutatePSACompatibleProxyInitSecurityContext(pod, container)
+			continue
+		}
+		m.mutateSecurityContext(pod, container)
+	}
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if m.existingContainers.Has(container.Name) {
+			continue
+		}
+		m.mutateSecurityContext(pod, container)
+	}
+	return nil
+}
+
+// mutateSecurityContext restricts the security context of an injected container,
+// containers without a UID(e.g. the Jaeger config init container) run as the proxy UID since runAsNonRoot rejects images running as root.
+func (m *psaCompatibleMutator) mutateSecurityContext(pod *corev1.Pod, container *corev1.Container) {
+	mutatePSACompatibleSecurityContext(pod, container)
+	if container.SecurityContext.RunAsUser == nil {
+		container.SecurityContext.RunAsUser = aws.Int64(proxyUIDOrDefault(m.mutatorConfig.runAsUser))
+	}
+}
+
+// mutatePSACompatibleProxyInitSecurityContext restricts the security context of the proxyinit container as far as it can run,
+// i.e. the baseline Pod Security Standard: it configures iptables rules as root and keeps the NET_ADMIN capability.
+func mutatePSACompatibleProxyInitSecurityContext(pod *corev1.Pod, container *corev1.Container) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	container.SecurityContext.AllowPrivilegeEscalation = aws.Bool(false)
+	container.SecurityContext.Capabilities = &corev1.Capabilities{
+		Add:  []corev1.Capability{"NET_ADMIN"},
+		Drop: []corev1.Capability{"ALL"},
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[corev1.SeccompContainerAnnotationKeyPrefix+container.Name] = corev1.SeccompProfileRuntimeDefault
+}
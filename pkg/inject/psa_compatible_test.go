@@ -0,0 +1,220 @@
+package inject
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_psaCompatibleMutator_mutate(t *testing.T) {
+	podBeforeInjection := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name: "app-init",
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+				},
+			},
+		},
+	}
+	injectedPod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name: "app-init",
+				},
+				{
+					Name: "proxyinit",
+					SecurityContext: &corev1.SecurityContext{
+						Capabilities: &corev1.Capabilities{
+							Add: []corev1.Capability{"NET_ADMIN"},
+						},
+					},
+				},
+				{
+					Name: "inject-jaeger-config",
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+				},
+				{
+					Name: "envoy",
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: aws.Int64(1337),
+					},
+				},
+				{
+					Name: "xray-daemon",
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: aws.Int64(1337),
+					},
+				},
+			},
+		},
+	}
+	restrictedSecurityContext := func(uid int64) *corev1.SecurityContext {
+		return &corev1.SecurityContext{
+			RunAsUser:                aws.Int64(uid),
+			RunAsNonRoot:             aws.Bool(true),
+			AllowPrivilegeEscalation: aws.Bool(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		}
+	}
+	type fields struct {
+		mutatorConfig psaCompatibleMutatorConfig
+		enabled       bool
+	}
+	type args struct {
+		pod *corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantPod *corev1.Pod
+	}{
+		{
+			name: "no-op when disabled",
+			fields: fields{
+				enabled: false,
+			},
+			args: args{
+				pod: injectedPod,
+			},
+			wantPod: injectedPod,
+		},
+		{
+			name: "restrict injected containers when enabled",
+			fields: fields{
+				mutatorConfig: psaCompatibleMutatorConfig{
+					runAsUser: 2000,
+				},
+				enabled: true,
+			},
+			args: args{
+				pod: injectedPod,
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"container.seccomp.security.alpha.kubernetes.io/proxyinit":            "runtime/default",
+						"container.seccomp.security.alpha.kubernetes.io/inject-jaeger-config": "runtime/default",
+						"container.seccomp.security.alpha.kubernetes.io/envoy":                "runtime/default",
+						"container.seccomp.security.alpha.kubernetes.io/xray-daemon":          "runtime/default",
+					},
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{
+							Name: "app-init",
+						},
+						{
+							Name: "proxyinit",
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: aws.Bool(false),
+								Capabilities: &corev1.Capabilities{
+									Add:  []corev1.Capability{"NET_ADMIN"},
+									Drop: []corev1.Capability{"ALL"},
+								},
+							},
+						},
+						{
+							Name:            "inject-jaeger-config",
+							SecurityContext: restrictedSecurityContext(2000),
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name: "app",
+						},
+						{
+							Name:            "envoy",
+							SecurityContext: restrictedSecurityContext(1337),
+						},
+						{
+							Name:            "xray-daemon",
+							SecurityContext: restrictedSecurityContext(1337),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "restrict custom named proxyinit container",
+			fields: fields{
+				mutatorConfig: psaCompatibleMutatorConfig{
+					proxyInitContainerName: "mesh-init",
+				},
+				enabled: true,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{
+							{
+								Name: "app-init",
+							},
+							{
+								Name: "mesh-init",
+							},
+						},
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+							},
+						},
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"container.seccomp.security.alpha.kubernetes.io/mesh-init": "runtime/default",
+					},
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{
+							Name: "app-init",
+						},
+						{
+							Name: "mesh-init",
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: aws.Bool(false),
+								Capabilities: &corev1.Capabilities{
+									Add:  []corev1.Capability{"NET_ADMIN"},
+									Drop: []corev1.Capability{"ALL"},
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name: "app",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newPSACompatibleMutator(tt.fields.mutatorConfig, podBeforeInjection, tt.fields.enabled)
+			pod := tt.args.pod.DeepCopy()
+			err := m.mutate(pod)
+			assert.NoError(t, err)
+			assert.True(t, cmp.Equal(tt.wantPod, pod), "diff", cmp.Diff(tt.wantPod, pod))
+		})
+	}
+}
@@ -8,13 +8,13 @@ import (
 	"strconv"
 )
 
-func buildEnvoySidecar(vars EnvoyTemplateVariables, env map[string]string) corev1.Container {
+func buildEnvoySidecar(vars EnvoyTemplateVariables, customEnv map[string]string, envOverrideAllowlist []string) corev1.Container {
 
 	envoy := corev1.Container{
-		Name:  "envoy",
+		Name:  vars.SidecarContainerName,
 		Image: vars.SidecarImage,
 		SecurityContext: &corev1.SecurityContext{
-			RunAsUser: aws.Int64(1337),
+			RunAsUser: aws.Int64(vars.SidecarRunAsUser),
 		},
 		Ports: []corev1.ContainerPort{
 			{
@@ -33,14 +33,29 @@ func buildEnvoySidecar(vars EnvoyTemplateVariables, env map[string]string) corev
 		},
 	}
 
+	if vars.SidecarRunAsGroup > 0 {
+		envoy.SecurityContext.RunAsGroup = aws.Int64(vars.SidecarRunAsGroup)
+	}
+
 	vn := fmt.Sprintf("mesh/%s/virtualNode/%s", vars.MeshName, vars.VirtualNodeName)
 
+	env := make(map[string]string, len(customEnv))
+	for key, val := range customEnv {
+		env[key] = val
+	}
+
 	// add all the controller managed env to the map so
 	// 1) we remove duplicates
-	// 2) we don't allow overriding controller managed env with pod annotations
+	// 2) we don't allow overriding controller managed env with pod annotations, unless allowlisted
 	env["APPMESH_VIRTUAL_NODE_NAME"] = vn
 	env["AWS_REGION"] = vars.AWSRegion
 
+	if vars.Cluster != "" {
+		// Specify the node.cluster Envoy refers to itself with in metrics and traces
+		// Default: the resource name of APPMESH_VIRTUAL_NODE_NAME
+		env["APPMESH_RESOURCE_CLUSTER"] = vars.Cluster
+	}
+
 	// Set the value to 1 to connect to the App Mesh Preview Channel endpoint.
 	// See https://docs.aws.amazon.com/app-mesh/latest/userguide/preview.html
 	env["APPMESH_PREVIEW"] = vars.Preview
@@ -49,6 +64,12 @@ func buildEnvoySidecar(vars EnvoyTemplateVariables, env map[string]string) corev
 	// Valid values: trace, debug, info, warning, error, critical, off
 	env["ENVOY_LOG_LEVEL"] = vars.LogLevel
 
+	if vars.InitialFetchTimeout != "" {
+		// Specify how long (in seconds) Envoy waits for the first config response from App Mesh
+		// Default: 0, Envoy waits indefinitely
+		env["ENVOY_INITIAL_FETCH_TIMEOUT"] = vars.InitialFetchTimeout
+	}
+
 	if vars.EnableSDS {
 		env["APPMESH_SDS_SOCKET_PATH"] = vars.SdsUdsPath
 	}
@@ -87,6 +108,19 @@ func buildEnvoySidecar(vars EnvoyTemplateVariables, env map[string]string) corev
 		// Specify an IP address or hostname to override the default Datadog agent address: 127.0.0.1
 		env["DATADOG_TRACER_ADDRESS"] = vars.DatadogTracerAddress
 
+		// Datadog unified service tagging, populated from pod labels so traces correlate
+		// with the application. Tags that aren't present on the pod are skipped.
+		// See https://docs.datadoghq.com/getting_started/tagging/unified_service_tagging
+		if vars.DatadogEnv != "" {
+			env["DD_ENV"] = vars.DatadogEnv
+		}
+		if vars.DatadogService != "" {
+			env["DD_SERVICE"] = vars.DatadogService
+		}
+		if vars.DatadogVersion != "" {
+			env["DD_VERSION"] = vars.DatadogVersion
+		}
+
 	}
 
 	if vars.EnableStatsTags {
@@ -122,11 +156,30 @@ func buildEnvoySidecar(vars EnvoyTemplateVariables, env map[string]string) corev
 		envoy.VolumeMounts = vol_mount
 	}
 
-	envoy.Env = getEnvoyEnv(env)
+	applySidecarEnvOverrides(env, customEnv, envOverrideAllowlist)
+	if vars.EnablePodMetadataEnv {
+		// Identify the pod of Envoy via the downward API, so its traces can be correlated with the pod
+		delete(env, podNameEnv)
+		delete(env, podNamespaceEnv)
+		envoy.Env = append(getEnvoyEnv(env),
+			refField(podNameEnv, "metadata.name"),
+			refField(podNamespaceEnv, "metadata.namespace"),
+		)
+	} else {
+		envoy.Env = getEnvoyEnv(env)
+	}
 	return envoy
 
 }
 
+const (
+	podNameEnv      = "APPMESH_PLATFORM_K8S_POD_NAME"
+	podNamespaceEnv = "APPMESH_PLATFORM_K8S_POD_NAMESPACE"
+)
+
+// hostIPRef can be set as agent address to reach an agent running on the node of the pod
+const hostIPRef = "ref:status.hostIP"
+
 func getEnvoyEnv(env map[string]string) []corev1.EnvVar {
 
 	ev := []corev1.EnvVar{}
@@ -134,8 +187,9 @@ func getEnvoyEnv(env map[string]string) []corev1.EnvVar {
 
 		switch k := key; k {
 		case "STATSD_ADDRESS", "DATADOG_TRACER_ADDRESS":
-			if val == "ref:status.hostIP" {
-				ev = append(ev, refHostIP(key))
+			// IPs and DNS hostnames are passed verbatim, only the node IP reference is resolved by Kubernetes
+			if val == hostIPRef {
+				ev = append(ev, refField(key, "status.hostIP"))
 			} else {
 				ev = append(ev, envVar(key, val))
 			}
@@ -147,8 +201,13 @@ func getEnvoyEnv(env map[string]string) []corev1.EnvVar {
 	return ev
 }
 
-func envoyReadinessProbe(initialDelaySeconds int32, periodSeconds int32, adminAccessPort string) *corev1.Probe {
-	envoyReadinessCommand := "curl -s http://localhost:" + adminAccessPort + "/server_info | grep state | grep -q LIVE"
+func envoyReadinessProbe(initialDelaySeconds int32, periodSeconds int32, adminAccessHost string, adminAccessPort string, tolerateDraining bool) *corev1.Probe {
+	readyStateCondition := "grep -q LIVE"
+	if tolerateDraining {
+		// a hot restarting Envoy reports DRAINING while the new epoch takes over its listeners
+		readyStateCondition = "grep -qE 'LIVE|DRAINING'"
+	}
+	envoyReadinessCommand := "curl -s http://" + adminAccessHost + ":" + adminAccessPort + "/server_info | grep state | " + readyStateCondition
 	return &corev1.Probe{
 		Handler: corev1.Handler{
 
@@ -243,15 +302,15 @@ func sidecarResources(cpuRequest, memoryRequest, cpuLimit, memoryLimit string) (
 	return resources, nil
 }
 
-// refHostIP is to use the k8s downward API and render the host IP
-// this is useful in cases where the tracing agent is running as a daemonset
-func refHostIP(envName string) corev1.EnvVar {
+// refField is to use the k8s downward API and render the pod field at fieldPath
+// this is useful in cases like the tracing agent running as a daemonset, which is reached at status.hostIP
+func refField(envName string, fieldPath string) corev1.EnvVar {
 	return corev1.EnvVar{
 		Name:  envName,
 		Value: "",
 		ValueFrom: &corev1.EnvVarSource{
 			FieldRef: &corev1.ObjectFieldSelector{
-				FieldPath: "status.hostIP",
+				FieldPath: fieldPath,
 			},
 		},
 	}
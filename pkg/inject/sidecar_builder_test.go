@@ -0,0 +1,152 @@
+package inject
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func Test_getEnvoyEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want []corev1.EnvVar
+	}{
+		{
+			name: "datadog tracer address is a hostname",
+			env: map[string]string{
+				"DATADOG_TRACER_ADDRESS": "datadog-agent.example.com",
+			},
+			want: []corev1.EnvVar{
+				{Name: "DATADOG_TRACER_ADDRESS", Value: "datadog-agent.example.com"},
+			},
+		},
+		{
+			name: "datadog tracer address is an IP",
+			env: map[string]string{
+				"DATADOG_TRACER_ADDRESS": "10.0.0.10",
+			},
+			want: []corev1.EnvVar{
+				{Name: "DATADOG_TRACER_ADDRESS", Value: "10.0.0.10"},
+			},
+		},
+		{
+			name: "datadog tracer address references the host IP",
+			env: map[string]string{
+				"DATADOG_TRACER_ADDRESS": "ref:status.hostIP",
+			},
+			want: []corev1.EnvVar{
+				{
+					Name: "DATADOG_TRACER_ADDRESS",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "status.hostIP",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "statsd address references the host IP",
+			env: map[string]string{
+				"STATSD_ADDRESS": "ref:status.hostIP",
+			},
+			want: []corev1.EnvVar{
+				{
+					Name: "STATSD_ADDRESS",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "status.hostIP",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "host IP reference isn't resolved for other env",
+			env: map[string]string{
+				"DD_ENV": "ref:status.hostIP",
+			},
+			want: []corev1.EnvVar{
+				{Name: "DD_ENV", Value: "ref:status.hostIP"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getEnvoyEnv(tt.env)
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func Test_buildEnvoySidecar_podMetadataEnv(t *testing.T) {
+	podNameEnvVar := corev1.EnvVar{
+		Name: "APPMESH_PLATFORM_K8S_POD_NAME",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.name",
+			},
+		},
+	}
+	podNamespaceEnvVar := corev1.EnvVar{
+		Name: "APPMESH_PLATFORM_K8S_POD_NAMESPACE",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.namespace",
+			},
+		},
+	}
+	tests := []struct {
+		name                 string
+		enablePodMetadataEnv bool
+		customEnv            map[string]string
+		allowlist            []string
+		wantEnv              []corev1.EnvVar
+		wantNoEnv            []string
+	}{
+		{
+			name:                 "pod metadata env disabled",
+			enablePodMetadataEnv: false,
+			wantNoEnv:            []string{"APPMESH_PLATFORM_K8S_POD_NAME", "APPMESH_PLATFORM_K8S_POD_NAMESPACE"},
+		},
+		{
+			name:                 "pod metadata env enabled",
+			enablePodMetadataEnv: true,
+			wantEnv:              []corev1.EnvVar{podNameEnvVar, podNamespaceEnvVar},
+		},
+		{
+			name:                 "pod metadata env enabled takes precedence over custom env",
+			enablePodMetadataEnv: true,
+			customEnv: map[string]string{
+				"APPMESH_PLATFORM_K8S_POD_NAME": "my-pod",
+			},
+			allowlist: []string{"APPMESH_PLATFORM_K8S_POD_NAME"},
+			wantEnv:   []corev1.EnvVar{podNameEnvVar, podNamespaceEnvVar},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars := EnvoyTemplateVariables{
+				MeshName:             "my-mesh",
+				VirtualNodeName:      "my-vn",
+				SidecarContainerName: "envoy",
+				AdminAccessPort:      9901,
+				EnablePodMetadataEnv: tt.enablePodMetadataEnv,
+			}
+			got := buildEnvoySidecar(vars, tt.customEnv, tt.allowlist)
+			gotEnvByName := make(map[string]corev1.EnvVar, len(got.Env))
+			for _, env := range got.Env {
+				_, duplicated := gotEnvByName[env.Name]
+				assert.False(t, duplicated, "duplicated env %s", env.Name)
+				gotEnvByName[env.Name] = env
+			}
+			for _, want := range tt.wantEnv {
+				assert.Equal(t, want, gotEnvByName[want.Name])
+			}
+			for _, name := range tt.wantNoEnv {
+				assert.NotContains(t, gotEnvByName, name)
+			}
+		})
+	}
+}
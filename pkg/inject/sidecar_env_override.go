@@ -0,0 +1,40 @@
+package inject
+
+import (
+	"fmt"
+)
+
+// lockedEnvoyEnv are controller managed Envoy env that pod annotations can never override,
+// since they define how Envoy identifies itself to App Mesh or are relied upon by other injected settings.
+var lockedEnvoyEnv = map[string]struct{}{
+	"APPMESH_VIRTUAL_NODE_NAME":   {},
+	"APPMESH_RESOURCE_CLUSTER":    {},
+	"AWS_REGION":                  {},
+	"APPMESH_PREVIEW":             {},
+	"APPMESH_SDS_SOCKET_PATH":     {},
+	"ENVOY_ADMIN_ACCESS_PORT":     {},
+	"ENVOY_ADMIN_ACCESS_LOG_FILE": {},
+	"ENVOY_TRACING_CFG_FILE":      {},
+}
+
+// validateSidecarEnvOverrideAllowlist makes sure none of the allowlisted env is locked.
+func validateSidecarEnvOverrideAllowlist(allowlist []string) error {
+	for _, key := range allowlist {
+		if _, ok := lockedEnvoyEnv[key]; ok {
+			return fmt.Errorf("invalid %s: %s is managed by the controller and can't be overridden", flagSidecarEnvOverrideAllowlist, key)
+		}
+	}
+	return nil
+}
+
+// applySidecarEnvOverrides overrides the controller managed env with the allowlisted custom env from pod annotations.
+func applySidecarEnvOverrides(env map[string]string, customEnv map[string]string, allowlist []string) {
+	for _, key := range allowlist {
+		if _, ok := lockedEnvoyEnv[key]; ok {
+			continue
+		}
+		if val, ok := customEnv[key]; ok {
+			env[key] = val
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package inject
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func Test_validateSidecarEnvOverrideAllowlist(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist []string
+		wantErr   error
+	}{
+		{
+			name:      "empty allowlist",
+			allowlist: nil,
+		},
+		{
+			name:      "non-locked env",
+			allowlist: []string{"ENVOY_LOG_LEVEL", "ENVOY_INITIAL_FETCH_TIMEOUT"},
+		},
+		{
+			name:      "locked env",
+			allowlist: []string{"ENVOY_LOG_LEVEL", "APPMESH_VIRTUAL_NODE_NAME"},
+			wantErr:   errors.New("invalid sidecar-env-override-allowlist: APPMESH_VIRTUAL_NODE_NAME is managed by the controller and can't be overridden"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSidecarEnvOverrideAllowlist(tt.allowlist)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_buildEnvoySidecar_envOverrides(t *testing.T) {
+	vars := EnvoyTemplateVariables{
+		AWSRegion:       "us-west-2",
+		MeshName:        "my-mesh",
+		VirtualNodeName: "my-vn_my-ns",
+		Preview:         "0",
+		LogLevel:        "info",
+		AdminAccessPort: 9901,
+		PreStopDelay:    "20",
+		SidecarImage:    "envoy:v2",
+	}
+	customEnv := map[string]string{
+		"ENVOY_LOG_LEVEL":           "debug",
+		"APPMESH_VIRTUAL_NODE_NAME": "mesh/other-mesh/virtualNode/other-vn",
+		"AWS_REGION":                "eu-west-1",
+		"DD_ENV":                    "qa1",
+	}
+	tests := []struct {
+		name      string
+		allowlist []string
+		wantEnv   []corev1.EnvVar
+	}{
+		{
+			name:      "controller managed env win without allowlist",
+			allowlist: nil,
+			wantEnv: []corev1.EnvVar{
+				{Name: "ENVOY_LOG_LEVEL", Value: "info"},
+				{Name: "APPMESH_VIRTUAL_NODE_NAME", Value: "mesh/my-mesh/virtualNode/my-vn_my-ns"},
+				{Name: "AWS_REGION", Value: "us-west-2"},
+				{Name: "DD_ENV", Value: "qa1"},
+			},
+		},
+		{
+			name:      "allowlisted env are overridden",
+			allowlist: []string{"ENVOY_LOG_LEVEL"},
+			wantEnv: []corev1.EnvVar{
+				{Name: "ENVOY_LOG_LEVEL", Value: "debug"},
+				{Name: "APPMESH_VIRTUAL_NODE_NAME", Value: "mesh/my-mesh/virtualNode/my-vn_my-ns"},
+				{Name: "AWS_REGION", Value: "us-west-2"},
+				{Name: "DD_ENV", Value: "qa1"},
+			},
+		},
+		{
+			name:      "locked env aren't overridden even if allowlisted",
+			allowlist: []string{"APPMESH_VIRTUAL_NODE_NAME", "AWS_REGION"},
+			wantEnv: []corev1.EnvVar{
+				{Name: "ENVOY_LOG_LEVEL", Value: "info"},
+				{Name: "APPMESH_VIRTUAL_NODE_NAME", Value: "mesh/my-mesh/virtualNode/my-vn_my-ns"},
+				{Name: "AWS_REGION", Value: "us-west-2"},
+				{Name: "DD_ENV", Value: "qa1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envoy := buildEnvoySidecar(vars, customEnv, tt.allowlist)
+			for _, env := range tt.wantEnv {
+				assert.Contains(t, envoy.Env, env)
+			}
+		})
+	}
+}
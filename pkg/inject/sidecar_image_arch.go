@@ -0,0 +1,61 @@
+package inject
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// archLabel is the well-known node label of the CPU architecture
+	archLabel = "kubernetes.io/arch"
+	archAmd64 = "amd64"
+	archArm64 = "arm64"
+)
+
+// sidecarImageForPod returns the Envoy image matching the architecture pod is constrained to,
+// or the manifest list image when pod isn't constrained to a single architecture or no image is configured for it.
+func sidecarImageForPod(cfg Config, pod *corev1.Pod) string {
+	switch getPodArch(pod) {
+	case archAmd64:
+		if cfg.SidecarImageAmd64 != "" {
+			return cfg.SidecarImageAmd64
+		}
+	case archArm64:
+		if cfg.SidecarImageArm64 != "" {
+			return cfg.SidecarImageArm64
+		}
+	}
+	return cfg.SidecarImage
+}
+
+// getPodArch returns the single architecture pod can be scheduled on, or empty if it isn't constrained to one.
+// both nodeSelector and required nodeAffinity are considered, preferred nodeAffinity isn't binding so it's ignored.
+func getPodArch(pod *corev1.Pod) string {
+	if arch, ok := pod.Spec.NodeSelector[archLabel]; ok {
+		return arch
+	}
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	// nodeSelectorTerms are ORed, so every term must constrain pod to the same architecture
+	arch := ""
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		termArch := getNodeSelectorTermArch(term)
+		if termArch == "" || (arch != "" && termArch != arch) {
+			return ""
+		}
+		arch = termArch
+	}
+	return arch
+}
+
+// getNodeSelectorTermArch returns the single architecture a nodeSelectorTerm selects, or empty if it doesn't select one.
+func getNodeSelectorTermArch(term corev1.NodeSelectorTerm) string {
+	for _, expr := range term.MatchExpressions {
+		// matchExpressions are ANDed, so a single expression selecting one architecture is enough
+		if expr.Key == archLabel && expr.Operator == corev1.NodeSelectorOpIn && len(expr.Values) == 1 {
+			return expr.Values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+package inject
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func Test_sidecarImageForPod(t *testing.T) {
+	archAffinity := func(terms ...corev1.NodeSelectorTerm) *corev1.Affinity {
+		return &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: terms,
+				},
+			},
+		}
+	}
+	archTerm := func(archs ...string) corev1.NodeSelectorTerm {
+		return corev1.NodeSelectorTerm{
+			MatchExpressions: []corev1.NodeSelectorRequirement{
+				{
+					Key:      "kubernetes.io/os",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"linux"},
+				},
+				{
+					Key:      "kubernetes.io/arch",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   archs,
+				},
+			},
+		}
+	}
+	cfg := Config{
+		SidecarImage:      "envoy:v1.17.2.0-prod",
+		SidecarImageAmd64: "envoy:v1.17.2.0-prod-amd64",
+		SidecarImageArm64: "envoy:v1.17.2.0-prod-arm64",
+	}
+	tests := []struct {
+		name string
+		cfg  Config
+		spec corev1.PodSpec
+		want string
+	}{
+		{
+			name: "unconstrained pod",
+			cfg:  cfg,
+			spec: corev1.PodSpec{},
+			want: "envoy:v1.17.2.0-prod",
+		},
+		{
+			name: "amd64 selected by nodeSelector",
+			cfg:  cfg,
+			spec: corev1.PodSpec{
+				NodeSelector: map[string]string{"kubernetes.io/arch": "amd64"},
+			},
+			want: "envoy:v1.17.2.0-prod-amd64",
+		},
+		{
+			name: "arm64 selected by nodeSelector",
+			cfg:  cfg,
+			spec: corev1.PodSpec{
+				NodeSelector: map[string]string{"kubernetes.io/arch": "arm64"},
+			},
+			want: "envoy:v1.17.2.0-prod-arm64",
+		},
+		{
+			name: "arm64 selected by nodeAffinity",
+			cfg:  cfg,
+			spec: corev1.PodSpec{
+				Affinity: archAffinity(archTerm("arm64")),
+			},
+			want: "envoy:v1.17.2.0-prod-arm64",
+		},
+		{
+			name: "amd64 selected by every nodeAffinity term",
+			cfg:  cfg,
+			spec: corev1.PodSpec{
+				Affinity: archAffinity(archTerm("amd64"), archTerm("amd64")),
+			},
+			want: "envoy:v1.17.2.0-prod-amd64",
+		},
+		{
+			name: "nodeAffinity terms select different archs",
+			cfg:  cfg,
+			spec: corev1.PodSpec{
+				Affinity: archAffinity(archTerm("amd64"), archTerm("arm64")),
+			},
+			want: "envoy:v1.17.2.0-prod",
+		},
+		{
+			name: "nodeAffinity term selects multiple archs",
+			cfg:  cfg,
+			spec: corev1.PodSpec{
+				Affinity: archAffinity(archTerm("amd64", "arm64")),
+			},
+			want: "envoy:v1.17.2.0-prod",
+		},
+		{
+			name: "arch only in preferred nodeAffinity",
+			cfg:  cfg,
+			spec: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{
+							{
+								Weight:     100,
+								Preference: archTerm("arm64"),
+							},
+						},
+					},
+				},
+			},
+			want: "envoy:v1.17.2.0-prod",
+		},
+		{
+			name: "arm64 selected but no arm64 image configured",
+			cfg: Config{
+				SidecarImage:      "envoy:v1.17.2.0-prod",
+				SidecarImageAmd64: "envoy:v1.17.2.0-prod-amd64",
+			},
+			spec: corev1.PodSpec{
+				NodeSelector: map[string]string{"kubernetes.io/arch": "arm64"},
+			},
+			want: "envoy:v1.17.2.0-prod",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: tt.spec}
+			got := sidecarImageForPod(tt.cfg, pod)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package inject
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// validateRequiredSidecarLimits makes sure only cpu and memory limits are required.
+func validateRequiredSidecarLimits(required []string) error {
+	for _, name := range required {
+		switch corev1.ResourceName(name) {
+		case corev1.ResourceCPU, corev1.ResourceMemory:
+		default:
+			return fmt.Errorf("invalid %s: %s, must be %s or %s", flagRequireSidecarLimits, name, corev1.ResourceCPU, corev1.ResourceMemory)
+		}
+	}
+	return nil
+}
+
+// validateSidecarLimits makes sure the Envoy container of injected pod declares the required resource limits.
+// limits are resolved from pod annotations or the controller defaults during injection, so they're checked afterwards.
+func (m *SidecarInjector) validateSidecarLimits(pod *corev1.Pod) error {
+	if len(m.config.RequireSidecarLimits) == 0 {
+		return nil
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name != envoyContainerNameOrDefault(m.config.SidecarContainerName) {
+			continue
+		}
+		for _, name := range m.config.RequireSidecarLimits {
+			if _, ok := container.Resources.Limits[corev1.ResourceName(name)]; ok {
+				continue
+			}
+			annotation, flag := AppMeshMemoryLimitAnnotation, flagSidecarMemoryLimits
+			if corev1.ResourceName(name) == corev1.ResourceCPU {
+				annotation, flag = AppMeshCPULimitAnnotation, flagSidecarCpuLimits
+			}
+			return errors.Errorf("sidecar container %s must declare %s limits as required by %s, annotate the pod with %s or set %s",
+				container.Name, name, flagRequireSidecarLimits, annotation, flag)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package inject
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_validateRequiredSidecarLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		required []string
+		wantErr  error
+	}{
+		{
+			name:     "no limits required",
+			required: nil,
+		},
+		{
+			name:     "memory and cpu limits required",
+			required: []string{"memory", "cpu"},
+		},
+		{
+			name:     "unknown resource required",
+			required: []string{"memory", "ephemeral-storage"},
+			wantErr:  errors.New("invalid require-sidecar-limits: ephemeral-storage, must be cpu or memory"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRequiredSidecarLimits(tt.required)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSidecarInjector_validateSidecarLimits(t *testing.T) {
+	envoyWithLimits := func(limits corev1.ResourceList) *corev1.Pod {
+		return &corev1.Pod{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "app"},
+					{Name: "envoy", Resources: corev1.ResourceRequirements{Limits: limits}},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name                 string
+		requireSidecarLimits []string
+		pod                  *corev1.Pod
+		wantErr              error
+	}{
+		{
+			name:                 "no limits when limits aren't required",
+			requireSidecarLimits: nil,
+			pod:                  envoyWithLimits(nil),
+		},
+		{
+			name:                 "memory limits when memory limits are required",
+			requireSidecarLimits: []string{"memory"},
+			pod: envoyWithLimits(corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			}),
+		},
+		{
+			name:                 "no limits when memory limits are required",
+			requireSidecarLimits: []string{"memory"},
+			pod:                  envoyWithLimits(nil),
+			wantErr:              errors.New("sidecar container envoy must declare memory limits as required by require-sidecar-limits, annotate the pod with appmesh.k8s.aws/memoryLimit or set sidecar-memory-limits"),
+		},
+		{
+			name:                 "cpu limits only when memory and cpu limits are required",
+			requireSidecarLimits: []string{"memory", "cpu"},
+			pod: envoyWithLimits(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("100m"),
+			}),
+			wantErr: errors.New("sidecar container envoy must declare memory limits as required by require-sidecar-limits, annotate the pod with appmesh.k8s.aws/memoryLimit or set sidecar-memory-limits"),
+		},
+		{
+			name:                 "memory limits only when memory and cpu limits are required",
+			requireSidecarLimits: []string{"memory", "cpu"},
+			pod: envoyWithLimits(corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			}),
+			wantErr: errors.New("sidecar container envoy must declare cpu limits as required by require-sidecar-limits, annotate the pod with appmesh.k8s.aws/cpuLimit or set sidecar-cpu-limits"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &SidecarInjector{
+				config: Config{
+					SidecarContainerName: "envoy",
+					RequireSidecarLimits: tt.requireSidecarLimits,
+				},
+				log: &log.NullLogger{},
+			}
+			err := m.validateSidecarLimits(tt.pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSidecarInjector_validateSidecarLimits_injected(t *testing.T) {
+	tests := []struct {
+		name                string
+		sidecarMemoryLimits string
+		annotations         map[string]string
+		wantErr             error
+	}{
+		{
+			name:                "memory limits from controller default",
+			sidecarMemoryLimits: "128Mi",
+		},
+		{
+			name: "memory limits from pod annotation",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/memoryLimit": "256Mi",
+			},
+		},
+		{
+			name:    "memory limits from neither annotation nor default",
+			wantErr: errors.New("sidecar container envoy must declare memory limits as required by require-sidecar-limits, annotate the pod with appmesh.k8s.aws/memoryLimit or set sidecar-memory-limits"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := getConfig(func(cnf Config) Config {
+				cnf.SidecarMemoryLimits = tt.sidecarMemoryLimits
+				cnf.RequireSidecarLimits = []string{"memory"}
+				return cnf
+			})
+			inj := NewSidecarInjector(conf, "000000000000", "us-west-2", nil, nil, nil, nil, nil, nil)
+			pod := getPod(tt.annotations)
+			err := inj.injectAppMeshPatches(getMesh(), getVn(nil), nil, pod)
+			assert.NoError(t, err)
+
+			err = inj.validateSidecarLimits(pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
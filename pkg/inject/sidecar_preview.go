@@ -0,0 +1,38 @@
+package inject
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// isPreviewEnabled returns whether pod connects to the App Mesh preview channel,
+// the appmesh.k8s.aws/preview annotation of pod takes precedence over the controller default.
+func isPreviewEnabled(defaultPreview bool, pod *corev1.Pod) (bool, error) {
+	v, ok := pod.ObjectMeta.Annotations[AppMeshPreviewAnnotation]
+	if !ok {
+		return defaultPreview, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "enabled", "1":
+		return true, nil
+	case "disabled", "0":
+		return false, nil
+	}
+	return false, errors.Errorf("malformed annotation %s: %s, expected enabled/1 or disabled/0", AppMeshPreviewAnnotation, v)
+}
+
+// sidecarImageForPreview returns the preview Envoy image in place of image for pods connecting to the App Mesh preview channel.
+// image is kept with a warning when no preview image is configured, since it might not be compatible with the preview channel.
+func (m *SidecarInjector) sidecarImageForPreview(pod *corev1.Pod, preview bool, image string) string {
+	if !preview {
+		return image
+	}
+	if m.config.SidecarPreviewImage == "" {
+		m.log.Info("preview channel is enabled without a preview Envoy image, falling back to the sidecar image",
+			"namespace", pod.Namespace, "pod", pod.GenerateName+pod.Name, "image", image, "flag", flagSidecarPreviewImage)
+		return image
+	}
+	return m.config.SidecarPreviewImage
+}
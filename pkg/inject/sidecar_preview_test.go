@@ -0,0 +1,71 @@
+package inject
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_isPreviewEnabled(t *testing.T) {
+	tests := []struct {
+		name           string
+		defaultPreview bool
+		annotations    map[string]string
+		want           bool
+		wantErr        error
+	}{
+		{
+			name:           "defaults to controller preview when not annotated",
+			defaultPreview: true,
+			want:           true,
+		},
+		{
+			name:           "enabled by annotation",
+			defaultPreview: false,
+			annotations:    map[string]string{AppMeshPreviewAnnotation: "enabled"},
+			want:           true,
+		},
+		{
+			name:           "enabled by annotation of 1",
+			defaultPreview: false,
+			annotations:    map[string]string{AppMeshPreviewAnnotation: "1"},
+			want:           true,
+		},
+		{
+			name:           "disabled by annotation",
+			defaultPreview: true,
+			annotations:    map[string]string{AppMeshPreviewAnnotation: "Disabled"},
+			want:           false,
+		},
+		{
+			name:           "disabled by annotation of 0",
+			defaultPreview: true,
+			annotations:    map[string]string{AppMeshPreviewAnnotation: "0"},
+			want:           false,
+		},
+		{
+			name:           "malformed annotation",
+			defaultPreview: false,
+			annotations:    map[string]string{AppMeshPreviewAnnotation: "true"},
+			wantErr:        errors.New("malformed annotation appmesh.k8s.aws/preview: true, expected enabled/1 or disabled/0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			got, err := isPreviewEnabled(tt.defaultPreview, pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package inject
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const sidecarVolumeMountReadOnly = "ro"
+
+// getSidecarVolumeMounts parses the extra volume mounts of envoy container from pod annotations.
+// each mount is in format volumeName:mountPath, optionally suffixed with :ro to mount it read-only.
+func getSidecarVolumeMounts(pod *corev1.Pod) ([]corev1.VolumeMount, error) {
+	v, ok := pod.ObjectMeta.Annotations[AppMeshSidecarVolumeMountsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var volumeMounts []corev1.VolumeMount
+	for _, segment := range strings.Split(v, ",") {
+		parts := strings.Split(segment, ":")
+		if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && strings.TrimSpace(parts[2]) != sidecarVolumeMountReadOnly) {
+			return nil, errors.Errorf("malformed annotation %s, expected format: %s", AppMeshSidecarVolumeMountsAnnotation, "volumeName:mountPath[:ro]")
+		}
+		volumeName := strings.TrimSpace(parts[0])
+		mountPath := strings.TrimSpace(parts[1])
+		if volumeName == "" || !path.IsAbs(mountPath) {
+			return nil, errors.Errorf("malformed annotation %s, expected format: %s", AppMeshSidecarVolumeMountsAnnotation, "volumeName:mountPath[:ro]")
+		}
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: mountPath,
+			ReadOnly:  len(parts) == 3,
+		})
+	}
+	return volumeMounts, nil
+}
+
+// mutateSidecarVolumeMounts mounts pod volumes referenced by pod annotations into envoy container.
+// it must run after the controller managed mounts are added, so that mounts conflicting with them are rejected instead of clobbering them.
+func mutateSidecarVolumeMounts(pod *corev1.Pod, envoyContainer *corev1.Container, volumeMounts []corev1.VolumeMount) error {
+	for _, volumeMount := range volumeMounts {
+		if !hasPodVolume(pod, volumeMount.Name) {
+			return errors.Errorf("volume %s referenced in annotation %s doesn't exist in pod", volumeMount.Name, AppMeshSidecarVolumeMountsAnnotation)
+		}
+		for _, existingMount := range envoyContainer.VolumeMounts {
+			if path.Clean(existingMount.MountPath) == path.Clean(volumeMount.MountPath) {
+				return errors.Errorf("mountPath %s referenced in annotation %s is already mounted with volume %s",
+					volumeMount.MountPath, AppMeshSidecarVolumeMountsAnnotation, existingMount.Name)
+			}
+		}
+		envoyContainer.VolumeMounts = append(envoyContainer.VolumeMounts, volumeMount)
+	}
+	return nil
+}
+
+// hasPodVolume checks whether pod has a volume with given name.
+func hasPodVolume(pod *corev1.Pod, volumeName string) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == volumeName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package inject
+
+import (
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_getSidecarVolumeMounts(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []corev1.VolumeMount
+		wantErr     error
+	}{
+		{
+			name: "no annotation",
+			want: nil,
+		},
+		{
+			name: "single mount",
+			annotations: map[string]string{
+				AppMeshSidecarVolumeMountsAnnotation: "shared-uds:/var/run/uds",
+			},
+			want: []corev1.VolumeMount{
+				{
+					Name:      "shared-uds",
+					MountPath: "/var/run/uds",
+				},
+			},
+		},
+		{
+			name: "multiple mounts with read-only mount",
+			annotations: map[string]string{
+				AppMeshSidecarVolumeMountsAnnotation: "shared-uds:/var/run/uds, config:/etc/app:ro",
+			},
+			want: []corev1.VolumeMount{
+				{
+					Name:      "shared-uds",
+					MountPath: "/var/run/uds",
+				},
+				{
+					Name:      "config",
+					MountPath: "/etc/app",
+					ReadOnly:  true,
+				},
+			},
+		},
+		{
+			name: "missing mountPath",
+			annotations: map[string]string{
+				AppMeshSidecarVolumeMountsAnnotation: "shared-uds",
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/sidecarVolumeMounts, expected format: volumeName:mountPath[:ro]"),
+		},
+		{
+			name: "relative mountPath",
+			annotations: map[string]string{
+				AppMeshSidecarVolumeMountsAnnotation: "shared-uds:var/run/uds",
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/sidecarVolumeMounts, expected format: volumeName:mountPath[:ro]"),
+		},
+		{
+			name: "unknown mount option",
+			annotations: map[string]string{
+				AppMeshSidecarVolumeMountsAnnotation: "shared-uds:/var/run/uds:rw",
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/sidecarVolumeMounts, expected format: volumeName:mountPath[:ro]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			got, err := getSidecarVolumeMounts(pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, cmp.Equal(tt.want, got), "diff", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func Test_mutateSidecarVolumeMounts(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "shared-uds",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				},
+				{
+					Name: AppMeshSDSSocketVolume,
+				},
+			},
+		},
+	}
+	sdsMount := corev1.VolumeMount{
+		Name:      AppMeshSDSSocketVolume,
+		MountPath: "/run/spire/sockets/agent.sock",
+	}
+	tracingMount := corev1.VolumeMount{
+		Name:      envoyTracingConfigVolumeName,
+		MountPath: "/tmp/envoy",
+	}
+	tests := []struct {
+		name          string
+		container     *corev1.Container
+		volumeMounts  []corev1.VolumeMount
+		wantContainer *corev1.Container
+		wantErr       error
+	}{
+		{
+			name: "mounts are appended after controller managed mounts",
+			container: &corev1.Container{
+				Name:         "envoy",
+				VolumeMounts: []corev1.VolumeMount{sdsMount, tracingMount},
+			},
+			volumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "shared-uds",
+					MountPath: "/var/run/uds",
+				},
+			},
+			wantContainer: &corev1.Container{
+				Name: "envoy",
+				VolumeMounts: []corev1.VolumeMount{
+					sdsMount,
+					tracingMount,
+					{
+						Name:      "shared-uds",
+						MountPath: "/var/run/uds",
+					},
+				},
+			},
+		},
+		{
+			name: "volume doesn't exist in pod",
+			container: &corev1.Container{
+				Name: "envoy",
+			},
+			volumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "unknown",
+					MountPath: "/var/run/uds",
+				},
+			},
+			wantErr: errors.New("volume unknown referenced in annotation appmesh.k8s.aws/sidecarVolumeMounts doesn't exist in pod"),
+		},
+		{
+			name: "mountPath conflicts with tracing mount",
+			container: &corev1.Container{
+				Name:         "envoy",
+				VolumeMounts: []corev1.VolumeMount{sdsMount, tracingMount},
+			},
+			volumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "shared-uds",
+					MountPath: "/tmp/envoy/",
+				},
+			},
+			wantErr: errors.New("mountPath /tmp/envoy/ referenced in annotation appmesh.k8s.aws/sidecarVolumeMounts is already mounted with volume envoy-tracing-config"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := tt.container.DeepCopy()
+			err := mutateSidecarVolumeMounts(pod.DeepCopy(), container, tt.volumeMounts)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, cmp.Equal(tt.wantContainer, container), "diff", cmp.Diff(tt.wantContainer, container))
+			}
+		})
+	}
+}
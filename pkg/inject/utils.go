@@ -4,13 +4,20 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"strconv"
+	"strings"
 	"text/template"
 )
 
 const (
 	AppMeshSDSSocketVolume = "appmesh-sds-socket-volume"
+
+	readinessProbeHostLocalhost  = "localhost"
+	readinessProbeHostLoopbackIP = "127.0.0.1"
 )
 
 var envoyUtilsLogger = ctrl.Log.WithName("envoy-utils")
@@ -78,16 +85,77 @@ func getSidecarMemoryLimit(defaultMemoryLimit string, pod *corev1.Pod) string {
 	return defaultMemoryLimit
 }
 
-// containsEnvoyContainer checks whether pod already contains "envoy" container and return the slice index
-func containsEnvoyContainer(pod *corev1.Pod) (bool, int) {
+// getPodLabel returns the value of pod label with given key, or empty if key is empty or label is absent
+func getPodLabel(pod *corev1.Pod, key string) string {
+	if key == "" {
+		return ""
+	}
+	return pod.ObjectMeta.Labels[key]
+}
+
+// containsEnvoyContainer checks whether pod already contains envoy container with given name and return the slice index
+func containsEnvoyContainer(pod *corev1.Pod, containerName string) (bool, int) {
 	for idx, container := range pod.Spec.Containers {
-		if container.Name == envoyContainerName {
+		if container.Name == containerName {
 			return true, idx
 		}
 	}
 	return false, -1
 }
 
+// envoyContainerNameOrDefault returns the configured name of envoy container, or envoyContainerName when it's unset.
+func envoyContainerNameOrDefault(name string) string {
+	if name != "" {
+		return name
+	}
+	return envoyContainerName
+}
+
+// mutateReadinessProbeOverrides overrides the timeout and thresholds of proxy readiness probe with pod annotations.
+func mutateReadinessProbeOverrides(pod *corev1.Pod, probe *corev1.Probe) error {
+	overrides := []struct {
+		annotation string
+		field      *int32
+	}{
+		{annotation: AppMeshReadinessProbeTimeoutAnnotation, field: &probe.TimeoutSeconds},
+		{annotation: AppMeshReadinessProbeSuccessThresholdAnnotation, field: &probe.SuccessThreshold},
+		{annotation: AppMeshReadinessProbeFailureThresholdAnnotation, field: &probe.FailureThreshold},
+	}
+	for _, override := range overrides {
+		v, ok := pod.ObjectMeta.Annotations[override.annotation]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || value < 1 {
+			return errors.Errorf("invalid annotation %s: %s, must be a positive integer", override.annotation, v)
+		}
+		*override.field = int32(value)
+	}
+	return nil
+}
+
+// getReadinessProbeHost returns the host the proxy readiness probe of pod queries the Envoy admin interface on.
+// localhost might not resolve on pods with an unusual CRI setup, 127.0.0.1 bypasses the resolution.
+func getReadinessProbeHost(pod *corev1.Pod) (string, error) {
+	v, ok := pod.ObjectMeta.Annotations[AppMeshReadinessProbeHostAnnotation]
+	if !ok {
+		return readinessProbeHostLocalhost, nil
+	}
+	switch v {
+	case readinessProbeHostLocalhost, readinessProbeHostLoopbackIP:
+		return v, nil
+	default:
+		return "", errors.Errorf("invalid annotation %s: %s, must be %s or %s", AppMeshReadinessProbeHostAnnotation, v,
+			readinessProbeHostLocalhost, readinessProbeHostLoopbackIP)
+	}
+}
+
+// isReadinessProbeDrainingTolerated checks whether the proxy readiness probe of pod should also pass while Envoy is DRAINING.
+func isReadinessProbeDrainingTolerated(pod *corev1.Pod) bool {
+	return strings.ToLower(pod.ObjectMeta.Annotations[AppMeshReadinessProbeTolerateDrainingAnnotation]) == "true"
+}
+
 func isSDSDisabled(pod *corev1.Pod) bool {
 	if v, ok := pod.ObjectMeta.Annotations[AppMeshSDSAnnotation]; ok {
 		if v == "disabled" {
@@ -130,3 +198,23 @@ func mutateSDSMounts(pod *corev1.Pod, envoyContainer *corev1.Container, SdsUdsPa
 	envoyContainer.VolumeMounts = append(envoyContainer.VolumeMounts, volumeMount)
 	pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
 }
+
+// mutatePSACompatibleSecurityContext restricts the container's security context so that it
+// can be admitted into namespaces enforcing the restricted Pod Security Standard.
+func mutatePSACompatibleSecurityContext(pod *corev1.Pod, container *corev1.Container) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	container.SecurityContext.RunAsNonRoot = aws.Bool(true)
+	container.SecurityContext.AllowPrivilegeEscalation = aws.Bool(false)
+	container.SecurityContext.Capabilities = &corev1.Capabilities{
+		Drop: []corev1.Capability{"ALL"},
+	}
+
+	// seccompProfile field is not available in the core API version we build against,
+	// the RuntimeDefault profile is requested via the per container annotation instead.
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[corev1.SeccompContainerAnnotationKeyPrefix+container.Name] = corev1.SeccompProfileRuntimeDefault
+}
@@ -1,14 +1,19 @@
 package inject
 
 import (
+	"errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"testing"
 )
 
 func Test_containsEnvoyContainer(t *testing.T) {
 	type args struct {
-		pod *corev1.Pod
+		pod           *corev1.Pod
+		containerName string
 	}
 	tests := []struct {
 		name string
@@ -27,6 +32,7 @@ func Test_containsEnvoyContainer(t *testing.T) {
 						},
 					},
 				},
+				containerName: envoyContainerName,
 			},
 			want: true,
 		},
@@ -42,14 +48,354 @@ func Test_containsEnvoyContainer(t *testing.T) {
 						},
 					},
 				},
+				containerName: envoyContainerName,
+			},
+			want: false,
+		},
+		{
+			name: "contains envoy container with custom name",
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "appmesh-envoy",
+							},
+						},
+					},
+				},
+				containerName: "appmesh-envoy",
+			},
+			want: true,
+		},
+		{
+			name: "doesn't match default name when custom name is configured",
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "envoy",
+							},
+						},
+					},
+				},
+				containerName: "appmesh-envoy",
 			},
 			want: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, _ := containsEnvoyContainer(tt.args.pod)
+			got, _ := containsEnvoyContainer(tt.args.pod, tt.args.containerName)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func Test_mutatePSACompatibleSecurityContext(t *testing.T) {
+	type args struct {
+		pod       *corev1.Pod
+		container *corev1.Container
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantPod       *corev1.Pod
+		wantContainer *corev1.Container
+	}{
+		{
+			name: "container without security context",
+			args: args{
+				pod: &corev1.Pod{},
+				container: &corev1.Container{
+					Name: "envoy",
+				},
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"container.seccomp.security.alpha.kubernetes.io/envoy": "runtime/default",
+					},
+				},
+			},
+			wantContainer: &corev1.Container{
+				Name: "envoy",
+				SecurityContext: &corev1.SecurityContext{
+					RunAsNonRoot:             aws.Bool(true),
+					AllowPrivilegeEscalation: aws.Bool(false),
+					Capabilities: &corev1.Capabilities{
+						Drop: []corev1.Capability{"ALL"},
+					},
+				},
+			},
+		},
+		{
+			name: "container with existing security context",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"some-key": "some-value",
+						},
+					},
+				},
+				container: &corev1.Container{
+					Name: "envoy",
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: aws.Int64(1337),
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"some-key": "some-value",
+						"container.seccomp.security.alpha.kubernetes.io/envoy": "runtime/default",
+					},
+				},
+			},
+			wantContainer: &corev1.Container{
+				Name: "envoy",
+				SecurityContext: &corev1.SecurityContext{
+					RunAsUser:                aws.Int64(1337),
+					RunAsNonRoot:             aws.Bool(true),
+					AllowPrivilegeEscalation: aws.Bool(false),
+					Capabilities: &corev1.Capabilities{
+						Drop: []corev1.Capability{"ALL"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := tt.args.pod.DeepCopy()
+			container := tt.args.container.DeepCopy()
+			mutatePSACompatibleSecurityContext(pod, container)
+			assert.True(t, cmp.Equal(tt.wantPod, pod), "diff", cmp.Diff(tt.wantPod, pod))
+			assert.True(t, cmp.Equal(tt.wantContainer, container), "diff", cmp.Diff(tt.wantContainer, container))
+		})
+	}
+}
+
+func Test_mutateReadinessProbeOverrides(t *testing.T) {
+	type args struct {
+		pod *corev1.Pod
+	}
+	tests := []struct {
+		name                 string
+		args                 args
+		wantTimeoutSeconds   int32
+		wantSuccessThreshold int32
+		wantFailureThreshold int32
+		wantErr              error
+	}{
+		{
+			name: "no annotations keep defaults",
+			args: args{
+				pod: &corev1.Pod{},
+			},
+			wantTimeoutSeconds:   1,
+			wantSuccessThreshold: 1,
+			wantFailureThreshold: 3,
+		},
+		{
+			name: "all annotations override defaults",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/readinessProbeTimeout":          "5",
+							"appmesh.k8s.aws/readinessProbeSuccessThreshold": "2",
+							"appmesh.k8s.aws/readinessProbeFailureThreshold": "10",
+						},
+					},
+				},
+			},
+			wantTimeoutSeconds:   5,
+			wantSuccessThreshold: 2,
+			wantFailureThreshold: 10,
+		},
+		{
+			name: "timeout annotation only",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/readinessProbeTimeout": "3",
+						},
+					},
+				},
+			},
+			wantTimeoutSeconds:   3,
+			wantSuccessThreshold: 1,
+			wantFailureThreshold: 3,
+		},
+		{
+			name: "timeout annotation is zero",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/readinessProbeTimeout": "0",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/readinessProbeTimeout: 0, must be a positive integer"),
+		},
+		{
+			name: "failure threshold annotation isn't an integer",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/readinessProbeFailureThreshold": "3s",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/readinessProbeFailureThreshold: 3s, must be a positive integer"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := envoyReadinessProbe(1, 10, "localhost", "9901", false)
+			err := mutateReadinessProbeOverrides(tt.args.pod, probe)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTimeoutSeconds, probe.TimeoutSeconds)
+				assert.Equal(t, tt.wantSuccessThreshold, probe.SuccessThreshold)
+				assert.Equal(t, tt.wantFailureThreshold, probe.FailureThreshold)
+				assert.Equal(t, int32(1), probe.InitialDelaySeconds)
+				assert.Equal(t, int32(10), probe.PeriodSeconds)
+			}
+		})
+	}
+}
+
+func Test_envoyReadinessProbe_tolerateDraining(t *testing.T) {
+	type args struct {
+		tolerateDraining bool
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantCommand []string
+	}{
+		{
+			name: "only LIVE is ready",
+			args: args{
+				tolerateDraining: false,
+			},
+			wantCommand: []string{"sh", "-c", "curl -s http://localhost:9901/server_info | grep state | grep -q LIVE"},
+		},
+		{
+			name: "LIVE and DRAINING are ready",
+			args: args{
+				tolerateDraining: true,
+			},
+			wantCommand: []string{"sh", "-c", "curl -s http://localhost:9901/server_info | grep state | grep -qE 'LIVE|DRAINING'"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := envoyReadinessProbe(1, 10, "localhost", "9901", tt.args.tolerateDraining)
+			assert.Equal(t, tt.wantCommand, probe.Exec.Command)
+		})
+	}
+	strictProbe := envoyReadinessProbe(1, 10, "localhost", "9901", false)
+	tolerantProbe := envoyReadinessProbe(1, 10, "localhost", "9901", true)
+	assert.NotEqual(t, strictProbe.Exec.Command, tolerantProbe.Exec.Command)
+}
+
+func Test_isReadinessProbeDrainingTolerated(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name: "annotation not specified",
+			want: false,
+		},
+		{
+			name: "annotation is true",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/readinessProbeTolerateDraining": "true",
+			},
+			want: true,
+		},
+		{
+			name: "annotation is false",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/readinessProbeTolerateDraining": "false",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			assert.Equal(t, tt.want, isReadinessProbeDrainingTolerated(pod))
+		})
+	}
+}
+
+func Test_getReadinessProbeHost(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+		wantErr     error
+	}{
+		{
+			name: "annotation not specified",
+			want: "localhost",
+		},
+		{
+			name: "annotation is localhost",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/readinessProbeHost": "localhost",
+			},
+			want: "localhost",
+		},
+		{
+			name: "annotation is 127.0.0.1",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/readinessProbeHost": "127.0.0.1",
+			},
+			want: "127.0.0.1",
+		},
+		{
+			name: "annotation is another host",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/readinessProbeHost": "envoy.example.com",
+			},
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/readinessProbeHost: envoy.example.com, must be localhost or 127.0.0.1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			got, err := getReadinessProbeHost(pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
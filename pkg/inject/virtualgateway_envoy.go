@@ -47,11 +47,15 @@ type virtualGatwayEnvoyConfig struct {
 	logLevel                   string
 	adminAccessPort            int32
 	adminAccessLogFile         string
+	accessLogVolume            bool
 	sidecarImage               string
+	sidecarContainerName       string
 	readinessProbeInitialDelay int32
 	readinessProbePeriod       int32
 	enableXrayTracing          bool
 	xrayDaemonPort             int32
+	caBundle                   string
+	enablePodMetadataEnv       bool
 }
 
 // newVirtualGatewayEnvoyConfig constructs new newVirtualGatewayEnvoyConfig
@@ -73,7 +77,7 @@ type virtualGatewayEnvoyConfig struct {
 }
 
 func (m *virtualGatewayEnvoyConfig) mutate(pod *corev1.Pod) error {
-	ok, envoyIdx := containsEnvoyContainer(pod)
+	ok, envoyIdx := containsEnvoyContainer(pod, envoyContainerNameOrDefault(m.mutatorConfig.sidecarContainerName))
 	if !ok {
 		return nil
 	}
@@ -111,18 +115,58 @@ func (m *virtualGatewayEnvoyConfig) mutate(pod *corev1.Pod) error {
 		pod.Spec.Containers[envoyIdx].Env = append(pod.Spec.Containers[envoyIdx].Env, e)
 	}
 
+	if m.mutatorConfig.enablePodMetadataEnv {
+		mutatePodMetadataEnv(&pod.Spec.Containers[envoyIdx])
+	}
+
 	// customer can bring their own envoy image/spec for virtual gateway so we will only set readiness probe if not already set
 	if pod.Spec.Containers[envoyIdx].ReadinessProbe == nil {
+		readinessProbeHost, err := getReadinessProbeHost(pod)
+		if err != nil {
+			return err
+		}
 		pod.Spec.Containers[envoyIdx].ReadinessProbe = envoyReadinessProbe(m.mutatorConfig.readinessProbeInitialDelay,
-			m.mutatorConfig.readinessProbePeriod, strconv.Itoa(int(m.mutatorConfig.adminAccessPort)))
+			m.mutatorConfig.readinessProbePeriod, readinessProbeHost, strconv.Itoa(int(m.mutatorConfig.adminAccessPort)), isReadinessProbeDrainingTolerated(pod))
+		if err := mutateReadinessProbeOverrides(pod, pod.Spec.Containers[envoyIdx].ReadinessProbe); err != nil {
+			return err
+		}
 	}
 
 	if m.mutatorConfig.enableSDS && !isSDSDisabled(pod) {
 		mutateSDSMounts(pod, &pod.Spec.Containers[envoyIdx], m.mutatorConfig.sdsUdsPath)
 	}
+
+	if m.mutatorConfig.caBundle != "" {
+		caBundle, err := parseCABundleSource(m.mutatorConfig.caBundle)
+		if err != nil {
+			return err
+		}
+		mutateCABundleMounts(pod, &pod.Spec.Containers[envoyIdx], caBundle)
+	}
+
+	if m.mutatorConfig.accessLogVolume {
+		if err := mutateAccessLogMounts(pod, &pod.Spec.Containers[envoyIdx], m.mutatorConfig.adminAccessLogFile); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// mutatePodMetadataEnv sets the pod name and namespace env of Envoy from the downward API,
+// they take precedence over the same env in the pod spec just like for virtual node pods.
+func mutatePodMetadataEnv(container *corev1.Container) {
+	var env []corev1.EnvVar
+	for _, e := range container.Env {
+		if e.Name != podNameEnv && e.Name != podNamespaceEnv {
+			env = append(env, e)
+		}
+	}
+	container.Env = append(env,
+		refField(podNameEnv, "metadata.name"),
+		refField(podNamespaceEnv, "metadata.namespace"),
+	)
+}
+
 func (m *virtualGatewayEnvoyConfig) buildTemplateVariables(pod *corev1.Pod) VirtualGatewayEnvoyVariables {
 	meshName := m.getAugmentedMeshName()
 	virtualGatewayName := aws.StringValue(m.vg.Spec.AWSName)
@@ -148,10 +192,8 @@ func (m *virtualGatewayEnvoyConfig) buildTemplateVariables(pod *corev1.Pod) Virt
 }
 
 func (m *virtualGatewayEnvoyConfig) getPreview(pod *corev1.Pod) string {
-	preview := m.mutatorConfig.preview
-	if v, ok := pod.ObjectMeta.Annotations[AppMeshPreviewAnnotation]; ok {
-		preview = strings.ToLower(v) == "enabled"
-	}
+	// malformed annotation is rejected before pod is mutated
+	preview, _ := isPreviewEnabled(m.mutatorConfig.preview, pod)
 	if preview {
 		return "1"
 	}
@@ -173,6 +215,18 @@ const (
 	gatewayImageSkipOverrideModeDisabled = "disabled"
 )
 
+// virtualGatewaySidecarImageForPod returns the Envoy image virtual gateway pod is overridden with,
+// which defaults to the image of sidecars.
+func virtualGatewaySidecarImageForPod(cfg Config, pod *corev1.Pod) string {
+	if v, ok := pod.ObjectMeta.Annotations[AppMeshGatewaySidecarImageAnnotation]; ok && strings.TrimSpace(v) != "" {
+		return strings.TrimSpace(v)
+	}
+	if cfg.GatewaySidecarImage != "" {
+		return cfg.GatewaySidecarImage
+	}
+	return sidecarImageForPod(cfg, pod)
+}
+
 func (m *virtualGatewayEnvoyConfig) virtualGatewayImageOverride(pod *corev1.Pod) bool {
 
 	var imageOverrideAnnotation string
@@ -1186,3 +1186,93 @@ func Test_virtualGatewayEnvoyMutator_mutate(t *testing.T) {
 		})
 	}
 }
+
+func Test_virtualGatewayEnvoyMutator_mutate_podMetadataEnv(t *testing.T) {
+	ms := &appmesh.Mesh{
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+	}
+	vg := &appmesh.VirtualGateway{
+		Spec: appmesh.VirtualGatewaySpec{
+			AWSName: aws.String("my-vg_my-ns"),
+		},
+	}
+	podNameEnvVar := corev1.EnvVar{
+		Name: "APPMESH_PLATFORM_K8S_POD_NAME",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.name",
+			},
+		},
+	}
+	podNamespaceEnvVar := corev1.EnvVar{
+		Name: "APPMESH_PLATFORM_K8S_POD_NAMESPACE",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.namespace",
+			},
+		},
+	}
+	tests := []struct {
+		name                 string
+		enablePodMetadataEnv bool
+		env                  []corev1.EnvVar
+		wantEnv              []corev1.EnvVar
+		wantNoEnv            []string
+	}{
+		{
+			name:                 "pod metadata env disabled",
+			enablePodMetadataEnv: false,
+			wantNoEnv:            []string{"APPMESH_PLATFORM_K8S_POD_NAME", "APPMESH_PLATFORM_K8S_POD_NAMESPACE"},
+		},
+		{
+			name:                 "pod metadata env enabled",
+			enablePodMetadataEnv: true,
+			wantEnv:              []corev1.EnvVar{podNameEnvVar, podNamespaceEnvVar},
+		},
+		{
+			name:                 "pod metadata env enabled takes precedence over container env",
+			enablePodMetadataEnv: true,
+			env: []corev1.EnvVar{
+				{
+					Name:  "APPMESH_PLATFORM_K8S_POD_NAME",
+					Value: "my-pod",
+				},
+			},
+			wantEnv: []corev1.EnvVar{podNameEnvVar, podNamespaceEnvVar},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newVirtualGatewayEnvoyConfig(virtualGatwayEnvoyConfig{
+				awsRegion:            "us-west-2",
+				enablePodMetadataEnv: tt.enablePodMetadataEnv,
+			}, ms, vg)
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:           "envoy",
+							Image:          "envoy:v2",
+							Env:            tt.env,
+							ReadinessProbe: &corev1.Probe{},
+						},
+					},
+				},
+			}
+			err := m.mutate(pod)
+			assert.NoError(t, err)
+			envByName := make(map[string][]corev1.EnvVar)
+			for _, env := range pod.Spec.Containers[0].Env {
+				envByName[env.Name] = append(envByName[env.Name], env)
+			}
+			for _, wantEnv := range tt.wantEnv {
+				assert.Equal(t, []corev1.EnvVar{wantEnv}, envByName[wantEnv.Name])
+			}
+			for _, name := range tt.wantNoEnv {
+				assert.NotContains(t, envByName, name)
+			}
+		})
+	}
+}
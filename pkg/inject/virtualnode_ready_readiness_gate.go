@@ -0,0 +1,32 @@
+package inject
+
+import (
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// newVirtualNodeReadyReadinessGate constructs new virtualNodeReadyReadinessGate
+func newVirtualNodeReadyReadinessGate(enabled bool) *virtualNodeReadyReadinessGate {
+	return &virtualNodeReadyReadinessGate{
+		enabled: enabled,
+	}
+}
+
+var _ PodMutator = &virtualNodeReadyReadinessGate{}
+
+// mutator adding a readiness gate that blocks pod readiness until its VirtualNode is active in AppMesh.
+type virtualNodeReadyReadinessGate struct {
+	enabled bool
+}
+
+func (m *virtualNodeReadyReadinessGate) mutate(pod *corev1.Pod) error {
+	if !m.enabled {
+		return nil
+	}
+	if !k8s.HasPodReadinessGate(pod, k8s.ConditionAppMeshVirtualNodeReady) {
+		pod.Spec.ReadinessGates = append(pod.Spec.ReadinessGates, corev1.PodReadinessGate{
+			ConditionType: k8s.ConditionAppMeshVirtualNodeReady,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package inject
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func Test_virtualNodeReadyReadinessGate_mutate(t *testing.T) {
+	type fields struct {
+		enabled bool
+	}
+	type args struct {
+		pod *corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantPod *corev1.Pod
+		wantErr error
+	}{
+		{
+			name: "no-op when disabled",
+			fields: fields{
+				enabled: false,
+			},
+			args: args{
+				pod: &corev1.Pod{},
+			},
+			wantPod: &corev1.Pod{},
+		},
+		{
+			name: "inject readinessGate when enabled",
+			fields: fields{
+				enabled: true,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						ReadinessGates: []corev1.PodReadinessGate{
+							{
+								ConditionType: "condition-A",
+							},
+						},
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "condition-A",
+						},
+						{
+							ConditionType: "appmesh.k8s.aws/virtual-node-ready",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no-op when readinessGate already exists",
+			fields: fields{
+				enabled: true,
+			},
+			args: args{
+				pod: &corev1.Pod{
+					Spec: corev1.PodSpec{
+						ReadinessGates: []corev1.PodReadinessGate{
+							{
+								ConditionType: "appmesh.k8s.aws/virtual-node-ready",
+							},
+						},
+					},
+				},
+			},
+			wantPod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "appmesh.k8s.aws/virtual-node-ready",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &virtualNodeReadyReadinessGate{
+				enabled: tt.fields.enabled,
+			}
+			pod := tt.args.pod.DeepCopy()
+			err := m.mutate(pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPod, pod)
+			}
+		})
+	}
+}
@@ -13,7 +13,7 @@ const xrayDaemonContainerTemplate = `
   "name": "xray-daemon",
   "image": "{{ .XRayImage }}",
   "securityContext": {
-    "runAsUser": 1337
+    "runAsUser": {{ .RunAsUser }}
   },
   "ports": [
     {
@@ -35,6 +35,7 @@ type XrayTemplateVariables struct {
 	AWSRegion      string
 	XRayImage      string
 	XrayDaemonPort int32
+	RunAsUser      int64
 }
 
 type xrayMutatorConfig struct {
@@ -45,6 +46,9 @@ type xrayMutatorConfig struct {
 	sidecarMemoryLimits   string
 	xRayImage             string
 	xRayDaemonPort        int32
+	runAsUser             int64
+	// terminationMessagePolicy of the X-Ray daemon container, left unset if empty
+	terminationMessagePolicy corev1.TerminationMessagePolicy
 }
 
 func newXrayMutator(mutatorConfig xrayMutatorConfig, enabled bool) *xrayMutator {
@@ -83,12 +87,14 @@ func (m *xrayMutator) mutate(pod *corev1.Pod) error {
 	if err != nil {
 		return err
 	}
-
-	// add resource requests and limits
-	container.Resources, err = sidecarResources(getSidecarCPURequest(m.mutatorConfig.sidecarCPURequests, pod),
-		getSidecarMemoryRequest(m.mutatorConfig.sidecarMemoryRequests, pod),
-		getSidecarCPULimit(m.mutatorConfig.sidecarCPULimits, pod),
-		getSidecarMemoryLimit(m.mutatorConfig.sidecarMemoryLimits, pod))
+	container.TerminationMessagePolicy = m.mutatorConfig.terminationMessagePolicy
+
+	// add resource requests and limits, the proxy annotations still apply unless the X-Ray ones are set
+	container.Resources, err = sidecarResources(
+		getXRayResource(pod, AppMeshXRayCPURequestAnnotation, getSidecarCPURequest(m.mutatorConfig.sidecarCPURequests, pod)),
+		getXRayResource(pod, AppMeshXRayMemoryRequestAnnotation, getSidecarMemoryRequest(m.mutatorConfig.sidecarMemoryRequests, pod)),
+		getXRayResource(pod, AppMeshXRayCPULimitAnnotation, getSidecarCPULimit(m.mutatorConfig.sidecarCPULimits, pod)),
+		getXRayResource(pod, AppMeshXRayMemoryLimitAnnotation, getSidecarMemoryLimit(m.mutatorConfig.sidecarMemoryLimits, pod)))
 	if err != nil {
 		return err
 	}
@@ -102,6 +108,7 @@ func (m *xrayMutator) buildTemplateVariables(pod *corev1.Pod) XrayTemplateVariab
 		AWSRegion:      m.mutatorConfig.awsRegion,
 		XRayImage:      m.mutatorConfig.xRayImage,
 		XrayDaemonPort: m.mutatorConfig.xRayDaemonPort,
+		RunAsUser:      proxyUIDOrDefault(m.mutatorConfig.runAsUser),
 	}
 }
 
@@ -126,6 +133,14 @@ func (m *xrayMutator) checkConfig() error {
 	return nil
 }
 
+// getXRayResource returns the X-Ray daemon resource quantity from pod annotation, or defaultValue if not set.
+func getXRayResource(pod *corev1.Pod, annotation string, defaultValue string) string {
+	if v, ok := pod.ObjectMeta.Annotations[annotation]; ok {
+		return v
+	}
+	return defaultValue
+}
+
 func containsXRAYDaemonContainer(pod *corev1.Pod) bool {
 	for _, container := range pod.Spec.Containers {
 		if container.Name == xrayDaemonContainerName {
@@ -0,0 +1,71 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// irsaRoleARNAnnotation binds a ServiceAccount to an IAM role via IAM roles for service accounts.
+	irsaRoleARNAnnotation     = "eks.amazonaws.com/role-arn"
+	defaultServiceAccountName = "default"
+)
+
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch
+
+// validateXRayServiceAccount warns when the X-Ray daemon of pod runs with a ServiceAccount lacking an IAM role,
+// in which case PutTraceSegments falls back to the node role. With xray-require-irsa, such pods are denied instead.
+// Failures to look up the ServiceAccount only deny pods with xray-require-irsa as well.
+func (m *SidecarInjector) validateXRayServiceAccount(ctx context.Context, pod *corev1.Pod) error {
+	issue, err := m.findXRayServiceAccountIssue(ctx, pod)
+	if err != nil {
+		if m.config.XRayRequireIRSA {
+			return err
+		}
+		m.log.Error(err, "unable to check whether X-Ray daemon has permissions for PutTraceSegments",
+			"namespace", pod.Namespace,
+			"name", pod.Name,
+			"generateName", pod.GenerateName,
+		)
+		return nil
+	}
+	if issue == "" {
+		return nil
+	}
+	if m.config.XRayRequireIRSA {
+		return errors.Errorf("%s, required by %s", issue, flagXRayRequireIRSA)
+	}
+	m.log.Info("X-Ray daemon may lack permissions for PutTraceSegments",
+		"namespace", pod.Namespace,
+		"name", pod.Name,
+		"generateName", pod.GenerateName,
+		"issue", issue,
+	)
+	return nil
+}
+
+// findXRayServiceAccountIssue returns why the ServiceAccount of pod isn't bound to an IAM role for the X-Ray daemon,
+// or empty if it's bound or pod doesn't run the X-Ray daemon.
+func (m *SidecarInjector) findXRayServiceAccountIssue(ctx context.Context, pod *corev1.Pod) (string, error) {
+	if !m.config.EnableXrayTracing || !containsXRAYDaemonContainer(pod) {
+		return "", nil
+	}
+	saName := pod.Spec.ServiceAccountName
+	if saName == "" {
+		saName = defaultServiceAccountName
+	}
+	req := webhook.ContextGetAdmissionRequest(ctx)
+	sa := &corev1.ServiceAccount{}
+	if err := m.getWithRetry(ctx, types.NamespacedName{Namespace: req.Namespace, Name: saName}, sa); err != nil {
+		return "", errors.Wrapf(err, "failed to get serviceAccount %s", saName)
+	}
+	if sa.Annotations[irsaRoleARNAnnotation] == "" {
+		return fmt.Sprintf("serviceAccount %s of X-Ray daemon doesn't have annotation %s", saName, irsaRoleARNAnnotation), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,149 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"testing"
+)
+
+func TestSidecarInjector_validateXRayServiceAccount(t *testing.T) {
+	irsaSA := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "tracing-sa",
+			Annotations: map[string]string{
+				"eks.amazonaws.com/role-arn": "arn:aws:iam::000000000000:role/xray",
+			},
+		},
+	}
+	defaultSA := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "default",
+		},
+	}
+	xrayPod := func(saName string) *corev1.Pod {
+		return &corev1.Pod{
+			Spec: corev1.PodSpec{
+				ServiceAccountName: saName,
+				Containers: []corev1.Container{
+					{Name: "app"},
+					{Name: "envoy"},
+					{Name: "xray-daemon"},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name              string
+		serviceAccounts   []*corev1.ServiceAccount
+		enableXrayTracing bool
+		requireIRSA       bool
+		pod               *corev1.Pod
+		wantIssue         string
+		wantFindErr       error
+		wantErr           error
+	}{
+		{
+			name:              "X-Ray tracing disabled",
+			enableXrayTracing: false,
+			requireIRSA:       true,
+			pod:               xrayPod(""),
+		},
+		{
+			name:              "pod without X-Ray daemon",
+			enableXrayTracing: true,
+			requireIRSA:       true,
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}, {Name: "envoy"}},
+				},
+			},
+		},
+		{
+			name:              "serviceAccount bound to IAM role",
+			serviceAccounts:   []*corev1.ServiceAccount{irsaSA},
+			enableXrayTracing: true,
+			requireIRSA:       true,
+			pod:               xrayPod("tracing-sa"),
+		},
+		{
+			name:              "default serviceAccount without IAM role only warns",
+			serviceAccounts:   []*corev1.ServiceAccount{defaultSA},
+			enableXrayTracing: true,
+			requireIRSA:       false,
+			pod:               xrayPod(""),
+			wantIssue:         "serviceAccount default of X-Ray daemon doesn't have annotation eks.amazonaws.com/role-arn",
+		},
+		{
+			name:              "default serviceAccount without IAM role is denied when required",
+			serviceAccounts:   []*corev1.ServiceAccount{defaultSA},
+			enableXrayTracing: true,
+			requireIRSA:       true,
+			pod:               xrayPod(""),
+			wantIssue:         "serviceAccount default of X-Ray daemon doesn't have annotation eks.amazonaws.com/role-arn",
+			wantErr:           errors.New("serviceAccount default of X-Ray daemon doesn't have annotation eks.amazonaws.com/role-arn, required by xray-require-irsa"),
+		},
+		{
+			name:              "serviceAccount doesn't exist only warns",
+			enableXrayTracing: true,
+			requireIRSA:       false,
+			pod:               xrayPod("tracing-sa"),
+			wantFindErr:       errors.New("failed to get serviceAccount tracing-sa: serviceaccounts \"tracing-sa\" not found"),
+		},
+		{
+			name:              "serviceAccount doesn't exist is denied when required",
+			enableXrayTracing: true,
+			requireIRSA:       true,
+			pod:               xrayPod("tracing-sa"),
+			wantFindErr:       errors.New("failed to get serviceAccount tracing-sa: serviceaccounts \"tracing-sa\" not found"),
+			wantErr:           errors.New("failed to get serviceAccount tracing-sa: serviceaccounts \"tracing-sa\" not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			m := &SidecarInjector{
+				config: Config{
+					EnableXrayTracing: tt.enableXrayTracing,
+					XRayRequireIRSA:   tt.requireIRSA,
+				},
+				k8sClient: k8sClient,
+				log:       &log.NullLogger{},
+			}
+			for _, sa := range tt.serviceAccounts {
+				err := k8sClient.Create(ctx, sa.DeepCopy())
+				assert.NoError(t, err)
+			}
+			ctx = webhook.ContextWithAdmissionRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{Namespace: "awesome-ns"},
+			})
+			issue, err := m.findXRayServiceAccountIssue(ctx, tt.pod)
+			if tt.wantFindErr != nil {
+				assert.EqualError(t, err, tt.wantFindErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantIssue, issue)
+			}
+			err = m.validateXRayServiceAccount(ctx, tt.pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
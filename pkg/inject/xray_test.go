@@ -60,6 +60,30 @@ func Test_xrayMutator_mutate(t *testing.T) {
 		},
 	}
 
+	xrayAnnotationCpuRequest, _ := resource.ParseQuantity("50m")
+	xrayAnnotationMemoryLimit, _ := resource.ParseQuantity("100Mi")
+
+	podWithXRayResourceAnnotations := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "my-pod",
+			Annotations: map[string]string{
+				AppMeshCPURequestAnnotation:      annotationCpuRequest.String(),
+				AppMeshMemoryLimitAnnotation:     annotationMemoryLimit.String(),
+				AppMeshXRayCPURequestAnnotation:  xrayAnnotationCpuRequest.String(),
+				AppMeshXRayMemoryLimitAnnotation: xrayAnnotationMemoryLimit.String(),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "app",
+					Image: "app/v1",
+				},
+			},
+		},
+	}
+
 	mutatorConfig := xrayMutatorConfig{
 		awsRegion:             "us-west-2",
 		sidecarCPURequests:    cpuRequests.String(),
@@ -587,6 +611,66 @@ func Test_xrayMutator_mutate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "x-ray resource annotations take precedence over proxy ones",
+			fields: fields{
+				enabled:       true,
+				mutatorConfig: mutatorConfig,
+			},
+			args: args{
+				pod: podWithXRayResourceAnnotations,
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "my-pod",
+					Annotations: map[string]string{
+						AppMeshCPURequestAnnotation:      annotationCpuRequest.String(),
+						AppMeshMemoryLimitAnnotation:     annotationMemoryLimit.String(),
+						AppMeshXRayCPURequestAnnotation:  xrayAnnotationCpuRequest.String(),
+						AppMeshXRayMemoryLimitAnnotation: xrayAnnotationMemoryLimit.String(),
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: "app/v1",
+						},
+						{
+							Name:  "xray-daemon",
+							Image: "amazon/aws-xray-daemon",
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser: aws.Int64(1337),
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "xray",
+									ContainerPort: 2000,
+									Protocol:      "UDP",
+								},
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "AWS_REGION",
+									Value: "us-west-2",
+								},
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									"cpu":    xrayAnnotationCpuRequest,
+									"memory": memoryRequests,
+								},
+								Limits: corev1.ResourceList{
+									"cpu":    cpuLimits,
+									"memory": xrayAnnotationMemoryLimit,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
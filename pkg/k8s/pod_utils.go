@@ -8,8 +8,20 @@ import (
 
 const (
 	ConditionAWSCloudMapHealthy = "conditions.appmesh.k8s.aws/aws-cloudmap-healthy"
+	// ConditionAppMeshVirtualNodeReady is true once the VirtualNode selecting the pod is active in AppMesh.
+	ConditionAppMeshVirtualNodeReady = "appmesh.k8s.aws/virtual-node-ready"
 )
 
+// HasPodReadinessGate checks whether pod contains readinessGate with specific conditionType.
+func HasPodReadinessGate(pod *corev1.Pod, conditionType corev1.PodConditionType) bool {
+	for _, item := range pod.Spec.ReadinessGates {
+		if item.ConditionType == conditionType {
+			return true
+		}
+	}
+	return false
+}
+
 // GetPodCondition will get pointer to Pod's existing condition.
 func GetPodCondition(pod *corev1.Pod, conditionType corev1.PodConditionType) *corev1.PodCondition {
 	for i := range pod.Status.Conditions {
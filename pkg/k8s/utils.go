@@ -5,6 +5,20 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 )
 
+const (
+	// AnnotationReconciliationPaused when set to "true" stops the controller from reconciling the annotated resource.
+	AnnotationReconciliationPaused = "appmesh.k8s.aws/paused"
+)
+
+const (
+	// AnnotationDeletionPolicy controls whether the AppMesh resource is deleted along with the annotated resource.
+	AnnotationDeletionPolicy = "appmesh.k8s.aws/deletionPolicy"
+	// DeletionPolicyDelete deletes the AppMesh resource, which is the default.
+	DeletionPolicyDelete = "delete"
+	// DeletionPolicyOrphan leaves the AppMesh resource intact and only removes the finalizer.
+	DeletionPolicyOrphan = "orphan"
+)
+
 // NamespacedName returns the namespaced name for k8s objects
 func NamespacedName(obj metav1.Object) types.NamespacedName {
 	return types.NamespacedName{
@@ -12,3 +26,13 @@ func NamespacedName(obj metav1.Object) types.NamespacedName {
 		Name:      obj.GetName(),
 	}
 }
+
+// IsReconciliationPaused checks whether reconciliation for k8s object is paused via annotation.
+func IsReconciliationPaused(obj metav1.Object) bool {
+	return obj.GetAnnotations()[AnnotationReconciliationPaused] == "true"
+}
+
+// IsDeletionPolicyOrphan checks whether the AppMesh resource for k8s object should be orphaned on deletion via annotation.
+func IsDeletionPolicyOrphan(obj metav1.Object) bool {
+	return obj.GetAnnotations()[AnnotationDeletionPolicy] == DeletionPolicyOrphan
+}
@@ -47,3 +47,105 @@ func TestNamespacedName(t *testing.T) {
 		})
 	}
 }
+
+func TestIsReconciliationPaused(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  metav1.Object
+		want bool
+	}{
+		{
+			name: "without annotations",
+			obj: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vs",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "paused annotation is true",
+			obj: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vs",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "paused annotation is false",
+			obj: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vs",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "false",
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsReconciliationPaused(tt.obj)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsDeletionPolicyOrphan(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  metav1.Object
+		want bool
+	}{
+		{
+			name: "without annotations",
+			obj: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vn",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "deletionPolicy annotation is orphan",
+			obj: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vn",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/deletionPolicy": "orphan",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "deletionPolicy annotation is delete",
+			obj: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vn",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/deletionPolicy": "delete",
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsDeletionPolicyOrphan(tt.obj)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
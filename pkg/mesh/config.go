@@ -0,0 +1,41 @@
+package mesh
+
+import (
+	"fmt"
+
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagDefaultEgressFilter          = "default-egress-filter"
+	flagEnforceMeshNamespaceSelector = "enforce-mesh-namespace-selector"
+	flagAutoCreateMesh               = "auto-create-mesh"
+)
+
+type Config struct {
+	// EgressFilter type applied to Meshes that don't specify egressFilter.
+	// empty means AppMesh's own default(ALLOW_ALL) is used.
+	DefaultEgressFilter string
+	// EnforceNamespaceSelector denies namespaced AppMesh CRs whose namespace isn't selected by their mesh.
+	EnforceNamespaceSelector bool
+	// AutoCreateMesh creates the mesh named by a namespace label when no mesh selects that namespace yet.
+	AutoCreateMesh bool
+}
+
+func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&cfg.DefaultEgressFilter, flagDefaultEgressFilter, "",
+		`EgressFilter type applied to Meshes that don't specify egressFilter - ALLOW_ALL or DROP_ALL`)
+	fs.BoolVar(&cfg.EnforceNamespaceSelector, flagEnforceMeshNamespaceSelector, false,
+		`If enabled, webhooks deny AppMesh resources whose namespace isn't selected by the namespaceSelector of their mesh`)
+	fs.BoolVar(&cfg.AutoCreateMesh, flagAutoCreateMesh, false,
+		`If enabled, webhooks create a minimal mesh named by the `+MeshNameLabel+` label of a namespace when no mesh selects it`)
+}
+
+func (cfg *Config) Validate() error {
+	switch appmesh.EgressFilterType(cfg.DefaultEgressFilter) {
+	case "", appmesh.EgressFilterTypeAllowAll, appmesh.EgressFilterTypeDropAll:
+		return nil
+	}
+	return fmt.Errorf("invalid %s %s, must be %s or %s", flagDefaultEgressFilter, cfg.DefaultEgressFilter, appmesh.EgressFilterTypeAllowAll, appmesh.EgressFilterTypeDropAll)
+}
@@ -0,0 +1,43 @@
+package mesh
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{
+			name: "no default egressFilter",
+			cfg:  Config{},
+		},
+		{
+			name: "ALLOW_ALL default egressFilter",
+			cfg:  Config{DefaultEgressFilter: "ALLOW_ALL"},
+		},
+		{
+			name: "DROP_ALL default egressFilter",
+			cfg:  Config{DefaultEgressFilter: "DROP_ALL"},
+		},
+		{
+			name:    "unknown default egressFilter",
+			cfg:     Config{DefaultEgressFilter: "drop_all"},
+			wantErr: errors.New("invalid default-egress-filter drop_all, must be ALLOW_ALL or DROP_ALL"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -3,8 +3,11 @@ package mesh
 import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -12,6 +15,11 @@ import (
 	"strings"
 )
 
+const (
+	// MeshNameLabel is the namespace label naming the mesh to create when auto-create-mesh is enabled.
+	MeshNameLabel = "appmesh.k8s.aws/meshName"
+)
+
 // MembershipDesignator designates mesh membership for namespaced AppMesh CRs.
 type MembershipDesignator interface {
 	// Designate will choose a mesh for given namespaced AppMesh CR.
@@ -19,8 +27,11 @@ type MembershipDesignator interface {
 }
 
 // NewMembershipDesignator creates new MembershipDesignator.
-func NewMembershipDesignator(k8sClient client.Client) MembershipDesignator {
-	return &membershipDesignator{k8sClient: k8sClient}
+func NewMembershipDesignator(k8sClient client.Client, cfg Config) MembershipDesignator {
+	return &membershipDesignator{
+		k8sClient:      k8sClient,
+		autoCreateMesh: cfg.AutoCreateMesh,
+	}
 }
 
 var _ MembershipDesignator = &membershipDesignator{}
@@ -28,10 +39,12 @@ var _ MembershipDesignator = &membershipDesignator{}
 // meshSelectorDesignator designates mesh membership based on selectors on mesh.
 type membershipDesignator struct {
 	k8sClient client.Client
+	// when enabled, the mesh named by the MeshNameLabel of a namespace is created if no mesh selects that namespace.
+	autoCreateMesh bool
 }
 
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
-// +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=meshes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=meshes,verbs=get;list;watch;create
 
 func (d *membershipDesignator) Designate(ctx context.Context, obj metav1.Object) (*appmesh.Mesh, error) {
 	objNS := corev1.Namespace{}
@@ -54,6 +67,9 @@ func (d *membershipDesignator) Designate(ctx context.Context, obj metav1.Object)
 		}
 	}
 	if len(meshCandidates) == 0 {
+		if meshName, ok := objNS.Labels[MeshNameLabel]; ok && d.autoCreateMesh {
+			return d.createMeshForNamespace(ctx, &objNS, meshName)
+		}
 		return nil, errors.Errorf("failed to find matching mesh for namespace: %s, expecting 1 but found %d",
 			obj.GetNamespace(), 0)
 	}
@@ -70,3 +86,49 @@ func (d *membershipDesignator) Designate(ctx context.Context, obj metav1.Object)
 	}
 	return meshCandidates[0], nil
 }
+
+// createMeshForNamespace creates a minimal mesh selecting namespaces labeled with its name.
+// an explicit mesh with the same name always wins, it's used only if it selects the namespace as well.
+// the mesh is never created for dry-run requests, since the mutating webhooks declare no side effects on dry-run.
+func (d *membershipDesignator) createMeshForNamespace(ctx context.Context, objNS *corev1.Namespace, meshName string) (*appmesh.Mesh, error) {
+	if req := webhook.ContextGetAdmissionRequest(ctx); req != nil && aws.BoolValue(req.DryRun) {
+		return nil, errors.Errorf("mesh %s named by label %s of namespace %s doesn't exist, it isn't auto-created for dry-run requests",
+			meshName, MeshNameLabel, objNS.Name)
+	}
+	mesh := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: meshName,
+		},
+		Spec: appmesh.MeshSpec{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					MeshNameLabel: meshName,
+				},
+			},
+		},
+	}
+	err := d.k8sClient.Create(ctx, mesh)
+	if err == nil {
+		return mesh, nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return nil, errors.Wrapf(err, "failed to create mesh %s for namespace: %s", meshName, objNS.Name)
+	}
+
+	// the mesh is either created explicitly or auto-created for another object in the meantime.
+	if err := d.k8sClient.Get(ctx, types.NamespacedName{Name: meshName}, mesh); err != nil {
+		return nil, errors.Wrapf(err, "failed to get mesh %s for namespace: %s", meshName, objNS.Name)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(mesh.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	if !selector.Matches(labels.Set(objNS.Labels)) {
+		return nil, errors.Errorf("mesh %s named by label %s of namespace %s already exists but doesn't select it",
+			meshName, MeshNameLabel, objNS.Name)
+	}
+	if !mesh.DeletionTimestamp.IsZero() {
+		return nil, errors.Errorf("unable to create new content in mesh %s because it is being terminated", mesh.Name)
+	}
+	return mesh, nil
+}
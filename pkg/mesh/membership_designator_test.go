@@ -4,14 +4,19 @@ import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/equality"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"testing"
 )
 
@@ -513,7 +518,7 @@ func Test_meshSelectorDesignator_Designate(t *testing.T) {
 			clientgoscheme.AddToScheme(k8sSchema)
 			appmesh.AddToScheme(k8sSchema)
 			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
-			designator := NewMembershipDesignator(k8sClient)
+			designator := NewMembershipDesignator(k8sClient, Config{})
 
 			for _, mesh := range tt.env.meshes {
 				err := k8sClient.Create(ctx, mesh.DeepCopy())
@@ -535,3 +540,151 @@ func Test_meshSelectorDesignator_Designate(t *testing.T) {
 		})
 	}
 }
+
+func Test_membershipDesignator_Designate_autoCreateMesh(t *testing.T) {
+	nsWithMeshNameLabel := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-ns",
+			Labels: map[string]string{
+				"appmesh.k8s.aws/meshName": "my-mesh",
+			},
+		},
+	}
+	nsWithoutMeshNameLabel := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-ns",
+		},
+	}
+	autoCreatedMesh := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"appmesh.k8s.aws/meshName": "my-mesh",
+				},
+			},
+		},
+	}
+	explicitMeshSelectingNS := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-explicit-mesh"),
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"appmesh.k8s.aws/meshName": "my-mesh",
+				},
+			},
+		},
+	}
+	explicitMeshNotSelectingNS := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"mesh": "x",
+				},
+			},
+		},
+	}
+	vn := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "my-vn",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		autoCreateMesh bool
+		dryRun         bool
+		meshes         []*appmesh.Mesh
+		namespace      *corev1.Namespace
+		want           *appmesh.Mesh
+		wantMeshes     []appmesh.Mesh
+		wantErr        error
+	}{
+		{
+			name:           "auto-create disabled",
+			autoCreateMesh: false,
+			namespace:      nsWithMeshNameLabel,
+			wantErr:        errors.New("failed to find matching mesh for namespace: my-ns, expecting 1 but found 0"),
+		},
+		{
+			name:           "auto-create enabled and namespace names a missing mesh",
+			autoCreateMesh: true,
+			namespace:      nsWithMeshNameLabel,
+			want:           autoCreatedMesh,
+			wantMeshes:     []appmesh.Mesh{*autoCreatedMesh},
+		},
+		{
+			name:           "auto-create enabled and namespace names a missing mesh in dry-run",
+			autoCreateMesh: true,
+			dryRun:         true,
+			namespace:      nsWithMeshNameLabel,
+			wantErr:        errors.New("mesh my-mesh named by label appmesh.k8s.aws/meshName of namespace my-ns doesn't exist, it isn't auto-created for dry-run requests"),
+		},
+		{
+			name:           "auto-create enabled but namespace doesn't name a mesh",
+			autoCreateMesh: true,
+			namespace:      nsWithoutMeshNameLabel,
+			wantErr:        errors.New("failed to find matching mesh for namespace: my-ns, expecting 1 but found 0"),
+		},
+		{
+			name:           "auto-create enabled and explicit mesh selects namespace",
+			autoCreateMesh: true,
+			meshes:         []*appmesh.Mesh{explicitMeshSelectingNS},
+			namespace:      nsWithMeshNameLabel,
+			want:           explicitMeshSelectingNS,
+			wantMeshes:     []appmesh.Mesh{*explicitMeshSelectingNS},
+		},
+		{
+			name:           "auto-create enabled and explicit mesh with same name doesn't select namespace",
+			autoCreateMesh: true,
+			meshes:         []*appmesh.Mesh{explicitMeshNotSelectingNS},
+			namespace:      nsWithMeshNameLabel,
+			wantErr:        errors.New("mesh my-mesh named by label appmesh.k8s.aws/meshName of namespace my-ns already exists but doesn't select it"),
+			wantMeshes:     []appmesh.Mesh{*explicitMeshNotSelectingNS},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			designator := NewMembershipDesignator(k8sClient, Config{AutoCreateMesh: tt.autoCreateMesh})
+
+			for _, mesh := range tt.meshes {
+				err := k8sClient.Create(ctx, mesh.DeepCopy())
+				assert.NoError(t, err)
+			}
+			err := k8sClient.Create(ctx, tt.namespace.DeepCopy())
+			assert.NoError(t, err)
+
+			ctx = webhook.ContextWithAdmissionRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{DryRun: aws.Bool(tt.dryRun)},
+			})
+			got, err := designator.Designate(ctx, vn)
+			opts := equality.IgnoreFakeClientPopulatedFields()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, cmp.Equal(tt.want, got, opts), "diff", cmp.Diff(tt.want, got, opts))
+			}
+
+			meshList := appmesh.MeshList{}
+			err = k8sClient.List(ctx, &meshList)
+			assert.NoError(t, err)
+			listOpts := cmp.Options{opts, cmpopts.EquateEmpty()}
+			assert.True(t, cmp.Equal(tt.wantMeshes, meshList.Items, listOpts), "diff", cmp.Diff(tt.wantMeshes, meshList.Items, listOpts))
+		})
+	}
+}
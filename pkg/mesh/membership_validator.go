@@ -0,0 +1,73 @@
+package mesh
+
+import (
+	"context"
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MembershipValidator validates mesh membership for namespaced AppMesh CRs.
+type MembershipValidator interface {
+	// Validate checks the mesh referenced by given namespaced AppMesh CR exists,
+	// and optionally the CR's namespace is selected by that mesh.
+	Validate(ctx context.Context, obj metav1.Object, meshRef *appmesh.MeshReference) error
+}
+
+// NewMembershipValidator creates new MembershipValidator.
+func NewMembershipValidator(k8sClient client.Client, cfg Config) MembershipValidator {
+	return &membershipValidator{
+		k8sClient:                k8sClient,
+		enforceNamespaceSelector: cfg.EnforceNamespaceSelector,
+	}
+}
+
+var _ MembershipValidator = &membershipValidator{}
+
+type membershipValidator struct {
+	k8sClient client.Client
+	// when enabled, the namespace of a CR must be selected by the namespaceSelector of its mesh.
+	enforceNamespaceSelector bool
+}
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=meshes,verbs=get;list;watch
+
+func (v *membershipValidator) Validate(ctx context.Context, obj metav1.Object, meshRef *appmesh.MeshReference) error {
+	// meshRef is designated by mutating webhooks, CRs that are been deleted must still be able to drop finalizers.
+	if meshRef == nil || !obj.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	mesh := &appmesh.Mesh{}
+	if err := v.k8sClient.Get(ctx, types.NamespacedName{Name: meshRef.Name}, mesh); err != nil {
+		if apierrors.IsNotFound(err) {
+			return errors.Errorf("mesh %s referenced in spec.meshRef doesn't exist", meshRef.Name)
+		}
+		return errors.Wrapf(err, "failed to get mesh %s referenced in spec.meshRef", meshRef.Name)
+	}
+	if !IsMeshReferenced(mesh, *meshRef) {
+		return errors.Errorf("mesh %s referenced in spec.meshRef has UID %s, expecting %s, the mesh might have been recreated",
+			meshRef.Name, mesh.UID, meshRef.UID)
+	}
+	if !v.enforceNamespaceSelector {
+		return nil
+	}
+	objNS := corev1.Namespace{}
+	if err := v.k8sClient.Get(ctx, types.NamespacedName{Name: obj.GetNamespace()}, &objNS); err != nil {
+		return errors.Wrapf(err, "failed to get namespace: %s", obj.GetNamespace())
+	}
+	selector, err := metav1.LabelSelectorAsSelector(mesh.Spec.NamespaceSelector)
+	if err != nil {
+		return err
+	}
+	if !selector.Matches(labels.Set(objNS.Labels)) {
+		return errors.Errorf("namespace %s isn't selected by namespaceSelector of mesh %s referenced in spec.meshRef",
+			obj.GetNamespace(), meshRef.Name)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package mesh
+
+import (
+	"context"
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_membershipValidator_Validate(t *testing.T) {
+	meshWithNSSelectorMeshX := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mesh-x",
+			UID:  "uid-mesh-x",
+		},
+		Spec: appmesh.MeshSpec{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"mesh": "x",
+				},
+			},
+		},
+	}
+	nsMeshX := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ns-mesh-x",
+			Labels: map[string]string{
+				"mesh": "x",
+			},
+		},
+	}
+	nsMeshY := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ns-mesh-y",
+			Labels: map[string]string{
+				"mesh": "y",
+			},
+		},
+	}
+	now := metav1.Now()
+
+	type env struct {
+		meshes     []*appmesh.Mesh
+		namespaces []*corev1.Namespace
+	}
+	type args struct {
+		obj     metav1.Object
+		meshRef *appmesh.MeshReference
+	}
+	tests := []struct {
+		name                     string
+		env                      env
+		enforceNamespaceSelector bool
+		args                     args
+		wantErr                  error
+	}{
+		{
+			name: "referenced mesh exists",
+			env: env{
+				meshes:     []*appmesh.Mesh{meshWithNSSelectorMeshX},
+				namespaces: []*corev1.Namespace{nsMeshX},
+			},
+			args: args{
+				obj: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-mesh-x",
+						Name:      "my-node",
+					},
+				},
+				meshRef: &appmesh.MeshReference{Name: "mesh-x", UID: "uid-mesh-x"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "meshRef isn't specified",
+			env:  env{},
+			args: args{
+				obj: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-mesh-x",
+						Name:      "my-node",
+					},
+				},
+				meshRef: nil,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "referenced mesh doesn't exist",
+			env: env{
+				namespaces: []*corev1.Namespace{nsMeshX},
+			},
+			args: args{
+				obj: &appmesh.VirtualService{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-mesh-x",
+						Name:      "my-service",
+					},
+				},
+				meshRef: &appmesh.MeshReference{Name: "mesh-x", UID: "uid-mesh-x"},
+			},
+			wantErr: errors.New("mesh mesh-x referenced in spec.meshRef doesn't exist"),
+		},
+		{
+			name: "referenced mesh doesn't exist but object is been deleted",
+			env:  env{},
+			args: args{
+				obj: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:         "ns-mesh-x",
+						Name:              "my-node",
+						DeletionTimestamp: &now,
+					},
+				},
+				meshRef: &appmesh.MeshReference{Name: "mesh-x", UID: "uid-mesh-x"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "referenced mesh has been recreated",
+			env: env{
+				meshes:     []*appmesh.Mesh{meshWithNSSelectorMeshX},
+				namespaces: []*corev1.Namespace{nsMeshX},
+			},
+			args: args{
+				obj: &appmesh.VirtualRouter{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-mesh-x",
+						Name:      "my-router",
+					},
+				},
+				meshRef: &appmesh.MeshReference{Name: "mesh-x", UID: "uid-old-mesh-x"},
+			},
+			wantErr: errors.New("mesh mesh-x referenced in spec.meshRef has UID uid-mesh-x, expecting uid-old-mesh-x, the mesh might have been recreated"),
+		},
+		{
+			name: "namespace isn't selected by referenced mesh, namespaceSelector not enforced",
+			env: env{
+				meshes:     []*appmesh.Mesh{meshWithNSSelectorMeshX},
+				namespaces: []*corev1.Namespace{nsMeshY},
+			},
+			args: args{
+				obj: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-mesh-y",
+						Name:      "my-node",
+					},
+				},
+				meshRef: &appmesh.MeshReference{Name: "mesh-x", UID: "uid-mesh-x"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "namespace isn't selected by referenced mesh, namespaceSelector enforced",
+			env: env{
+				meshes:     []*appmesh.Mesh{meshWithNSSelectorMeshX},
+				namespaces: []*corev1.Namespace{nsMeshY},
+			},
+			enforceNamespaceSelector: true,
+			args: args{
+				obj: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-mesh-y",
+						Name:      "my-node",
+					},
+				},
+				meshRef: &appmesh.MeshReference{Name: "mesh-x", UID: "uid-mesh-x"},
+			},
+			wantErr: errors.New("namespace ns-mesh-y isn't selected by namespaceSelector of mesh mesh-x referenced in spec.meshRef"),
+		},
+		{
+			name: "namespace is selected by referenced mesh, namespaceSelector enforced",
+			env: env{
+				meshes:     []*appmesh.Mesh{meshWithNSSelectorMeshX},
+				namespaces: []*corev1.Namespace{nsMeshX},
+			},
+			enforceNamespaceSelector: true,
+			args: args{
+				obj: &appmesh.GatewayRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-mesh-x",
+						Name:      "my-route",
+					},
+				},
+				meshRef: &appmesh.MeshReference{Name: "mesh-x", UID: "uid-mesh-x"},
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			validator := NewMembershipValidator(k8sClient, Config{EnforceNamespaceSelector: tt.enforceNamespaceSelector})
+
+			for _, mesh := range tt.env.meshes {
+				err := k8sClient.Create(ctx, mesh.DeepCopy())
+				assert.NoError(t, err)
+			}
+			for _, ns := range tt.env.namespaces {
+				err := k8sClient.Create(ctx, ns.DeepCopy())
+				assert.NoError(t, err)
+			}
+
+			err := validator.Validate(ctx, tt.args.obj, tt.args.meshRef)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
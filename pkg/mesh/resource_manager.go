@@ -6,6 +6,7 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/conversions"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/tagging"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
@@ -30,13 +31,17 @@ func NewDefaultResourceManager(
 	k8sClient client.Client,
 	appMeshSDK services.AppMesh,
 	accountID string,
+	cfg Config,
+	tagsManager tagging.Manager,
 	log logr.Logger) ResourceManager {
 
 	return &defaultResourceManager{
-		k8sClient:  k8sClient,
-		appMeshSDK: appMeshSDK,
-		accountID:  accountID,
-		log:        log,
+		k8sClient:   k8sClient,
+		appMeshSDK:  appMeshSDK,
+		accountID:   accountID,
+		cfg:         cfg,
+		tagsManager: tagsManager,
+		log:         log,
 	}
 }
 
@@ -45,11 +50,16 @@ type defaultResourceManager struct {
 	k8sClient  client.Client
 	appMeshSDK services.AppMesh
 	// current iam identity's aws accountID, used to differentiate mesh ownership.
-	accountID string
-	log       logr.Logger
+	accountID   string
+	cfg         Config
+	tagsManager tagging.Manager
+	log         logr.Logger
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, ms *appmesh.Mesh) error {
+	if k8s.IsReconciliationPaused(ms) {
+		return m.updateCRDMeshReconciliationPaused(ctx, ms)
+	}
 	sdkMS, err := m.findSDKMesh(ctx, ms)
 	if err != nil {
 		return err
@@ -64,6 +74,9 @@ func (m *defaultResourceManager) Reconcile(ctx context.Context, ms *appmesh.Mesh
 		if err != nil {
 			return err
 		}
+		if err := m.updateSDKMeshTags(ctx, sdkMS, ms); err != nil {
+			return err
+		}
 	}
 	return m.updateCRDMesh(ctx, ms, sdkMS)
 }
@@ -79,6 +92,10 @@ func (m *defaultResourceManager) Cleanup(ctx context.Context, ms *appmesh.Mesh)
 	if sdkMS == nil {
 		return nil
 	}
+	if k8s.IsDeletionPolicyOrphan(ms) {
+		m.sdkMeshLogger(sdkMS, ms).V(1).Info("skip mesh deletion since its deletionPolicy is orphan")
+		return nil
+	}
 
 	return m.deleteSDKMesh(ctx, sdkMS, ms)
 }
@@ -99,13 +116,14 @@ func (m *defaultResourceManager) findSDKMesh(ctx context.Context, ms *appmesh.Me
 }
 
 func (m *defaultResourceManager) createSDKMesh(ctx context.Context, ms *appmesh.Mesh) (*appmeshsdk.MeshData, error) {
-	sdkMSSpec, err := BuildSDKMeshSpec(ctx, ms)
+	sdkMSSpec, err := BuildSDKMeshSpec(ctx, m.applyDefaultEgressFilter(ms))
 	if err != nil {
 		return nil, err
 	}
 	resp, err := m.appMeshSDK.CreateMeshWithContext(ctx, &appmeshsdk.CreateMeshInput{
 		MeshName: ms.Spec.AWSName,
 		Spec:     sdkMSSpec,
+		Tags:     m.tagsManager.BuildSDKTags(ms),
 	})
 	if err != nil {
 		return nil, err
@@ -115,7 +133,7 @@ func (m *defaultResourceManager) createSDKMesh(ctx context.Context, ms *appmesh.
 
 func (m *defaultResourceManager) updateSDKMesh(ctx context.Context, sdkMS *appmeshsdk.MeshData, ms *appmesh.Mesh) (*appmeshsdk.MeshData, error) {
 	actualSDKMSSpec := sdkMS.Spec
-	desiredSDKMSSpec, err := BuildSDKMeshSpec(ctx, ms)
+	desiredSDKMSSpec, err := BuildSDKMeshSpec(ctx, m.applyDefaultEgressFilter(ms))
 	if err != nil {
 		return nil, err
 	}
@@ -124,16 +142,12 @@ func (m *defaultResourceManager) updateSDKMesh(ctx context.Context, sdkMS *appme
 		return sdkMS, nil
 	}
 	if !m.isSDKMeshControlledByCRDMesh(ctx, sdkMS, ms) {
-		m.log.V(1).Info("skip mesh update since it's not controlled",
-			"mesh", k8s.NamespacedName(ms),
-			"meshARN", aws.StringValue(sdkMS.Metadata.Arn),
-		)
+		m.sdkMeshLogger(sdkMS, ms).V(1).Info("skip mesh update since it's not controlled")
 		return sdkMS, nil
 	}
 
 	diff := cmp.Diff(desiredSDKMSSpec, actualSDKMSSpec, opts)
-	m.log.V(1).Info("meshSpec changed",
-		"mesh", k8s.NamespacedName(ms),
+	m.sdkMeshLogger(sdkMS, ms).V(1).Info("meshSpec changed",
 		"actualSDKMSSpec", actualSDKMSSpec,
 		"desiredSDKMSSpec", desiredSDKMSSpec,
 		"diff", diff,
@@ -150,20 +164,14 @@ func (m *defaultResourceManager) updateSDKMesh(ctx context.Context, sdkMS *appme
 
 func (m *defaultResourceManager) deleteSDKMesh(ctx context.Context, sdkMS *appmeshsdk.MeshData, ms *appmesh.Mesh) error {
 	if !m.isSDKMeshOwnedByCRDMesh(ctx, sdkMS, ms) {
-		m.log.V(1).Info("skip mesh deletion since its not owned",
-			"mesh", k8s.NamespacedName(ms),
-			"meshARN", aws.StringValue(sdkMS.Metadata.Arn),
-		)
+		m.sdkMeshLogger(sdkMS, ms).V(1).Info("skip mesh deletion since its not owned")
 		return nil
 	}
 
 	_, err := m.appMeshSDK.DeleteMeshWithContext(ctx, &appmeshsdk.DeleteMeshInput{
 		MeshName: sdkMS.MeshName,
 	})
-	if err != nil {
-		return err
-	}
-	return nil
+	return services.IgnoreAppMeshNotFound(err)
 }
 
 func (m *defaultResourceManager) updateCRDMesh(ctx context.Context, ms *appmesh.Mesh, sdkMS *appmeshsdk.MeshData) error {
@@ -186,6 +194,10 @@ func (m *defaultResourceManager) updateCRDMesh(ctx context.Context, ms *appmesh.
 	if updateCondition(ms, appmesh.MeshActive, msActiveConditionStatus, nil, nil) {
 		needsUpdate = true
 	}
+	if getCondition(ms, appmesh.MeshReconciliationPaused) != nil &&
+		updateCondition(ms, appmesh.MeshReconciliationPaused, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
 
 	if !needsUpdate {
 		return nil
@@ -193,6 +205,32 @@ func (m *defaultResourceManager) updateCRDMesh(ctx context.Context, ms *appmesh.
 	return m.k8sClient.Status().Patch(ctx, ms, client.MergeFrom(oldMS))
 }
 
+// updateCRDMeshReconciliationPaused records the ReconciliationPaused condition without touching the AppMesh Mesh.
+func (m *defaultResourceManager) updateCRDMeshReconciliationPaused(ctx context.Context, ms *appmesh.Mesh) error {
+	oldMS := ms.DeepCopy()
+	if !updateCondition(ms, appmesh.MeshReconciliationPaused, corev1.ConditionTrue, nil, nil) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, ms, client.MergeFrom(oldMS))
+}
+
+// sdkMeshLogger returns a logger carrying the identity of ms and its AppMesh Mesh.
+func (m *defaultResourceManager) sdkMeshLogger(sdkMS *appmeshsdk.MeshData, ms *appmesh.Mesh) logr.Logger {
+	return m.log.WithValues(
+		"meshName", aws.StringValue(sdkMS.MeshName),
+		"mesh", k8s.NamespacedName(ms),
+		"arn", aws.StringValue(sdkMS.Metadata.Arn),
+	)
+}
+
+// updateSDKMeshTags corrects drift of the tags propagated from labels of ms on its AppMesh Mesh.
+func (m *defaultResourceManager) updateSDKMeshTags(ctx context.Context, sdkMS *appmeshsdk.MeshData, ms *appmesh.Mesh) error {
+	if !m.isSDKMeshControlledByCRDMesh(ctx, sdkMS, ms) {
+		return nil
+	}
+	return m.tagsManager.ReconcileSDKTags(ctx, aws.StringValue(sdkMS.Metadata.Arn), ms)
+}
+
 // isSDKMeshControlledByCRDMesh checks whether an AppMesh mesh is controlled by CRDMesh
 // if it's controlled, CRDMesh update is responsible for update AppMesh mesh.
 func (m *defaultResourceManager) isSDKMeshControlledByCRDMesh(ctx context.Context, sdkMS *appmeshsdk.MeshData, ms *appmesh.Mesh) bool {
@@ -214,6 +252,19 @@ func (m *defaultResourceManager) isSDKMeshOwnedByCRDMesh(ctx context.Context, sd
 	return true
 }
 
+// applyDefaultEgressFilter returns ms with the configured default egressFilter applied if ms doesn't specify its own.
+// ms itself is never modified, a copy is returned when the default is applied.
+func (m *defaultResourceManager) applyDefaultEgressFilter(ms *appmesh.Mesh) *appmesh.Mesh {
+	if m.cfg.DefaultEgressFilter == "" || ms.Spec.EgressFilter != nil {
+		return ms
+	}
+	msWithDefaults := ms.DeepCopy()
+	msWithDefaults.Spec.EgressFilter = &appmesh.EgressFilter{
+		Type: appmesh.EgressFilterType(m.cfg.DefaultEgressFilter),
+	}
+	return msWithDefaults
+}
+
 func BuildSDKMeshSpec(ctx context.Context, ms *appmesh.Mesh) (*appmeshsdk.MeshSpec, error) {
 	converter := conversion.NewConverter(conversion.DefaultNameFunc)
 	converter.RegisterUntypedConversionFunc((*appmesh.MeshSpec)(nil), (*appmeshsdk.MeshSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
@@ -3,12 +3,16 @@ package mesh
 import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/equality"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -104,6 +108,55 @@ func Test_defaultResourceManager_updateCRDMesh(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "mesh resumed after pause needs patch ReconciliationPaused condition",
+			args: args{
+				ms: &appmesh.Mesh{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "ms-1",
+					},
+					Status: appmesh.MeshStatus{
+						MeshARN: aws.String("arn-1"),
+						Conditions: []appmesh.MeshCondition{
+							{
+								Type:   appmesh.MeshActive,
+								Status: corev1.ConditionTrue,
+							},
+							{
+								Type:   appmesh.MeshReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+				sdkMS: &appmeshsdk.MeshData{
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn: aws.String("arn-1"),
+					},
+					Status: &appmeshsdk.MeshStatus{
+						Status: aws.String(appmeshsdk.MeshStatusCodeActive),
+					},
+				},
+			},
+			wantMS: &appmesh.Mesh{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ms-1",
+				},
+				Status: appmesh.MeshStatus{
+					MeshARN: aws.String("arn-1"),
+					Conditions: []appmesh.MeshCondition{
+						{
+							Type:   appmesh.MeshActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.MeshReconciliationPaused,
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -138,6 +191,132 @@ func Test_defaultResourceManager_updateCRDMesh(t *testing.T) {
 	}
 }
 
+func Test_defaultResourceManager_Reconcile_paused(t *testing.T) {
+	type args struct {
+		ms *appmesh.Mesh
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantMS  *appmesh.Mesh
+		wantErr error
+	}{
+		{
+			name: "paused mesh records ReconciliationPaused condition",
+			args: args{
+				ms: &appmesh.Mesh{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "ms-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.MeshStatus{
+						MeshARN: aws.String("arn-1"),
+						Conditions: []appmesh.MeshCondition{
+							{
+								Type:   appmesh.MeshActive,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantMS: &appmesh.Mesh{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ms-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.MeshStatus{
+					MeshARN: aws.String("arn-1"),
+					Conditions: []appmesh.MeshCondition{
+						{
+							Type:   appmesh.MeshActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.MeshReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "paused mesh already has ReconciliationPaused condition",
+			args: args{
+				ms: &appmesh.Mesh{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "ms-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.MeshStatus{
+						MeshARN: aws.String("arn-1"),
+						Conditions: []appmesh.MeshCondition{
+							{
+								Type:   appmesh.MeshReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantMS: &appmesh.Mesh{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ms-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.MeshStatus{
+					MeshARN: aws.String("arn-1"),
+					Conditions: []appmesh.MeshCondition{
+						{
+							Type:   appmesh.MeshReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			// appMeshSDK is left unset, any AppMesh API call while paused would fail the test.
+			m := &defaultResourceManager{
+				k8sClient: k8sClient,
+				log:       log.NullLogger{},
+			}
+
+			err := k8sClient.Create(ctx, tt.args.ms.DeepCopy())
+			assert.NoError(t, err)
+			err = m.Reconcile(ctx, tt.args.ms)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				gotMS := &appmesh.Mesh{}
+				err = k8sClient.Get(ctx, k8s.NamespacedName(tt.args.ms), gotMS)
+				assert.NoError(t, err)
+				opts := cmp.Options{
+					equality.IgnoreFakeClientPopulatedFields(),
+					cmpopts.IgnoreTypes((*metav1.Time)(nil)),
+				}
+				assert.True(t, cmp.Equal(tt.wantMS, gotMS, opts), "diff", cmp.Diff(tt.wantMS, gotMS, opts))
+			}
+		})
+	}
+}
+
 func Test_defaultResourceManager_isSDKMeshControlledByCRDMesh(t *testing.T) {
 	type fields struct {
 		accountID string
@@ -245,3 +424,123 @@ func Test_defaultResourceManager_isSDKMeshOwnedByCRDMesh(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultResourceManager_applyDefaultEgressFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    Config
+		ms     *appmesh.Mesh
+		wantMS *appmesh.Mesh
+	}{
+		{
+			name: "no default configured",
+			cfg:  Config{},
+			ms: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{},
+			},
+			wantMS: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{},
+			},
+		},
+		{
+			name: "default applied when mesh doesn't specify egressFilter",
+			cfg:  Config{DefaultEgressFilter: "DROP_ALL"},
+			ms: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{},
+			},
+			wantMS: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					EgressFilter: &appmesh.EgressFilter{
+						Type: appmesh.EgressFilterTypeDropAll,
+					},
+				},
+			},
+		},
+		{
+			name: "mesh's own egressFilter wins over default",
+			cfg:  Config{DefaultEgressFilter: "DROP_ALL"},
+			ms: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					EgressFilter: &appmesh.EgressFilter{
+						Type: appmesh.EgressFilterTypeAllowAll,
+					},
+				},
+			},
+			wantMS: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					EgressFilter: &appmesh.EgressFilter{
+						Type: appmesh.EgressFilterTypeAllowAll,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				cfg: tt.cfg,
+				log: &log.NullLogger{},
+			}
+			original := tt.ms.DeepCopy()
+			got := m.applyDefaultEgressFilter(tt.ms)
+			assert.True(t, cmp.Equal(tt.wantMS, got), "diff", cmp.Diff(tt.wantMS, got))
+			assert.True(t, cmp.Equal(original, tt.ms), "mesh shouldn't be modified")
+		})
+	}
+}
+
+// fakeAppMesh fails AppMesh deletions with deleteErr.
+type fakeAppMesh struct {
+	services.AppMesh
+	deleteErr error
+}
+
+func (f *fakeAppMesh) DeleteMeshWithContext(_ context.Context, _ *appmeshsdk.DeleteMeshInput, _ ...request.Option) (*appmeshsdk.DeleteMeshOutput, error) {
+	if f.deleteErr != nil {
+		return nil, f.deleteErr
+	}
+	return &appmeshsdk.DeleteMeshOutput{}, nil
+}
+
+func Test_defaultResourceManager_deleteSDKMesh(t *testing.T) {
+	sdkMesh := &appmeshsdk.MeshData{
+		MeshName: aws.String("my-mesh"),
+		Metadata: &appmeshsdk.ResourceMetadata{
+			MeshOwner:     aws.String("222222222"),
+			ResourceOwner: aws.String("222222222"),
+		},
+	}
+	tests := []struct {
+		name      string
+		deleteErr error
+		wantErr   error
+	}{
+		{
+			name: "AppMesh mesh deleted",
+		},
+		{
+			name:      "AppMesh mesh deleted out-of-band",
+			deleteErr: awserr.New("NotFoundException", "mesh not found", nil),
+		},
+		{
+			name:      "AppMesh mesh deletion fails",
+			deleteErr: awserr.New("ResourceInUseException", "mesh in use", nil),
+			wantErr:   errors.New("ResourceInUseException: mesh in use"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				appMeshSDK: &fakeAppMesh{deleteErr: tt.deleteErr},
+				accountID:  "222222222",
+				log:        &log.NullLogger{},
+			}
+			err := m.deleteSDKMesh(context.Background(), sdkMesh, &appmesh.Mesh{})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package tagging
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	flagPropagateLabelsAsTags = "propagate-labels-as-tags"
+
+	// AppMesh limits the length of tag keys to 128 characters.
+	maxTagKeyLength = 128
+)
+
+type Config struct {
+	// Keys of labels copied from AppMesh CRs into the tags of their AppMesh resources.
+	PropagateLabelsAsTags []string
+}
+
+func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&cfg.PropagateLabelsAsTags, flagPropagateLabelsAsTags, nil,
+		`Comma separated keys of labels copied from AppMesh CRs into the tags of their AppMesh resources`)
+}
+
+func (cfg *Config) Validate() error {
+	for _, key := range cfg.PropagateLabelsAsTags {
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return fmt.Errorf("invalid %s: %s isn't a valid label key: %s", flagPropagateLabelsAsTags, key, strings.Join(errs, ", "))
+		}
+		if len(key) > maxTagKeyLength {
+			return fmt.Errorf("invalid %s: %s must be no more than %d characters to be used as tag key", flagPropagateLabelsAsTags, key, maxTagKeyLength)
+		}
+	}
+	return nil
+}
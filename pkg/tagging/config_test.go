@@ -0,0 +1,41 @@
+package tagging
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	longLabelKey := strings.Repeat("a", 60) + "." + strings.Repeat("b", 39) + "/" + strings.Repeat("c", 28)
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{
+			name: "no labels propagated",
+			cfg:  Config{},
+		},
+		{
+			name: "labels propagated",
+			cfg:  Config{PropagateLabelsAsTags: []string{"team", "app.kubernetes.io/name"}},
+		},
+		{
+			name:    "label key longer than tag key limit",
+			cfg:     Config{PropagateLabelsAsTags: []string{"team", longLabelKey}},
+			wantErr: errors.New("invalid propagate-labels-as-tags: " + longLabelKey + " must be no more than 128 characters to be used as tag key"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
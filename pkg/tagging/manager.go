@@ -0,0 +1,146 @@
+package tagging
+
+import (
+	"context"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
+	"github.com/aws/aws-sdk-go/aws"
+	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Manager manages the AppMesh tags propagated from labels of AppMesh CRs.
+type Manager interface {
+	// BuildSDKTags returns the tags propagated from labels of obj, used while creating its AppMesh resource.
+	BuildSDKTags(obj metav1.Object) []*appmeshsdk.TagRef
+
+	// ReconcileSDKTags corrects drift of the tags propagated from labels of obj on its AppMesh resource.
+	// tags whose key isn't propagated from labels are left untouched.
+	ReconcileSDKTags(ctx context.Context, arn string, obj metav1.Object) error
+
+	// ReconcileListedSDKTags corrects drift like ReconcileSDKTags, but from actualTags already listed on the AppMesh resource of obj.
+	ReconcileListedSDKTags(ctx context.Context, arn string, obj metav1.Object, actualTags map[string]string) error
+}
+
+// NewDefaultManager constructs new Manager.
+func NewDefaultManager(appMeshSDK services.AppMesh, cfg Config, log logr.Logger) Manager {
+	return &defaultManager{
+		appMeshSDK: appMeshSDK,
+		labelKeys:  dedupeLabelKeys(cfg.PropagateLabelsAsTags),
+		log:        log,
+	}
+}
+
+var _ Manager = &defaultManager{}
+
+type defaultManager struct {
+	appMeshSDK services.AppMesh
+	// keys of labels propagated as tags, tags with other keys are never touched.
+	labelKeys []string
+	log       logr.Logger
+}
+
+func (m *defaultManager) BuildSDKTags(obj metav1.Object) []*appmeshsdk.TagRef {
+	desiredTags := m.buildDesiredTags(obj)
+	var sdkTags []*appmeshsdk.TagRef
+	for _, key := range m.labelKeys {
+		value, ok := desiredTags[key]
+		if !ok {
+			continue
+		}
+		sdkTags = append(sdkTags, &appmeshsdk.TagRef{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+	return sdkTags
+}
+
+func (m *defaultManager) ReconcileSDKTags(ctx context.Context, arn string, obj metav1.Object) error {
+	if len(m.labelKeys) == 0 {
+		return nil
+	}
+	actualTags, err := m.listSDKTags(ctx, arn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list tags of %s", arn)
+	}
+	return m.ReconcileListedSDKTags(ctx, arn, obj, actualTags)
+}
+
+func (m *defaultManager) ReconcileListedSDKTags(ctx context.Context, arn string, obj metav1.Object, actualTags map[string]string) error {
+	desiredTags := m.buildDesiredTags(obj)
+
+	var tagsToAdd []*appmeshsdk.TagRef
+	var tagKeysToRemove []*string
+	for _, key := range m.labelKeys {
+		desiredValue, desired := desiredTags[key]
+		actualValue, actual := actualTags[key]
+		switch {
+		case desired && (!actual || desiredValue != actualValue):
+			tagsToAdd = append(tagsToAdd, &appmeshsdk.TagRef{Key: aws.String(key), Value: aws.String(desiredValue)})
+		case !desired && actual:
+			tagKeysToRemove = append(tagKeysToRemove, aws.String(key))
+		}
+	}
+	if len(tagsToAdd) != 0 {
+		m.log.V(1).Info("adding propagated tags", "arn", arn, "tags", tagsToAdd)
+		if _, err := m.appMeshSDK.TagResourceWithContext(ctx, &appmeshsdk.TagResourceInput{
+			ResourceArn: aws.String(arn),
+			Tags:        tagsToAdd,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to tag %s", arn)
+		}
+	}
+	if len(tagKeysToRemove) != 0 {
+		m.log.V(1).Info("removing propagated tags", "arn", arn, "tagKeys", aws.StringValueSlice(tagKeysToRemove))
+		if _, err := m.appMeshSDK.UntagResourceWithContext(ctx, &appmeshsdk.UntagResourceInput{
+			ResourceArn: aws.String(arn),
+			TagKeys:     tagKeysToRemove,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to untag %s", arn)
+		}
+	}
+	return nil
+}
+
+// buildDesiredTags returns the tags propagated from labels of obj, labels that obj doesn't have are omitted.
+func (m *defaultManager) buildDesiredTags(obj metav1.Object) map[string]string {
+	labels := obj.GetLabels()
+	desiredTags := make(map[string]string)
+	for _, key := range m.labelKeys {
+		if value, ok := labels[key]; ok {
+			desiredTags[key] = value
+		}
+	}
+	return desiredTags
+}
+
+func (m *defaultManager) listSDKTags(ctx context.Context, arn string) (map[string]string, error) {
+	tags := make(map[string]string)
+	if err := m.appMeshSDK.ListTagsForResourcePagesWithContext(ctx, &appmeshsdk.ListTagsForResourceInput{
+		ResourceArn: aws.String(arn),
+	}, func(output *appmeshsdk.ListTagsForResourceOutput, lastPage bool) bool {
+		for _, tag := range output.Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// dedupeLabelKeys removes duplicate label keys while keeping their order.
+func dedupeLabelKeys(labelKeys []string) []string {
+	var dedupedKeys []string
+	seen := make(map[string]struct{})
+	for _, key := range labelKeys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		dedupedKeys = append(dedupedKeys, key)
+	}
+	return dedupedKeys
+}
@@ -0,0 +1,182 @@
+package tagging
+
+import (
+	"context"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+// fakeAppMesh serves the tags of a single AppMesh resource.
+type fakeAppMesh struct {
+	services.AppMesh
+	tags      map[string]string
+	listErr   error
+	listCalls int
+	tagCalls  int
+}
+
+func (f *fakeAppMesh) ListTagsForResourcePagesWithContext(_ context.Context, _ *appmeshsdk.ListTagsForResourceInput, fn func(*appmeshsdk.ListTagsForResourceOutput, bool) bool, _ ...request.Option) error {
+	f.listCalls++
+	if f.listErr != nil {
+		return f.listErr
+	}
+	var sdkTags []*appmeshsdk.TagRef
+	for key, value := range f.tags {
+		sdkTags = append(sdkTags, &appmeshsdk.TagRef{Key: aws.String(key), Value: aws.String(value)})
+	}
+	fn(&appmeshsdk.ListTagsForResourceOutput{Tags: sdkTags}, true)
+	return nil
+}
+
+func (f *fakeAppMesh) TagResourceWithContext(_ context.Context, input *appmeshsdk.TagResourceInput, _ ...request.Option) (*appmeshsdk.TagResourceOutput, error) {
+	f.tagCalls++
+	for _, tag := range input.Tags {
+		f.tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return &appmeshsdk.TagResourceOutput{}, nil
+}
+
+func (f *fakeAppMesh) UntagResourceWithContext(_ context.Context, input *appmeshsdk.UntagResourceInput, _ ...request.Option) (*appmeshsdk.UntagResourceOutput, error) {
+	f.tagCalls++
+	for _, key := range input.TagKeys {
+		delete(f.tags, aws.StringValue(key))
+	}
+	return &appmeshsdk.UntagResourceOutput{}, nil
+}
+
+func Test_defaultManager_BuildSDKTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		labelKeys []string
+		labels    map[string]string
+		want      []*appmeshsdk.TagRef
+	}{
+		{
+			name:   "no labels propagated",
+			labels: map[string]string{"team": "payments"},
+			want:   nil,
+		},
+		{
+			name:      "propagated labels are copied in flag order",
+			labelKeys: []string{"team", "app.kubernetes.io/name"},
+			labels: map[string]string{
+				"app.kubernetes.io/name": "checkout",
+				"team":                   "payments",
+				"tier":                   "backend",
+			},
+			want: []*appmeshsdk.TagRef{
+				{Key: aws.String("team"), Value: aws.String("payments")},
+				{Key: aws.String("app.kubernetes.io/name"), Value: aws.String("checkout")},
+			},
+		},
+		{
+			name:      "missing labels are omitted",
+			labelKeys: []string{"team", "cost-center"},
+			labels:    map[string]string{"team": "payments"},
+			want: []*appmeshsdk.TagRef{
+				{Key: aws.String("team"), Value: aws.String("payments")},
+			},
+		},
+		{
+			name:      "duplicate label keys produce a single tag",
+			labelKeys: []string{"team", "team"},
+			labels:    map[string]string{"team": "payments"},
+			want: []*appmeshsdk.TagRef{
+				{Key: aws.String("team"), Value: aws.String("payments")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewDefaultManager(&fakeAppMesh{}, Config{PropagateLabelsAsTags: tt.labelKeys}, log.NullLogger{})
+			obj := &metav1.ObjectMeta{Labels: tt.labels}
+			assert.Equal(t, tt.want, m.BuildSDKTags(obj))
+		})
+	}
+}
+
+func Test_defaultManager_ReconcileSDKTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		labelKeys    []string
+		labels       map[string]string
+		actualTags   map[string]string
+		listErr      error
+		wantTags     map[string]string
+		wantTagCalls int
+		wantErr      error
+	}{
+		{
+			name:         "no labels propagated",
+			labels:       map[string]string{"team": "payments"},
+			actualTags:   map[string]string{"owner": "platform"},
+			wantTags:     map[string]string{"owner": "platform"},
+			wantTagCalls: 0,
+		},
+		{
+			name:         "tags already match labels",
+			labelKeys:    []string{"team"},
+			labels:       map[string]string{"team": "payments"},
+			actualTags:   map[string]string{"team": "payments", "owner": "platform"},
+			wantTags:     map[string]string{"team": "payments", "owner": "platform"},
+			wantTagCalls: 0,
+		},
+		{
+			name:         "missing and changed tags are corrected",
+			labelKeys:    []string{"team", "app.kubernetes.io/name"},
+			labels:       map[string]string{"team": "checkout", "app.kubernetes.io/name": "cart"},
+			actualTags:   map[string]string{"team": "payments", "owner": "platform"},
+			wantTags:     map[string]string{"team": "checkout", "app.kubernetes.io/name": "cart", "owner": "platform"},
+			wantTagCalls: 1,
+		},
+		{
+			name:         "tags of removed labels are removed",
+			labelKeys:    []string{"team", "cost-center"},
+			labels:       map[string]string{"team": "payments"},
+			actualTags:   map[string]string{"team": "payments", "cost-center": "1234", "owner": "platform"},
+			wantTags:     map[string]string{"team": "payments", "owner": "platform"},
+			wantTagCalls: 1,
+		},
+		{
+			name:       "failed to list tags",
+			labelKeys:  []string{"team"},
+			labels:     map[string]string{"team": "payments"},
+			actualTags: map[string]string{},
+			listErr:    errors.New("access denied"),
+			wantErr:    errors.New("failed to list tags of arn-1: access denied"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appMeshSDK := &fakeAppMesh{tags: tt.actualTags, listErr: tt.listErr}
+			m := NewDefaultManager(appMeshSDK, Config{PropagateLabelsAsTags: tt.labelKeys}, log.NullLogger{})
+			obj := &metav1.ObjectMeta{Labels: tt.labels}
+			err := m.ReconcileSDKTags(context.Background(), "arn-1", obj)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTags, appMeshSDK.tags)
+				assert.Equal(t, tt.wantTagCalls, appMeshSDK.tagCalls)
+			}
+		})
+	}
+}
+
+func Test_defaultManager_ReconcileListedSDKTags(t *testing.T) {
+	appMeshSDK := &fakeAppMesh{tags: map[string]string{"team": "payments", "owner": "platform"}}
+	m := NewDefaultManager(appMeshSDK, Config{PropagateLabelsAsTags: []string{"team"}}, log.NullLogger{})
+	obj := &metav1.ObjectMeta{Labels: map[string]string{"team": "checkout"}}
+	err := m.ReconcileListedSDKTags(context.Background(), "arn-1", obj, map[string]string{"team": "payments", "owner": "platform"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "checkout", "owner": "platform"}, appMeshSDK.tags)
+	assert.Equal(t, 0, appMeshSDK.listCalls)
+	assert.Equal(t, 1, appMeshSDK.tagCalls)
+}
@@ -0,0 +1,33 @@
+package virtualgateway
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	flagPodLabel = "virtualgateway-pod-label"
+)
+
+type Config struct {
+	// Label whose value names the VirtualGateway of a pod in its namespace, used instead of VirtualGateway podSelectors if non-empty.
+	PodLabel string
+}
+
+func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&cfg.PodLabel, flagPodLabel, "",
+		`Label identifying VirtualGateway pods, whose value is the name of the VirtualGateway in the pod's namespace. `+
+			`Pods without it are still matched against VirtualGateway podSelectors. Empty(default) uses podSelectors only`)
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.PodLabel == "" {
+		return nil
+	}
+	if errs := validation.IsQualifiedName(cfg.PodLabel); len(errs) != 0 {
+		return fmt.Errorf("invalid %s %s, must be a valid label key", flagPodLabel, cfg.PodLabel)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package virtualgateway
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{
+			name: "pod label not configured",
+			cfg:  Config{},
+		},
+		{
+			name: "pod label configured",
+			cfg: Config{
+				PodLabel: "example.com/gateway",
+			},
+		},
+		{
+			name: "invalid pod label",
+			cfg: Config{
+				PodLabel: "example.com/gateway/name",
+			},
+			wantErr: errors.New("invalid virtualgateway-pod-label example.com/gateway/name, must be a valid label key"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
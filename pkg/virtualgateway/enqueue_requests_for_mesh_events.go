@@ -7,6 +7,7 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
 	"github.com/go-logr/logr"
 	"k8s.io/client-go/util/workqueue"
+	"reflect"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -34,12 +35,13 @@ func (h *enqueueRequestsForMeshEvents) Create(e event.CreateEvent, queue workque
 
 // Update is called in response to an update event
 func (h *enqueueRequestsForMeshEvents) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
-	// virtualGateway reconcile depends on mesh is active or not.
-	// so we only need to trigger virtualGateway reconcile if mesh's active status changed.
+	// virtualGateway reconcile depends on mesh is active or not, and on mesh's virtualGatewayDefaults.
+	// so we only need to trigger virtualGateway reconcile if mesh's active status or virtualGatewayDefaults changed.
 	msOld := e.ObjectOld.(*appmesh.Mesh)
 	msNew := e.ObjectNew.(*appmesh.Mesh)
 
-	if mesh.IsMeshActive(msOld) != mesh.IsMeshActive(msNew) {
+	if mesh.IsMeshActive(msOld) != mesh.IsMeshActive(msNew) ||
+		!reflect.DeepEqual(msOld.Spec.VirtualGatewayDefaults, msNew.Spec.VirtualGatewayDefaults) {
 		h.enqueueVirtualGatewaysForMesh(context.Background(), queue, msNew)
 	}
 }
@@ -126,6 +126,45 @@ func Test_enqueueRequestsForMeshEvents_Update(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "virtualGatewayDefaults changed",
+			env: env{
+				virtualGateways: []*appmesh.VirtualGateway{vg1, vg2},
+			},
+			args: args{
+				e: event.UpdateEvent{
+					ObjectOld: &appmesh.Mesh{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "my-mesh",
+							UID:  "a385048d-aba8-4235-9a11-4173764c8ab7",
+						},
+					},
+					ObjectNew: &appmesh.Mesh{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "my-mesh",
+							UID:  "a385048d-aba8-4235-9a11-4173764c8ab7",
+						},
+						Spec: appmesh.MeshSpec{
+							VirtualGatewayDefaults: &appmesh.VirtualGatewayDefaults{
+								ConnectionPool: &appmesh.VirtualGatewayConnectionPool{
+									HTTP: &appmesh.HTTPConnectionPool{
+										MaxConnections: 100,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantRequests: []reconcile.Request{
+				{
+					NamespacedName: k8s.NamespacedName(vg1),
+				},
+				{
+					NamespacedName: k8s.NamespacedName(vg2),
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -24,8 +25,11 @@ type MembershipDesignator interface {
 }
 
 // NewMembershipDesignator creates new MembershipDesignator.
-func NewMembershipDesignator(k8sClient client.Client) MembershipDesignator {
-	return &membershipDesignator{k8sClient: k8sClient}
+func NewMembershipDesignator(k8sClient client.Client, cfg Config) MembershipDesignator {
+	return &membershipDesignator{
+		k8sClient: k8sClient,
+		podLabel:  cfg.PodLabel,
+	}
 }
 
 var _ MembershipDesignator = &membershipDesignator{}
@@ -33,6 +37,8 @@ var _ MembershipDesignator = &membershipDesignator{}
 // virtualGatewaySelectorDesignator designates VirtualGateway membership based on selectors on VirtualGateway.
 type membershipDesignator struct {
 	k8sClient client.Client
+	// when non-empty, pods with this label are designated to the VirtualGateway named by its value.
+	podLabel string
 }
 
 // +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=virtualgateways,verbs=get;list;watch
@@ -41,6 +47,9 @@ func (d *membershipDesignator) DesignateForPod(ctx context.Context, pod *corev1.
 
 	// see https://github.com/kubernetes/kubernetes/issues/88282 and https://github.com/kubernetes/kubernetes/issues/76680
 	req := webhook.ContextGetAdmissionRequest(ctx)
+	if vgName := pod.Labels[d.podLabel]; d.podLabel != "" && vgName != "" {
+		return d.designateForPodByLabel(ctx, req.Namespace, vgName)
+	}
 	vgList := appmesh.VirtualGatewayList{}
 	if err := d.k8sClient.List(ctx, &vgList, client.InNamespace(req.Namespace)); err != nil {
 		return nil, errors.Wrap(err, "failed to list VirtualGateways in cluster")
@@ -70,6 +79,19 @@ func (d *membershipDesignator) DesignateForPod(ctx context.Context, pod *corev1.
 	return vgCandidates[0], nil
 }
 
+// designateForPodByLabel returns the VirtualGateway named by the podLabel of a pod in namespace.
+// the label explicitly identifies a gateway pod, so a VirtualGateway that doesn't exist is an error instead of skipping injection.
+func (d *membershipDesignator) designateForPodByLabel(ctx context.Context, namespace string, vgName string) (*appmesh.VirtualGateway, error) {
+	vg := &appmesh.VirtualGateway{}
+	if err := d.k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: vgName}, vg); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.Errorf("virtualGateway %s/%s referenced by pod label %s doesn't exist", namespace, vgName, d.podLabel)
+		}
+		return nil, errors.Wrapf(err, "failed to get virtualGateway %s/%s referenced by pod label %s", namespace, vgName, d.podLabel)
+	}
+	return vg, nil
+}
+
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 func (d *membershipDesignator) DesignateForGatewayRoute(ctx context.Context, obj *appmesh.GatewayRoute) (*appmesh.VirtualGateway, error) {
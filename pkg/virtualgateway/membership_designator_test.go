@@ -708,7 +708,7 @@ func Test_virtualGatewayMembershipDesignator_DesignateForGatewayRoute(t *testing
 			clientgoscheme.AddToScheme(k8sSchema)
 			appmesh.AddToScheme(k8sSchema)
 			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
-			designator := NewMembershipDesignator(k8sClient)
+			designator := NewMembershipDesignator(k8sClient, Config{})
 
 			for _, vg := range tt.env.virtualGateways {
 				err := k8sClient.Create(ctx, vg.DeepCopy())
@@ -1122,7 +1122,7 @@ func Test_virtualGatewayMembershipDesignator_DesignateForPod(t *testing.T) {
 			clientgoscheme.AddToScheme(k8sSchema)
 			appmesh.AddToScheme(k8sSchema)
 			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
-			designator := NewMembershipDesignator(k8sClient)
+			designator := NewMembershipDesignator(k8sClient, Config{})
 
 			for _, ns := range tt.env.namespaces {
 				err := k8sClient.Create(ctx, ns.DeepCopy())
@@ -1148,3 +1148,101 @@ func Test_virtualGatewayMembershipDesignator_DesignateForPod(t *testing.T) {
 		})
 	}
 }
+
+func Test_virtualGatewayMembershipDesignator_DesignateForPod_podLabel(t *testing.T) {
+	vgIngress := &appmesh.VirtualGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ingress-gw",
+		},
+		Spec: appmesh.VirtualGatewaySpec{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": "ingress-gw",
+				},
+			},
+		},
+	}
+	vgOtherNS := &appmesh.VirtualGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "other-ns",
+			Name:      "egress-gw",
+		},
+		Spec: appmesh.VirtualGatewaySpec{},
+	}
+	tests := []struct {
+		name      string
+		podLabel  string
+		podLabels map[string]string
+		want      *appmesh.VirtualGateway
+		wantErr   error
+	}{
+		{
+			name:     "pod with pod label is designated to the virtualGateway it names",
+			podLabel: "example.com/gateway",
+			podLabels: map[string]string{
+				"example.com/gateway": "ingress-gw",
+			},
+			want: vgIngress,
+		},
+		{
+			name:     "pod without pod label is matched against podSelectors",
+			podLabel: "example.com/gateway",
+			podLabels: map[string]string{
+				"app": "ingress-gw",
+			},
+			want: vgIngress,
+		},
+		{
+			name:     "pod label isn't considered when not configured",
+			podLabel: "",
+			podLabels: map[string]string{
+				"example.com/gateway": "ingress-gw",
+			},
+			want: nil,
+		},
+		{
+			name:     "pod label naming a virtualGateway in another namespace",
+			podLabel: "example.com/gateway",
+			podLabels: map[string]string{
+				"example.com/gateway": "egress-gw",
+			},
+			wantErr: errors.New("virtualGateway awesome-ns/egress-gw referenced by pod label example.com/gateway doesn't exist"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			designator := NewMembershipDesignator(k8sClient, Config{PodLabel: tt.podLabel})
+
+			for _, vg := range []*appmesh.VirtualGateway{vgIngress, vgOtherNS} {
+				err := k8sClient.Create(ctx, vg.DeepCopy())
+				assert.NoError(t, err)
+			}
+
+			ctx = webhook.ContextWithAdmissionRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{Namespace: "awesome-ns"},
+			})
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-pod",
+					Labels:    tt.podLabels,
+				},
+			}
+
+			got, err := designator.DesignateForPod(ctx, pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				opts := equality.IgnoreFakeClientPopulatedFields()
+				assert.True(t, cmp.Equal(tt.want, got, opts), "diff", cmp.Diff(tt.want, got, opts))
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package virtualgateway
 
 import (
 	"context"
+	"fmt"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/conversions"
@@ -10,6 +11,7 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/tagging"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
@@ -35,6 +37,7 @@ func NewDefaultResourceManager(
 	appMeshSDK services.AppMesh,
 	referencesResolver references.Resolver,
 	accountID string,
+	tagsManager tagging.Manager,
 	log logr.Logger) ResourceManager {
 
 	return &defaultResourceManager{
@@ -42,6 +45,7 @@ func NewDefaultResourceManager(
 		appMeshSDK:         appMeshSDK,
 		referencesResolver: referencesResolver,
 		accountID:          accountID,
+		tagsManager:        tagsManager,
 		log:                log,
 	}
 }
@@ -52,15 +56,19 @@ type defaultResourceManager struct {
 	appMeshSDK         services.AppMesh
 	referencesResolver references.Resolver
 	accountID          string
+	tagsManager        tagging.Manager
 	log                logr.Logger
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, vg *appmesh.VirtualGateway) error {
+	if k8s.IsReconciliationPaused(vg) {
+		return m.updateCRDVirtualGatewayReconciliationPaused(ctx, vg)
+	}
 	ms, err := m.findMeshDependency(ctx, vg)
 	if err != nil {
 		return err
 	}
-	if err := m.validateMeshDependencies(ctx, ms); err != nil {
+	if err := m.validateMeshDependencies(ctx, ms, vg); err != nil {
 		return err
 	}
 
@@ -78,6 +86,9 @@ func (m *defaultResourceManager) Reconcile(ctx context.Context, vg *appmesh.Virt
 		if err != nil {
 			return err
 		}
+		if err := m.updateSDKVirtualGatewayTags(ctx, sdkVG, vg); err != nil {
+			return err
+		}
 	}
 
 	return m.updateCRDVirtualGateway(ctx, vg, sdkVG)
@@ -98,6 +109,10 @@ func (m *defaultResourceManager) Cleanup(ctx context.Context, vg *appmesh.Virtua
 	if sdkVG == nil {
 		return nil
 	}
+	if k8s.IsDeletionPolicyOrphan(vg) {
+		m.sdkVirtualGatewayLogger(sdkVG, vg).V(1).Info("skip virtualGateway deletion since its deletionPolicy is orphan")
+		return nil
+	}
 
 	return m.deleteSDKVirtualGateway(ctx, sdkVG, ms, vg)
 }
@@ -115,8 +130,12 @@ func (m *defaultResourceManager) findMeshDependency(ctx context.Context, vg *app
 }
 
 // validateMeshDependencies validate the Mesh dependency for this virtualGateway.
-func (m *defaultResourceManager) validateMeshDependencies(ctx context.Context, ms *appmesh.Mesh) error {
+// virtualGateway is marked as WaitingForMesh and requeued with backoff until the mesh becomes active.
+func (m *defaultResourceManager) validateMeshDependencies(ctx context.Context, ms *appmesh.Mesh, vg *appmesh.VirtualGateway) error {
 	if !mesh.IsMeshActive(ms) {
+		if err := m.updateCRDVirtualGatewayWaitingForMesh(ctx, vg, ms); err != nil {
+			return err
+		}
 		return runtime.NewRequeueError(errors.New("mesh is not active yet"))
 	}
 	return nil
@@ -139,7 +158,7 @@ func (m *defaultResourceManager) findSDKVirtualGateway(ctx context.Context, ms *
 }
 
 func (m *defaultResourceManager) createSDKVirtualGateway(ctx context.Context, ms *appmesh.Mesh, vg *appmesh.VirtualGateway) (*appmeshsdk.VirtualGatewayData, error) {
-	sdkVGSpec, err := BuildSDKVirtualGatewaySpec(ctx, vg)
+	sdkVGSpec, err := BuildSDKVirtualGatewaySpec(ctx, applyMeshVirtualGatewayDefaults(ms, vg))
 	if err != nil {
 		return nil, err
 	}
@@ -148,6 +167,7 @@ func (m *defaultResourceManager) createSDKVirtualGateway(ctx context.Context, ms
 		MeshOwner:          ms.Spec.MeshOwner,
 		Spec:               sdkVGSpec,
 		VirtualGatewayName: vg.Spec.AWSName,
+		Tags:               m.buildSDKVirtualGatewayTags(ctx, vg),
 	})
 	if err != nil {
 		return nil, err
@@ -157,7 +177,7 @@ func (m *defaultResourceManager) createSDKVirtualGateway(ctx context.Context, ms
 
 func (m *defaultResourceManager) updateSDKVirtualGateway(ctx context.Context, sdkVG *appmeshsdk.VirtualGatewayData, ms *appmesh.Mesh, vg *appmesh.VirtualGateway) (*appmeshsdk.VirtualGatewayData, error) {
 	actualSDKVGSpec := sdkVG.Spec
-	desiredSDKVGSpec, err := BuildSDKVirtualGatewaySpec(ctx, vg)
+	desiredSDKVGSpec, err := BuildSDKVirtualGatewaySpec(ctx, applyMeshVirtualGatewayDefaults(ms, vg))
 	if err != nil {
 		return nil, err
 	}
@@ -167,16 +187,12 @@ func (m *defaultResourceManager) updateSDKVirtualGateway(ctx context.Context, sd
 		return sdkVG, nil
 	}
 	if !m.isSDKVirtualGatewayControlledByCRDVirtualGateway(ctx, sdkVG, vg) {
-		m.log.V(2).Info("skip virtualGateway update since it's not controlled",
-			"virtualGateway", k8s.NamespacedName(vg),
-			"virtualGatewayARN", aws.StringValue(sdkVG.Metadata.Arn),
-		)
+		m.sdkVirtualGatewayLogger(sdkVG, vg).V(2).Info("skip virtualGateway update since it's not controlled")
 		return sdkVG, nil
 	}
 
 	diff := cmp.Diff(desiredSDKVGSpec, actualSDKVGSpec, opts)
-	m.log.V(2).Info("virtualGatewaySpec changed",
-		"virtualGateway", k8s.NamespacedName(vg),
+	m.sdkVirtualGatewayLogger(sdkVG, vg).V(2).Info("virtualGatewaySpec changed",
 		"actualSDKVGSpec", actualSDKVGSpec,
 		"desiredSDKVGSpec", desiredSDKVGSpec,
 		"diff", diff,
@@ -195,10 +211,7 @@ func (m *defaultResourceManager) updateSDKVirtualGateway(ctx context.Context, sd
 
 func (m *defaultResourceManager) deleteSDKVirtualGateway(ctx context.Context, sdkVG *appmeshsdk.VirtualGatewayData, ms *appmesh.Mesh, vg *appmesh.VirtualGateway) error {
 	if !m.isSDKVirtualGatewayOwnedByCRDVirtualGateway(ctx, sdkVG, vg) {
-		m.log.V(2).Info("skip mesh virtualGateway since its not owned",
-			"virtualGateway", k8s.NamespacedName(vg),
-			"virtualGatewayARN", aws.StringValue(sdkVG.Metadata.Arn),
-		)
+		m.sdkVirtualGatewayLogger(sdkVG, vg).V(2).Info("skip mesh virtualGateway since its not owned")
 		return nil
 	}
 
@@ -207,10 +220,7 @@ func (m *defaultResourceManager) deleteSDKVirtualGateway(ctx context.Context, sd
 		MeshOwner:          ms.Spec.MeshOwner,
 		VirtualGatewayName: sdkVG.VirtualGatewayName,
 	})
-	if err != nil {
-		return err
-	}
-	return nil
+	return services.IgnoreAppMeshNotFound(err)
 }
 
 func (m *defaultResourceManager) updateCRDVirtualGateway(ctx context.Context, vg *appmesh.VirtualGateway, sdkVG *appmeshsdk.VirtualGatewayData) error {
@@ -228,6 +238,14 @@ func (m *defaultResourceManager) updateCRDVirtualGateway(ctx context.Context, vg
 	if updateCondition(vg, appmesh.VirtualGatewayActive, vgActiveConditionStatus, nil, nil) {
 		needsUpdate = true
 	}
+	if getCondition(vg, appmesh.VirtualGatewayReconciliationPaused) != nil &&
+		updateCondition(vg, appmesh.VirtualGatewayReconciliationPaused, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
+	if getCondition(vg, appmesh.VirtualGatewayWaitingForMesh) != nil &&
+		updateCondition(vg, appmesh.VirtualGatewayWaitingForMesh, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
 
 	if !needsUpdate {
 		return nil
@@ -235,9 +253,44 @@ func (m *defaultResourceManager) updateCRDVirtualGateway(ctx context.Context, vg
 	return m.k8sClient.Status().Patch(ctx, vg, client.MergeFrom(oldVG))
 }
 
+// updateCRDVirtualGatewayReconciliationPaused records the ReconciliationPaused condition without touching the AppMesh VirtualGateway.
+func (m *defaultResourceManager) updateCRDVirtualGatewayReconciliationPaused(ctx context.Context, vg *appmesh.VirtualGateway) error {
+	oldVG := vg.DeepCopy()
+	if !updateCondition(vg, appmesh.VirtualGatewayReconciliationPaused, corev1.ConditionTrue, nil, nil) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, vg, client.MergeFrom(oldVG))
+}
+
+// updateCRDVirtualGatewayWaitingForMesh records the WaitingForMesh condition while the mesh of vg isn't active.
+func (m *defaultResourceManager) updateCRDVirtualGatewayWaitingForMesh(ctx context.Context, vg *appmesh.VirtualGateway, ms *appmesh.Mesh) error {
+	oldVG := vg.DeepCopy()
+	message := fmt.Sprintf("mesh %s is not active yet", ms.Name)
+	if !updateCondition(vg, appmesh.VirtualGatewayWaitingForMesh, corev1.ConditionTrue, nil, aws.String(message)) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, vg, client.MergeFrom(oldVG))
+}
+
 func (m *defaultResourceManager) buildSDKVirtualGatewayTags(ctx context.Context, vg *appmesh.VirtualGateway) []*appmeshsdk.TagRef {
-	// TODO, support tags
-	return nil
+	return m.tagsManager.BuildSDKTags(vg)
+}
+
+// sdkVirtualGatewayLogger returns a logger carrying the identity of vg and its AppMesh VirtualGateway.
+func (m *defaultResourceManager) sdkVirtualGatewayLogger(sdkVG *appmeshsdk.VirtualGatewayData, vg *appmesh.VirtualGateway) logr.Logger {
+	return m.log.WithValues(
+		"meshName", aws.StringValue(sdkVG.MeshName),
+		"virtualGateway", k8s.NamespacedName(vg),
+		"arn", aws.StringValue(sdkVG.Metadata.Arn),
+	)
+}
+
+// updateSDKVirtualGatewayTags corrects drift of the tags propagated from labels of vg on its AppMesh VirtualGateway.
+func (m *defaultResourceManager) updateSDKVirtualGatewayTags(ctx context.Context, sdkVG *appmeshsdk.VirtualGatewayData, vg *appmesh.VirtualGateway) error {
+	if !m.isSDKVirtualGatewayControlledByCRDVirtualGateway(ctx, sdkVG, vg) {
+		return nil
+	}
+	return m.tagsManager.ReconcileSDKTags(ctx, aws.StringValue(sdkVG.Metadata.Arn), vg)
 }
 
 // isSDKVirtualGatewayControlledByCRDVirtualGateway checks whether an AppMesh virtualGateway is controlled by CRD virtualGateway
@@ -261,6 +314,49 @@ func (m *defaultResourceManager) isSDKVirtualGatewayOwnedByCRDVirtualGateway(ctx
 	return true
 }
 
+// applyMeshVirtualGatewayDefaults returns vg with the mesh's default connectionPool applied to listeners that don't specify their own.
+// vg itself is never modified, a copy is returned when defaults are applied.
+func applyMeshVirtualGatewayDefaults(ms *appmesh.Mesh, vg *appmesh.VirtualGateway) *appmesh.VirtualGateway {
+	if ms.Spec.VirtualGatewayDefaults == nil || ms.Spec.VirtualGatewayDefaults.ConnectionPool == nil {
+		return vg
+	}
+	vgWithDefaults := vg
+	for i, listener := range vg.Spec.Listeners {
+		if listener.ConnectionPool != nil {
+			continue
+		}
+		connectionPool := buildDefaultListenerConnectionPool(ms.Spec.VirtualGatewayDefaults.ConnectionPool, listener.PortMapping.Protocol)
+		if connectionPool == nil {
+			continue
+		}
+		if vgWithDefaults == vg {
+			vgWithDefaults = vg.DeepCopy()
+		}
+		vgWithDefaults.Spec.Listeners[i].ConnectionPool = connectionPool
+	}
+	return vgWithDefaults
+}
+
+// buildDefaultListenerConnectionPool returns the default connectionPool matching a listener's protocol, or nil if there is none.
+// AppMesh only allows the connectionPool of a listener's own protocol.
+func buildDefaultListenerConnectionPool(defaultConnectionPool *appmesh.VirtualGatewayConnectionPool, protocol appmesh.VirtualGatewayPortProtocol) *appmesh.VirtualGatewayConnectionPool {
+	switch protocol {
+	case appmesh.VirtualGatewayPortProtocolHTTP:
+		if defaultConnectionPool.HTTP != nil {
+			return &appmesh.VirtualGatewayConnectionPool{HTTP: defaultConnectionPool.HTTP.DeepCopy()}
+		}
+	case appmesh.VirtualGatewayPortProtocolHTTP2:
+		if defaultConnectionPool.HTTP2 != nil {
+			return &appmesh.VirtualGatewayConnectionPool{HTTP2: defaultConnectionPool.HTTP2.DeepCopy()}
+		}
+	case appmesh.VirtualGatewayPortProtocolGRPC:
+		if defaultConnectionPool.GRPC != nil {
+			return &appmesh.VirtualGatewayConnectionPool{GRPC: defaultConnectionPool.GRPC.DeepCopy()}
+		}
+	}
+	return nil
+}
+
 func BuildSDKVirtualGatewaySpec(ctx context.Context, vg *appmesh.VirtualGateway) (*appmeshsdk.VirtualGatewaySpec, error) {
 	converter := conversion.NewConverter(conversion.DefaultNameFunc)
 	converter.RegisterUntypedConversionFunc((*appmesh.VirtualGatewaySpec)(nil), (*appmeshsdk.VirtualGatewaySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
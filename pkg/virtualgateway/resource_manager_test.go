@@ -4,9 +4,13 @@ import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	mock_resolver "github.com/aws/aws-app-mesh-controller-for-k8s/mocks/aws-app-mesh-controller-for-k8s/pkg/references"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/equality"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	appmeshruntime "github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
@@ -107,6 +111,55 @@ func Test_defaultResourceManager_updateCRDVirtualGateway(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "virtualGateway resumed after pause needs patch ReconciliationPaused condition",
+			args: args{
+				vg: &appmesh.VirtualGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vg-1",
+					},
+					Status: appmesh.VirtualGatewayStatus{
+						VirtualGatewayARN: aws.String("arn-1"),
+						Conditions: []appmesh.VirtualGatewayCondition{
+							{
+								Type:   appmesh.VirtualGatewayActive,
+								Status: corev1.ConditionTrue,
+							},
+							{
+								Type:   appmesh.VirtualGatewayReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+				sdkVG: &appmeshsdk.VirtualGatewayData{
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn: aws.String("arn-1"),
+					},
+					Status: &appmeshsdk.VirtualGatewayStatus{
+						Status: aws.String(appmeshsdk.VirtualGatewayStatusCodeActive),
+					},
+				},
+			},
+			wantVG: &appmesh.VirtualGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vg-1",
+				},
+				Status: appmesh.VirtualGatewayStatus{
+					VirtualGatewayARN: aws.String("arn-1"),
+					Conditions: []appmesh.VirtualGatewayCondition{
+						{
+							Type:   appmesh.VirtualGatewayActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.VirtualGatewayReconciliationPaused,
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -140,6 +193,136 @@ func Test_defaultResourceManager_updateCRDVirtualGateway(t *testing.T) {
 	}
 }
 
+func Test_defaultResourceManager_Reconcile_paused(t *testing.T) {
+	type args struct {
+		vg *appmesh.VirtualGateway
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantVG  *appmesh.VirtualGateway
+		wantErr error
+	}{
+		{
+			name: "paused virtualGateway records ReconciliationPaused condition",
+			args: args{
+				vg: &appmesh.VirtualGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vg-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.VirtualGatewayStatus{
+						VirtualGatewayARN: aws.String("arn-1"),
+						Conditions: []appmesh.VirtualGatewayCondition{
+							{
+								Type:   appmesh.VirtualGatewayActive,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantVG: &appmesh.VirtualGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vg-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.VirtualGatewayStatus{
+					VirtualGatewayARN: aws.String("arn-1"),
+					Conditions: []appmesh.VirtualGatewayCondition{
+						{
+							Type:   appmesh.VirtualGatewayActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.VirtualGatewayReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "paused virtualGateway already has ReconciliationPaused condition",
+			args: args{
+				vg: &appmesh.VirtualGateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vg-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.VirtualGatewayStatus{
+						VirtualGatewayARN: aws.String("arn-1"),
+						Conditions: []appmesh.VirtualGatewayCondition{
+							{
+								Type:   appmesh.VirtualGatewayReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantVG: &appmesh.VirtualGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vg-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.VirtualGatewayStatus{
+					VirtualGatewayARN: aws.String("arn-1"),
+					Conditions: []appmesh.VirtualGatewayCondition{
+						{
+							Type:   appmesh.VirtualGatewayReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			// neither referencesResolver nor appMeshSDK expect any calls while paused.
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			m := &defaultResourceManager{
+				k8sClient:          k8sClient,
+				referencesResolver: resolver,
+				log:                log.NullLogger{},
+			}
+
+			err := k8sClient.Create(ctx, tt.args.vg.DeepCopy())
+			assert.NoError(t, err)
+			err = m.Reconcile(ctx, tt.args.vg)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				gotVG := &appmesh.VirtualGateway{}
+				err = k8sClient.Get(ctx, k8s.NamespacedName(tt.args.vg), gotVG)
+				assert.NoError(t, err)
+				opts := cmp.Options{
+					equality.IgnoreFakeClientPopulatedFields(),
+					cmpopts.IgnoreTypes((*metav1.Time)(nil)),
+				}
+				assert.True(t, cmp.Equal(tt.wantVG, gotVG, opts), "diff", cmp.Diff(tt.wantVG, gotVG, opts))
+			}
+		})
+	}
+}
+
 func Test_defaultResourceManager_isSDKVirtualGatewayControlledByCRDVirtualGateway(t *testing.T) {
 	type fields struct {
 		accountID string
@@ -435,11 +618,239 @@ func Test_defaultResourceManager_validateMeshDependency(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
 			m := &defaultResourceManager{
-				log: log.NullLogger{},
+				k8sClient: k8sClient,
+				log:       log.NullLogger{},
+			}
+			vg := &appmesh.VirtualGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "my-vg",
+				},
+			}
+			err := k8sClient.Create(ctx, vg.DeepCopy())
+			assert.NoError(t, err)
+
+			err = m.validateMeshDependencies(ctx, tt.args.mesh, vg)
+			gotVG := &appmesh.VirtualGateway{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(vg), gotVG))
+			waitingForMesh := getCondition(gotVG, appmesh.VirtualGatewayWaitingForMesh)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				var requeueErr *appmeshruntime.RequeueError
+				assert.True(t, errors.As(err, &requeueErr), "vg must be requeued while the mesh isn't active")
+				if assert.NotNil(t, waitingForMesh) {
+					assert.Equal(t, corev1.ConditionTrue, waitingForMesh.Status)
+					assert.Equal(t, "mesh my-mesh is not active yet", aws.StringValue(waitingForMesh.Message))
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Nil(t, waitingForMesh)
 			}
+		})
+	}
+}
+
+func Test_applyMeshVirtualGatewayDefaults(t *testing.T) {
+	meshConnectionPool := appmesh.VirtualGatewayConnectionPool{
+		HTTP: &appmesh.HTTPConnectionPool{
+			MaxConnections:     100,
+			MaxPendingRequests: aws.Int64(50),
+		},
+		GRPC: &appmesh.GRPCConnectionPool{
+			MaxRequests: 200,
+		},
+	}
+	listener := func(protocol appmesh.VirtualGatewayPortProtocol, connectionPool *appmesh.VirtualGatewayConnectionPool) appmesh.VirtualGatewayListener {
+		return appmesh.VirtualGatewayListener{
+			PortMapping: appmesh.VirtualGatewayPortMapping{
+				Port:     8080,
+				Protocol: protocol,
+			},
+			ConnectionPool: connectionPool,
+		}
+	}
+	type args struct {
+		ms *appmesh.Mesh
+		vg *appmesh.VirtualGateway
+	}
+	tests := []struct {
+		name string
+		args args
+		want *appmesh.VirtualGateway
+	}{
+		{
+			name: "mesh without virtualGatewayDefaults",
+			args: args{
+				ms: &appmesh.Mesh{},
+				vg: &appmesh.VirtualGateway{
+					Spec: appmesh.VirtualGatewaySpec{
+						Listeners: []appmesh.VirtualGatewayListener{listener(appmesh.VirtualGatewayPortProtocolHTTP, nil)},
+					},
+				},
+			},
+			want: &appmesh.VirtualGateway{
+				Spec: appmesh.VirtualGatewaySpec{
+					Listeners: []appmesh.VirtualGatewayListener{listener(appmesh.VirtualGatewayPortProtocolHTTP, nil)},
+				},
+			},
+		},
+		{
+			name: "listener without connectionPool gets the default matching its protocol",
+			args: args{
+				ms: &appmesh.Mesh{
+					Spec: appmesh.MeshSpec{
+						VirtualGatewayDefaults: &appmesh.VirtualGatewayDefaults{
+							ConnectionPool: meshConnectionPool.DeepCopy(),
+						},
+					},
+				},
+				vg: &appmesh.VirtualGateway{
+					Spec: appmesh.VirtualGatewaySpec{
+						Listeners: []appmesh.VirtualGatewayListener{
+							listener(appmesh.VirtualGatewayPortProtocolHTTP, nil),
+							listener(appmesh.VirtualGatewayPortProtocolGRPC, nil),
+						},
+					},
+				},
+			},
+			want: &appmesh.VirtualGateway{
+				Spec: appmesh.VirtualGatewaySpec{
+					Listeners: []appmesh.VirtualGatewayListener{
+						listener(appmesh.VirtualGatewayPortProtocolHTTP, &appmesh.VirtualGatewayConnectionPool{
+							HTTP: &appmesh.HTTPConnectionPool{
+								MaxConnections:     100,
+								MaxPendingRequests: aws.Int64(50),
+							},
+						}),
+						listener(appmesh.VirtualGatewayPortProtocolGRPC, &appmesh.VirtualGatewayConnectionPool{
+							GRPC: &appmesh.GRPCConnectionPool{
+								MaxRequests: 200,
+							},
+						}),
+					},
+				},
+			},
+		},
+		{
+			name: "listener without default for its protocol is kept",
+			args: args{
+				ms: &appmesh.Mesh{
+					Spec: appmesh.MeshSpec{
+						VirtualGatewayDefaults: &appmesh.VirtualGatewayDefaults{
+							ConnectionPool: meshConnectionPool.DeepCopy(),
+						},
+					},
+				},
+				vg: &appmesh.VirtualGateway{
+					Spec: appmesh.VirtualGatewaySpec{
+						Listeners: []appmesh.VirtualGatewayListener{listener(appmesh.VirtualGatewayPortProtocolHTTP2, nil)},
+					},
+				},
+			},
+			want: &appmesh.VirtualGateway{
+				Spec: appmesh.VirtualGatewaySpec{
+					Listeners: []appmesh.VirtualGatewayListener{listener(appmesh.VirtualGatewayPortProtocolHTTP2, nil)},
+				},
+			},
+		},
+		{
+			name: "listener's own connectionPool takes precedence",
+			args: args{
+				ms: &appmesh.Mesh{
+					Spec: appmesh.MeshSpec{
+						VirtualGatewayDefaults: &appmesh.VirtualGatewayDefaults{
+							ConnectionPool: meshConnectionPool.DeepCopy(),
+						},
+					},
+				},
+				vg: &appmesh.VirtualGateway{
+					Spec: appmesh.VirtualGatewaySpec{
+						Listeners: []appmesh.VirtualGatewayListener{
+							listener(appmesh.VirtualGatewayPortProtocolHTTP, &appmesh.VirtualGatewayConnectionPool{
+								HTTP: &appmesh.HTTPConnectionPool{
+									MaxConnections: 10,
+								},
+							}),
+						},
+					},
+				},
+			},
+			want: &appmesh.VirtualGateway{
+				Spec: appmesh.VirtualGatewaySpec{
+					Listeners: []appmesh.VirtualGatewayListener{
+						listener(appmesh.VirtualGatewayPortProtocolHTTP, &appmesh.VirtualGatewayConnectionPool{
+							HTTP: &appmesh.HTTPConnectionPool{
+								MaxConnections: 10,
+							},
+						}),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalVG := tt.args.vg.DeepCopy()
+			got := applyMeshVirtualGatewayDefaults(tt.args.ms, tt.args.vg)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, originalVG, tt.args.vg)
+		})
+	}
+}
+
+// fakeAppMesh fails AppMesh deletions with deleteErr.
+type fakeAppMesh struct {
+	services.AppMesh
+	deleteErr error
+}
+
+func (f *fakeAppMesh) DeleteVirtualGatewayWithContext(_ context.Context, _ *appmeshsdk.DeleteVirtualGatewayInput, _ ...request.Option) (*appmeshsdk.DeleteVirtualGatewayOutput, error) {
+	if f.deleteErr != nil {
+		return nil, f.deleteErr
+	}
+	return &appmeshsdk.DeleteVirtualGatewayOutput{}, nil
+}
 
-			err := m.validateMeshDependencies(ctx, tt.args.mesh)
+func Test_defaultResourceManager_deleteSDKVirtualGateway(t *testing.T) {
+	sdkVirtualGateway := &appmeshsdk.VirtualGatewayData{
+		MeshName:           aws.String("my-mesh"),
+		VirtualGatewayName: aws.String("my-virtualgateway"),
+		Metadata: &appmeshsdk.ResourceMetadata{
+			MeshOwner:     aws.String("222222222"),
+			ResourceOwner: aws.String("222222222"),
+		},
+	}
+	tests := []struct {
+		name      string
+		deleteErr error
+		wantErr   error
+	}{
+		{
+			name: "AppMesh virtualGateway deleted",
+		},
+		{
+			name:      "AppMesh virtualGateway deleted out-of-band",
+			deleteErr: awserr.New("NotFoundException", "virtualGateway not found", nil),
+		},
+		{
+			name:      "AppMesh virtualGateway deletion fails",
+			deleteErr: awserr.New("ResourceInUseException", "virtualGateway in use", nil),
+			wantErr:   errors.New("ResourceInUseException: virtualGateway in use"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				appMeshSDK: &fakeAppMesh{deleteErr: tt.deleteErr},
+				accountID:  "222222222",
+				log:        &log.NullLogger{},
+			}
+			err := m.deleteSDKVirtualGateway(context.Background(), sdkVirtualGateway, &appmesh.Mesh{}, &appmesh.VirtualGateway{})
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
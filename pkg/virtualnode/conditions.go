@@ -7,8 +7,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// getCondition will get pointer to virtualNode's existing condition.
-func getCondition(vn *appmesh.VirtualNode, conditionType appmesh.VirtualNodeConditionType) *appmesh.VirtualNodeCondition {
+// GetCondition will get pointer to virtualNode's existing condition.
+func GetCondition(vn *appmesh.VirtualNode, conditionType appmesh.VirtualNodeConditionType) *appmesh.VirtualNodeCondition {
 	for i := range vn.Status.Conditions {
 		if vn.Status.Conditions[i].Type == conditionType {
 			return &vn.Status.Conditions[i]
@@ -17,10 +17,10 @@ func getCondition(vn *appmesh.VirtualNode, conditionType appmesh.VirtualNodeCond
 	return nil
 }
 
-// updateCondition will update virtualNode's condition. returns whether it's updated.
-func updateCondition(vn *appmesh.VirtualNode, conditionType appmesh.VirtualNodeConditionType, status corev1.ConditionStatus, reason *string, message *string) bool {
+// UpdateCondition will update virtualNode's condition. returns whether it's updated.
+func UpdateCondition(vn *appmesh.VirtualNode, conditionType appmesh.VirtualNodeConditionType, status corev1.ConditionStatus, reason *string, message *string) bool {
 	now := metav1.Now()
-	existingCondition := getCondition(vn, conditionType)
+	existingCondition := GetCondition(vn, conditionType)
 	if existingCondition == nil {
 		newCondition := appmesh.VirtualNodeCondition{
 			Type:               conditionType,
@@ -12,7 +12,7 @@ import (
 	"testing"
 )
 
-func Test_getCondition(t *testing.T) {
+func TestGetCondition(t *testing.T) {
 	type args struct {
 		vn            *appmesh.VirtualNode
 		conditionType v1beta2.VirtualNodeConditionType
@@ -57,13 +57,13 @@ func Test_getCondition(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := getCondition(tt.args.vn, tt.args.conditionType)
+			got := GetCondition(tt.args.vn, tt.args.conditionType)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
-func Test_updateCondition(t *testing.T) {
+func TestUpdateCondition(t *testing.T) {
 	type args struct {
 		vn            *appmesh.VirtualNode
 		conditionType appmesh.VirtualNodeConditionType
@@ -261,7 +261,7 @@ func Test_updateCondition(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotChanged := updateCondition(tt.args.vn, tt.args.conditionType, tt.args.status, tt.args.reason, tt.args.message)
+			gotChanged := UpdateCondition(tt.args.vn, tt.args.conditionType, tt.args.status, tt.args.reason, tt.args.message)
 			opts := cmpopts.IgnoreTypes((*metav1.Time)(nil))
 			assert.True(t, cmp.Equal(tt.wantVN, tt.args.vn, opts), "diff", cmp.Diff(tt.wantVN, tt.args.vn, opts))
 			assert.Equal(t, tt.wantChanged, gotChanged)
@@ -0,0 +1,140 @@
+package virtualnode
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagResourceNamePrefix = "virtualnode-name-prefix"
+	flagResourceNameSuffix = "virtualnode-name-suffix"
+
+	flagServiceDeletionPolicy   = "virtualnode-service-deletion-policy"
+	flagNameCollisionPolicy     = "virtualnode-name-collision-policy"
+	flagEnableAdoption          = "virtualnode-enable-adoption"
+	flagPodSelectionPolicy      = "virtualnode-pod-selection-policy"
+	flagExternallyDeletedPolicy = "virtualnode-externally-deleted-policy"
+
+	// AppMesh limits the length of virtual node names to 255 characters.
+	maxAWSNameLength = 255
+)
+
+// AppMesh names may only contain letters, numbers, hyphens and underscores.
+var awsNameCharsPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]*$`)
+
+const (
+	// ServiceDeletionPolicyIgnore doesn't check the k8s Service behind a VirtualNode's DNS hostname.
+	ServiceDeletionPolicyIgnore = "ignore"
+	// ServiceDeletionPolicyRetain keeps the AppMesh VirtualNode and records a warning event when its k8s Service is deleted.
+	ServiceDeletionPolicyRetain = "retain"
+	// ServiceDeletionPolicyDelete deletes the AppMesh VirtualNode when its k8s Service is deleted.
+	ServiceDeletionPolicyDelete = "delete"
+)
+
+const (
+	// NameCollisionPolicyDeny fails reconciliation of a VirtualNode whose AppMesh VirtualNode is claimed by another VirtualNode.
+	NameCollisionPolicyDeny = "deny"
+	// NameCollisionPolicyWarn records a warning event and marks the VirtualNode inactive, leaving the AppMesh VirtualNode claimed by another VirtualNode untouched.
+	NameCollisionPolicyWarn = "warn"
+)
+
+const (
+	// PodSelectionPolicyDeny fails designation of a pod matched by the podSelector of multiple VirtualNodes.
+	PodSelectionPolicyDeny = "deny"
+	// PodSelectionPolicyMostSpecific designates a pod matched by multiple VirtualNodes to the one with the most specific podSelector.
+	PodSelectionPolicyMostSpecific = "most-specific"
+)
+
+const (
+	// ExternallyDeletedPolicyRecreate creates the AppMesh VirtualNode again once it's been deleted outside of the controller.
+	ExternallyDeletedPolicyRecreate = "recreate"
+	// ExternallyDeletedPolicySkip records the ExternallyDeleted condition and requeues slowly instead of creating the AppMesh VirtualNode again.
+	ExternallyDeletedPolicySkip = "skip"
+)
+
+type Config struct {
+	// Prefix added to defaulted AppMesh VirtualNode names.
+	ResourceNamePrefix string
+	// Suffix added to defaulted AppMesh VirtualNode names.
+	ResourceNameSuffix string
+	// How to handle VirtualNodes whose k8s Service behind DNS hostname got deleted.
+	ServiceDeletionPolicy string
+	// How to handle VirtualNodes whose AppMesh name is claimed by another VirtualNode.
+	NameCollisionPolicy string
+	// If enabled, existing AppMesh VirtualNodes not claimed by any VirtualNode are adopted instead of failing reconciliation.
+	// other kinds don't track ownership, so they're never subject to adoption.
+	EnableAdoption bool
+	// How to designate pods matched by the podSelector of multiple VirtualNodes.
+	PodSelectionPolicy string
+	// How to handle VirtualNodes whose AppMesh VirtualNode is deleted outside of the controller.
+	// other kinds never check whether their AppMesh resource is deleted, so they aren't subject to it.
+	ExternallyDeletedPolicy string
+}
+
+func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&cfg.ResourceNamePrefix, flagResourceNamePrefix, "",
+		`Prefix added to the AppMesh name of VirtualNodes that don't specify awsName, other resources keep their defaulted name`)
+	fs.StringVar(&cfg.ResourceNameSuffix, flagResourceNameSuffix, "",
+		`Suffix added to the AppMesh name of VirtualNodes that don't specify awsName, other resources keep their defaulted name`)
+	fs.StringVar(&cfg.ServiceDeletionPolicy, flagServiceDeletionPolicy, ServiceDeletionPolicyIgnore,
+		`How to handle VirtualNodes whose k8s Service behind DNS hostname is deleted - ignore(default), retain or delete`)
+	fs.StringVar(&cfg.NameCollisionPolicy, flagNameCollisionPolicy, NameCollisionPolicyDeny,
+		`How to handle VirtualNodes whose AppMesh name is already claimed by another VirtualNode - deny(default) or warn`)
+	fs.BoolVar(&cfg.EnableAdoption, flagEnableAdoption, false,
+		`If enabled, VirtualNodes adopt existing AppMesh VirtualNodes with the same name that aren't claimed by any VirtualNode, instead of failing reconciliation. `+
+			`Only applies to VirtualNodes, other resources always manage the existing AppMesh resource with the same name`)
+	fs.StringVar(&cfg.PodSelectionPolicy, flagPodSelectionPolicy, PodSelectionPolicyDeny,
+		`How to handle pods matched by the podSelector of multiple VirtualNodes - deny(default) or most-specific, which picks the VirtualNode whose podSelector has the most requirements`)
+	fs.StringVar(&cfg.ExternallyDeletedPolicy, flagExternallyDeletedPolicy, ExternallyDeletedPolicyRecreate,
+		`How to handle VirtualNodes whose AppMesh VirtualNode is described with DELETED status - recreate(default) or skip, which marks them ExternallyDeleted and requeues slowly. `+
+			`Only applies to VirtualNodes, other resources don't check the status of their AppMesh resource`)
+}
+
+func (cfg *Config) Validate() error {
+	if !awsNameCharsPattern.MatchString(cfg.ResourceNamePrefix) {
+		return fmt.Errorf("invalid %s %s: must only contain letters, numbers, hyphens and underscores", flagResourceNamePrefix, cfg.ResourceNamePrefix)
+	}
+	if !awsNameCharsPattern.MatchString(cfg.ResourceNameSuffix) {
+		return fmt.Errorf("invalid %s %s: must only contain letters, numbers, hyphens and underscores", flagResourceNameSuffix, cfg.ResourceNameSuffix)
+	}
+	if len(cfg.ResourceNamePrefix)+len(cfg.ResourceNameSuffix) >= maxAWSNameLength {
+		return fmt.Errorf("invalid %s and %s: combined length must be less than %d", flagResourceNamePrefix, flagResourceNameSuffix, maxAWSNameLength)
+	}
+	switch cfg.ServiceDeletionPolicy {
+	case "", ServiceDeletionPolicyIgnore, ServiceDeletionPolicyRetain, ServiceDeletionPolicyDelete:
+	default:
+		return fmt.Errorf("invalid %s %s, must be %s, %s or %s", flagServiceDeletionPolicy, cfg.ServiceDeletionPolicy,
+			ServiceDeletionPolicyIgnore, ServiceDeletionPolicyRetain, ServiceDeletionPolicyDelete)
+	}
+	switch cfg.NameCollisionPolicy {
+	case "", NameCollisionPolicyDeny, NameCollisionPolicyWarn:
+	default:
+		return fmt.Errorf("invalid %s %s, must be %s or %s", flagNameCollisionPolicy, cfg.NameCollisionPolicy,
+			NameCollisionPolicyDeny, NameCollisionPolicyWarn)
+	}
+	switch cfg.PodSelectionPolicy {
+	case "", PodSelectionPolicyDeny, PodSelectionPolicyMostSpecific:
+	default:
+		return fmt.Errorf("invalid %s %s, must be %s or %s", flagPodSelectionPolicy, cfg.PodSelectionPolicy,
+			PodSelectionPolicyDeny, PodSelectionPolicyMostSpecific)
+	}
+	switch cfg.ExternallyDeletedPolicy {
+	case "", ExternallyDeletedPolicyRecreate, ExternallyDeletedPolicySkip:
+	default:
+		return fmt.Errorf("invalid %s %s, must be %s or %s", flagExternallyDeletedPolicy, cfg.ExternallyDeletedPolicy,
+			ExternallyDeletedPolicyRecreate, ExternallyDeletedPolicySkip)
+	}
+	return nil
+}
+
+// IsServiceDeletionCheckEnabled checks whether the k8s Service behind a VirtualNode's DNS hostname should be tracked.
+func (cfg *Config) IsServiceDeletionCheckEnabled() bool {
+	return cfg.ServiceDeletionPolicy == ServiceDeletionPolicyRetain || cfg.ServiceDeletionPolicy == ServiceDeletionPolicyDelete
+}
+
+// BuildAWSName returns the AppMesh name for given base name with configured prefix and suffix applied.
+func (cfg *Config) BuildAWSName(name string) string {
+	return cfg.ResourceNamePrefix + name + cfg.ResourceNameSuffix
+}
@@ -0,0 +1,154 @@
+package virtualnode
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestConfig_BuildAWSName(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{
+			name: "no prefix or suffix",
+			cfg:  Config{},
+			want: "my-vn_awesome-ns",
+		},
+		{
+			name: "prefix only",
+			cfg: Config{
+				ResourceNamePrefix: "dev-",
+			},
+			want: "dev-my-vn_awesome-ns",
+		},
+		{
+			name: "suffix only",
+			cfg: Config{
+				ResourceNameSuffix: "_prod",
+			},
+			want: "my-vn_awesome-ns_prod",
+		},
+		{
+			name: "prefix and suffix",
+			cfg: Config{
+				ResourceNamePrefix: "dev-",
+				ResourceNameSuffix: "_prod",
+			},
+			want: "dev-my-vn_awesome-ns_prod",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cfg.BuildAWSName("my-vn_awesome-ns")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{
+			name: "empty prefix and suffix",
+			cfg:  Config{},
+		},
+		{
+			name: "valid prefix and suffix",
+			cfg: Config{
+				ResourceNamePrefix: "dev-",
+				ResourceNameSuffix: "_prod",
+			},
+		},
+		{
+			name: "prefix and suffix too long",
+			cfg: Config{
+				ResourceNamePrefix: strings.Repeat("a", 200),
+				ResourceNameSuffix: strings.Repeat("b", 55),
+			},
+			wantErr: errors.New("invalid virtualnode-name-prefix and virtualnode-name-suffix: combined length must be less than 255"),
+		},
+		{
+			name: "prefix with invalid characters",
+			cfg: Config{
+				ResourceNamePrefix: "dev.",
+			},
+			wantErr: errors.New("invalid virtualnode-name-prefix dev.: must only contain letters, numbers, hyphens and underscores"),
+		},
+		{
+			name: "suffix with invalid characters",
+			cfg: Config{
+				ResourceNameSuffix: "/prod",
+			},
+			wantErr: errors.New("invalid virtualnode-name-suffix /prod: must only contain letters, numbers, hyphens and underscores"),
+		},
+		{
+			name: "valid service deletion policy",
+			cfg: Config{
+				ServiceDeletionPolicy: ServiceDeletionPolicyDelete,
+			},
+		},
+		{
+			name: "unknown service deletion policy",
+			cfg: Config{
+				ServiceDeletionPolicy: "orphan",
+			},
+			wantErr: errors.New("invalid virtualnode-service-deletion-policy orphan, must be ignore, retain or delete"),
+		},
+		{
+			name: "valid name collision policy",
+			cfg: Config{
+				NameCollisionPolicy: NameCollisionPolicyWarn,
+			},
+		},
+		{
+			name: "unknown name collision policy",
+			cfg: Config{
+				NameCollisionPolicy: "adopt",
+			},
+			wantErr: errors.New("invalid virtualnode-name-collision-policy adopt, must be deny or warn"),
+		},
+		{
+			name: "valid pod selection policy",
+			cfg: Config{
+				PodSelectionPolicy: PodSelectionPolicyMostSpecific,
+			},
+		},
+		{
+			name: "unknown pod selection policy",
+			cfg: Config{
+				PodSelectionPolicy: "first",
+			},
+			wantErr: errors.New("invalid virtualnode-pod-selection-policy first, must be deny or most-specific"),
+		},
+		{
+			name: "valid externally deleted policy",
+			cfg: Config{
+				ExternallyDeletedPolicy: ExternallyDeletedPolicySkip,
+			},
+		},
+		{
+			name: "unknown externally deleted policy",
+			cfg: Config{
+				ExternallyDeletedPolicy: "ignore",
+			},
+			wantErr: errors.New("invalid virtualnode-externally-deleted-policy ignore, must be recreate or skip"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -126,6 +126,51 @@ func Test_enqueueRequestsForMeshEvents_Update(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "backendDefaults changed",
+			env: env{
+				virtualNodes: []*appmesh.VirtualNode{vn1, vn2},
+			},
+			args: args{
+				e: event.UpdateEvent{
+					ObjectOld: &appmesh.Mesh{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "my-mesh",
+							UID:  "a385048d-aba8-4235-9a11-4173764c8ab7",
+						},
+					},
+					ObjectNew: &appmesh.Mesh{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "my-mesh",
+							UID:  "a385048d-aba8-4235-9a11-4173764c8ab7",
+						},
+						Spec: appmesh.MeshSpec{
+							BackendDefaults: &appmesh.BackendDefaults{
+								ClientPolicy: &appmesh.ClientPolicy{
+									TLS: &appmesh.ClientPolicyTLS{
+										Validation: appmesh.TLSValidationContext{
+											Trust: appmesh.TLSValidationContextTrust{
+												File: &appmesh.TLSValidationContextFileTrust{
+													CertificateChain: "/certs/ca-chain.pem",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantRequests: []reconcile.Request{
+				{
+					NamespacedName: k8s.NamespacedName(vn1),
+				},
+				{
+					NamespacedName: k8s.NamespacedName(vn2),
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
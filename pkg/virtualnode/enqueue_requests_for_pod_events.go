@@ -0,0 +1,84 @@
+package virtualnode
+
+import (
+	"context"
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+func NewEnqueueRequestsForPodEvents(k8sClient client.Client, log logr.Logger) *enqueueRequestsForPodEvents {
+	return &enqueueRequestsForPodEvents{
+		k8sClient: k8sClient,
+		log:       log,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForPodEvents)(nil)
+
+type enqueueRequestsForPodEvents struct {
+	k8sClient client.Client
+	log       logr.Logger
+}
+
+// Create is called in response to an create event
+func (h *enqueueRequestsForPodEvents) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	pod := e.Object.(*corev1.Pod)
+	h.enqueueVirtualNodesForPod(context.Background(), queue, pod)
+}
+
+// Update is called in response to an update event
+func (h *enqueueRequestsForPodEvents) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	// pod labels might change and make it selected by another virtualNode.
+	podNew := e.ObjectNew.(*corev1.Pod)
+	h.enqueueVirtualNodesForPod(context.Background(), queue, podNew)
+}
+
+// Delete is called in response to a delete event
+func (h *enqueueRequestsForPodEvents) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	// no-op
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request
+func (h *enqueueRequestsForPodEvents) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	// no-op
+}
+
+// enqueueVirtualNodesForPod enqueues virtualNodes selecting pods that still wait for the virtualNodeReady condition.
+func (h *enqueueRequestsForPodEvents) enqueueVirtualNodesForPod(ctx context.Context, queue workqueue.RateLimitingInterface, pod *corev1.Pod) {
+	if !k8s.HasPodReadinessGate(pod, k8s.ConditionAppMeshVirtualNodeReady) {
+		return
+	}
+	if condition := k8s.GetPodCondition(pod, k8s.ConditionAppMeshVirtualNodeReady); condition != nil && condition.Status == corev1.ConditionTrue {
+		return
+	}
+	vnList := &appmesh.VirtualNodeList{}
+	if err := h.k8sClient.List(ctx, vnList, client.InNamespace(pod.Namespace)); err != nil {
+		h.log.Error(err, "failed to enqueue virtualNodes for pod events",
+			"pod", k8s.NamespacedName(pod))
+		return
+	}
+	for _, vn := range vnList.Items {
+		if vn.Spec.PodSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(vn.Spec.PodSelector)
+		if err != nil {
+			h.log.Error(err, "failed to parse podSelector",
+				"virtualNode", k8s.NamespacedName(&vn))
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			queue.Add(ctrl.Request{NamespacedName: k8s.NamespacedName(&vn)})
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package virtualnode
+
+import (
+	"context"
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+)
+
+func Test_enqueueRequestsForPodEvents_enqueueVirtualNodesForPod(t *testing.T) {
+	vnSelectingPod := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "vn-1",
+		},
+		Spec: appmesh.VirtualNodeSpec{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": "my-app",
+				},
+			},
+		},
+	}
+	vnNotSelectingPod := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "vn-2",
+		},
+		Spec: appmesh.VirtualNodeSpec{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": "other-app",
+				},
+			},
+		},
+	}
+	vnWithoutPodSelector := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "vn-3",
+		},
+	}
+	podWithReadinessGate := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "pod-1",
+			Labels: map[string]string{
+				"app": "my-app",
+			},
+		},
+		Spec: corev1.PodSpec{
+			ReadinessGates: []corev1.PodReadinessGate{
+				{
+					ConditionType: k8s.ConditionAppMeshVirtualNodeReady,
+				},
+			},
+		},
+	}
+	podAlreadyReady := podWithReadinessGate.DeepCopy()
+	podAlreadyReady.Status.Conditions = []corev1.PodCondition{
+		{
+			Type:   k8s.ConditionAppMeshVirtualNodeReady,
+			Status: corev1.ConditionTrue,
+		},
+	}
+	podWithoutReadinessGate := podWithReadinessGate.DeepCopy()
+	podWithoutReadinessGate.Spec.ReadinessGates = nil
+
+	type env struct {
+		virtualNodes []*appmesh.VirtualNode
+	}
+	type args struct {
+		pod *corev1.Pod
+	}
+	tests := []struct {
+		name         string
+		env          env
+		args         args
+		wantRequests []reconcile.Request
+	}{
+		{
+			name: "pod waiting for virtualNodeReady condition",
+			env: env{
+				virtualNodes: []*appmesh.VirtualNode{vnSelectingPod, vnNotSelectingPod, vnWithoutPodSelector},
+			},
+			args: args{
+				pod: podWithReadinessGate,
+			},
+			wantRequests: []reconcile.Request{
+				{
+					NamespacedName: k8s.NamespacedName(vnSelectingPod),
+				},
+			},
+		},
+		{
+			name: "pod already has virtualNodeReady condition",
+			env: env{
+				virtualNodes: []*appmesh.VirtualNode{vnSelectingPod},
+			},
+			args: args{
+				pod: podAlreadyReady,
+			},
+			wantRequests: nil,
+		},
+		{
+			name: "pod without readinessGate",
+			env: env{
+				virtualNodes: []*appmesh.VirtualNode{vnSelectingPod},
+			},
+			args: args{
+				pod: podWithoutReadinessGate,
+			},
+			wantRequests: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			h := &enqueueRequestsForPodEvents{
+				k8sClient: k8sClient,
+				log:       &log.NullLogger{},
+			}
+
+			for _, vn := range tt.env.virtualNodes {
+				err := k8sClient.Create(ctx, vn.DeepCopy())
+				assert.NoError(t, err)
+			}
+
+			h.enqueueVirtualNodesForPod(ctx, queue, tt.args.pod)
+			var gotRequests []reconcile.Request
+			queueLen := queue.Len()
+			for i := 0; i < queueLen; i++ {
+				item, _ := queue.Get()
+				gotRequests = append(gotRequests, item.(reconcile.Request))
+			}
+
+			opt := cmpopts.SortSlices(compareReconcileRequest)
+			assert.True(t, cmp.Equal(tt.wantRequests, gotRequests, opt), "diff: %v", cmp.Diff(tt.wantRequests, gotRequests, opt))
+		})
+	}
+}
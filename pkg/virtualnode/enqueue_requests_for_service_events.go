@@ -0,0 +1,68 @@
+package virtualnode
+
+import (
+	"context"
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+func NewEnqueueRequestsForServiceEvents(k8sClient client.Client, log logr.Logger) *enqueueRequestsForServiceEvents {
+	return &enqueueRequestsForServiceEvents{
+		k8sClient: k8sClient,
+		log:       log,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForServiceEvents)(nil)
+
+type enqueueRequestsForServiceEvents struct {
+	k8sClient client.Client
+	log       logr.Logger
+}
+
+// Create is called in response to an create event
+func (h *enqueueRequestsForServiceEvents) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	// virtualNodes whose service got deleted are reconciled again once it's back.
+	svc := e.Object.(*corev1.Service)
+	h.enqueueVirtualNodesForService(context.Background(), queue, k8s.NamespacedName(svc))
+}
+
+// Update is called in response to an update event
+func (h *enqueueRequestsForServiceEvents) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	// no-op
+}
+
+// Delete is called in response to a delete event
+func (h *enqueueRequestsForServiceEvents) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	svc := e.Object.(*corev1.Service)
+	h.enqueueVirtualNodesForService(context.Background(), queue, k8s.NamespacedName(svc))
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request
+func (h *enqueueRequestsForServiceEvents) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	// no-op
+}
+
+func (h *enqueueRequestsForServiceEvents) enqueueVirtualNodesForService(ctx context.Context, queue workqueue.RateLimitingInterface, svcKey types.NamespacedName) {
+	vnList := &appmesh.VirtualNodeList{}
+	if err := h.k8sClient.List(ctx, vnList); err != nil {
+		h.log.Error(err, "failed to enqueue virtualNodes for service events",
+			"service", svcKey)
+		return
+	}
+	for _, vn := range vnList.Items {
+		if vnSvcKey, ok := ExtractDNSServiceKey(&vn); !ok || vnSvcKey != svcKey {
+			continue
+		}
+		queue.Add(ctrl.Request{NamespacedName: k8s.NamespacedName(&vn)})
+	}
+}
@@ -0,0 +1,116 @@
+package virtualnode
+
+import (
+	"context"
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+)
+
+func Test_enqueueRequestsForServiceEvents_enqueueVirtualNodesForService(t *testing.T) {
+	vnWithoutServiceDiscovery := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "vn-without-service-discovery",
+		},
+		Spec: appmesh.VirtualNodeSpec{},
+	}
+	vnWithNonMatchingHostname := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "vn-with-non-matching-hostname",
+		},
+		Spec: appmesh.VirtualNodeSpec{
+			ServiceDiscovery: &appmesh.ServiceDiscovery{
+				DNS: &appmesh.DNSServiceDiscovery{
+					Hostname: "other-svc.my-ns.svc.cluster.local",
+				},
+			},
+		},
+	}
+	vnWithMatchingHostname := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "other-ns",
+			Name:      "vn-with-matching-hostname",
+		},
+		Spec: appmesh.VirtualNodeSpec{
+			ServiceDiscovery: &appmesh.ServiceDiscovery{
+				DNS: &appmesh.DNSServiceDiscovery{
+					Hostname: "my-svc.my-ns.svc.cluster.local",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		virtualNodes []*appmesh.VirtualNode
+		svcKey       types.NamespacedName
+		wantRequests []reconcile.Request
+	}{
+		{
+			name: "only vn with matching DNS hostname should be enqueued",
+			virtualNodes: []*appmesh.VirtualNode{
+				vnWithoutServiceDiscovery,
+				vnWithNonMatchingHostname,
+				vnWithMatchingHostname,
+			},
+			svcKey: types.NamespacedName{Namespace: "my-ns", Name: "my-svc"},
+			wantRequests: []reconcile.Request{
+				{
+					NamespacedName: k8s.NamespacedName(vnWithMatchingHostname),
+				},
+			},
+		},
+		{
+			name: "no vn with matching DNS hostname",
+			virtualNodes: []*appmesh.VirtualNode{
+				vnWithoutServiceDiscovery,
+				vnWithNonMatchingHostname,
+			},
+			svcKey:       types.NamespacedName{Namespace: "my-ns", Name: "my-svc"},
+			wantRequests: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			h := &enqueueRequestsForServiceEvents{
+				k8sClient: k8sClient,
+				log:       &log.NullLogger{},
+			}
+
+			for _, vn := range tt.virtualNodes {
+				err := k8sClient.Create(ctx, vn.DeepCopy())
+				assert.NoError(t, err)
+			}
+
+			h.enqueueVirtualNodesForService(ctx, queue, tt.svcKey)
+			var gotRequests []reconcile.Request
+			queueLen := queue.Len()
+			for i := 0; i < queueLen; i++ {
+				item, _ := queue.Get()
+				gotRequests = append(gotRequests, item.(reconcile.Request))
+			}
+
+			opt := cmpopts.SortSlices(compareReconcileRequest)
+			assert.True(t, cmp.Equal(tt.wantRequests, gotRequests, opt), "diff: %v", cmp.Diff(tt.wantRequests, gotRequests, opt))
+		})
+	}
+}
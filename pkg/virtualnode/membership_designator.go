@@ -5,6 +5,7 @@ import (
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,8 +21,12 @@ type MembershipDesignator interface {
 }
 
 // NewMembershipDesignator creates new MembershipDesignator.
-func NewMembershipDesignator(k8sClient client.Client) MembershipDesignator {
-	return &membershipDesignator{k8sClient: k8sClient}
+func NewMembershipDesignator(k8sClient client.Client, cfg Config, log logr.Logger) MembershipDesignator {
+	return &membershipDesignator{
+		k8sClient:          k8sClient,
+		podSelectionPolicy: cfg.PodSelectionPolicy,
+		log:                log,
+	}
 }
 
 var _ MembershipDesignator = &membershipDesignator{}
@@ -29,6 +34,9 @@ var _ MembershipDesignator = &membershipDesignator{}
 // meshSelectorDesignator designates VirtualNode membership based on selectors on VirtualNode.
 type membershipDesignator struct {
 	k8sClient client.Client
+	// how to designate pods matched by multiple VirtualNodes.
+	podSelectionPolicy string
+	log                logr.Logger
 }
 
 // +kubebuilder:rbac:groups=appmesh.k8s.aws,resources=virtualnodes,verbs=get;list;watch
@@ -56,12 +64,57 @@ func (d *membershipDesignator) Designate(ctx context.Context, pod *corev1.Pod) (
 		return nil, nil
 	}
 	if len(vnCandidates) > 1 {
-		var vnCandidatesNames []string
-		for _, vn := range vnCandidates {
-			vnCandidatesNames = append(vnCandidatesNames, k8s.NamespacedName(vn).String())
-		}
+		return d.designateAmongMultipleCandidates(pod, vnCandidates)
+	}
+	return vnCandidates[0], nil
+}
+
+// designateAmongMultipleCandidates designates pod matched by the podSelector of multiple VirtualNodes based on the pod selection policy.
+// With the most-specific policy, the VirtualNode whose podSelector has the most requirements is chosen,
+// pod is still denied if multiple VirtualNodes are equally specific since the choice wouldn't be deterministic.
+func (d *membershipDesignator) designateAmongMultipleCandidates(pod *corev1.Pod, vnCandidates []*appmesh.VirtualNode) (*appmesh.VirtualNode, error) {
+	var vnCandidatesNames []string
+	for _, vn := range vnCandidates {
+		vnCandidatesNames = append(vnCandidatesNames, k8s.NamespacedName(vn).String())
+	}
+	if d.podSelectionPolicy != PodSelectionPolicyMostSpecific {
+		d.log.Info("denied pod matching multiple VirtualNodes",
+			"pod", k8s.NamespacedName(pod), "virtualNodes", vnCandidatesNames)
 		return nil, errors.Errorf("found multiple matching VirtualNodes for pod %s: %s",
 			k8s.NamespacedName(pod).String(), strings.Join(vnCandidatesNames, ","))
 	}
-	return vnCandidates[0], nil
+
+	var mostSpecificVNs []*appmesh.VirtualNode
+	mostSpecificity := -1
+	for _, vn := range vnCandidates {
+		specificity := PodSelectorSpecificity(vn.Spec.PodSelector)
+		if specificity > mostSpecificity {
+			mostSpecificVNs = []*appmesh.VirtualNode{vn}
+			mostSpecificity = specificity
+		} else if specificity == mostSpecificity {
+			mostSpecificVNs = append(mostSpecificVNs, vn)
+		}
+	}
+	if len(mostSpecificVNs) > 1 {
+		var mostSpecificVNNames []string
+		for _, vn := range mostSpecificVNs {
+			mostSpecificVNNames = append(mostSpecificVNNames, k8s.NamespacedName(vn).String())
+		}
+		d.log.Info("denied pod matching multiple equally specific VirtualNodes",
+			"pod", k8s.NamespacedName(pod), "virtualNodes", mostSpecificVNNames)
+		return nil, errors.Errorf("found multiple equally specific matching VirtualNodes for pod %s: %s",
+			k8s.NamespacedName(pod).String(), strings.Join(mostSpecificVNNames, ","))
+	}
+	d.log.Info("designated pod matching multiple VirtualNodes to the most specific one",
+		"pod", k8s.NamespacedName(pod), "virtualNode", k8s.NamespacedName(mostSpecificVNs[0]), "virtualNodes", vnCandidatesNames)
+	return mostSpecificVNs[0], nil
+}
+
+// PodSelectorSpecificity returns the number of requirements of a podSelector,
+// pods matched by multiple VirtualNodes belong to the most specific one with the most-specific pod selection policy.
+func PodSelectorSpecificity(selector *metav1.LabelSelector) int {
+	if selector == nil {
+		return 0
+	}
+	return len(selector.MatchLabels) + len(selector.MatchExpressions)
 }
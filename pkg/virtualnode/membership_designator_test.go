@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"testing"
 )
@@ -407,7 +408,7 @@ func Test_membershipDesignator_Designate(t *testing.T) {
 			clientgoscheme.AddToScheme(k8sSchema)
 			appmesh.AddToScheme(k8sSchema)
 			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
-			designator := NewMembershipDesignator(k8sClient)
+			designator := NewMembershipDesignator(k8sClient, Config{}, &log.NullLogger{})
 
 			for _, ns := range tt.env.namespaces {
 				err := k8sClient.Create(ctx, ns.DeepCopy())
@@ -432,3 +433,136 @@ func Test_membershipDesignator_Designate(t *testing.T) {
 		})
 	}
 }
+
+func Test_membershipDesignator_Designate_podSelectionPolicy(t *testing.T) {
+	vnWithPodSelectorApp := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "vn-app",
+		},
+		Spec: appmesh.VirtualNodeSpec{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": "my-app",
+				},
+			},
+		},
+	}
+	vnWithPodSelectorAppV2 := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "vn-app-v2",
+		},
+		Spec: appmesh.VirtualNodeSpec{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": "my-app",
+				},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "version",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"v2"},
+					},
+				},
+			},
+		},
+	}
+	vnWithPodSelectorTier := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "vn-tier",
+		},
+		Spec: appmesh.VirtualNodeSpec{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"tier": "backend",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name               string
+		podSelectionPolicy string
+		virtualNodes       []*appmesh.VirtualNode
+		podLabels          map[string]string
+		want               *appmesh.VirtualNode
+		wantErr            error
+	}{
+		{
+			name:               "single match",
+			podSelectionPolicy: PodSelectionPolicyDeny,
+			virtualNodes:       []*appmesh.VirtualNode{vnWithPodSelectorApp, vnWithPodSelectorTier},
+			podLabels:          map[string]string{"app": "my-app"},
+			want:               vnWithPodSelectorApp,
+		},
+		{
+			name:               "no match",
+			podSelectionPolicy: PodSelectionPolicyMostSpecific,
+			virtualNodes:       []*appmesh.VirtualNode{vnWithPodSelectorApp, vnWithPodSelectorTier},
+			podLabels:          map[string]string{"app": "other-app"},
+			want:               nil,
+		},
+		{
+			name:               "ambiguous match with deny policy",
+			podSelectionPolicy: PodSelectionPolicyDeny,
+			virtualNodes:       []*appmesh.VirtualNode{vnWithPodSelectorApp, vnWithPodSelectorAppV2},
+			podLabels:          map[string]string{"app": "my-app", "version": "v2"},
+			wantErr:            errors.New("found multiple matching VirtualNodes for pod awesome-ns/my-pod: awesome-ns/vn-app,awesome-ns/vn-app-v2"),
+		},
+		{
+			name:               "ambiguous match with default policy",
+			podSelectionPolicy: "",
+			virtualNodes:       []*appmesh.VirtualNode{vnWithPodSelectorApp, vnWithPodSelectorAppV2},
+			podLabels:          map[string]string{"app": "my-app", "version": "v2"},
+			wantErr:            errors.New("found multiple matching VirtualNodes for pod awesome-ns/my-pod: awesome-ns/vn-app,awesome-ns/vn-app-v2"),
+		},
+		{
+			name:               "ambiguous match with most-specific policy",
+			podSelectionPolicy: PodSelectionPolicyMostSpecific,
+			virtualNodes:       []*appmesh.VirtualNode{vnWithPodSelectorApp, vnWithPodSelectorAppV2},
+			podLabels:          map[string]string{"app": "my-app", "version": "v2"},
+			want:               vnWithPodSelectorAppV2,
+		},
+		{
+			name:               "ambiguous match with most-specific policy but equally specific virtualNodes",
+			podSelectionPolicy: PodSelectionPolicyMostSpecific,
+			virtualNodes:       []*appmesh.VirtualNode{vnWithPodSelectorApp, vnWithPodSelectorTier},
+			podLabels:          map[string]string{"app": "my-app", "tier": "backend"},
+			wantErr:            errors.New("found multiple equally specific matching VirtualNodes for pod awesome-ns/my-pod: awesome-ns/vn-app,awesome-ns/vn-tier"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			designator := NewMembershipDesignator(k8sClient, Config{PodSelectionPolicy: tt.podSelectionPolicy}, &log.NullLogger{})
+			for _, vn := range tt.virtualNodes {
+				err := k8sClient.Create(ctx, vn.DeepCopy())
+				assert.NoError(t, err)
+			}
+			ctx = webhook.ContextWithAdmissionRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{Namespace: "awesome-ns"},
+			})
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-pod",
+					Labels:    tt.podLabels,
+				},
+			}
+
+			got, err := designator.Designate(ctx, pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				opts := equality.IgnoreFakeClientPopulatedFields()
+				assert.True(t, cmp.Equal(tt.want, got, opts), "diff", cmp.Diff(tt.want, got, opts))
+			}
+		})
+	}
+}
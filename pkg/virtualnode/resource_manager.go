@@ -2,6 +2,7 @@ package virtualnode
 
 import (
 	"context"
+	"fmt"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/conversions"
@@ -10,6 +11,7 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/tagging"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
@@ -17,9 +19,27 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/conversion"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
+)
+
+const (
+	reasonServiceDeleted = "ServiceDeleted"
+	reasonNameCollision  = "NameCollision"
+	reasonAdopted        = "Adopted"
+	reasonNotAdopted     = "NotAdopted"
+
+	// externallyDeletedRequeueDelay is how long VirtualNodes whose AppMesh VirtualNode is deleted externally wait before being reconciled again.
+	externallyDeletedRequeueDelay = 10 * time.Minute
+
+	// ownerTagKey tags AppMesh VirtualNodes with the VirtualNode claiming them,
+	// so VirtualNodes mapped to the same AppMesh name are detected instead of overwriting each other.
+	ownerTagKey = "appmesh.k8s.aws/virtualNode"
 )
 
 // ResourceManager is dedicated to manage AppMesh VirtualNode resources for k8s VirtualNode CRs.
@@ -36,6 +56,9 @@ func NewDefaultResourceManager(
 	appMeshSDK services.AppMesh,
 	referencesResolver references.Resolver,
 	accountID string,
+	cfg Config,
+	eventRecorder record.EventRecorder,
+	tagsManager tagging.Manager,
 	log logr.Logger) ResourceManager {
 
 	return &defaultResourceManager{
@@ -43,6 +66,9 @@ func NewDefaultResourceManager(
 		appMeshSDK:         appMeshSDK,
 		referencesResolver: referencesResolver,
 		accountID:          accountID,
+		cfg:                cfg,
+		eventRecorder:      eventRecorder,
+		tagsManager:        tagsManager,
 		log:                log,
 	}
 }
@@ -53,15 +79,24 @@ type defaultResourceManager struct {
 	appMeshSDK         services.AppMesh
 	referencesResolver references.Resolver
 	accountID          string
+	cfg                Config
+	eventRecorder      record.EventRecorder
+	tagsManager        tagging.Manager
 	log                logr.Logger
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, vn *appmesh.VirtualNode) error {
+	if k8s.IsReconciliationPaused(vn) {
+		return m.updateCRDVirtualNodeReconciliationPaused(ctx, vn)
+	}
 	ms, err := m.findMeshDependency(ctx, vn)
 	if err != nil {
 		return err
 	}
-	if err := m.validateMeshDependencies(ctx, ms); err != nil {
+	if err := m.validateMeshDependencies(ctx, ms, vn); err != nil {
+		return err
+	}
+	if err := validateCloudMapNamespaceDependency(vn); err != nil {
 		return err
 	}
 	vsByKey, err := m.findVirtualServiceDependencies(ctx, vn)
@@ -71,24 +106,65 @@ func (m *defaultResourceManager) Reconcile(ctx context.Context, vn *appmesh.Virt
 	if err := m.validateVirtualServiceDependencies(ctx, ms, vsByKey); err != nil {
 		return err
 	}
+	if m.cfg.IsServiceDeletionCheckEnabled() {
+		svcKey, serviceDeleted, err := m.findDeletedDNSService(ctx, vn)
+		if err != nil {
+			return err
+		}
+		if serviceDeleted {
+			if m.cfg.ServiceDeletionPolicy == ServiceDeletionPolicyDelete {
+				return m.cleanupSDKVirtualNodeForDeletedService(ctx, ms, vn, svcKey)
+			}
+			m.eventRecorder.Eventf(vn, corev1.EventTypeWarning, reasonServiceDeleted,
+				"service %v behind DNS hostname is deleted, retaining AppMesh VirtualNode", svcKey)
+		}
+	}
 
 	sdkVN, err := m.findSDKVirtualNode(ctx, ms, vn)
 	if err != nil {
 		return err
 	}
+	if isSDKVirtualNodeDeleted(sdkVN) {
+		if m.cfg.ExternallyDeletedPolicy == ExternallyDeletedPolicySkip {
+			return m.handleExternallyDeletedSDKVirtualNode(ctx, sdkVN, vn)
+		}
+		m.sdkVirtualNodeLogger(sdkVN, vn).Info("recreating virtualNode since it's deleted externally")
+		sdkVN = nil
+	}
 	if sdkVN == nil {
 		sdkVN, err = m.createSDKVirtualNode(ctx, ms, vn, vsByKey)
 		if err != nil {
 			return err
 		}
 	} else {
+		var sdkVNTags map[string]string
+		if m.isSDKVirtualNodeControlledByCRDVirtualNode(ctx, sdkVN, vn) {
+			// tags are listed once, both to claim the AppMesh virtualNode and to correct drift of its propagated tags.
+			sdkVNTags, err = m.listSDKVirtualNodeTags(ctx, sdkVN)
+			if err != nil {
+				return err
+			}
+		}
+		collidedOwner, err := m.claimSDKVirtualNode(ctx, sdkVN, vn, sdkVNTags)
+		if err != nil {
+			return err
+		}
+		if collidedOwner != "" {
+			return m.updateCRDVirtualNodeNameCollision(ctx, vn, sdkVN, collidedOwner)
+		}
 		sdkVN, err = m.updateSDKVirtualNode(ctx, sdkVN, ms, vn, vsByKey)
 		if err != nil {
 			return err
 		}
+		if err := m.updateSDKVirtualNodeTags(ctx, sdkVN, vn, sdkVNTags); err != nil {
+			return err
+		}
 	}
 
-	return m.updateCRDVirtualNode(ctx, vn, sdkVN)
+	if err := m.updateCRDVirtualNode(ctx, vn, sdkVN); err != nil {
+		return err
+	}
+	return m.updatePodsVirtualNodeReadyCondition(ctx, vn)
 }
 
 func (m *defaultResourceManager) Cleanup(ctx context.Context, vn *appmesh.VirtualNode) error {
@@ -106,6 +182,10 @@ func (m *defaultResourceManager) Cleanup(ctx context.Context, vn *appmesh.Virtua
 	if sdkVN == nil {
 		return nil
 	}
+	if k8s.IsDeletionPolicyOrphan(vn) {
+		m.sdkVirtualNodeLogger(sdkVN, vn).V(1).Info("skip virtualNode deletion since its deletionPolicy is orphan")
+		return nil
+	}
 
 	return m.deleteSDKVirtualNode(ctx, sdkVN, ms, vn)
 }
@@ -123,13 +203,30 @@ func (m *defaultResourceManager) findMeshDependency(ctx context.Context, vn *app
 }
 
 // validateMeshDependencies validate the Mesh dependency for this virtualNode.
-func (m *defaultResourceManager) validateMeshDependencies(ctx context.Context, ms *appmesh.Mesh) error {
+// virtualNode is marked as WaitingForMesh and requeued with backoff until the mesh becomes active.
+func (m *defaultResourceManager) validateMeshDependencies(ctx context.Context, ms *appmesh.Mesh, vn *appmesh.VirtualNode) error {
 	if !mesh.IsMeshActive(ms) {
+		if err := m.updateCRDVirtualNodeWaitingForMesh(ctx, vn, ms); err != nil {
+			return err
+		}
 		return runtime.NewRequeueError(errors.New("mesh is not active yet"))
 	}
 	return nil
 }
 
+// validateCloudMapNamespaceDependency validates the CloudMap namespace dependency for this virtualNode.
+// AppMesh VirtualNodes refer to CloudMap namespaces by name, so virtualNode referencing its namespace by ID is requeued
+// until the CloudMap reconciler has resolved the namespace name.
+func validateCloudMapNamespaceDependency(vn *appmesh.VirtualNode) error {
+	if vn.Spec.ServiceDiscovery == nil || vn.Spec.ServiceDiscovery.AWSCloudMap == nil || vn.Spec.ServiceDiscovery.AWSCloudMap.NamespaceName != "" {
+		return nil
+	}
+	if aws.StringValue(vn.Status.CloudMapNamespaceName) == "" {
+		return runtime.NewRequeueError(errors.Errorf("cloudMap namespace %v isn't resolved yet", vn.Spec.ServiceDiscovery.AWSCloudMap.NamespaceID))
+	}
+	return nil
+}
+
 // findVirtualServiceDependencies find the VirtualService dependencies for this virtualNode.
 func (m *defaultResourceManager) findVirtualServiceDependencies(ctx context.Context, vn *appmesh.VirtualNode) (map[types.NamespacedName]*appmesh.VirtualService, error) {
 	vsByKey := make(map[types.NamespacedName]*appmesh.VirtualService, len(vn.Spec.Backends))
@@ -160,6 +257,50 @@ func (m *defaultResourceManager) validateVirtualServiceDependencies(ctx context.
 	return nil
 }
 
+// findDeletedDNSService checks whether the k8s Service behind vn's DNS hostname is deleted.
+// only a NotFound response counts as deleted, other errors like transient API read failures are returned so reconcile is retried.
+func (m *defaultResourceManager) findDeletedDNSService(ctx context.Context, vn *appmesh.VirtualNode) (types.NamespacedName, bool, error) {
+	svcKey, ok := ExtractDNSServiceKey(vn)
+	if !ok {
+		return svcKey, false, nil
+	}
+	svc := &corev1.Service{}
+	if err := m.k8sClient.Get(ctx, svcKey, svc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return svcKey, true, nil
+		}
+		return svcKey, false, errors.Wrapf(err, "failed to get service %v", svcKey)
+	}
+	return svcKey, !svc.DeletionTimestamp.IsZero(), nil
+}
+
+// cleanupSDKVirtualNodeForDeletedService deletes AppMesh VirtualNode for vn whose k8s Service is deleted, and marks vn as inactive.
+// the AppMesh VirtualNode is created again once the k8s Service comes back.
+func (m *defaultResourceManager) cleanupSDKVirtualNodeForDeletedService(ctx context.Context, ms *appmesh.Mesh, vn *appmesh.VirtualNode, svcKey types.NamespacedName) error {
+	sdkVN, err := m.findSDKVirtualNode(ctx, ms, vn)
+	if err != nil {
+		return err
+	}
+	if sdkVN != nil {
+		if k8s.IsDeletionPolicyOrphan(vn) {
+			m.sdkVirtualNodeLogger(sdkVN, vn).V(1).Info("skip virtualNode deletion since its deletionPolicy is orphan")
+		} else {
+			if err := m.deleteSDKVirtualNode(ctx, sdkVN, ms, vn); err != nil {
+				return err
+			}
+			m.eventRecorder.Eventf(vn, corev1.EventTypeWarning, reasonServiceDeleted,
+				"service %v behind DNS hostname is deleted, deleted AppMesh VirtualNode", svcKey)
+		}
+	}
+
+	oldVN := vn.DeepCopy()
+	message := fmt.Sprintf("service %v behind DNS hostname is deleted", svcKey)
+	if !UpdateCondition(vn, appmesh.VirtualNodeActive, corev1.ConditionFalse, aws.String(reasonServiceDeleted), aws.String(message)) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, vn, client.MergeFrom(oldVN))
+}
+
 func (m *defaultResourceManager) findSDKVirtualNode(ctx context.Context, ms *appmesh.Mesh, vn *appmesh.VirtualNode) (*appmeshsdk.VirtualNodeData, error) {
 	resp, err := m.appMeshSDK.DescribeVirtualNodeWithContext(ctx, &appmeshsdk.DescribeVirtualNodeInput{
 		MeshName:        ms.Spec.AWSName,
@@ -176,8 +317,13 @@ func (m *defaultResourceManager) findSDKVirtualNode(ctx context.Context, ms *app
 	return resp.VirtualNode, nil
 }
 
+// isSDKVirtualNodeDeleted checks whether sdkVN is described with DELETED status, i.e. it's been deleted outside of the controller.
+func isSDKVirtualNodeDeleted(sdkVN *appmeshsdk.VirtualNodeData) bool {
+	return sdkVN != nil && sdkVN.Status != nil && aws.StringValue(sdkVN.Status.Status) == appmeshsdk.VirtualNodeStatusCodeDeleted
+}
+
 func (m *defaultResourceManager) createSDKVirtualNode(ctx context.Context, ms *appmesh.Mesh, vn *appmesh.VirtualNode, vsByKey map[types.NamespacedName]*appmesh.VirtualService) (*appmeshsdk.VirtualNodeData, error) {
-	sdkVNSpec, err := BuildSDKVirtualNodeSpec(vn, vsByKey)
+	sdkVNSpec, err := BuildSDKVirtualNodeSpec(applyCloudMapNamespaceName(applyMeshBackendDefaults(ms, vn)), vsByKey)
 	if err != nil {
 		return nil, err
 	}
@@ -186,6 +332,7 @@ func (m *defaultResourceManager) createSDKVirtualNode(ctx context.Context, ms *a
 		MeshOwner:       ms.Spec.MeshOwner,
 		Spec:            sdkVNSpec,
 		VirtualNodeName: vn.Spec.AWSName,
+		Tags:            append(m.buildSDKVirtualNodeTags(ctx, vn), buildSDKVirtualNodeOwnerTag(vn)),
 	})
 	if err != nil {
 		return nil, err
@@ -195,7 +342,7 @@ func (m *defaultResourceManager) createSDKVirtualNode(ctx context.Context, ms *a
 
 func (m *defaultResourceManager) updateSDKVirtualNode(ctx context.Context, sdkVN *appmeshsdk.VirtualNodeData, ms *appmesh.Mesh, vn *appmesh.VirtualNode, vsByKey map[types.NamespacedName]*appmesh.VirtualService) (*appmeshsdk.VirtualNodeData, error) {
 	actualSDKVNSpec := sdkVN.Spec
-	desiredSDKVNSpec, err := BuildSDKVirtualNodeSpec(vn, vsByKey)
+	desiredSDKVNSpec, err := BuildSDKVirtualNodeSpec(applyCloudMapNamespaceName(applyMeshBackendDefaults(ms, vn)), vsByKey)
 	if err != nil {
 		return nil, err
 	}
@@ -205,16 +352,12 @@ func (m *defaultResourceManager) updateSDKVirtualNode(ctx context.Context, sdkVN
 		return sdkVN, nil
 	}
 	if !m.isSDKVirtualNodeControlledByCRDVirtualNode(ctx, sdkVN, vn) {
-		m.log.V(1).Info("skip virtualNode update since it's not controlled",
-			"virtualNode", k8s.NamespacedName(vn),
-			"virtualNodeARN", aws.StringValue(sdkVN.Metadata.Arn),
-		)
+		m.sdkVirtualNodeLogger(sdkVN, vn).V(1).Info("skip virtualNode update since it's not controlled")
 		return sdkVN, nil
 	}
 
 	diff := cmp.Diff(desiredSDKVNSpec, actualSDKVNSpec, opts)
-	m.log.V(1).Info("virtualNodeSpec changed",
-		"virtualNode", k8s.NamespacedName(vn),
+	m.sdkVirtualNodeLogger(sdkVN, vn).V(1).Info("virtualNodeSpec changed",
 		"actualSDKVNSpec", actualSDKVNSpec,
 		"desiredSDKVNSpec", desiredSDKVNSpec,
 		"diff", diff,
@@ -233,22 +376,29 @@ func (m *defaultResourceManager) updateSDKVirtualNode(ctx context.Context, sdkVN
 
 func (m *defaultResourceManager) deleteSDKVirtualNode(ctx context.Context, sdkVN *appmeshsdk.VirtualNodeData, ms *appmesh.Mesh, vn *appmesh.VirtualNode) error {
 	if !m.isSDKVirtualNodeOwnedByCRDVirtualNode(ctx, sdkVN, vn) {
-		m.log.V(1).Info("skip mesh virtualNode since its not owned",
-			"virtualNode", k8s.NamespacedName(vn),
-			"virtualNodeARN", aws.StringValue(sdkVN.Metadata.Arn),
-		)
+		m.sdkVirtualNodeLogger(sdkVN, vn).V(1).Info("skip mesh virtualNode since its not owned")
+		return nil
+	}
+	sdkVNTags, err := m.listSDKVirtualNodeTags(ctx, sdkVN)
+	if err != nil {
+		return err
+	}
+	owner := sdkVNTags[ownerTagKey]
+	if owner != "" && owner != k8s.NamespacedName(vn).String() {
+		m.sdkVirtualNodeLogger(sdkVN, vn).Info("skip virtualNode deletion since it's claimed by another virtualNode", "owner", owner)
+		return nil
+	}
+	if owner == "" && !isSDKVirtualNodeReconciledByCRDVirtualNode(sdkVN, vn) {
+		m.sdkVirtualNodeLogger(sdkVN, vn).Info("skip virtualNode deletion since it's never been adopted")
 		return nil
 	}
 
-	_, err := m.appMeshSDK.DeleteVirtualNodeWithContext(ctx, &appmeshsdk.DeleteVirtualNodeInput{
+	_, err = m.appMeshSDK.DeleteVirtualNodeWithContext(ctx, &appmeshsdk.DeleteVirtualNodeInput{
 		MeshName:        ms.Spec.AWSName,
 		MeshOwner:       ms.Spec.MeshOwner,
 		VirtualNodeName: sdkVN.VirtualNodeName,
 	})
-	if err != nil {
-		return err
-	}
-	return nil
+	return services.IgnoreAppMeshNotFound(err)
 }
 
 func (m *defaultResourceManager) updateCRDVirtualNode(ctx context.Context, vn *appmesh.VirtualNode, sdkVN *appmeshsdk.VirtualNodeData) error {
@@ -267,7 +417,19 @@ func (m *defaultResourceManager) updateCRDVirtualNode(ctx context.Context, vn *a
 	if sdkVN.Status != nil && aws.StringValue(sdkVN.Status.Status) == appmeshsdk.VirtualNodeStatusCodeActive {
 		vnActiveConditionStatus = corev1.ConditionTrue
 	}
-	if updateCondition(vn, appmesh.VirtualNodeActive, vnActiveConditionStatus, nil, nil) {
+	if UpdateCondition(vn, appmesh.VirtualNodeActive, vnActiveConditionStatus, nil, nil) {
+		needsUpdate = true
+	}
+	if GetCondition(vn, appmesh.VirtualNodeReconciliationPaused) != nil &&
+		UpdateCondition(vn, appmesh.VirtualNodeReconciliationPaused, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
+	if GetCondition(vn, appmesh.VirtualNodeWaitingForMesh) != nil &&
+		UpdateCondition(vn, appmesh.VirtualNodeWaitingForMesh, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
+	if GetCondition(vn, appmesh.VirtualNodeExternallyDeleted) != nil &&
+		UpdateCondition(vn, appmesh.VirtualNodeExternallyDeleted, corev1.ConditionFalse, nil, nil) {
 		needsUpdate = true
 	}
 
@@ -277,11 +439,194 @@ func (m *defaultResourceManager) updateCRDVirtualNode(ctx context.Context, vn *a
 	return m.k8sClient.Status().Patch(ctx, vn, client.MergeFrom(oldVN))
 }
 
-func (m *defaultResourceManager) buildSDKVirtualNodeTags(ctx context.Context, vn *appmesh.VirtualNode) []*appmeshsdk.TagRef {
-	// TODO, support tags
+// updateCRDVirtualNodeReconciliationPaused records the ReconciliationPaused condition without touching the AppMesh VirtualNode.
+func (m *defaultResourceManager) updateCRDVirtualNodeReconciliationPaused(ctx context.Context, vn *appmesh.VirtualNode) error {
+	oldVN := vn.DeepCopy()
+	if !UpdateCondition(vn, appmesh.VirtualNodeReconciliationPaused, corev1.ConditionTrue, nil, nil) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, vn, client.MergeFrom(oldVN))
+}
+
+// updateCRDVirtualNodeWaitingForMesh records the WaitingForMesh condition while the mesh of vn isn't active.
+func (m *defaultResourceManager) updateCRDVirtualNodeWaitingForMesh(ctx context.Context, vn *appmesh.VirtualNode, ms *appmesh.Mesh) error {
+	oldVN := vn.DeepCopy()
+	message := fmt.Sprintf("mesh %s is not active yet", ms.Name)
+	if !UpdateCondition(vn, appmesh.VirtualNodeWaitingForMesh, corev1.ConditionTrue, nil, aws.String(message)) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, vn, client.MergeFrom(oldVN))
+}
+
+// updateCRDVirtualNodeNameCollision records vn as inactive when its AppMesh VirtualNode is claimed by another virtualNode,
+// neither the ARN nor the status of the AppMesh VirtualNode belong to vn, so pods selected by vn won't pass the readinessGate.
+func (m *defaultResourceManager) updateCRDVirtualNodeNameCollision(ctx context.Context, vn *appmesh.VirtualNode, sdkVN *appmeshsdk.VirtualNodeData, owner string) error {
+	oldVN := vn.DeepCopy()
+	needsUpdate := false
+	if vn.Status.VirtualNodeARN != nil && aws.StringValue(vn.Status.VirtualNodeARN) == aws.StringValue(sdkVN.Metadata.Arn) {
+		vn.Status.VirtualNodeARN = nil
+		needsUpdate = true
+	}
+	if aws.Int64Value(vn.Status.ObservedGeneration) != vn.Generation {
+		vn.Status.ObservedGeneration = aws.Int64(vn.Generation)
+		needsUpdate = true
+	}
+	message := fmt.Sprintf("AppMesh virtualNode %s is already claimed by virtualNode %s", aws.StringValue(sdkVN.VirtualNodeName), owner)
+	if UpdateCondition(vn, appmesh.VirtualNodeActive, corev1.ConditionFalse, aws.String(reasonNameCollision), aws.String(message)) {
+		needsUpdate = true
+	}
+	if !needsUpdate {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, vn, client.MergeFrom(oldVN))
+}
+
+// handleExternallyDeletedSDKVirtualNode records the ExternallyDeleted condition for vn whose AppMesh VirtualNode got deleted outside of the controller,
+// and requeues vn slowly without recreating it, so the deletion isn't reverted while it's investigated.
+func (m *defaultResourceManager) handleExternallyDeletedSDKVirtualNode(ctx context.Context, sdkVN *appmeshsdk.VirtualNodeData, vn *appmesh.VirtualNode) error {
+	m.sdkVirtualNodeLogger(sdkVN, vn).Info("skip virtualNode reconciliation since it's deleted externally")
+	oldVN := vn.DeepCopy()
+	message := fmt.Sprintf("AppMesh virtualNode %s is deleted externally, it won't be recreated", aws.StringValue(sdkVN.VirtualNodeName))
+	needsUpdate := false
+	if UpdateCondition(vn, appmesh.VirtualNodeExternallyDeleted, corev1.ConditionTrue, nil, aws.String(message)) {
+		needsUpdate = true
+	}
+	if UpdateCondition(vn, appmesh.VirtualNodeActive, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
+	if needsUpdate {
+		if err := m.k8sClient.Status().Patch(ctx, vn, client.MergeFrom(oldVN)); err != nil {
+			return err
+		}
+	}
+	return runtime.NewRequeueAfterError(errors.New(message), externallyDeletedRequeueDelay)
+}
+
+// updatePodsVirtualNodeReadyCondition marks the virtualNodeReady condition as true for pods selected by vn once vn is active.
+// pods of a virtualNode that never becomes active are left untouched, so they won't pass the readinessGate.
+func (m *defaultResourceManager) updatePodsVirtualNodeReadyCondition(ctx context.Context, vn *appmesh.VirtualNode) error {
+	if !IsVirtualNodeActive(vn) || vn.Spec.PodSelector == nil {
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(vn.Spec.PodSelector)
+	if err != nil {
+		return err
+	}
+	podList := &corev1.PodList{}
+	if err := m.k8sClient.List(ctx, podList, client.InNamespace(vn.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return errors.Wrap(err, "failed to list pods for virtualNode")
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !k8s.HasPodReadinessGate(pod, k8s.ConditionAppMeshVirtualNodeReady) {
+			continue
+		}
+		if condition := k8s.GetPodCondition(pod, k8s.ConditionAppMeshVirtualNodeReady); condition != nil && condition.Status == corev1.ConditionTrue {
+			continue
+		}
+		oldPod := pod.DeepCopy()
+		k8s.UpdatePodCondition(pod, k8s.ConditionAppMeshVirtualNodeReady, corev1.ConditionTrue, nil, nil)
+		if err := m.k8sClient.Status().Patch(ctx, pod, client.MergeFrom(oldPod)); err != nil {
+			return errors.Wrapf(err, "failed to update virtualNodeReady condition for pod %v", k8s.NamespacedName(pod))
+		}
+	}
 	return nil
 }
 
+func (m *defaultResourceManager) buildSDKVirtualNodeTags(ctx context.Context, vn *appmesh.VirtualNode) []*appmeshsdk.TagRef {
+	return m.tagsManager.BuildSDKTags(vn)
+}
+
+// sdkVirtualNodeLogger returns a logger carrying the identity of vn and its AppMesh VirtualNode.
+func (m *defaultResourceManager) sdkVirtualNodeLogger(sdkVN *appmeshsdk.VirtualNodeData, vn *appmesh.VirtualNode) logr.Logger {
+	return m.log.WithValues(
+		"meshName", aws.StringValue(sdkVN.MeshName),
+		"virtualNode", k8s.NamespacedName(vn),
+		"arn", aws.StringValue(sdkVN.Metadata.Arn),
+	)
+}
+
+// updateSDKVirtualNodeTags corrects drift of the tags propagated from labels of vn on its AppMesh VirtualNode, given the tags listed on it.
+func (m *defaultResourceManager) updateSDKVirtualNodeTags(ctx context.Context, sdkVN *appmeshsdk.VirtualNodeData, vn *appmesh.VirtualNode, sdkVNTags map[string]string) error {
+	if !m.isSDKVirtualNodeControlledByCRDVirtualNode(ctx, sdkVN, vn) {
+		return nil
+	}
+	return m.tagsManager.ReconcileListedSDKTags(ctx, aws.StringValue(sdkVN.Metadata.Arn), vn, sdkVNTags)
+}
+
+// claimSDKVirtualNode tags an AppMesh virtualNode with vn as its owner unless sdkVNTags listed on it claim it already,
+// and returns the other virtualNode mapped to the same AppMesh name it's claimed by, or empty if it's claimed by vn.
+// AppMesh virtualNodes created before ownership was tracked are claimed by the virtualNode that reconciled them,
+// other unclaimed AppMesh virtualNodes are only claimed when adoption is enabled.
+func (m *defaultResourceManager) claimSDKVirtualNode(ctx context.Context, sdkVN *appmeshsdk.VirtualNodeData, vn *appmesh.VirtualNode, sdkVNTags map[string]string) (string, error) {
+	if !m.isSDKVirtualNodeControlledByCRDVirtualNode(ctx, sdkVN, vn) {
+		return "", nil
+	}
+	arn := aws.StringValue(sdkVN.Metadata.Arn)
+	owner := sdkVNTags[ownerTagKey]
+	if owner == "" {
+		adopted := !isSDKVirtualNodeReconciledByCRDVirtualNode(sdkVN, vn)
+		if adopted && !m.cfg.EnableAdoption {
+			message := fmt.Sprintf("AppMesh virtualNode %s already exists and isn't claimed by any virtualNode, enable adoption to manage it",
+				aws.StringValue(sdkVN.VirtualNodeName))
+			m.eventRecorder.Event(vn, corev1.EventTypeWarning, reasonNotAdopted, message)
+			return "", errors.New(message)
+		}
+		if _, err := m.appMeshSDK.TagResourceWithContext(ctx, &appmeshsdk.TagResourceInput{
+			ResourceArn: aws.String(arn),
+			Tags:        []*appmeshsdk.TagRef{buildSDKVirtualNodeOwnerTag(vn)},
+		}); err != nil {
+			return "", errors.Wrapf(err, "failed to tag %s", arn)
+		}
+		if adopted {
+			m.eventRecorder.Eventf(vn, corev1.EventTypeNormal, reasonAdopted, "adopted AppMesh virtualNode %s", aws.StringValue(sdkVN.VirtualNodeName))
+		}
+		return "", nil
+	}
+	if owner == k8s.NamespacedName(vn).String() {
+		return "", nil
+	}
+
+	message := fmt.Sprintf("AppMesh virtualNode %s is already claimed by virtualNode %s", aws.StringValue(sdkVN.VirtualNodeName), owner)
+	if m.cfg.NameCollisionPolicy == NameCollisionPolicyWarn {
+		m.eventRecorder.Eventf(vn, corev1.EventTypeWarning, reasonNameCollision, "%s, skipping update", message)
+		return owner, nil
+	}
+	m.eventRecorder.Event(vn, corev1.EventTypeWarning, reasonNameCollision, message)
+	return owner, errors.New(message)
+}
+
+// listSDKVirtualNodeTags lists the tags of an AppMesh virtualNode, its ownerTagKey tag holds the virtualNode it's claimed by.
+func (m *defaultResourceManager) listSDKVirtualNodeTags(ctx context.Context, sdkVN *appmeshsdk.VirtualNodeData) (map[string]string, error) {
+	arn := aws.StringValue(sdkVN.Metadata.Arn)
+	tags := make(map[string]string)
+	if err := m.appMeshSDK.ListTagsForResourcePagesWithContext(ctx, &appmeshsdk.ListTagsForResourceInput{
+		ResourceArn: aws.String(arn),
+	}, func(output *appmeshsdk.ListTagsForResourceOutput, lastPage bool) bool {
+		for _, tag := range output.Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list tags of %s", arn)
+	}
+	return tags, nil
+}
+
+// isSDKVirtualNodeReconciledByCRDVirtualNode checks whether vn reconciled the AppMesh virtualNode before,
+// e.g. created it before ownership was tracked, as opposed to an AppMesh virtualNode created outside of the controller.
+func isSDKVirtualNodeReconciledByCRDVirtualNode(sdkVN *appmeshsdk.VirtualNodeData, vn *appmesh.VirtualNode) bool {
+	return vn.Status.VirtualNodeARN != nil && aws.StringValue(vn.Status.VirtualNodeARN) == aws.StringValue(sdkVN.Metadata.Arn)
+}
+
+// buildSDKVirtualNodeOwnerTag returns the tag claiming an AppMesh virtualNode for vn.
+func buildSDKVirtualNodeOwnerTag(vn *appmesh.VirtualNode) *appmeshsdk.TagRef {
+	return &appmeshsdk.TagRef{
+		Key:   aws.String(ownerTagKey),
+		Value: aws.String(k8s.NamespacedName(vn).String()),
+	}
+}
+
 // isSDKVirtualNodeControlledByCRDVirtualNode checks whether an AppMesh virtualNode is controlled by CRD virtualNode
 // if it's controlled, CRD virtualNode update is responsible for updating the AppMesh virtualNode.
 func (m *defaultResourceManager) isSDKVirtualNodeControlledByCRDVirtualNode(ctx context.Context, sdkVN *appmeshsdk.VirtualNodeData, vn *appmesh.VirtualNode) bool {
@@ -300,6 +645,33 @@ func (m *defaultResourceManager) isSDKVirtualNodeOwnedByCRDVirtualNode(ctx conte
 	return true
 }
 
+// applyMeshBackendDefaults returns vn with the mesh's backendDefaults clientPolicy applied if vn doesn't specify its own.
+// vn itself is never modified, a copy is returned when defaults are applied.
+func applyMeshBackendDefaults(ms *appmesh.Mesh, vn *appmesh.VirtualNode) *appmesh.VirtualNode {
+	if ms.Spec.BackendDefaults == nil || ms.Spec.BackendDefaults.ClientPolicy == nil {
+		return vn
+	}
+	if vn.Spec.BackendDefaults != nil && vn.Spec.BackendDefaults.ClientPolicy != nil {
+		return vn
+	}
+	vnWithDefaults := vn.DeepCopy()
+	if vnWithDefaults.Spec.BackendDefaults == nil {
+		vnWithDefaults.Spec.BackendDefaults = &appmesh.BackendDefaults{}
+	}
+	vnWithDefaults.Spec.BackendDefaults.ClientPolicy = ms.Spec.BackendDefaults.ClientPolicy.DeepCopy()
+	return vnWithDefaults
+}
+
+// applyCloudMapNamespaceName fills in the CloudMap namespace name resolved for vn that references its namespace by ID.
+func applyCloudMapNamespaceName(vn *appmesh.VirtualNode) *appmesh.VirtualNode {
+	if vn.Spec.ServiceDiscovery == nil || vn.Spec.ServiceDiscovery.AWSCloudMap == nil || vn.Spec.ServiceDiscovery.AWSCloudMap.NamespaceName != "" {
+		return vn
+	}
+	vnWithNamespaceName := vn.DeepCopy()
+	vnWithNamespaceName.Spec.ServiceDiscovery.AWSCloudMap.NamespaceName = aws.StringValue(vn.Status.CloudMapNamespaceName)
+	return vnWithNamespaceName
+}
+
 func BuildSDKVirtualNodeSpec(vn *appmesh.VirtualNode, vsByKey map[types.NamespacedName]*appmesh.VirtualService) (*appmeshsdk.VirtualNodeSpec, error) {
 	converter := conversion.NewConverter(conversion.DefaultNameFunc)
 	converter.RegisterUntypedConversionFunc((*appmesh.VirtualNodeSpec)(nil), (*appmeshsdk.VirtualNodeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
@@ -4,10 +4,16 @@ import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	mock_resolver "github.com/aws/aws-app-mesh-controller-for-k8s/mocks/aws-app-mesh-controller-for-k8s/pkg/references"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/equality"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	appmeshruntime "github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/tagging"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
+	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -18,6 +24,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
@@ -108,6 +116,55 @@ func Test_defaultResourceManager_updateCRDVirtualNode(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "virtualNode resumed after pause needs patch ReconciliationPaused condition",
+			args: args{
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vn-1",
+					},
+					Status: appmesh.VirtualNodeStatus{
+						VirtualNodeARN: aws.String("arn-1"),
+						Conditions: []appmesh.VirtualNodeCondition{
+							{
+								Type:   appmesh.VirtualNodeActive,
+								Status: corev1.ConditionTrue,
+							},
+							{
+								Type:   appmesh.VirtualNodeReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+				sdkVN: &appmeshsdk.VirtualNodeData{
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn: aws.String("arn-1"),
+					},
+					Status: &appmeshsdk.VirtualNodeStatus{
+						Status: aws.String(appmeshsdk.VirtualNodeStatusCodeActive),
+					},
+				},
+			},
+			wantVN: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vn-1",
+				},
+				Status: appmesh.VirtualNodeStatus{
+					VirtualNodeARN: aws.String("arn-1"),
+					Conditions: []appmesh.VirtualNodeCondition{
+						{
+							Type:   appmesh.VirtualNodeActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.VirtualNodeReconciliationPaused,
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -141,133 +198,660 @@ func Test_defaultResourceManager_updateCRDVirtualNode(t *testing.T) {
 	}
 }
 
-func Test_defaultResourceManager_isSDKVirtualNodeControlledByCRDVirtualNode(t *testing.T) {
-	type fields struct {
-		accountID string
-	}
+func Test_defaultResourceManager_Reconcile_paused(t *testing.T) {
 	type args struct {
-		sdkVN *appmeshsdk.VirtualNodeData
-		vn    *appmesh.VirtualNode
+		vn *appmesh.VirtualNode
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   bool
+		name    string
+		args    args
+		wantVN  *appmesh.VirtualNode
+		wantErr error
 	}{
 		{
-			name:   "sdkVN is controlled by crdVN",
-			fields: fields{accountID: "222222222"},
+			name: "paused virtualNode records ReconciliationPaused condition",
 			args: args{
-				sdkVN: &appmeshsdk.VirtualNodeData{
-					Metadata: &appmeshsdk.ResourceMetadata{
-						ResourceOwner: aws.String("222222222"),
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vn-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.VirtualNodeStatus{
+						VirtualNodeARN: aws.String("arn-1"),
+						Conditions: []appmesh.VirtualNodeCondition{
+							{
+								Type:   appmesh.VirtualNodeActive,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantVN: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vn-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.VirtualNodeStatus{
+					VirtualNodeARN: aws.String("arn-1"),
+					Conditions: []appmesh.VirtualNodeCondition{
+						{
+							Type:   appmesh.VirtualNodeActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.VirtualNodeReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
 					},
 				},
-				vn: &appmesh.VirtualNode{},
 			},
-			want: true,
 		},
 		{
-			name:   "sdkVN isn't controlled by crdVN",
-			fields: fields{accountID: "222222222"},
+			name: "paused virtualNode already has ReconciliationPaused condition",
 			args: args{
-				sdkVN: &appmeshsdk.VirtualNodeData{
-					Metadata: &appmeshsdk.ResourceMetadata{
-						ResourceOwner: aws.String("33333333"),
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vn-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.VirtualNodeStatus{
+						VirtualNodeARN: aws.String("arn-1"),
+						Conditions: []appmesh.VirtualNodeCondition{
+							{
+								Type:   appmesh.VirtualNodeReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantVN: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vn-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.VirtualNodeStatus{
+					VirtualNodeARN: aws.String("arn-1"),
+					Conditions: []appmesh.VirtualNodeCondition{
+						{
+							Type:   appmesh.VirtualNodeReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
 					},
 				},
-				vn: &appmesh.VirtualNode{},
 			},
-			want: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			// neither referencesResolver nor appMeshSDK expect any calls while paused.
+			resolver := mock_resolver.NewMockResolver(ctrl)
 			m := &defaultResourceManager{
-				accountID: tt.fields.accountID,
-				log:       &log.NullLogger{},
+				k8sClient:          k8sClient,
+				referencesResolver: resolver,
+				log:                log.NullLogger{},
+			}
+
+			err := k8sClient.Create(ctx, tt.args.vn.DeepCopy())
+			assert.NoError(t, err)
+			err = m.Reconcile(ctx, tt.args.vn)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				gotVN := &appmesh.VirtualNode{}
+				err = k8sClient.Get(ctx, k8s.NamespacedName(tt.args.vn), gotVN)
+				assert.NoError(t, err)
+				opts := cmp.Options{
+					equality.IgnoreFakeClientPopulatedFields(),
+					cmpopts.IgnoreTypes((*metav1.Time)(nil)),
+				}
+				assert.True(t, cmp.Equal(tt.wantVN, gotVN, opts), "diff", cmp.Diff(tt.wantVN, gotVN, opts))
 			}
-			got := m.isSDKVirtualNodeControlledByCRDVirtualNode(ctx, tt.args.sdkVN, tt.args.vn)
-			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
-func Test_defaultResourceManager_isSDKVirtualNodeOwnedByCRDVirtualNode(t *testing.T) {
-	type fields struct {
-		accountID string
+// fakeAppMesh serves a single AppMesh VirtualNode and records updates, deletions and tags.
+type fakeAppMesh struct {
+	services.AppMesh
+	sdkVN               *appmeshsdk.VirtualNodeData
+	describeErr         error
+	deleteErr           error
+	tags                map[string]string
+	listTagsCalls       int
+	updatedVirtualNodes []string
+	deletedVirtualNodes []string
+	createdVirtualNodes []string
+	updatedSpecs        []*appmeshsdk.VirtualNodeSpec
+}
+
+func (f *fakeAppMesh) CreateVirtualNodeWithContext(_ context.Context, input *appmeshsdk.CreateVirtualNodeInput, _ ...request.Option) (*appmeshsdk.CreateVirtualNodeOutput, error) {
+	f.createdVirtualNodes = append(f.createdVirtualNodes, aws.StringValue(input.VirtualNodeName))
+	return &appmeshsdk.CreateVirtualNodeOutput{
+		VirtualNode: &appmeshsdk.VirtualNodeData{
+			MeshName:        input.MeshName,
+			VirtualNodeName: input.VirtualNodeName,
+			Spec:            input.Spec,
+			Metadata: &appmeshsdk.ResourceMetadata{
+				Arn: aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/" + aws.StringValue(input.VirtualNodeName)),
+			},
+			Status: &appmeshsdk.VirtualNodeStatus{
+				Status: aws.String(appmeshsdk.VirtualNodeStatusCodeActive),
+			},
+		},
+	}, nil
+}
+
+func (f *fakeAppMesh) DescribeVirtualNodeWithContext(_ context.Context, _ *appmeshsdk.DescribeVirtualNodeInput, _ ...request.Option) (*appmeshsdk.DescribeVirtualNodeOutput, error) {
+	if f.describeErr != nil {
+		return nil, f.describeErr
 	}
-	type args struct {
-		sdkVN *appmeshsdk.VirtualNodeData
-		vn    *appmesh.VirtualNode
+	return &appmeshsdk.DescribeVirtualNodeOutput{VirtualNode: f.sdkVN}, nil
+}
+
+func (f *fakeAppMesh) UpdateVirtualNodeWithContext(_ context.Context, input *appmeshsdk.UpdateVirtualNodeInput, _ ...request.Option) (*appmeshsdk.UpdateVirtualNodeOutput, error) {
+	f.updatedVirtualNodes = append(f.updatedVirtualNodes, aws.StringValue(input.VirtualNodeName))
+	f.updatedSpecs = append(f.updatedSpecs, input.Spec)
+	return &appmeshsdk.UpdateVirtualNodeOutput{VirtualNode: f.sdkVN}, nil
+}
+
+func (f *fakeAppMesh) DeleteVirtualNodeWithContext(_ context.Context, input *appmeshsdk.DeleteVirtualNodeInput, _ ...request.Option) (*appmeshsdk.DeleteVirtualNodeOutput, error) {
+	if f.deleteErr != nil {
+		return nil, f.deleteErr
+	}
+	f.deletedVirtualNodes = append(f.deletedVirtualNodes, aws.StringValue(input.VirtualNodeName))
+	return &appmeshsdk.DeleteVirtualNodeOutput{}, nil
+}
+
+func (f *fakeAppMesh) ListTagsForResourcePagesWithContext(_ context.Context, _ *appmeshsdk.ListTagsForResourceInput, fn func(*appmeshsdk.ListTagsForResourceOutput, bool) bool, _ ...request.Option) error {
+	f.listTagsCalls++
+	output := &appmeshsdk.ListTagsForResourceOutput{}
+	for key, value := range f.tags {
+		output.Tags = append(output.Tags, &appmeshsdk.TagRef{Key: aws.String(key), Value: aws.String(value)})
+	}
+	fn(output, true)
+	return nil
+}
+
+func (f *fakeAppMesh) TagResourceWithContext(_ context.Context, input *appmeshsdk.TagResourceInput, _ ...request.Option) (*appmeshsdk.TagResourceOutput, error) {
+	if f.tags == nil {
+		f.tags = make(map[string]string)
+	}
+	for _, tag := range input.Tags {
+		f.tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return &appmeshsdk.TagResourceOutput{}, nil
+}
+
+func Test_defaultResourceManager_Cleanup_deletionPolicy(t *testing.T) {
+	ms := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   bool
+		name                    string
+		annotations             map[string]string
+		wantDeletedVirtualNodes []string
 	}{
 		{
-			name:   "sdkVN is owned by crdVN",
-			fields: fields{accountID: "222222222"},
-			args: args{
-				sdkVN: &appmeshsdk.VirtualNodeData{
-					Metadata: &appmeshsdk.ResourceMetadata{
-						ResourceOwner: aws.String("222222222"),
-					},
-				},
-				vn: &appmesh.VirtualNode{},
+			name:                    "default deletionPolicy deletes AppMesh virtualNode",
+			annotations:             nil,
+			wantDeletedVirtualNodes: []string{"vn-1_my-ns"},
+		},
+		{
+			name: "delete deletionPolicy deletes AppMesh virtualNode",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/deletionPolicy": "delete",
 			},
-			want: true,
+			wantDeletedVirtualNodes: []string{"vn-1_my-ns"},
 		},
 		{
-			name:   "sdkVN isn't owned by crdVN",
-			fields: fields{accountID: "222222222"},
-			args: args{
-				sdkVN: &appmeshsdk.VirtualNodeData{
-					Metadata: &appmeshsdk.ResourceMetadata{
-						ResourceOwner: aws.String("33333333"),
-					},
-				},
-				vn: &appmesh.VirtualNode{},
+			name: "orphan deletionPolicy keeps AppMesh virtualNode",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/deletionPolicy": "orphan",
 			},
-			want: false,
+			wantDeletedVirtualNodes: nil,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			resolver.EXPECT().ResolveMeshReference(gomock.Any(), gomock.Any()).Return(ms, nil)
+			appMeshSDK := &fakeAppMesh{
+				sdkVN: &appmeshsdk.VirtualNodeData{
+					MeshName:        aws.String("my-mesh"),
+					VirtualNodeName: aws.String("vn-1_my-ns"),
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn:           aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+						ResourceOwner: aws.String("222222222"),
+					},
+				},
+			}
 			m := &defaultResourceManager{
-				accountID: tt.fields.accountID,
-				log:       &log.NullLogger{},
+				appMeshSDK:         appMeshSDK,
+				referencesResolver: resolver,
+				accountID:          "222222222",
+				log:                log.NullLogger{},
 			}
-			got := m.isSDKVirtualNodeOwnedByCRDVirtualNode(ctx, tt.args.sdkVN, tt.args.vn)
-			assert.Equal(t, tt.want, got)
+			vn := &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "my-ns",
+					Name:        "vn-1",
+					Annotations: tt.annotations,
+				},
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("vn-1_my-ns"),
+					MeshRef: &appmesh.MeshReference{
+						Name: "my-mesh",
+					},
+				},
+				Status: appmesh.VirtualNodeStatus{
+					VirtualNodeARN: aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+				},
+			}
+			err := m.Cleanup(ctx, vn)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDeletedVirtualNodes, appMeshSDK.deletedVirtualNodes)
 		})
 	}
 }
 
-func Test_defaultResourceManager_findMeshDependency(t *testing.T) {
-	type fields struct {
-		ResolveMeshReference func(ctx context.Context, ref appmesh.MeshReference) (*appmesh.Mesh, error)
-	}
-	type args struct {
-		vn *appmesh.VirtualNode
+func Test_defaultResourceManager_Cleanup_notFound(t *testing.T) {
+	ms := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    *appmesh.Mesh
-		wantErr error
+		name                    string
+		describeErr             error
+		deleteErr               error
+		wantDeletedVirtualNodes []string
+		wantErr                 error
 	}{
 		{
-			name: "virtualNode with mesh",
-			args: args{
-				vn: &appmesh.VirtualNode{
-					ObjectMeta: metav1.ObjectMeta{
+			name:                    "AppMesh virtualNode deleted out-of-band before describe",
+			describeErr:             awserr.New("NotFoundException", "virtualNode not found", nil),
+			wantDeletedVirtualNodes: nil,
+		},
+		{
+			name:                    "AppMesh virtualNode deleted out-of-band before delete",
+			deleteErr:               awserr.New("NotFoundException", "virtualNode not found", nil),
+			wantDeletedVirtualNodes: nil,
+		},
+		{
+			name:      "AppMesh virtualNode deletion fails",
+			deleteErr: awserr.New("ResourceInUseException", "virtualNode in use", nil),
+			wantErr:   errors.New("ResourceInUseException: virtualNode in use"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			resolver.EXPECT().ResolveMeshReference(gomock.Any(), gomock.Any()).Return(ms, nil)
+			appMeshSDK := &fakeAppMesh{
+				sdkVN: &appmeshsdk.VirtualNodeData{
+					MeshName:        aws.String("my-mesh"),
+					VirtualNodeName: aws.String("vn-1_my-ns"),
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn:           aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+						ResourceOwner: aws.String("222222222"),
+					},
+				},
+				describeErr: tt.describeErr,
+				deleteErr:   tt.deleteErr,
+			}
+			m := &defaultResourceManager{
+				appMeshSDK:         appMeshSDK,
+				referencesResolver: resolver,
+				accountID:          "222222222",
+				log:                log.NullLogger{},
+			}
+			vn := &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "vn-1",
+				},
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("vn-1_my-ns"),
+					MeshRef: &appmesh.MeshReference{
+						Name: "my-mesh",
+					},
+				},
+				Status: appmesh.VirtualNodeStatus{
+					VirtualNodeARN: aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+				},
+			}
+			// the finalizer is removed once Cleanup succeeds
+			err := m.Cleanup(ctx, vn)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantDeletedVirtualNodes, appMeshSDK.deletedVirtualNodes)
+		})
+	}
+}
+
+func Test_defaultResourceManager_updatePodsVirtualNodeReadyCondition(t *testing.T) {
+	activeVN := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "vn-1",
+		},
+		Spec: appmesh.VirtualNodeSpec{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": "my-app",
+				},
+			},
+		},
+		Status: appmesh.VirtualNodeStatus{
+			Conditions: []appmesh.VirtualNodeCondition{
+				{
+					Type:   appmesh.VirtualNodeActive,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	inactiveVN := activeVN.DeepCopy()
+	inactiveVN.Status.Conditions[0].Status = corev1.ConditionFalse
+
+	podWithReadinessGate := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "pod-1",
+			Labels: map[string]string{
+				"app": "my-app",
+			},
+		},
+		Spec: corev1.PodSpec{
+			ReadinessGates: []corev1.PodReadinessGate{
+				{
+					ConditionType: k8s.ConditionAppMeshVirtualNodeReady,
+				},
+			},
+		},
+	}
+	podWithoutReadinessGate := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "pod-2",
+			Labels: map[string]string{
+				"app": "my-app",
+			},
+		},
+	}
+	podNotSelected := podWithReadinessGate.DeepCopy()
+	podNotSelected.Name = "pod-3"
+	podNotSelected.Labels = map[string]string{"app": "other-app"}
+
+	type env struct {
+		pods []*corev1.Pod
+	}
+	type args struct {
+		vn *appmesh.VirtualNode
+	}
+	tests := []struct {
+		name      string
+		env       env
+		args      args
+		wantConds map[string][]corev1.PodCondition
+	}{
+		{
+			name: "active virtualNode marks selected pods with readinessGate as ready",
+			env: env{
+				pods: []*corev1.Pod{podWithReadinessGate, podWithoutReadinessGate, podNotSelected},
+			},
+			args: args{
+				vn: activeVN,
+			},
+			wantConds: map[string][]corev1.PodCondition{
+				"pod-1": {
+					{
+						Type:   k8s.ConditionAppMeshVirtualNodeReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+				"pod-2": nil,
+				"pod-3": nil,
+			},
+		},
+		{
+			name: "inactive virtualNode leaves pods unready",
+			env: env{
+				pods: []*corev1.Pod{podWithReadinessGate},
+			},
+			args: args{
+				vn: inactiveVN,
+			},
+			wantConds: map[string][]corev1.PodCondition{
+				"pod-1": nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			m := &defaultResourceManager{
+				k8sClient: k8sClient,
+				log:       log.NullLogger{},
+			}
+			for _, pod := range tt.env.pods {
+				err := k8sClient.Create(ctx, pod.DeepCopy())
+				assert.NoError(t, err)
+			}
+
+			err := m.updatePodsVirtualNodeReadyCondition(ctx, tt.args.vn)
+			assert.NoError(t, err)
+			for podName, wantConds := range tt.wantConds {
+				gotPod := &corev1.Pod{}
+				err := k8sClient.Get(ctx, types.NamespacedName{Namespace: "my-ns", Name: podName}, gotPod)
+				assert.NoError(t, err)
+				opts := cmpopts.IgnoreTypes(metav1.Time{})
+				assert.True(t, cmp.Equal(wantConds, gotPod.Status.Conditions, opts), "diff", cmp.Diff(wantConds, gotPod.Status.Conditions, opts))
+			}
+		})
+	}
+}
+
+func Test_defaultResourceManager_isSDKVirtualNodeControlledByCRDVirtualNode(t *testing.T) {
+	type fields struct {
+		accountID string
+	}
+	type args struct {
+		sdkVN *appmeshsdk.VirtualNodeData
+		vn    *appmesh.VirtualNode
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   bool
+	}{
+		{
+			name:   "sdkVN is controlled by crdVN",
+			fields: fields{accountID: "222222222"},
+			args: args{
+				sdkVN: &appmeshsdk.VirtualNodeData{
+					Metadata: &appmeshsdk.ResourceMetadata{
+						ResourceOwner: aws.String("222222222"),
+					},
+				},
+				vn: &appmesh.VirtualNode{},
+			},
+			want: true,
+		},
+		{
+			name:   "sdkVN isn't controlled by crdVN",
+			fields: fields{accountID: "222222222"},
+			args: args{
+				sdkVN: &appmeshsdk.VirtualNodeData{
+					Metadata: &appmeshsdk.ResourceMetadata{
+						ResourceOwner: aws.String("33333333"),
+					},
+				},
+				vn: &appmesh.VirtualNode{},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := &defaultResourceManager{
+				accountID: tt.fields.accountID,
+				log:       &log.NullLogger{},
+			}
+			got := m.isSDKVirtualNodeControlledByCRDVirtualNode(ctx, tt.args.sdkVN, tt.args.vn)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultResourceManager_deleteSDKVirtualNode_logging(t *testing.T) {
+	sdkVN := &appmeshsdk.VirtualNodeData{
+		MeshName:        aws.String("my-mesh"),
+		VirtualNodeName: aws.String("vn-1_my-ns"),
+		Metadata: &appmeshsdk.ResourceMetadata{
+			Arn:           aws.String("arn:aws:appmesh:us-west-2:33333333:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+			ResourceOwner: aws.String("33333333"),
+		},
+	}
+	vn := &appmesh.VirtualNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "vn-1",
+		},
+	}
+	var entries []logEntry
+	m := &defaultResourceManager{
+		accountID: "222222222",
+		log:       &recordingLogger{entries: &entries},
+	}
+	err := m.deleteSDKVirtualNode(context.Background(), sdkVN, &appmesh.Mesh{}, vn)
+	assert.NoError(t, err)
+	assert.Equal(t, []logEntry{
+		{
+			msg: "skip mesh virtualNode since its not owned",
+			keysAndValues: map[interface{}]interface{}{
+				"meshName":    "my-mesh",
+				"virtualNode": types.NamespacedName{Namespace: "my-ns", Name: "vn-1"},
+				"arn":         "arn:aws:appmesh:us-west-2:33333333:mesh/my-mesh/virtualNode/vn-1_my-ns",
+			},
+		},
+	}, entries)
+}
+
+func Test_defaultResourceManager_isSDKVirtualNodeOwnedByCRDVirtualNode(t *testing.T) {
+	type fields struct {
+		accountID string
+	}
+	type args struct {
+		sdkVN *appmeshsdk.VirtualNodeData
+		vn    *appmesh.VirtualNode
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   bool
+	}{
+		{
+			name:   "sdkVN is owned by crdVN",
+			fields: fields{accountID: "222222222"},
+			args: args{
+				sdkVN: &appmeshsdk.VirtualNodeData{
+					Metadata: &appmeshsdk.ResourceMetadata{
+						ResourceOwner: aws.String("222222222"),
+					},
+				},
+				vn: &appmesh.VirtualNode{},
+			},
+			want: true,
+		},
+		{
+			name:   "sdkVN isn't owned by crdVN",
+			fields: fields{accountID: "222222222"},
+			args: args{
+				sdkVN: &appmeshsdk.VirtualNodeData{
+					Metadata: &appmeshsdk.ResourceMetadata{
+						ResourceOwner: aws.String("33333333"),
+					},
+				},
+				vn: &appmesh.VirtualNode{},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := &defaultResourceManager{
+				accountID: tt.fields.accountID,
+				log:       &log.NullLogger{},
+			}
+			got := m.isSDKVirtualNodeOwnedByCRDVirtualNode(ctx, tt.args.sdkVN, tt.args.vn)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultResourceManager_findMeshDependency(t *testing.T) {
+	type fields struct {
+		ResolveMeshReference func(ctx context.Context, ref appmesh.MeshReference) (*appmesh.Mesh, error)
+	}
+	type args struct {
+		vn *appmesh.VirtualNode
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *appmesh.Mesh
+		wantErr error
+	}{
+		{
+			name: "virtualNode with mesh",
+			args: args{
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
 						Name: "vn-1",
 					},
 					Spec: appmesh.VirtualNodeSpec{
@@ -439,15 +1023,38 @@ func Test_defaultResourceManager_validateMeshDependency(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
 			m := &defaultResourceManager{
-				log: log.NullLogger{},
+				k8sClient: k8sClient,
+				log:       log.NullLogger{},
+			}
+			vn := &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "my-vn",
+				},
 			}
+			err := k8sClient.Create(ctx, vn.DeepCopy())
+			assert.NoError(t, err)
 
-			err := m.validateMeshDependencies(ctx, tt.args.mesh)
+			err = m.validateMeshDependencies(ctx, tt.args.mesh, vn)
+			gotVN := &appmesh.VirtualNode{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(vn), gotVN))
+			waitingForMesh := GetCondition(gotVN, appmesh.VirtualNodeWaitingForMesh)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
+				var requeueErr *appmeshruntime.RequeueError
+				assert.True(t, errors.As(err, &requeueErr), "vn must be requeued while the mesh isn't active")
+				if assert.NotNil(t, waitingForMesh) {
+					assert.Equal(t, corev1.ConditionTrue, waitingForMesh.Status)
+					assert.Equal(t, "mesh my-mesh is not active yet", aws.StringValue(waitingForMesh.Message))
+				}
 			} else {
 				assert.NoError(t, err)
+				assert.Nil(t, waitingForMesh)
 			}
 		})
 	}
@@ -593,3 +1200,1338 @@ func Test_defaultResourceManager_findVirtualServiceDependencies(t *testing.T) {
 		})
 	}
 }
+
+func Test_BuildSDKVirtualNodeSpec(t *testing.T) {
+	defaultClientPolicy := appmesh.ClientPolicy{
+		TLS: &appmesh.ClientPolicyTLS{
+			Validation: appmesh.TLSValidationContext{
+				Trust: appmesh.TLSValidationContextTrust{
+					File: &appmesh.TLSValidationContextFileTrust{
+						CertificateChain: "/certs/ca-chain.pem",
+					},
+				},
+			},
+		},
+	}
+	overrideClientPolicy := appmesh.ClientPolicy{
+		TLS: &appmesh.ClientPolicyTLS{
+			Enforce: aws.Bool(false),
+			Ports:   []appmesh.PortNumber{8443},
+			Validation: appmesh.TLSValidationContext{
+				Trust: appmesh.TLSValidationContextTrust{
+					ACM: &appmesh.TLSValidationContextACMTrust{
+						CertificateAuthorityARNs: []string{"arn:aws:acm-pca:us-west-2:000000000000:certificate-authority/ca"},
+					},
+				},
+			},
+		},
+	}
+	sdkDefaultClientPolicy := &appmeshsdk.ClientPolicy{
+		Tls: &appmeshsdk.ClientPolicyTls{
+			Validation: &appmeshsdk.TlsValidationContext{
+				Trust: &appmeshsdk.TlsValidationContextTrust{
+					File: &appmeshsdk.TlsValidationContextFileTrust{
+						CertificateChain: aws.String("/certs/ca-chain.pem"),
+					},
+				},
+			},
+		},
+	}
+	sdkOverrideClientPolicy := &appmeshsdk.ClientPolicy{
+		Tls: &appmeshsdk.ClientPolicyTls{
+			Enforce: aws.Bool(false),
+			Ports:   []*int64{aws.Int64(8443)},
+			Validation: &appmeshsdk.TlsValidationContext{
+				Trust: &appmeshsdk.TlsValidationContextTrust{
+					Acm: &appmeshsdk.TlsValidationContextAcmTrust{
+						CertificateAuthorityArns: aws.StringSlice([]string{"arn:aws:acm-pca:us-west-2:000000000000:certificate-authority/ca"}),
+					},
+				},
+			},
+		},
+	}
+	vsByKey := map[types.NamespacedName]*appmesh.VirtualService{
+		types.NamespacedName{Namespace: "my-ns", Name: "vs-1"}: &appmesh.VirtualService{
+			Spec: appmesh.VirtualServiceSpec{
+				AWSName: aws.String("vs-1.my-ns"),
+			},
+		},
+		types.NamespacedName{Namespace: "my-ns", Name: "vs-2"}: &appmesh.VirtualService{
+			Spec: appmesh.VirtualServiceSpec{
+				AWSName: aws.String("vs-2.my-ns"),
+			},
+		},
+	}
+	type args struct {
+		vn      *appmesh.VirtualNode
+		vsByKey map[types.NamespacedName]*appmesh.VirtualService
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *appmeshsdk.VirtualNodeSpec
+		wantErr error
+	}{
+		{
+			name: "backendDefaults clientPolicy only",
+			args: args{
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "vn",
+					},
+					Spec: appmesh.VirtualNodeSpec{
+						Backends: []appmesh.Backend{
+							{
+								VirtualService: appmesh.VirtualServiceBackend{
+									VirtualServiceRef: &appmesh.VirtualServiceReference{Name: "vs-1"},
+								},
+							},
+							{
+								VirtualService: appmesh.VirtualServiceBackend{
+									VirtualServiceRef: &appmesh.VirtualServiceReference{Name: "vs-2"},
+								},
+							},
+						},
+						BackendDefaults: &appmesh.BackendDefaults{
+							ClientPolicy: defaultClientPolicy.DeepCopy(),
+						},
+					},
+				},
+				vsByKey: vsByKey,
+			},
+			want: &appmeshsdk.VirtualNodeSpec{
+				Backends: []*appmeshsdk.Backend{
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("vs-1.my-ns"),
+						},
+					},
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("vs-2.my-ns"),
+						},
+					},
+				},
+				BackendDefaults: &appmeshsdk.BackendDefaults{
+					ClientPolicy: sdkDefaultClientPolicy,
+				},
+			},
+		},
+		{
+			name: "backend clientPolicy only",
+			args: args{
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "vn",
+					},
+					Spec: appmesh.VirtualNodeSpec{
+						Backends: []appmesh.Backend{
+							{
+								VirtualService: appmesh.VirtualServiceBackend{
+									VirtualServiceRef: &appmesh.VirtualServiceReference{Name: "vs-1"},
+									ClientPolicy:      overrideClientPolicy.DeepCopy(),
+								},
+							},
+						},
+					},
+				},
+				vsByKey: vsByKey,
+			},
+			want: &appmeshsdk.VirtualNodeSpec{
+				Backends: []*appmeshsdk.Backend{
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("vs-1.my-ns"),
+							ClientPolicy:       sdkOverrideClientPolicy,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "backendDefaults clientPolicy with backend override",
+			args: args{
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "vn",
+					},
+					Spec: appmesh.VirtualNodeSpec{
+						Backends: []appmesh.Backend{
+							{
+								VirtualService: appmesh.VirtualServiceBackend{
+									VirtualServiceRef: &appmesh.VirtualServiceReference{Name: "vs-1"},
+								},
+							},
+							{
+								VirtualService: appmesh.VirtualServiceBackend{
+									VirtualServiceRef: &appmesh.VirtualServiceReference{Name: "vs-2"},
+									ClientPolicy:      overrideClientPolicy.DeepCopy(),
+								},
+							},
+						},
+						BackendDefaults: &appmesh.BackendDefaults{
+							ClientPolicy: defaultClientPolicy.DeepCopy(),
+						},
+					},
+				},
+				vsByKey: vsByKey,
+			},
+			want: &appmeshsdk.VirtualNodeSpec{
+				Backends: []*appmeshsdk.Backend{
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("vs-1.my-ns"),
+						},
+					},
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("vs-2.my-ns"),
+							ClientPolicy:       sdkOverrideClientPolicy,
+						},
+					},
+				},
+				BackendDefaults: &appmeshsdk.BackendDefaults{
+					ClientPolicy: sdkDefaultClientPolicy,
+				},
+			},
+		},
+		{
+			name: "backend by virtualServiceARN in shared mesh",
+			args: args{
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "vn",
+					},
+					Spec: appmesh.VirtualNodeSpec{
+						Backends: []appmesh.Backend{
+							{
+								VirtualService: appmesh.VirtualServiceBackend{
+									VirtualServiceARN: aws.String("arn:aws:appmesh:us-west-2:222222222222:mesh/shared-mesh/virtualService/vs-3.shared.local"),
+								},
+							},
+						},
+					},
+				},
+				vsByKey: map[types.NamespacedName]*appmesh.VirtualService{},
+			},
+			want: &appmeshsdk.VirtualNodeSpec{
+				Backends: []*appmeshsdk.Backend{
+					{
+						VirtualService: &appmeshsdk.VirtualServiceBackend{
+							VirtualServiceName: aws.String("vs-3.shared.local"),
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildSDKVirtualNodeSpec(tt.args.vn, tt.args.vsByKey)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_applyMeshBackendDefaults(t *testing.T) {
+	meshClientPolicy := appmesh.ClientPolicy{
+		TLS: &appmesh.ClientPolicyTLS{
+			Enforce: aws.Bool(true),
+			Validation: appmesh.TLSValidationContext{
+				Trust: appmesh.TLSValidationContextTrust{
+					File: &appmesh.TLSValidationContextFileTrust{
+						CertificateChain: "/certs/mesh-ca-chain.pem",
+					},
+				},
+			},
+		},
+	}
+	vnClientPolicy := appmesh.ClientPolicy{
+		TLS: &appmesh.ClientPolicyTLS{
+			Enforce: aws.Bool(false),
+			Validation: appmesh.TLSValidationContext{
+				Trust: appmesh.TLSValidationContextTrust{
+					File: &appmesh.TLSValidationContextFileTrust{
+						CertificateChain: "/certs/vn-ca-chain.pem",
+					},
+				},
+			},
+		},
+	}
+	type args struct {
+		ms *appmesh.Mesh
+		vn *appmesh.VirtualNode
+	}
+	tests := []struct {
+		name string
+		args args
+		want *appmesh.VirtualNode
+	}{
+		{
+			name: "mesh without backendDefaults",
+			args: args{
+				ms: &appmesh.Mesh{},
+				vn: &appmesh.VirtualNode{
+					Spec: appmesh.VirtualNodeSpec{
+						AWSName: aws.String("vn"),
+					},
+				},
+			},
+			want: &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("vn"),
+				},
+			},
+		},
+		{
+			name: "virtualNode without backendDefaults",
+			args: args{
+				ms: &appmesh.Mesh{
+					Spec: appmesh.MeshSpec{
+						BackendDefaults: &appmesh.BackendDefaults{
+							ClientPolicy: meshClientPolicy.DeepCopy(),
+						},
+					},
+				},
+				vn: &appmesh.VirtualNode{
+					Spec: appmesh.VirtualNodeSpec{
+						AWSName: aws.String("vn"),
+					},
+				},
+			},
+			want: &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("vn"),
+					BackendDefaults: &appmesh.BackendDefaults{
+						ClientPolicy: meshClientPolicy.DeepCopy(),
+					},
+				},
+			},
+		},
+		{
+			name: "virtualNode backendDefaults without clientPolicy",
+			args: args{
+				ms: &appmesh.Mesh{
+					Spec: appmesh.MeshSpec{
+						BackendDefaults: &appmesh.BackendDefaults{
+							ClientPolicy: meshClientPolicy.DeepCopy(),
+						},
+					},
+				},
+				vn: &appmesh.VirtualNode{
+					Spec: appmesh.VirtualNodeSpec{
+						AWSName:         aws.String("vn"),
+						BackendDefaults: &appmesh.BackendDefaults{},
+					},
+				},
+			},
+			want: &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("vn"),
+					BackendDefaults: &appmesh.BackendDefaults{
+						ClientPolicy: meshClientPolicy.DeepCopy(),
+					},
+				},
+			},
+		},
+		{
+			name: "virtualNode clientPolicy overrides mesh backendDefaults",
+			args: args{
+				ms: &appmesh.Mesh{
+					Spec: appmesh.MeshSpec{
+						BackendDefaults: &appmesh.BackendDefaults{
+							ClientPolicy: meshClientPolicy.DeepCopy(),
+						},
+					},
+				},
+				vn: &appmesh.VirtualNode{
+					Spec: appmesh.VirtualNodeSpec{
+						AWSName: aws.String("vn"),
+						BackendDefaults: &appmesh.BackendDefaults{
+							ClientPolicy: vnClientPolicy.DeepCopy(),
+						},
+					},
+				},
+			},
+			want: &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("vn"),
+					BackendDefaults: &appmesh.BackendDefaults{
+						ClientPolicy: vnClientPolicy.DeepCopy(),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalVN := tt.args.vn.DeepCopy()
+			got := applyMeshBackendDefaults(tt.args.ms, tt.args.vn)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, originalVN, tt.args.vn)
+		})
+	}
+}
+
+type logEntry struct {
+	msg           string
+	keysAndValues map[interface{}]interface{}
+}
+
+// recordingLogger is a logr.Logger that records every Info entry along with its accumulated values.
+type recordingLogger struct {
+	entries *[]logEntry
+	values  []interface{}
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	kvs := make(map[interface{}]interface{})
+	all := append(append([]interface{}{}, l.values...), keysAndValues...)
+	for i := 0; i+1 < len(all); i += 2 {
+		kvs[all[i]] = all[i+1]
+	}
+	*l.entries = append(*l.entries, logEntry{msg: msg, keysAndValues: kvs})
+}
+
+func (l *recordingLogger) Enabled() bool {
+	return true
+}
+
+func (l *recordingLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.Info(msg, append(keysAndValues, "error", err)...)
+}
+
+func (l *recordingLogger) V(level int) logr.InfoLogger {
+	return l
+}
+
+func (l *recordingLogger) WithValues(keysAndValues ...interface{}) logr.Logger {
+	return &recordingLogger{
+		entries: l.entries,
+		values:  append(append([]interface{}{}, l.values...), keysAndValues...),
+	}
+}
+
+func (l *recordingLogger) WithName(name string) logr.Logger {
+	return l
+}
+
+// serviceReadFailingClient fails every read of k8s Services, simulating transient API read failures.
+type serviceReadFailingClient struct {
+	client.Client
+}
+
+func (c *serviceReadFailingClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	if _, ok := obj.(*corev1.Service); ok {
+		return errors.New("etcdserver: request timed out")
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func Test_defaultResourceManager_Reconcile_serviceDeleted(t *testing.T) {
+	ms := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+		Status: appmesh.MeshStatus{
+			Conditions: []appmesh.MeshCondition{
+				{
+					Type:   appmesh.MeshActive,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "my-svc",
+		},
+	}
+	tests := []struct {
+		name                    string
+		policy                  string
+		annotations             map[string]string
+		services                []*corev1.Service
+		serviceReadFails        bool
+		wantDeletedVirtualNodes []string
+		wantEvents              []string
+		wantActiveCondition     *appmesh.VirtualNodeCondition
+		wantErr                 error
+	}{
+		{
+			name:                    "ignore policy never checks service",
+			policy:                  ServiceDeletionPolicyIgnore,
+			serviceReadFails:        true,
+			wantDeletedVirtualNodes: nil,
+			wantEvents:              nil,
+		},
+		{
+			name:                    "retain policy records warning when service is deleted",
+			policy:                  ServiceDeletionPolicyRetain,
+			wantDeletedVirtualNodes: nil,
+			wantEvents: []string{
+				"Warning ServiceDeleted service my-ns/my-svc behind DNS hostname is deleted, retaining AppMesh VirtualNode",
+			},
+		},
+		{
+			name:                    "retain policy keeps quiet when service exists",
+			policy:                  ServiceDeletionPolicyRetain,
+			services:                []*corev1.Service{svc},
+			wantDeletedVirtualNodes: nil,
+			wantEvents:              nil,
+		},
+		{
+			name:                    "delete policy deletes AppMesh virtualNode when service is deleted",
+			policy:                  ServiceDeletionPolicyDelete,
+			wantDeletedVirtualNodes: []string{"vn-1_my-ns"},
+			wantEvents: []string{
+				"Warning ServiceDeleted service my-ns/my-svc behind DNS hostname is deleted, deleted AppMesh VirtualNode",
+			},
+			wantActiveCondition: &appmesh.VirtualNodeCondition{
+				Type:    appmesh.VirtualNodeActive,
+				Status:  corev1.ConditionFalse,
+				Reason:  aws.String("ServiceDeleted"),
+				Message: aws.String("service my-ns/my-svc behind DNS hostname is deleted"),
+			},
+		},
+		{
+			name:   "delete policy respects orphan deletionPolicy",
+			policy: ServiceDeletionPolicyDelete,
+			annotations: map[string]string{
+				"appmesh.k8s.aws/deletionPolicy": "orphan",
+			},
+			wantDeletedVirtualNodes: nil,
+			wantEvents:              nil,
+			wantActiveCondition: &appmesh.VirtualNodeCondition{
+				Type:    appmesh.VirtualNodeActive,
+				Status:  corev1.ConditionFalse,
+				Reason:  aws.String("ServiceDeleted"),
+				Message: aws.String("service my-ns/my-svc behind DNS hostname is deleted"),
+			},
+		},
+		{
+			name:                    "delete policy keeps AppMesh virtualNode when service exists",
+			policy:                  ServiceDeletionPolicyDelete,
+			services:                []*corev1.Service{svc},
+			wantDeletedVirtualNodes: nil,
+			wantEvents:              nil,
+		},
+		{
+			name:                    "delete policy doesn't fire on transient service read failures",
+			policy:                  ServiceDeletionPolicyDelete,
+			serviceReadFails:        true,
+			wantDeletedVirtualNodes: nil,
+			wantEvents:              nil,
+			wantErr:                 errors.New("failed to get service my-ns/my-svc: etcdserver: request timed out"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			var k8sClient client.Client = testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, svc := range tt.services {
+				err := k8sClient.Create(ctx, svc.DeepCopy())
+				assert.NoError(t, err)
+			}
+			if tt.serviceReadFails {
+				k8sClient = &serviceReadFailingClient{Client: k8sClient}
+			}
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			resolver.EXPECT().ResolveMeshReference(gomock.Any(), gomock.Any()).Return(ms, nil)
+			eventRecorder := record.NewFakeRecorder(10)
+
+			vn := &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "my-ns",
+					Name:        "vn-1",
+					Annotations: tt.annotations,
+				},
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("vn-1_my-ns"),
+					MeshRef: &appmesh.MeshReference{
+						Name: "my-mesh",
+					},
+					ServiceDiscovery: &appmesh.ServiceDiscovery{
+						DNS: &appmesh.DNSServiceDiscovery{
+							Hostname: "my-svc.my-ns.svc.cluster.local",
+						},
+					},
+				},
+				Status: appmesh.VirtualNodeStatus{
+					VirtualNodeARN: aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+				},
+			}
+			sdkVNSpec, err := BuildSDKVirtualNodeSpec(vn, nil)
+			assert.NoError(t, err)
+			appMeshSDK := &fakeAppMesh{
+				sdkVN: &appmeshsdk.VirtualNodeData{
+					MeshName:        aws.String("my-mesh"),
+					VirtualNodeName: aws.String("vn-1_my-ns"),
+					Spec:            sdkVNSpec,
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn:           aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+						ResourceOwner: aws.String("222222222"),
+					},
+				},
+			}
+			m := &defaultResourceManager{
+				k8sClient:          k8sClient,
+				appMeshSDK:         appMeshSDK,
+				referencesResolver: resolver,
+				accountID:          "222222222",
+				cfg:                Config{ServiceDeletionPolicy: tt.policy},
+				eventRecorder:      eventRecorder,
+				tagsManager:        tagging.NewDefaultManager(appMeshSDK, tagging.Config{}, log.NullLogger{}),
+				log:                log.NullLogger{},
+			}
+			err = k8sClient.Create(ctx, vn.DeepCopy())
+			assert.NoError(t, err)
+
+			err = m.Reconcile(ctx, vn)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantDeletedVirtualNodes, appMeshSDK.deletedVirtualNodes)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+			if tt.wantActiveCondition != nil {
+				gotVN := &appmesh.VirtualNode{}
+				err = k8sClient.Get(ctx, k8s.NamespacedName(vn), gotVN)
+				assert.NoError(t, err)
+				opts := cmpopts.IgnoreTypes((*metav1.Time)(nil))
+				gotActiveCondition := GetCondition(gotVN, appmesh.VirtualNodeActive)
+				assert.True(t, cmp.Equal(tt.wantActiveCondition, gotActiveCondition, opts), "diff", cmp.Diff(tt.wantActiveCondition, gotActiveCondition, opts))
+			}
+		})
+	}
+}
+
+func Test_defaultResourceManager_updateSDKVirtualNode_backends(t *testing.T) {
+	ms := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+	}
+	backend := func(vsName string) appmesh.Backend {
+		return appmesh.Backend{
+			VirtualService: appmesh.VirtualServiceBackend{
+				VirtualServiceARN: aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualService/" + vsName),
+			},
+		}
+	}
+	sdkBackend := func(vsName string) *appmeshsdk.Backend {
+		return &appmeshsdk.Backend{
+			VirtualService: &appmeshsdk.VirtualServiceBackend{
+				VirtualServiceName: aws.String(vsName),
+			},
+		}
+	}
+	tests := []struct {
+		name                    string
+		backends                []appmesh.Backend
+		sdkBackends             []*appmeshsdk.Backend
+		wantUpdatedVirtualNodes []string
+	}{
+		{
+			name:                    "reordered backends don't trigger update",
+			backends:                []appmesh.Backend{backend("svc-a.my-ns"), backend("svc-b.my-ns")},
+			sdkBackends:             []*appmeshsdk.Backend{sdkBackend("svc-b.my-ns"), sdkBackend("svc-a.my-ns")},
+			wantUpdatedVirtualNodes: nil,
+		},
+		{
+			name:                    "added backend triggers update",
+			backends:                []appmesh.Backend{backend("svc-a.my-ns"), backend("svc-b.my-ns"), backend("svc-c.my-ns")},
+			sdkBackends:             []*appmeshsdk.Backend{sdkBackend("svc-b.my-ns"), sdkBackend("svc-a.my-ns")},
+			wantUpdatedVirtualNodes: []string{"vn-1_my-ns"},
+		},
+		{
+			name:                    "removed backend triggers update",
+			backends:                []appmesh.Backend{backend("svc-a.my-ns")},
+			sdkBackends:             []*appmeshsdk.Backend{sdkBackend("svc-b.my-ns"), sdkBackend("svc-a.my-ns")},
+			wantUpdatedVirtualNodes: []string{"vn-1_my-ns"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vn := &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "vn-1",
+				},
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName:  aws.String("vn-1_my-ns"),
+					Backends: tt.backends,
+				},
+			}
+			sdkVN := &appmeshsdk.VirtualNodeData{
+				MeshName:        aws.String("my-mesh"),
+				VirtualNodeName: aws.String("vn-1_my-ns"),
+				Metadata: &appmeshsdk.ResourceMetadata{
+					ResourceOwner: aws.String("222222222"),
+				},
+				Spec: &appmeshsdk.VirtualNodeSpec{
+					Backends: tt.sdkBackends,
+				},
+			}
+			appMeshSDK := &fakeAppMesh{sdkVN: sdkVN}
+			m := &defaultResourceManager{
+				appMeshSDK: appMeshSDK,
+				accountID:  "222222222",
+				log:        &log.NullLogger{},
+			}
+			_, err := m.updateSDKVirtualNode(context.Background(), sdkVN, ms, vn, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUpdatedVirtualNodes, appMeshSDK.updatedVirtualNodes)
+		})
+	}
+}
+
+func Test_defaultResourceManager_updateSDKVirtualNode_httpTimeout(t *testing.T) {
+	ms := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+	}
+	vnWithHTTPTimeout := func(timeout *appmesh.HTTPTimeout) *appmesh.VirtualNode {
+		return &appmesh.VirtualNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-ns",
+				Name:      "vn-1",
+			},
+			Spec: appmesh.VirtualNodeSpec{
+				AWSName: aws.String("vn-1_my-ns"),
+				Listeners: []appmesh.Listener{
+					{
+						PortMapping: appmesh.PortMapping{
+							Port:     8080,
+							Protocol: appmesh.PortProtocolHTTP,
+						},
+						Timeout: &appmesh.ListenerTimeout{
+							HTTP: timeout,
+						},
+					},
+				},
+			},
+		}
+	}
+	perRequest := &appmesh.Duration{Unit: appmesh.DurationUnitMS, Value: 500}
+	idle := &appmesh.Duration{Unit: appmesh.DurationUnitS, Value: 300}
+	tests := []struct {
+		name                    string
+		actualTimeout           *appmesh.HTTPTimeout
+		desiredTimeout          *appmesh.HTTPTimeout
+		wantUpdatedVirtualNodes []string
+		wantUpdatedTimeout      *appmeshsdk.HttpTimeout
+	}{
+		{
+			name:                    "unchanged timeouts don't trigger update",
+			actualTimeout:           &appmesh.HTTPTimeout{PerRequest: perRequest, Idle: idle},
+			desiredTimeout:          &appmesh.HTTPTimeout{PerRequest: perRequest, Idle: idle},
+			wantUpdatedVirtualNodes: nil,
+		},
+		{
+			name:                    "changing only idle timeout keeps perRequest timeout",
+			actualTimeout:           &appmesh.HTTPTimeout{PerRequest: perRequest, Idle: idle},
+			desiredTimeout:          &appmesh.HTTPTimeout{PerRequest: perRequest, Idle: &appmesh.Duration{Unit: appmesh.DurationUnitS, Value: 3600}},
+			wantUpdatedVirtualNodes: []string{"vn-1_my-ns"},
+			wantUpdatedTimeout: &appmeshsdk.HttpTimeout{
+				PerRequest: &appmeshsdk.Duration{Unit: aws.String("ms"), Value: aws.Int64(500)},
+				Idle:       &appmeshsdk.Duration{Unit: aws.String("s"), Value: aws.Int64(3600)},
+			},
+		},
+		{
+			name:                    "changing only perRequest timeout keeps idle timeout",
+			actualTimeout:           &appmesh.HTTPTimeout{PerRequest: perRequest, Idle: idle},
+			desiredTimeout:          &appmesh.HTTPTimeout{PerRequest: &appmesh.Duration{Unit: appmesh.DurationUnitMS, Value: 100}, Idle: idle},
+			wantUpdatedVirtualNodes: []string{"vn-1_my-ns"},
+			wantUpdatedTimeout: &appmeshsdk.HttpTimeout{
+				PerRequest: &appmeshsdk.Duration{Unit: aws.String("ms"), Value: aws.Int64(100)},
+				Idle:       &appmeshsdk.Duration{Unit: aws.String("s"), Value: aws.Int64(300)},
+			},
+		},
+		{
+			name:                    "setting only idle timeout leaves perRequest timeout unset",
+			actualTimeout:           nil,
+			desiredTimeout:          &appmesh.HTTPTimeout{Idle: idle},
+			wantUpdatedVirtualNodes: []string{"vn-1_my-ns"},
+			wantUpdatedTimeout: &appmeshsdk.HttpTimeout{
+				Idle: &appmeshsdk.Duration{Unit: aws.String("s"), Value: aws.Int64(300)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualSDKVNSpec, err := BuildSDKVirtualNodeSpec(vnWithHTTPTimeout(tt.actualTimeout), nil)
+			assert.NoError(t, err)
+			sdkVN := &appmeshsdk.VirtualNodeData{
+				MeshName:        aws.String("my-mesh"),
+				VirtualNodeName: aws.String("vn-1_my-ns"),
+				Metadata: &appmeshsdk.ResourceMetadata{
+					ResourceOwner: aws.String("222222222"),
+				},
+				Spec: actualSDKVNSpec,
+			}
+			appMeshSDK := &fakeAppMesh{sdkVN: sdkVN}
+			m := &defaultResourceManager{
+				appMeshSDK: appMeshSDK,
+				accountID:  "222222222",
+				log:        &log.NullLogger{},
+			}
+			_, err = m.updateSDKVirtualNode(context.Background(), sdkVN, ms, vnWithHTTPTimeout(tt.desiredTimeout), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUpdatedVirtualNodes, appMeshSDK.updatedVirtualNodes)
+			if tt.wantUpdatedTimeout != nil {
+				assert.Equal(t, tt.wantUpdatedTimeout, appMeshSDK.updatedSpecs[0].Listeners[0].Timeout.Http)
+			}
+		})
+	}
+}
+
+func Test_defaultResourceManager_Reconcile_nameCollision(t *testing.T) {
+	ms := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+		Status: appmesh.MeshStatus{
+			Conditions: []appmesh.MeshCondition{
+				{
+					Type:   appmesh.MeshActive,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name                    string
+		policy                  string
+		enableAdoption          bool
+		vnARN                   *string
+		tags                    map[string]string
+		wantTags                map[string]string
+		wantUpdatedVirtualNodes []string
+		wantEvents              []string
+		wantVNARN               *string
+		wantActiveCondition     *appmesh.VirtualNodeCondition
+		wantPodReady            bool
+		wantErr                 error
+	}{
+		{
+			name:                    "unclaimed AppMesh virtualNode reconciled before is claimed",
+			policy:                  NameCollisionPolicyDeny,
+			vnARN:                   aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+			tags:                    nil,
+			wantTags:                map[string]string{"appmesh.k8s.aws/virtualNode": "my-ns/vn-1"},
+			wantUpdatedVirtualNodes: []string{"vn-1_my-ns"},
+			wantVNARN:               aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+			wantActiveCondition: &appmesh.VirtualNodeCondition{
+				Type:   appmesh.VirtualNodeActive,
+				Status: corev1.ConditionTrue,
+			},
+			wantPodReady: true,
+		},
+		{
+			name:                    "unclaimed AppMesh virtualNode isn't adopted by default",
+			policy:                  NameCollisionPolicyDeny,
+			tags:                    nil,
+			wantTags:                nil,
+			wantUpdatedVirtualNodes: nil,
+			wantEvents: []string{
+				"Warning NotAdopted AppMesh virtualNode vn-1_my-ns already exists and isn't claimed by any virtualNode, enable adoption to manage it",
+			},
+			wantErr: errors.New("AppMesh virtualNode vn-1_my-ns already exists and isn't claimed by any virtualNode, enable adoption to manage it"),
+		},
+		{
+			name:                    "unclaimed AppMesh virtualNode is adopted when adoption is enabled",
+			policy:                  NameCollisionPolicyDeny,
+			enableAdoption:          true,
+			tags:                    nil,
+			wantTags:                map[string]string{"appmesh.k8s.aws/virtualNode": "my-ns/vn-1"},
+			wantUpdatedVirtualNodes: []string{"vn-1_my-ns"},
+			wantEvents: []string{
+				"Normal Adopted adopted AppMesh virtualNode vn-1_my-ns",
+			},
+			wantVNARN: aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+			wantActiveCondition: &appmesh.VirtualNodeCondition{
+				Type:   appmesh.VirtualNodeActive,
+				Status: corev1.ConditionTrue,
+			},
+			wantPodReady: true,
+		},
+		{
+			name:                    "adoption doesn't take over AppMesh virtualNode claimed by another virtualNode",
+			policy:                  NameCollisionPolicyDeny,
+			enableAdoption:          true,
+			tags:                    map[string]string{"appmesh.k8s.aws/virtualNode": "other-ns/vn-2"},
+			wantTags:                map[string]string{"appmesh.k8s.aws/virtualNode": "other-ns/vn-2"},
+			wantUpdatedVirtualNodes: nil,
+			wantEvents: []string{
+				"Warning NameCollision AppMesh virtualNode vn-1_my-ns is already claimed by virtualNode other-ns/vn-2",
+			},
+			wantErr: errors.New("AppMesh virtualNode vn-1_my-ns is already claimed by virtualNode other-ns/vn-2"),
+		},
+		{
+			name:                    "AppMesh virtualNode claimed by same virtualNode is updated",
+			policy:                  NameCollisionPolicyDeny,
+			tags:                    map[string]string{"appmesh.k8s.aws/virtualNode": "my-ns/vn-1"},
+			wantTags:                map[string]string{"appmesh.k8s.aws/virtualNode": "my-ns/vn-1"},
+			wantUpdatedVirtualNodes: []string{"vn-1_my-ns"},
+			wantVNARN:               aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+			wantActiveCondition: &appmesh.VirtualNodeCondition{
+				Type:   appmesh.VirtualNodeActive,
+				Status: corev1.ConditionTrue,
+			},
+			wantPodReady: true,
+		},
+		{
+			name:                    "deny policy fails on AppMesh virtualNode claimed by another virtualNode",
+			policy:                  NameCollisionPolicyDeny,
+			tags:                    map[string]string{"appmesh.k8s.aws/virtualNode": "other-ns/vn-2"},
+			wantTags:                map[string]string{"appmesh.k8s.aws/virtualNode": "other-ns/vn-2"},
+			wantUpdatedVirtualNodes: nil,
+			wantEvents: []string{
+				"Warning NameCollision AppMesh virtualNode vn-1_my-ns is already claimed by virtualNode other-ns/vn-2",
+			},
+			wantErr: errors.New("AppMesh virtualNode vn-1_my-ns is already claimed by virtualNode other-ns/vn-2"),
+		},
+		{
+			name:                    "warn policy skips update of AppMesh virtualNode claimed by another virtualNode",
+			policy:                  NameCollisionPolicyWarn,
+			vnARN:                   aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+			tags:                    map[string]string{"appmesh.k8s.aws/virtualNode": "other-ns/vn-2"},
+			wantTags:                map[string]string{"appmesh.k8s.aws/virtualNode": "other-ns/vn-2"},
+			wantUpdatedVirtualNodes: nil,
+			wantEvents: []string{
+				"Warning NameCollision AppMesh virtualNode vn-1_my-ns is already claimed by virtualNode other-ns/vn-2, skipping update",
+			},
+			wantVNARN: nil,
+			wantActiveCondition: &appmesh.VirtualNodeCondition{
+				Type:    appmesh.VirtualNodeActive,
+				Status:  corev1.ConditionFalse,
+				Reason:  aws.String("NameCollision"),
+				Message: aws.String("AppMesh virtualNode vn-1_my-ns is already claimed by virtualNode other-ns/vn-2"),
+			},
+			wantPodReady: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			resolver.EXPECT().ResolveMeshReference(gomock.Any(), gomock.Any()).Return(ms, nil)
+			eventRecorder := record.NewFakeRecorder(10)
+
+			vn := &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "vn-1",
+				},
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("vn-1_my-ns"),
+					MeshRef: &appmesh.MeshReference{
+						Name: "my-mesh",
+					},
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "vn-1"},
+					},
+				},
+				Status: appmesh.VirtualNodeStatus{
+					VirtualNodeARN: tt.vnARN,
+				},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "pod-1",
+					Labels:    map[string]string{"app": "vn-1"},
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: k8s.ConditionAppMeshVirtualNodeReady,
+						},
+					},
+				},
+			}
+			appMeshSDK := &fakeAppMesh{
+				// the spec of vn-2 differs from vn-1, which is what makes both fight over it without detection.
+				sdkVN: &appmeshsdk.VirtualNodeData{
+					MeshName:        aws.String("my-mesh"),
+					VirtualNodeName: aws.String("vn-1_my-ns"),
+					Spec: &appmeshsdk.VirtualNodeSpec{
+						ServiceDiscovery: &appmeshsdk.ServiceDiscovery{
+							Dns: &appmeshsdk.DnsServiceDiscovery{
+								Hostname: aws.String("vn-2.other-ns.svc.cluster.local"),
+							},
+						},
+					},
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn:           aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+						ResourceOwner: aws.String("222222222"),
+					},
+					Status: &appmeshsdk.VirtualNodeStatus{
+						Status: aws.String(appmeshsdk.VirtualNodeStatusCodeActive),
+					},
+				},
+				tags: tt.tags,
+			}
+			m := &defaultResourceManager{
+				k8sClient:          k8sClient,
+				appMeshSDK:         appMeshSDK,
+				referencesResolver: resolver,
+				accountID:          "222222222",
+				cfg:                Config{NameCollisionPolicy: tt.policy, EnableAdoption: tt.enableAdoption},
+				eventRecorder:      eventRecorder,
+				tagsManager:        tagging.NewDefaultManager(appMeshSDK, tagging.Config{PropagateLabelsAsTags: []string{"team"}}, log.NullLogger{}),
+				log:                log.NullLogger{},
+			}
+			err := k8sClient.Create(ctx, vn.DeepCopy())
+			assert.NoError(t, err)
+			err = k8sClient.Create(ctx, pod.DeepCopy())
+			assert.NoError(t, err)
+
+			err = m.Reconcile(ctx, vn)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantTags, appMeshSDK.tags)
+			// tags listed to claim the AppMesh virtualNode are reused to correct drift of propagated tags.
+			assert.Equal(t, 1, appMeshSDK.listTagsCalls)
+			assert.Equal(t, tt.wantUpdatedVirtualNodes, appMeshSDK.updatedVirtualNodes)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+			assert.Equal(t, tt.wantVNARN, vn.Status.VirtualNodeARN)
+			opts := cmpopts.IgnoreTypes(&metav1.Time{})
+			gotActiveCondition := GetCondition(vn, appmesh.VirtualNodeActive)
+			assert.True(t, cmp.Equal(tt.wantActiveCondition, gotActiveCondition, opts), "diff", cmp.Diff(tt.wantActiveCondition, gotActiveCondition, opts))
+			gotPod := &corev1.Pod{}
+			err = k8sClient.Get(ctx, k8s.NamespacedName(pod), gotPod)
+			assert.NoError(t, err)
+			gotPodCondition := k8s.GetPodCondition(gotPod, k8s.ConditionAppMeshVirtualNodeReady)
+			assert.Equal(t, tt.wantPodReady, gotPodCondition != nil && gotPodCondition.Status == corev1.ConditionTrue)
+		})
+	}
+}
+
+func Test_defaultResourceManager_Cleanup_nameCollision(t *testing.T) {
+	ms := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+	}
+	tests := []struct {
+		name                    string
+		vnARN                   *string
+		tags                    map[string]string
+		wantDeletedVirtualNodes []string
+	}{
+		{
+			name:                    "AppMesh virtualNode claimed by same virtualNode is deleted",
+			tags:                    map[string]string{"appmesh.k8s.aws/virtualNode": "my-ns/vn-1"},
+			wantDeletedVirtualNodes: []string{"vn-1_my-ns"},
+		},
+		{
+			name:                    "unclaimed AppMesh virtualNode reconciled before is deleted",
+			vnARN:                   aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+			tags:                    nil,
+			wantDeletedVirtualNodes: []string{"vn-1_my-ns"},
+		},
+		{
+			name:                    "unclaimed AppMesh virtualNode never adopted is kept",
+			tags:                    nil,
+			wantDeletedVirtualNodes: nil,
+		},
+		{
+			name:                    "AppMesh virtualNode claimed by another virtualNode is kept",
+			tags:                    map[string]string{"appmesh.k8s.aws/virtualNode": "other-ns/vn-2"},
+			wantDeletedVirtualNodes: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			resolver.EXPECT().ResolveMeshReference(gomock.Any(), gomock.Any()).Return(ms, nil)
+			appMeshSDK := &fakeAppMesh{
+				sdkVN: &appmeshsdk.VirtualNodeData{
+					MeshName:        aws.String("my-mesh"),
+					VirtualNodeName: aws.String("vn-1_my-ns"),
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn:           aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+						ResourceOwner: aws.String("222222222"),
+					},
+				},
+				tags: tt.tags,
+			}
+			m := &defaultResourceManager{
+				appMeshSDK:         appMeshSDK,
+				referencesResolver: resolver,
+				accountID:          "222222222",
+				log:                log.NullLogger{},
+			}
+			vn := &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "vn-1",
+				},
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("vn-1_my-ns"),
+					MeshRef: &appmesh.MeshReference{
+						Name: "my-mesh",
+					},
+				},
+				Status: appmesh.VirtualNodeStatus{
+					VirtualNodeARN: tt.vnARN,
+				},
+			}
+			err := m.Cleanup(ctx, vn)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDeletedVirtualNodes, appMeshSDK.deletedVirtualNodes)
+		})
+	}
+}
+
+func Test_defaultResourceManager_Reconcile_externallyDeleted(t *testing.T) {
+	ms := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+		Status: appmesh.MeshStatus{
+			Conditions: []appmesh.MeshCondition{
+				{
+					Type:   appmesh.MeshActive,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name                    string
+		policy                  string
+		wantCreatedVirtualNodes []string
+		wantConditions          map[appmesh.VirtualNodeConditionType]corev1.ConditionStatus
+		wantErr                 error
+	}{
+		{
+			name:                    "default policy recreates AppMesh virtualNode",
+			policy:                  "",
+			wantCreatedVirtualNodes: []string{"vn-1_my-ns"},
+			wantConditions: map[appmesh.VirtualNodeConditionType]corev1.ConditionStatus{
+				appmesh.VirtualNodeActive: corev1.ConditionTrue,
+			},
+		},
+		{
+			name:                    "recreate policy recreates AppMesh virtualNode",
+			policy:                  ExternallyDeletedPolicyRecreate,
+			wantCreatedVirtualNodes: []string{"vn-1_my-ns"},
+			wantConditions: map[appmesh.VirtualNodeConditionType]corev1.ConditionStatus{
+				appmesh.VirtualNodeActive: corev1.ConditionTrue,
+			},
+		},
+		{
+			name:                    "skip policy marks virtualNode ExternallyDeleted without recreating",
+			policy:                  ExternallyDeletedPolicySkip,
+			wantCreatedVirtualNodes: nil,
+			wantConditions: map[appmesh.VirtualNodeConditionType]corev1.ConditionStatus{
+				appmesh.VirtualNodeActive:            corev1.ConditionFalse,
+				appmesh.VirtualNodeExternallyDeleted: corev1.ConditionTrue,
+			},
+			wantErr: errors.New("AppMesh virtualNode vn-1_my-ns is deleted externally, it won't be recreated"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			resolver.EXPECT().ResolveMeshReference(gomock.Any(), gomock.Any()).Return(ms, nil)
+
+			vn := &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "vn-1",
+				},
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("vn-1_my-ns"),
+					MeshRef: &appmesh.MeshReference{
+						Name: "my-mesh",
+					},
+				},
+				Status: appmesh.VirtualNodeStatus{
+					VirtualNodeARN: aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+					Conditions: []appmesh.VirtualNodeCondition{
+						{
+							Type:   appmesh.VirtualNodeActive,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			}
+			appMeshSDK := &fakeAppMesh{
+				sdkVN: &appmeshsdk.VirtualNodeData{
+					MeshName:        aws.String("my-mesh"),
+					VirtualNodeName: aws.String("vn-1_my-ns"),
+					Spec:            &appmeshsdk.VirtualNodeSpec{},
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn:           aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/vn-1_my-ns"),
+						ResourceOwner: aws.String("222222222"),
+					},
+					Status: &appmeshsdk.VirtualNodeStatus{
+						Status: aws.String(appmeshsdk.VirtualNodeStatusCodeDeleted),
+					},
+				},
+			}
+			m := &defaultResourceManager{
+				k8sClient:          k8sClient,
+				appMeshSDK:         appMeshSDK,
+				referencesResolver: resolver,
+				accountID:          "222222222",
+				cfg:                Config{ExternallyDeletedPolicy: tt.policy},
+				eventRecorder:      record.NewFakeRecorder(10),
+				tagsManager:        tagging.NewDefaultManager(appMeshSDK, tagging.Config{}, log.NullLogger{}),
+				log:                log.NullLogger{},
+			}
+			err := k8sClient.Create(ctx, vn.DeepCopy())
+			assert.NoError(t, err)
+
+			err = m.Reconcile(ctx, vn)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCreatedVirtualNodes, appMeshSDK.createdVirtualNodes)
+			assert.Nil(t, appMeshSDK.updatedVirtualNodes)
+
+			gotVN := &appmesh.VirtualNode{}
+			err = k8sClient.Get(ctx, k8s.NamespacedName(vn), gotVN)
+			assert.NoError(t, err)
+			gotConditions := make(map[appmesh.VirtualNodeConditionType]corev1.ConditionStatus)
+			for _, condition := range gotVN.Status.Conditions {
+				gotConditions[condition.Type] = condition.Status
+			}
+			assert.Equal(t, tt.wantConditions, gotConditions)
+		})
+	}
+}
+
+func Test_applyCloudMapNamespaceName(t *testing.T) {
+	tests := []struct {
+		name              string
+		vn                *appmesh.VirtualNode
+		wantNamespaceName string
+		wantErr           error
+	}{
+		{
+			name: "namespace referenced by name",
+			vn: &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					ServiceDiscovery: &appmesh.ServiceDiscovery{
+						AWSCloudMap: &appmesh.AWSCloudMapServiceDiscovery{
+							NamespaceName: "my-ns.local",
+						},
+					},
+				},
+			},
+			wantNamespaceName: "my-ns.local",
+		},
+		{
+			name: "name of namespace referenced by ID is resolved",
+			vn: &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					ServiceDiscovery: &appmesh.ServiceDiscovery{
+						AWSCloudMap: &appmesh.AWSCloudMapServiceDiscovery{
+							NamespaceID: "ns-1",
+						},
+					},
+				},
+				Status: appmesh.VirtualNodeStatus{
+					CloudMapNamespaceName: aws.String("my-ns.local"),
+				},
+			},
+			wantNamespaceName: "my-ns.local",
+		},
+		{
+			name: "name of namespace referenced by ID isn't resolved yet",
+			vn: &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					ServiceDiscovery: &appmesh.ServiceDiscovery{
+						AWSCloudMap: &appmesh.AWSCloudMapServiceDiscovery{
+							NamespaceID: "ns-1",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("cloudMap namespace ns-1 isn't resolved yet"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCloudMapNamespaceDependency(tt.vn)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			got := applyCloudMapNamespaceName(tt.vn)
+			assert.Equal(t, tt.wantNamespaceName, got.Spec.ServiceDiscovery.AWSCloudMap.NamespaceName)
+		})
+	}
+}
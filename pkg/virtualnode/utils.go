@@ -3,6 +3,8 @@ package virtualnode
 import (
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"strings"
 )
 
 // IsVirtualNodeActive checks whether given virtualNode is active.
@@ -15,3 +17,16 @@ func IsVirtualNodeActive(vn *appmesh.VirtualNode) bool {
 	}
 	return false
 }
+
+// ExtractDNSServiceKey returns the key of the k8s Service behind virtualNode's DNS hostname.
+// only cluster-local hostnames in the format of <service>.<namespace>.svc[.<cluster-domain>] refer to a k8s Service.
+func ExtractDNSServiceKey(vn *appmesh.VirtualNode) (types.NamespacedName, bool) {
+	if vn.Spec.ServiceDiscovery == nil || vn.Spec.ServiceDiscovery.DNS == nil {
+		return types.NamespacedName{}, false
+	}
+	parts := strings.Split(strings.TrimSuffix(vn.Spec.ServiceDiscovery.DNS.Hostname, "."), ".")
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" || parts[2] != "svc" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: parts[1], Name: parts[0]}, true
+}
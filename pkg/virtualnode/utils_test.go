@@ -4,6 +4,7 @@ import (
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"gotest.tools/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"testing"
 )
 
@@ -83,3 +84,57 @@ func TestIsVirtualNodeActive(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractDNSServiceKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		hostname string
+		want     types.NamespacedName
+		wantOK   bool
+	}{
+		{
+			name:     "fully qualified service hostname",
+			hostname: "my-svc.my-ns.svc.cluster.local",
+			want:     types.NamespacedName{Namespace: "my-ns", Name: "my-svc"},
+			wantOK:   true,
+		},
+		{
+			name:     "service hostname with trailing dot",
+			hostname: "my-svc.my-ns.svc.cluster.local.",
+			want:     types.NamespacedName{Namespace: "my-ns", Name: "my-svc"},
+			wantOK:   true,
+		},
+		{
+			name:     "service hostname without cluster domain",
+			hostname: "my-svc.my-ns.svc",
+			want:     types.NamespacedName{Namespace: "my-ns", Name: "my-svc"},
+			wantOK:   true,
+		},
+		{
+			name:     "short service hostname",
+			hostname: "my-svc.my-ns",
+			wantOK:   false,
+		},
+		{
+			name:     "external hostname",
+			hostname: "www.example.com",
+			wantOK:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vn := &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					ServiceDiscovery: &appmesh.ServiceDiscovery{
+						DNS: &appmesh.DNSServiceDiscovery{
+							Hostname: tt.hostname,
+						},
+					},
+				},
+			}
+			got, gotOK := ExtractDNSServiceKey(vn)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, gotOK)
+		})
+	}
+}
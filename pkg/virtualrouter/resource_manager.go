@@ -2,6 +2,7 @@ package virtualrouter
 
 import (
 	"context"
+	"fmt"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/conversions"
@@ -9,6 +10,7 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/tagging"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -33,7 +35,7 @@ type ResourceManager interface {
 }
 
 func NewDefaultResourceManager(k8sClient client.Client, appMeshSDK services.AppMesh, referencesResolver references.Resolver,
-	accountID string, log logr.Logger) ResourceManager {
+	accountID string, tagsManager tagging.Manager, log logr.Logger) ResourceManager {
 	routesManager := newDefaultRoutesManager(appMeshSDK, log)
 	return &defaultResourceManager{
 		k8sClient:          k8sClient,
@@ -41,6 +43,7 @@ func NewDefaultResourceManager(k8sClient client.Client, appMeshSDK services.AppM
 		referencesResolver: referencesResolver,
 		routesManager:      routesManager,
 		accountID:          accountID,
+		tagsManager:        tagsManager,
 		log:                log,
 	}
 }
@@ -51,15 +54,19 @@ type defaultResourceManager struct {
 	referencesResolver references.Resolver
 	routesManager      routesManager
 	accountID          string
+	tagsManager        tagging.Manager
 	log                logr.Logger
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, vr *appmesh.VirtualRouter) error {
+	if k8s.IsReconciliationPaused(vr) {
+		return m.updateCRDVirtualRouterReconciliationPaused(ctx, vr)
+	}
 	ms, err := m.findMeshDependency(ctx, vr)
 	if err != nil {
 		return err
 	}
-	if err := m.validateMeshDependencies(ctx, ms); err != nil {
+	if err := m.validateMeshDependencies(ctx, ms, vr); err != nil {
 		return err
 	}
 	vnByKey, err := m.findVirtualNodeDependencies(ctx, vr)
@@ -89,6 +96,9 @@ func (m *defaultResourceManager) Reconcile(ctx context.Context, vr *appmesh.Virt
 		if err != nil {
 			return err
 		}
+		if err := m.updateSDKVirtualRouterTags(ctx, sdkVR, vr); err != nil {
+			return err
+		}
 		sdkRouteByName, err = m.routesManager.update(ctx, ms, vr, vnByKey)
 		if err != nil {
 			return err
@@ -113,6 +123,10 @@ func (m *defaultResourceManager) Cleanup(ctx context.Context, vr *appmesh.Virtua
 	if sdkVR == nil {
 		return nil
 	}
+	if k8s.IsDeletionPolicyOrphan(vr) {
+		m.sdkVirtualRouterLogger(sdkVR, vr).V(1).Info("skip virtualRouter deletion since its deletionPolicy is orphan")
+		return nil
+	}
 	if err := m.routesManager.cleanup(ctx, ms, vr); err != nil {
 		return err
 	}
@@ -132,8 +146,12 @@ func (m *defaultResourceManager) findMeshDependency(ctx context.Context, vr *app
 }
 
 // validateMeshDependencies validate the Mesh dependency for this VirtualRouter.
-func (m *defaultResourceManager) validateMeshDependencies(ctx context.Context, ms *appmesh.Mesh) error {
+// VirtualRouter is marked as WaitingForMesh and requeued with backoff until the mesh becomes active.
+func (m *defaultResourceManager) validateMeshDependencies(ctx context.Context, ms *appmesh.Mesh, vr *appmesh.VirtualRouter) error {
 	if !mesh.IsMeshActive(ms) {
+		if err := m.updateCRDVirtualRouterWaitingForMesh(ctx, vr, ms); err != nil {
+			return err
+		}
 		return runtime.NewRequeueError(errors.New("mesh is not active yet"))
 	}
 	return nil
@@ -194,6 +212,7 @@ func (m *defaultResourceManager) createSDKVirtualRouter(ctx context.Context, ms
 		MeshOwner:         ms.Spec.MeshOwner,
 		VirtualRouterName: vr.Spec.AWSName,
 		Spec:              sdkVRSpec,
+		Tags:              m.tagsManager.BuildSDKTags(vr),
 	})
 	if err != nil {
 		return nil, err
@@ -213,16 +232,12 @@ func (m *defaultResourceManager) updateSDKVirtualRouter(ctx context.Context, sdk
 		return sdkVR, nil
 	}
 	if !m.isSDKVirtualRouterControlledByCRDVirtualRouter(ctx, sdkVR, vr) {
-		m.log.V(1).Info("skip virtualRouter update since it's not controlled",
-			"virtualRouter", k8s.NamespacedName(vr),
-			"virtualRouterARN", aws.StringValue(sdkVR.Metadata.Arn),
-		)
+		m.sdkVirtualRouterLogger(sdkVR, vr).V(1).Info("skip virtualRouter update since it's not controlled")
 		return sdkVR, nil
 	}
 
 	diff := cmp.Diff(desiredSDKVRSpec, actualSDKVRSpec, opts)
-	m.log.V(1).Info("virtualRouterSpec changed",
-		"virtualRouter", k8s.NamespacedName(vr),
+	m.sdkVirtualRouterLogger(sdkVR, vr).V(1).Info("virtualRouterSpec changed",
 		"actualSDKVRSpec", actualSDKVRSpec,
 		"desiredSDKVRSpec", desiredSDKVRSpec,
 		"diff", diff,
@@ -241,10 +256,7 @@ func (m *defaultResourceManager) updateSDKVirtualRouter(ctx context.Context, sdk
 
 func (m *defaultResourceManager) deleteSDKVirtualRouter(ctx context.Context, sdkVR *appmeshsdk.VirtualRouterData, vr *appmesh.VirtualRouter) error {
 	if !m.isSDKVirtualRouterOwnedByCRDVirtualRouter(ctx, sdkVR, vr) {
-		m.log.V(1).Info("skip virtualRouter deletion since its not owned",
-			"virtualRouter", k8s.NamespacedName(vr),
-			"virtualRouterARN", aws.StringValue(sdkVR.Metadata.Arn),
-		)
+		m.sdkVirtualRouterLogger(sdkVR, vr).V(1).Info("skip virtualRouter deletion since its not owned")
 		return nil
 	}
 	_, err := m.appMeshSDK.DeleteVirtualRouterWithContext(ctx, &appmeshsdk.DeleteVirtualRouterInput{
@@ -252,10 +264,7 @@ func (m *defaultResourceManager) deleteSDKVirtualRouter(ctx context.Context, sdk
 		MeshOwner:         sdkVR.Metadata.MeshOwner,
 		VirtualRouterName: sdkVR.VirtualRouterName,
 	})
-	if err != nil {
-		return err
-	}
-	return nil
+	return services.IgnoreAppMeshNotFound(err)
 }
 
 func (m *defaultResourceManager) updateCRDVirtualRouter(ctx context.Context, vr *appmesh.VirtualRouter, sdkVR *appmeshsdk.VirtualRouterData, sdkRouteByName map[string]*appmeshsdk.RouteData) error {
@@ -275,7 +284,8 @@ func (m *defaultResourceManager) updateCRDVirtualRouter(ctx context.Context, vr
 	for name, sdkRoute := range sdkRouteByName {
 		routeARNByName[name] = aws.StringValue(sdkRoute.Metadata.Arn)
 	}
-	if !cmp.Equal(vr.Status.RouteARNs, routeARNByName) {
+	// a VirtualRouter without routes reads back with nil routeARNs, which shouldn't trigger an update.
+	if !cmp.Equal(vr.Status.RouteARNs, routeARNByName, cmpopts.EquateEmpty()) {
 		vr.Status.RouteARNs = routeARNByName
 		needsUpdate = true
 	}
@@ -287,6 +297,14 @@ func (m *defaultResourceManager) updateCRDVirtualRouter(ctx context.Context, vr
 	if updateCondition(vr, appmesh.VirtualRouterActive, vrActiveConditionStatus, nil, nil) {
 		needsUpdate = true
 	}
+	if getCondition(vr, appmesh.VirtualRouterReconciliationPaused) != nil &&
+		updateCondition(vr, appmesh.VirtualRouterReconciliationPaused, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
+	if getCondition(vr, appmesh.VirtualRouterWaitingForMesh) != nil &&
+		updateCondition(vr, appmesh.VirtualRouterWaitingForMesh, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
 
 	if !needsUpdate {
 		return nil
@@ -294,6 +312,42 @@ func (m *defaultResourceManager) updateCRDVirtualRouter(ctx context.Context, vr
 	return m.k8sClient.Status().Patch(ctx, vr, client.MergeFrom(oldVR))
 }
 
+// updateCRDVirtualRouterReconciliationPaused records the ReconciliationPaused condition without touching the AppMesh VirtualRouter.
+func (m *defaultResourceManager) updateCRDVirtualRouterReconciliationPaused(ctx context.Context, vr *appmesh.VirtualRouter) error {
+	oldVR := vr.DeepCopy()
+	if !updateCondition(vr, appmesh.VirtualRouterReconciliationPaused, corev1.ConditionTrue, nil, nil) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, vr, client.MergeFrom(oldVR))
+}
+
+// updateCRDVirtualRouterWaitingForMesh records the WaitingForMesh condition while the mesh of vr isn't active.
+func (m *defaultResourceManager) updateCRDVirtualRouterWaitingForMesh(ctx context.Context, vr *appmesh.VirtualRouter, ms *appmesh.Mesh) error {
+	oldVR := vr.DeepCopy()
+	message := fmt.Sprintf("mesh %s is not active yet", ms.Name)
+	if !updateCondition(vr, appmesh.VirtualRouterWaitingForMesh, corev1.ConditionTrue, nil, aws.String(message)) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, vr, client.MergeFrom(oldVR))
+}
+
+// sdkVirtualRouterLogger returns a logger carrying the identity of vr and its AppMesh VirtualRouter.
+func (m *defaultResourceManager) sdkVirtualRouterLogger(sdkVR *appmeshsdk.VirtualRouterData, vr *appmesh.VirtualRouter) logr.Logger {
+	return m.log.WithValues(
+		"meshName", aws.StringValue(sdkVR.MeshName),
+		"virtualRouter", k8s.NamespacedName(vr),
+		"arn", aws.StringValue(sdkVR.Metadata.Arn),
+	)
+}
+
+// updateSDKVirtualRouterTags corrects drift of the tags propagated from labels of vr on its AppMesh VirtualRouter.
+func (m *defaultResourceManager) updateSDKVirtualRouterTags(ctx context.Context, sdkVR *appmeshsdk.VirtualRouterData, vr *appmesh.VirtualRouter) error {
+	if !m.isSDKVirtualRouterControlledByCRDVirtualRouter(ctx, sdkVR, vr) {
+		return nil
+	}
+	return m.tagsManager.ReconcileSDKTags(ctx, aws.StringValue(sdkVR.Metadata.Arn), vr)
+}
+
 // isSDKVirtualRouterControlledByCRDVirtualRouter checks whether an AppMesh virtualRouter is controlled by CRD VirtualRouter.
 // if it's controlled, CRD VirtualRouter update is responsible for updating the AppMesh virtualRouter.
 func (m *defaultResourceManager) isSDKVirtualRouterControlledByCRDVirtualRouter(ctx context.Context, sdkVR *appmeshsdk.VirtualRouterData, vr *appmesh.VirtualRouter) bool {
@@ -4,9 +4,14 @@ import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	mock_resolver "github.com/aws/aws-app-mesh-controller-for-k8s/mocks/aws-app-mesh-controller-for-k8s/pkg/references"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/equality"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	appmeshruntime "github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/tagging"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
@@ -192,6 +197,68 @@ func Test_defaultResourceManager_updateCRDVirtualRouter(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "virtualRouter resumed after pause needs patch ReconciliationPaused condition",
+			args: args{
+				vr: &appmesh.VirtualRouter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vr-1",
+					},
+					Status: appmesh.VirtualRouterStatus{
+						VirtualRouterARN: aws.String("arn-1"),
+						RouteARNs: map[string]string{
+							"route-1": "route-arn-1",
+						},
+						Conditions: []appmesh.VirtualRouterCondition{
+							{
+								Type:   appmesh.VirtualRouterActive,
+								Status: corev1.ConditionTrue,
+							},
+							{
+								Type:   appmesh.VirtualRouterReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+				sdkVR: &appmeshsdk.VirtualRouterData{
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn: aws.String("arn-1"),
+					},
+					Status: &appmeshsdk.VirtualRouterStatus{
+						Status: aws.String(appmeshsdk.VirtualRouterStatusCodeActive),
+					},
+				},
+				sdkRouteByName: map[string]*appmeshsdk.RouteData{
+					"route-1": {
+						Metadata: &appmeshsdk.ResourceMetadata{
+							Arn: aws.String("route-arn-1"),
+						},
+					},
+				},
+			},
+			wantVR: &appmesh.VirtualRouter{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vr-1",
+				},
+				Status: appmesh.VirtualRouterStatus{
+					VirtualRouterARN: aws.String("arn-1"),
+					RouteARNs: map[string]string{
+						"route-1": "route-arn-1",
+					},
+					Conditions: []appmesh.VirtualRouterCondition{
+						{
+							Type:   appmesh.VirtualRouterActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.VirtualRouterReconciliationPaused,
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -225,6 +292,136 @@ func Test_defaultResourceManager_updateCRDVirtualRouter(t *testing.T) {
 	}
 }
 
+func Test_defaultResourceManager_Reconcile_paused(t *testing.T) {
+	type args struct {
+		vr *appmesh.VirtualRouter
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantVR  *appmesh.VirtualRouter
+		wantErr error
+	}{
+		{
+			name: "paused virtualRouter records ReconciliationPaused condition",
+			args: args{
+				vr: &appmesh.VirtualRouter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vr-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.VirtualRouterStatus{
+						VirtualRouterARN: aws.String("arn-1"),
+						Conditions: []appmesh.VirtualRouterCondition{
+							{
+								Type:   appmesh.VirtualRouterActive,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantVR: &appmesh.VirtualRouter{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vr-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.VirtualRouterStatus{
+					VirtualRouterARN: aws.String("arn-1"),
+					Conditions: []appmesh.VirtualRouterCondition{
+						{
+							Type:   appmesh.VirtualRouterActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.VirtualRouterReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "paused virtualRouter already has ReconciliationPaused condition",
+			args: args{
+				vr: &appmesh.VirtualRouter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vr-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.VirtualRouterStatus{
+						VirtualRouterARN: aws.String("arn-1"),
+						Conditions: []appmesh.VirtualRouterCondition{
+							{
+								Type:   appmesh.VirtualRouterReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantVR: &appmesh.VirtualRouter{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vr-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.VirtualRouterStatus{
+					VirtualRouterARN: aws.String("arn-1"),
+					Conditions: []appmesh.VirtualRouterCondition{
+						{
+							Type:   appmesh.VirtualRouterReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			// neither referencesResolver nor appMeshSDK expect any calls while paused.
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			m := &defaultResourceManager{
+				k8sClient:          k8sClient,
+				referencesResolver: resolver,
+				log:                log.NullLogger{},
+			}
+
+			err := k8sClient.Create(ctx, tt.args.vr.DeepCopy())
+			assert.NoError(t, err)
+			err = m.Reconcile(ctx, tt.args.vr)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				gotVR := &appmesh.VirtualRouter{}
+				err = k8sClient.Get(ctx, k8s.NamespacedName(tt.args.vr), gotVR)
+				assert.NoError(t, err)
+				opts := cmp.Options{
+					equality.IgnoreFakeClientPopulatedFields(),
+					cmpopts.IgnoreTypes((*metav1.Time)(nil)),
+				}
+				assert.True(t, cmp.Equal(tt.wantVR, gotVR, opts), "diff", cmp.Diff(tt.wantVR, gotVR, opts))
+			}
+		})
+	}
+}
+
 func Test_defaultResourceManager_isSDKVirtualRouterControlledByCRDVirtualRouter(t *testing.T) {
 	type fields struct {
 		accountID string
@@ -586,15 +783,38 @@ func Test_defaultResourceManager_validateMeshDependency(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
 			m := &defaultResourceManager{
-				log: log.NullLogger{},
+				k8sClient: k8sClient,
+				log:       log.NullLogger{},
 			}
+			vr := &appmesh.VirtualRouter{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "my-vr",
+				},
+			}
+			err := k8sClient.Create(ctx, vr.DeepCopy())
+			assert.NoError(t, err)
 
-			err := m.validateMeshDependencies(ctx, tt.args.mesh)
+			err = m.validateMeshDependencies(ctx, tt.args.mesh, vr)
+			gotVR := &appmesh.VirtualRouter{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(vr), gotVR))
+			waitingForMesh := getCondition(gotVR, appmesh.VirtualRouterWaitingForMesh)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
+				var requeueErr *appmeshruntime.RequeueError
+				assert.True(t, errors.As(err, &requeueErr), "vr must be requeued while the mesh isn't active")
+				if assert.NotNil(t, waitingForMesh) {
+					assert.Equal(t, corev1.ConditionTrue, waitingForMesh.Status)
+					assert.Equal(t, "mesh my-mesh is not active yet", aws.StringValue(waitingForMesh.Message))
+				}
 			} else {
 				assert.NoError(t, err)
+				assert.Nil(t, waitingForMesh)
 			}
 		})
 	}
@@ -762,3 +982,166 @@ func Test_defaultResourceManager_findVirtualNodeDependencies(t *testing.T) {
 		})
 	}
 }
+
+// fakeAppMesh serves a single AppMesh VirtualRouter without routes, and fails deletions with deleteErr.
+type fakeAppMesh struct {
+	services.AppMesh
+	sdkVR     *appmeshsdk.VirtualRouterData
+	deleteErr error
+}
+
+func (f *fakeAppMesh) DescribeVirtualRouterWithContext(_ context.Context, _ *appmeshsdk.DescribeVirtualRouterInput, _ ...request.Option) (*appmeshsdk.DescribeVirtualRouterOutput, error) {
+	if f.sdkVR == nil {
+		return nil, awserr.New("NotFoundException", "virtualRouter not found", nil)
+	}
+	return &appmeshsdk.DescribeVirtualRouterOutput{VirtualRouter: f.sdkVR}, nil
+}
+
+func (f *fakeAppMesh) CreateVirtualRouterWithContext(_ context.Context, input *appmeshsdk.CreateVirtualRouterInput, _ ...request.Option) (*appmeshsdk.CreateVirtualRouterOutput, error) {
+	f.sdkVR = &appmeshsdk.VirtualRouterData{
+		MeshName:          input.MeshName,
+		VirtualRouterName: input.VirtualRouterName,
+		Spec:              input.Spec,
+		Metadata: &appmeshsdk.ResourceMetadata{
+			Arn:           aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualRouter/vr-1_my-ns"),
+			MeshOwner:     aws.String("222222222"),
+			ResourceOwner: aws.String("222222222"),
+		},
+		Status: &appmeshsdk.VirtualRouterStatus{
+			Status: aws.String(appmeshsdk.VirtualRouterStatusCodeActive),
+		},
+	}
+	return &appmeshsdk.CreateVirtualRouterOutput{VirtualRouter: f.sdkVR}, nil
+}
+
+func (f *fakeAppMesh) ListRoutesPagesWithContext(_ context.Context, _ *appmeshsdk.ListRoutesInput, fn func(*appmeshsdk.ListRoutesOutput, bool) bool, _ ...request.Option) error {
+	fn(&appmeshsdk.ListRoutesOutput{}, true)
+	return nil
+}
+
+func (f *fakeAppMesh) DeleteRouteWithContext(_ context.Context, _ *appmeshsdk.DeleteRouteInput, _ ...request.Option) (*appmeshsdk.DeleteRouteOutput, error) {
+	if f.deleteErr != nil {
+		return nil, f.deleteErr
+	}
+	return &appmeshsdk.DeleteRouteOutput{}, nil
+}
+
+func (f *fakeAppMesh) DeleteVirtualRouterWithContext(_ context.Context, _ *appmeshsdk.DeleteVirtualRouterInput, _ ...request.Option) (*appmeshsdk.DeleteVirtualRouterOutput, error) {
+	if f.deleteErr != nil {
+		return nil, f.deleteErr
+	}
+	return &appmeshsdk.DeleteVirtualRouterOutput{}, nil
+}
+
+func Test_defaultResourceManager_Reconcile_withoutRoutes(t *testing.T) {
+	ms := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+		Status: appmesh.MeshStatus{
+			Conditions: []appmesh.MeshCondition{
+				{
+					Type:   appmesh.MeshActive,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	vr := &appmesh.VirtualRouter{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "vr-1",
+		},
+		Spec: appmesh.VirtualRouterSpec{
+			AWSName: aws.String("vr-1_my-ns"),
+			Listeners: []appmesh.VirtualRouterListener{
+				{
+					PortMapping: appmesh.PortMapping{
+						Port:     8080,
+						Protocol: appmesh.PortProtocolHTTP,
+					},
+				},
+			},
+			MeshRef: &appmesh.MeshReference{
+				Name: "my-mesh",
+			},
+		},
+	}
+
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	appmesh.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	resolver := mock_resolver.NewMockResolver(ctrl)
+	resolver.EXPECT().ResolveMeshReference(gomock.Any(), *vr.Spec.MeshRef).Return(ms, nil).Times(2)
+	appMeshSDK := &fakeAppMesh{}
+	m := NewDefaultResourceManager(k8sClient, appMeshSDK, resolver, "222222222", tagging.NewDefaultManager(appMeshSDK, tagging.Config{}, log.NullLogger{}), log.NullLogger{})
+	assert.NoError(t, k8sClient.Create(ctx, vr.DeepCopy()))
+
+	// the first reconcile creates AppMesh VirtualRouter without any routes.
+	assert.NoError(t, m.Reconcile(ctx, vr.DeepCopy()))
+	gotVR := &appmesh.VirtualRouter{}
+	assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(vr), gotVR))
+	assert.Equal(t, aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualRouter/vr-1_my-ns"), gotVR.Status.VirtualRouterARN)
+	assert.Empty(t, gotVR.Status.RouteARNs)
+	activeCondition := getCondition(gotVR, appmesh.VirtualRouterActive)
+	if assert.NotNil(t, activeCondition) {
+		assert.Equal(t, corev1.ConditionTrue, activeCondition.Status)
+	}
+
+	// the following reconcile finds nothing to change.
+	assert.NoError(t, m.Reconcile(ctx, gotVR.DeepCopy()))
+	reconciledVR := &appmesh.VirtualRouter{}
+	assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(vr), reconciledVR))
+	assert.Equal(t, gotVR.ResourceVersion, reconciledVR.ResourceVersion)
+}
+
+func Test_defaultResourceManager_deleteSDKVirtualRouter(t *testing.T) {
+	sdkVirtualRouter := &appmeshsdk.VirtualRouterData{
+		MeshName:          aws.String("my-mesh"),
+		VirtualRouterName: aws.String("my-virtualrouter"),
+		Metadata: &appmeshsdk.ResourceMetadata{
+			MeshOwner:     aws.String("222222222"),
+			ResourceOwner: aws.String("222222222"),
+		},
+	}
+	tests := []struct {
+		name      string
+		deleteErr error
+		wantErr   error
+	}{
+		{
+			name: "AppMesh virtualRouter deleted",
+		},
+		{
+			name:      "AppMesh virtualRouter deleted out-of-band",
+			deleteErr: awserr.New("NotFoundException", "virtualRouter not found", nil),
+		},
+		{
+			name:      "AppMesh virtualRouter deletion fails",
+			deleteErr: awserr.New("ResourceInUseException", "virtualRouter in use", nil),
+			wantErr:   errors.New("ResourceInUseException: virtualRouter in use"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				appMeshSDK: &fakeAppMesh{deleteErr: tt.deleteErr},
+				accountID:  "222222222",
+				log:        &log.NullLogger{},
+			}
+			err := m.deleteSDKVirtualRouter(context.Background(), sdkVirtualRouter, &appmesh.VirtualRouter{})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
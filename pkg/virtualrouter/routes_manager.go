@@ -5,6 +5,7 @@ import (
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/conversions"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/equality"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
 	"github.com/aws/aws-sdk-go/aws"
@@ -12,7 +13,6 @@ import (
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/conversion"
 	"k8s.io/apimachinery/pkg/types"
@@ -167,7 +167,7 @@ func (m *defaultRoutesManager) updateSDKRoute(ctx context.Context, sdkRoute *app
 		return nil, err
 	}
 
-	opts := cmpopts.EquateEmpty()
+	opts := equality.CompareOptionForRouteSpec()
 	if cmp.Equal(desiredSDKRouteSpec, actualSDKRouteSpec, opts) {
 		return sdkRoute, nil
 	}
@@ -199,10 +199,7 @@ func (m *defaultRoutesManager) deleteSDKRoute(ctx context.Context, sdkRoute *app
 		VirtualRouterName: sdkRoute.VirtualRouterName,
 		RouteName:         sdkRoute.RouteName,
 	})
-	if err != nil {
-		return err
-	}
-	return nil
+	return services.IgnoreAppMeshNotFound(err)
 }
 
 type routeAndSDKRouteRef struct {
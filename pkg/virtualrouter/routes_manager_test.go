@@ -1,12 +1,16 @@
 package virtualrouter
 
 import (
+	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -706,3 +710,47 @@ func Test_BuildSDKRouteSpec(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultRoutesManager_deleteSDKRoute(t *testing.T) {
+	sdkRoute := &appmeshsdk.RouteData{
+		MeshName:          aws.String("my-mesh"),
+		VirtualRouterName: aws.String("my-virtualrouter"),
+		RouteName:         aws.String("my-route"),
+		Metadata: &appmeshsdk.ResourceMetadata{
+			MeshOwner:     aws.String("222222222"),
+			ResourceOwner: aws.String("222222222"),
+		},
+	}
+	tests := []struct {
+		name      string
+		deleteErr error
+		wantErr   error
+	}{
+		{
+			name: "AppMesh route deleted",
+		},
+		{
+			name:      "AppMesh route deleted out-of-band",
+			deleteErr: awserr.New("NotFoundException", "route not found", nil),
+		},
+		{
+			name:      "AppMesh route deletion fails",
+			deleteErr: awserr.New("BadRequestException", "bad request", nil),
+			wantErr:   errors.New("BadRequestException: bad request"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultRoutesManager{
+				appMeshSDK: &fakeAppMesh{deleteErr: tt.deleteErr},
+				log:        &log.NullLogger{},
+			}
+			err := m.deleteSDKRoute(context.Background(), sdkRoute)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package virtualservice
+
+import (
+	"strconv"
+	"strings"
+
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// AnnotationCanaryWeights splits the traffic of a VirtualService between two VirtualNodes in its namespace,
+	// in the format of <stableVirtualNode>=<weight>,<canaryVirtualNode>=<weight>.
+	// The weights are applied to routes of the VirtualRouter provider that only target these two VirtualNodes.
+	AnnotationCanaryWeights = "appmesh.k8s.aws/canaryWeights"
+
+	canaryWeightsFormat = "<stableVirtualNode>=<weight>,<canaryVirtualNode>=<weight>"
+	maxCanaryWeight     = 100
+)
+
+// CanaryTarget is a VirtualNode of a canary weight split along with its weight.
+type CanaryTarget struct {
+	VirtualNodeName string
+	Weight          int64
+}
+
+// ParseCanaryWeights parses the canary weight split of a VirtualService from its annotation.
+// returns nil if vs doesn't specify canary weights.
+func ParseCanaryWeights(vs *appmesh.VirtualService) ([]CanaryTarget, error) {
+	v, ok := vs.Annotations[AnnotationCanaryWeights]
+	if !ok {
+		return nil, nil
+	}
+	segments := strings.Split(v, ",")
+	if len(segments) != 2 {
+		return nil, errors.Errorf("malformed annotation %s: %s, expected format: %s", AnnotationCanaryWeights, v, canaryWeightsFormat)
+	}
+	targets := make([]CanaryTarget, 0, len(segments))
+	var totalWeight int64
+	for _, segment := range segments {
+		pair := strings.Split(segment, "=")
+		if len(pair) != 2 || strings.TrimSpace(pair[0]) == "" {
+			return nil, errors.Errorf("malformed annotation %s: %s, expected format: %s", AnnotationCanaryWeights, v, canaryWeightsFormat)
+		}
+		weight, err := strconv.ParseInt(strings.TrimSpace(pair[1]), 10, 64)
+		if err != nil || weight < 0 || weight > maxCanaryWeight {
+			return nil, errors.Errorf("invalid annotation %s: weight %s of virtualNode %s must be an integer between 0 and %d",
+				AnnotationCanaryWeights, strings.TrimSpace(pair[1]), strings.TrimSpace(pair[0]), maxCanaryWeight)
+		}
+		targets = append(targets, CanaryTarget{VirtualNodeName: strings.TrimSpace(pair[0]), Weight: weight})
+		totalWeight += weight
+	}
+	if targets[0].VirtualNodeName == targets[1].VirtualNodeName {
+		return nil, errors.Errorf("invalid annotation %s: stable and canary virtualNode must be different", AnnotationCanaryWeights)
+	}
+	if totalWeight == 0 {
+		return nil, errors.Errorf("invalid annotation %s: weights can't all be zero", AnnotationCanaryWeights)
+	}
+	return targets, nil
+}
+
+// BuildCanaryRoutes returns routes of vr with the canary weight split of vs applied.
+// Only routes whose weighted targets all reference the canary VirtualNodes are changed, it's an error if there is none.
+func BuildCanaryRoutes(vs *appmesh.VirtualService, vr *appmesh.VirtualRouter, targets []CanaryTarget) ([]appmesh.Route, error) {
+	canaryVNKeys := make(map[types.NamespacedName]bool, len(targets))
+	canaryWeightedTargets := make([]appmesh.WeightedTarget, 0, len(targets))
+	for _, target := range targets {
+		vnRef := appmesh.VirtualNodeReference{Name: target.VirtualNodeName}
+		if vs.Namespace != vr.Namespace {
+			vnNamespace := vs.Namespace
+			vnRef.Namespace = &vnNamespace
+		}
+		canaryVNKeys[references.ObjectKeyForVirtualNodeReference(vr, vnRef)] = true
+		canaryWeightedTargets = append(canaryWeightedTargets, appmesh.WeightedTarget{
+			VirtualNodeRef: &vnRef,
+			Weight:         target.Weight,
+		})
+	}
+
+	routes := make([]appmesh.Route, 0, len(vr.Spec.Routes))
+	matchedRoutes := 0
+	for _, route := range vr.Spec.Routes {
+		route := *route.DeepCopy()
+		weightedTargets := routeWeightedTargets(&route)
+		if weightedTargets != nil && isCanaryWeightedTargets(vr, *weightedTargets, canaryVNKeys) {
+			*weightedTargets = append([]appmesh.WeightedTarget(nil), canaryWeightedTargets...)
+			matchedRoutes++
+		}
+		routes = append(routes, route)
+	}
+	if matchedRoutes == 0 {
+		return nil, errors.Errorf("no route of virtualRouter %v only targets virtualNodes %s and %s",
+			k8s.NamespacedName(vr), targets[0].VirtualNodeName, targets[1].VirtualNodeName)
+	}
+	return routes, nil
+}
+
+// routeWeightedTargets returns the weighted targets of route's action, or nil if route has none.
+func routeWeightedTargets(route *appmesh.Route) *[]appmesh.WeightedTarget {
+	switch {
+	case route.HTTPRoute != nil:
+		return &route.HTTPRoute.Action.WeightedTargets
+	case route.HTTP2Route != nil:
+		return &route.HTTP2Route.Action.WeightedTargets
+	case route.GRPCRoute != nil:
+		return &route.GRPCRoute.Action.WeightedTargets
+	case route.TCPRoute != nil:
+		return &route.TCPRoute.Action.WeightedTargets
+	}
+	return nil
+}
+
+// isCanaryWeightedTargets checks whether all weightedTargets reference one of the canary VirtualNodes.
+func isCanaryWeightedTargets(vr *appmesh.VirtualRouter, weightedTargets []appmesh.WeightedTarget, canaryVNKeys map[types.NamespacedName]bool) bool {
+	if len(weightedTargets) == 0 {
+		return false
+	}
+	for _, target := range weightedTargets {
+		if target.VirtualNodeRef == nil {
+			return false
+		}
+		if !canaryVNKeys[references.ObjectKeyForVirtualNodeReference(vr, *target.VirtualNodeRef)] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,300 @@
+package virtualservice
+
+import (
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_ParseCanaryWeights(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []CanaryTarget
+		wantErr     error
+	}{
+		{
+			name: "annotation not specified",
+			want: nil,
+		},
+		{
+			name: "stable and canary weights",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/canaryWeights": "vn-stable=90, vn-canary=10",
+			},
+			want: []CanaryTarget{
+				{VirtualNodeName: "vn-stable", Weight: 90},
+				{VirtualNodeName: "vn-canary", Weight: 10},
+			},
+		},
+		{
+			name: "all traffic to canary",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/canaryWeights": "vn-stable=0,vn-canary=100",
+			},
+			want: []CanaryTarget{
+				{VirtualNodeName: "vn-stable", Weight: 0},
+				{VirtualNodeName: "vn-canary", Weight: 100},
+			},
+		},
+		{
+			name: "single target",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/canaryWeights": "vn-stable=100",
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/canaryWeights: vn-stable=100, expected format: <stableVirtualNode>=<weight>,<canaryVirtualNode>=<weight>"),
+		},
+		{
+			name: "missing virtualNode name",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/canaryWeights": "=90,vn-canary=10",
+			},
+			wantErr: errors.New("malformed annotation appmesh.k8s.aws/canaryWeights: =90,vn-canary=10, expected format: <stableVirtualNode>=<weight>,<canaryVirtualNode>=<weight>"),
+		},
+		{
+			name: "weight out of range",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/canaryWeights": "vn-stable=90,vn-canary=101",
+			},
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/canaryWeights: weight 101 of virtualNode vn-canary must be an integer between 0 and 100"),
+		},
+		{
+			name: "weight isn't an integer",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/canaryWeights": "vn-stable=ninety,vn-canary=10",
+			},
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/canaryWeights: weight ninety of virtualNode vn-stable must be an integer between 0 and 100"),
+		},
+		{
+			name: "same virtualNode twice",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/canaryWeights": "vn-stable=90,vn-stable=10",
+			},
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/canaryWeights: stable and canary virtualNode must be different"),
+		},
+		{
+			name: "all weights are zero",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/canaryWeights": "vn-stable=0,vn-canary=0",
+			},
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/canaryWeights: weights can't all be zero"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns-1",
+					Name:        "vs-1",
+					Annotations: tt.annotations,
+				},
+			}
+			got, err := ParseCanaryWeights(vs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_BuildCanaryRoutes(t *testing.T) {
+	targets := []CanaryTarget{
+		{VirtualNodeName: "vn-stable", Weight: 80},
+		{VirtualNodeName: "vn-canary", Weight: 20},
+	}
+	vs := &appmesh.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "vs-1",
+		},
+	}
+	tests := []struct {
+		name    string
+		vs      *appmesh.VirtualService
+		vr      *appmesh.VirtualRouter
+		want    []appmesh.Route
+		wantErr error
+	}{
+		{
+			name: "http route targeting stable only",
+			vs:   vs,
+			vr: &appmesh.VirtualRouter{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "vr-1",
+				},
+				Spec: appmesh.VirtualRouterSpec{
+					Routes: []appmesh.Route{
+						{
+							Name: "route-1",
+							HTTPRoute: &appmesh.HTTPRoute{
+								Match: appmesh.HTTPRouteMatch{Prefix: "/"},
+								Action: appmesh.HTTPRouteAction{
+									WeightedTargets: []appmesh.WeightedTarget{
+										{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-stable"}, Weight: 100},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []appmesh.Route{
+				{
+					Name: "route-1",
+					HTTPRoute: &appmesh.HTTPRoute{
+						Match: appmesh.HTTPRouteMatch{Prefix: "/"},
+						Action: appmesh.HTTPRouteAction{
+							WeightedTargets: []appmesh.WeightedTarget{
+								{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-stable"}, Weight: 80},
+								{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-canary"}, Weight: 20},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "routes targeting other virtualNodes are kept",
+			vs:   vs,
+			vr: &appmesh.VirtualRouter{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "vr-1",
+				},
+				Spec: appmesh.VirtualRouterSpec{
+					Routes: []appmesh.Route{
+						{
+							Name: "route-tcp",
+							TCPRoute: &appmesh.TCPRoute{
+								Action: appmesh.TCPRouteAction{
+									WeightedTargets: []appmesh.WeightedTarget{
+										{VirtualNodeRef: &appmesh.VirtualNodeReference{Namespace: aws.String("ns-1"), Name: "vn-stable"}, Weight: 50},
+										{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-canary"}, Weight: 50},
+									},
+								},
+							},
+						},
+						{
+							Name: "route-other",
+							TCPRoute: &appmesh.TCPRoute{
+								Action: appmesh.TCPRouteAction{
+									WeightedTargets: []appmesh.WeightedTarget{
+										{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-stable"}, Weight: 50},
+										{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-other"}, Weight: 50},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []appmesh.Route{
+				{
+					Name: "route-tcp",
+					TCPRoute: &appmesh.TCPRoute{
+						Action: appmesh.TCPRouteAction{
+							WeightedTargets: []appmesh.WeightedTarget{
+								{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-stable"}, Weight: 80},
+								{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-canary"}, Weight: 20},
+							},
+						},
+					},
+				},
+				{
+					Name: "route-other",
+					TCPRoute: &appmesh.TCPRoute{
+						Action: appmesh.TCPRouteAction{
+							WeightedTargets: []appmesh.WeightedTarget{
+								{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-stable"}, Weight: 50},
+								{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-other"}, Weight: 50},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "virtualRouter in another namespace",
+			vs:   vs,
+			vr: &appmesh.VirtualRouter{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-2",
+					Name:      "vr-1",
+				},
+				Spec: appmesh.VirtualRouterSpec{
+					Routes: []appmesh.Route{
+						{
+							Name: "route-1",
+							GRPCRoute: &appmesh.GRPCRoute{
+								Action: appmesh.GRPCRouteAction{
+									WeightedTargets: []appmesh.WeightedTarget{
+										{VirtualNodeRef: &appmesh.VirtualNodeReference{Namespace: aws.String("ns-1"), Name: "vn-stable"}, Weight: 100},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []appmesh.Route{
+				{
+					Name: "route-1",
+					GRPCRoute: &appmesh.GRPCRoute{
+						Action: appmesh.GRPCRouteAction{
+							WeightedTargets: []appmesh.WeightedTarget{
+								{VirtualNodeRef: &appmesh.VirtualNodeReference{Namespace: aws.String("ns-1"), Name: "vn-stable"}, Weight: 80},
+								{VirtualNodeRef: &appmesh.VirtualNodeReference{Namespace: aws.String("ns-1"), Name: "vn-canary"}, Weight: 20},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no route targets the canary virtualNodes",
+			vs:   vs,
+			vr: &appmesh.VirtualRouter{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "vr-1",
+				},
+				Spec: appmesh.VirtualRouterSpec{
+					Routes: []appmesh.Route{
+						{
+							Name: "route-1",
+							HTTP2Route: &appmesh.HTTPRoute{
+								Action: appmesh.HTTPRouteAction{
+									WeightedTargets: []appmesh.WeightedTarget{
+										{VirtualNodeARN: aws.String("arn:aws:appmesh:us-west-2:000000000000:mesh/mesh-1/virtualNode/vn-stable"), Weight: 100},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("no route of virtualRouter ns-1/vr-1 only targets virtualNodes vn-stable and vn-canary"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.vr.DeepCopy()
+			got, err := BuildCanaryRoutes(tt.vs, tt.vr, targets)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, cmp.Equal(tt.want, got), "diff", cmp.Diff(tt.want, got))
+			}
+			assert.Equal(t, original, tt.vr)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package virtualservice
+
+import (
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/pkg/errors"
+)
+
+const (
+	// AnnotationReferenceOnly marks a VirtualService as a reference to an AppMesh VirtualService managed outside of the cluster.
+	// The controller only describes it to populate status, and never creates, updates, tags or deletes it.
+	AnnotationReferenceOnly = "appmesh.k8s.aws/referenceOnly"
+)
+
+// IsReferenceOnly checks whether vs only references an externally managed AppMesh VirtualService via annotation.
+func IsReferenceOnly(vs *appmesh.VirtualService) bool {
+	return vs.Annotations[AnnotationReferenceOnly] == "true"
+}
+
+// ValidateReferenceOnly makes sure a reference only vs doesn't specify a provider, which would never be applied.
+func ValidateReferenceOnly(vs *appmesh.VirtualService) error {
+	if !IsReferenceOnly(vs) {
+		return nil
+	}
+	if vs.Spec.Provider != nil {
+		return errors.Errorf("virtualService with annotation %s: \"true\" must not specify provider", AnnotationReferenceOnly)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package virtualservice
+
+import (
+	"errors"
+	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_ValidateReferenceOnly(t *testing.T) {
+	provider := &appmesh.VirtualServiceProvider{
+		VirtualNode: &appmesh.VirtualNodeServiceProvider{
+			VirtualNodeARN: aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualNode/node-v1"),
+		},
+	}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		provider    *appmesh.VirtualServiceProvider
+		wantErr     error
+	}{
+		{
+			name:     "not reference only with provider",
+			provider: provider,
+		},
+		{
+			name: "reference only without provider",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/referenceOnly": "true",
+			},
+		},
+		{
+			name: "reference only with provider",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/referenceOnly": "true",
+			},
+			provider: provider,
+			wantErr:  errors.New("virtualService with annotation appmesh.k8s.aws/referenceOnly: \"true\" must not specify provider"),
+		},
+		{
+			name: "reference only disabled with provider",
+			annotations: map[string]string{
+				"appmesh.k8s.aws/referenceOnly": "false",
+			},
+			provider: provider,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "my-ns",
+					Name:        "payments",
+					Annotations: tt.annotations,
+				},
+				Spec: appmesh.VirtualServiceSpec{
+					Provider: tt.provider,
+				},
+			}
+			err := ValidateReferenceOnly(vs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package virtualservice
 
 import (
 	"context"
+	"fmt"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/conversions"
@@ -9,6 +10,7 @@ import (
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/tagging"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualrouter"
 	"github.com/aws/aws-sdk-go/aws"
@@ -38,12 +40,14 @@ func NewDefaultResourceManager(
 	appMeshSDK services.AppMesh,
 	referencesResolver references.Resolver,
 	accountID string,
+	tagsManager tagging.Manager,
 	log logr.Logger) ResourceManager {
 	return &defaultResourceManager{
 		k8sClient:          k8sClient,
 		appMeshSDK:         appMeshSDK,
 		referencesResolver: referencesResolver,
 		accountID:          accountID,
+		tagsManager:        tagsManager,
 		log:                log,
 	}
 }
@@ -53,17 +57,24 @@ type defaultResourceManager struct {
 	appMeshSDK         services.AppMesh
 	referencesResolver references.Resolver
 	accountID          string
+	tagsManager        tagging.Manager
 	log                logr.Logger
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, vs *appmesh.VirtualService) error {
+	if k8s.IsReconciliationPaused(vs) {
+		return m.updateCRDVirtualServiceReconciliationPaused(ctx, vs)
+	}
 	ms, err := m.findMeshDependency(ctx, vs)
 	if err != nil {
 		return err
 	}
-	if err := m.validateMeshDependencies(ctx, ms); err != nil {
+	if err := m.validateMeshDependencies(ctx, ms, vs); err != nil {
 		return err
 	}
+	if IsReferenceOnly(vs) {
+		return m.reconcileReferenceOnly(ctx, ms, vs)
+	}
 	vnByKey, err := m.findVirtualNodeDependencies(ctx, vs)
 	if err != nil {
 		return err
@@ -78,6 +89,9 @@ func (m *defaultResourceManager) Reconcile(ctx context.Context, vs *appmesh.Virt
 	if err := m.validateVirtualRouterDependencies(ctx, ms, vrByKey); err != nil {
 		return err
 	}
+	if err := m.reconcileCanaryWeights(ctx, vs, vrByKey); err != nil {
+		return err
+	}
 
 	sdkVS, err := m.findSDKVirtualService(ctx, ms, vs)
 	if err != nil {
@@ -93,11 +107,18 @@ func (m *defaultResourceManager) Reconcile(ctx context.Context, vs *appmesh.Virt
 		if err != nil {
 			return err
 		}
+		if err := m.updateSDKVirtualServiceTags(ctx, sdkVS, vs); err != nil {
+			return err
+		}
 	}
 	return m.updateCRDVirtualService(ctx, vs, sdkVS)
 }
 
 func (m *defaultResourceManager) Cleanup(ctx context.Context, vs *appmesh.VirtualService) error {
+	if IsReferenceOnly(vs) {
+		m.log.V(1).Info("skip virtualService deletion since it's reference only", "virtualService", k8s.NamespacedName(vs))
+		return nil
+	}
 	ms, err := m.findMeshDependency(ctx, vs)
 	if err != nil {
 		return err
@@ -112,9 +133,30 @@ func (m *defaultResourceManager) Cleanup(ctx context.Context, vs *appmesh.Virtua
 	if sdkVS == nil {
 		return nil
 	}
+	if k8s.IsDeletionPolicyOrphan(vs) {
+		m.sdkVirtualServiceLogger(sdkVS, vs).V(1).Info("skip virtualService deletion since its deletionPolicy is orphan")
+		return nil
+	}
 	return m.deleteSDKVirtualService(ctx, sdkVS, vs)
 }
 
+// reconcileReferenceOnly populates status of vs from the externally managed AppMesh VirtualService it references.
+// It's requeued with backoff until the AppMesh VirtualService exists.
+func (m *defaultResourceManager) reconcileReferenceOnly(ctx context.Context, ms *appmesh.Mesh, vs *appmesh.VirtualService) error {
+	if err := ValidateReferenceOnly(vs); err != nil {
+		return err
+	}
+	sdkVS, err := m.findSDKVirtualService(ctx, ms, vs)
+	if err != nil {
+		return err
+	}
+	if sdkVS == nil {
+		return runtime.NewRequeueError(errors.Errorf("AppMesh virtualService %s referenced by virtualService %v doesn't exist",
+			aws.StringValue(vs.Spec.AWSName), k8s.NamespacedName(vs)))
+	}
+	return m.updateCRDVirtualService(ctx, vs, sdkVS)
+}
+
 // findMeshDependency find the Mesh dependency for this VirtualService.
 func (m *defaultResourceManager) findMeshDependency(ctx context.Context, vs *appmesh.VirtualService) (*appmesh.Mesh, error) {
 	if vs.Spec.MeshRef == nil {
@@ -128,8 +170,12 @@ func (m *defaultResourceManager) findMeshDependency(ctx context.Context, vs *app
 }
 
 // validateMeshDependencies validate the Mesh dependency for this VirtualService.
-func (m *defaultResourceManager) validateMeshDependencies(ctx context.Context, ms *appmesh.Mesh) error {
+// VirtualService is marked as WaitingForMesh and requeued with backoff until the mesh becomes active.
+func (m *defaultResourceManager) validateMeshDependencies(ctx context.Context, ms *appmesh.Mesh, vs *appmesh.VirtualService) error {
 	if !mesh.IsMeshActive(ms) {
+		if err := m.updateCRDVirtualServiceWaitingForMesh(ctx, vs, ms); err != nil {
+			return err
+		}
 		return runtime.NewRequeueError(errors.New("mesh is not active yet"))
 	}
 	return nil
@@ -193,6 +239,42 @@ func (m *defaultResourceManager) validateVirtualRouterDependencies(ctx context.C
 	return nil
 }
 
+// reconcileCanaryWeights applies the canary weight split of vs to the routes of its VirtualRouter provider.
+func (m *defaultResourceManager) reconcileCanaryWeights(ctx context.Context, vs *appmesh.VirtualService, vrByKey map[types.NamespacedName]*appmesh.VirtualRouter) error {
+	targets, err := ParseCanaryWeights(vs)
+	if err != nil {
+		return err
+	}
+	if targets == nil {
+		return nil
+	}
+	if vs.Spec.Provider == nil || vs.Spec.Provider.VirtualRouter == nil || vs.Spec.Provider.VirtualRouter.VirtualRouterRef == nil {
+		return errors.Errorf("annotation %s requires a virtualRouterRef provider", AnnotationCanaryWeights)
+	}
+	for _, target := range targets {
+		vnRef := appmesh.VirtualNodeReference{Name: target.VirtualNodeName}
+		if _, err := m.referencesResolver.ResolveVirtualNodeReference(ctx, vs, vnRef); err != nil {
+			return errors.Wrapf(err, "failed to resolve canary virtualNode %s", target.VirtualNodeName)
+		}
+	}
+	vr := vrByKey[references.ObjectKeyForVirtualRouterReference(vs, *vs.Spec.Provider.VirtualRouter.VirtualRouterRef)]
+	routes, err := BuildCanaryRoutes(vs, vr, targets)
+	if err != nil {
+		return err
+	}
+	if cmp.Equal(vr.Spec.Routes, routes) {
+		return nil
+	}
+	oldVR := vr.DeepCopy()
+	vr.Spec.Routes = routes
+	m.log.V(1).Info("applying canary weights to virtualRouter routes",
+		"virtualService", k8s.NamespacedName(vs),
+		"virtualRouter", k8s.NamespacedName(vr),
+		"diff", cmp.Diff(oldVR.Spec.Routes, routes),
+	)
+	return m.k8sClient.Patch(ctx, vr, client.MergeFrom(oldVR))
+}
+
 func (m *defaultResourceManager) findSDKVirtualService(ctx context.Context, ms *appmesh.Mesh, vs *appmesh.VirtualService) (*appmeshsdk.VirtualServiceData, error) {
 	resp, err := m.appMeshSDK.DescribeVirtualServiceWithContext(ctx, &appmeshsdk.DescribeVirtualServiceInput{
 		MeshName:           ms.Spec.AWSName,
@@ -220,6 +302,7 @@ func (m *defaultResourceManager) createSDKVirtualService(ctx context.Context, ms
 		MeshOwner:          ms.Spec.MeshOwner,
 		VirtualServiceName: vs.Spec.AWSName,
 		Spec:               sdkVSSpec,
+		Tags:               m.tagsManager.BuildSDKTags(vs),
 	})
 	if err != nil {
 		return nil, err
@@ -240,16 +323,12 @@ func (m *defaultResourceManager) updateSDKVirtualService(ctx context.Context, sd
 		return sdkVS, nil
 	}
 	if !m.isSDKVirtualServiceControlledByCRDVirtualService(ctx, sdkVS, vs) {
-		m.log.V(1).Info("skip virtualService update since it's not controlled",
-			"virtualService", k8s.NamespacedName(vs),
-			"virtualServiceARN", aws.StringValue(sdkVS.Metadata.Arn),
-		)
+		m.sdkVirtualServiceLogger(sdkVS, vs).V(1).Info("skip virtualService update since it's not controlled")
 		return sdkVS, nil
 	}
 
 	diff := cmp.Diff(desiredSDKVSSpec, actualSDKVSSpec, opts)
-	m.log.V(1).Info("virtualServiceSpec changed",
-		"virtualService", k8s.NamespacedName(vs),
+	m.sdkVirtualServiceLogger(sdkVS, vs).V(1).Info("virtualServiceSpec changed",
 		"actualSDKVRSpec", actualSDKVSSpec,
 		"desiredSDKVRSpec", desiredSDKVSSpec,
 		"diff", diff,
@@ -268,10 +347,7 @@ func (m *defaultResourceManager) updateSDKVirtualService(ctx context.Context, sd
 
 func (m *defaultResourceManager) deleteSDKVirtualService(ctx context.Context, sdkVS *appmeshsdk.VirtualServiceData, vs *appmesh.VirtualService) error {
 	if !m.isSDKVirtualServiceOwnedByCRDVirtualService(ctx, sdkVS, vs) {
-		m.log.V(1).Info("skip virtualService deletion since its not owned",
-			"virtualService", k8s.NamespacedName(vs),
-			"virtualServiceARN", aws.StringValue(sdkVS.Metadata.Arn),
-		)
+		m.sdkVirtualServiceLogger(sdkVS, vs).V(1).Info("skip virtualService deletion since its not owned")
 		return nil
 	}
 	_, err := m.appMeshSDK.DeleteVirtualServiceWithContext(ctx, &appmeshsdk.DeleteVirtualServiceInput{
@@ -279,10 +355,7 @@ func (m *defaultResourceManager) deleteSDKVirtualService(ctx context.Context, sd
 		MeshOwner:          sdkVS.Metadata.MeshOwner,
 		VirtualServiceName: sdkVS.VirtualServiceName,
 	})
-	if err != nil {
-		return err
-	}
-	return nil
+	return services.IgnoreAppMeshNotFound(err)
 }
 
 func (m *defaultResourceManager) updateCRDVirtualService(ctx context.Context, vs *appmesh.VirtualService, sdkVS *appmeshsdk.VirtualServiceData) error {
@@ -304,6 +377,14 @@ func (m *defaultResourceManager) updateCRDVirtualService(ctx context.Context, vs
 	if updateCondition(vs, appmesh.VirtualServiceActive, vsActiveConditionStatus, nil, nil) {
 		needsUpdate = true
 	}
+	if getCondition(vs, appmesh.VirtualServiceReconciliationPaused) != nil &&
+		updateCondition(vs, appmesh.VirtualServiceReconciliationPaused, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
+	if getCondition(vs, appmesh.VirtualServiceWaitingForMesh) != nil &&
+		updateCondition(vs, appmesh.VirtualServiceWaitingForMesh, corev1.ConditionFalse, nil, nil) {
+		needsUpdate = true
+	}
 
 	if !needsUpdate {
 		return nil
@@ -311,6 +392,42 @@ func (m *defaultResourceManager) updateCRDVirtualService(ctx context.Context, vs
 	return m.k8sClient.Status().Patch(ctx, vs, client.MergeFrom(oldVS))
 }
 
+// updateCRDVirtualServiceReconciliationPaused records the ReconciliationPaused condition without touching the AppMesh VirtualService.
+func (m *defaultResourceManager) updateCRDVirtualServiceReconciliationPaused(ctx context.Context, vs *appmesh.VirtualService) error {
+	oldVS := vs.DeepCopy()
+	if !updateCondition(vs, appmesh.VirtualServiceReconciliationPaused, corev1.ConditionTrue, nil, nil) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, vs, client.MergeFrom(oldVS))
+}
+
+// updateCRDVirtualServiceWaitingForMesh records the WaitingForMesh condition while the mesh of vs isn't active.
+func (m *defaultResourceManager) updateCRDVirtualServiceWaitingForMesh(ctx context.Context, vs *appmesh.VirtualService, ms *appmesh.Mesh) error {
+	oldVS := vs.DeepCopy()
+	message := fmt.Sprintf("mesh %s is not active yet", ms.Name)
+	if !updateCondition(vs, appmesh.VirtualServiceWaitingForMesh, corev1.ConditionTrue, nil, aws.String(message)) {
+		return nil
+	}
+	return m.k8sClient.Status().Patch(ctx, vs, client.MergeFrom(oldVS))
+}
+
+// sdkVirtualServiceLogger returns a logger carrying the identity of vs and its AppMesh VirtualService.
+func (m *defaultResourceManager) sdkVirtualServiceLogger(sdkVS *appmeshsdk.VirtualServiceData, vs *appmesh.VirtualService) logr.Logger {
+	return m.log.WithValues(
+		"meshName", aws.StringValue(sdkVS.MeshName),
+		"virtualService", k8s.NamespacedName(vs),
+		"arn", aws.StringValue(sdkVS.Metadata.Arn),
+	)
+}
+
+// updateSDKVirtualServiceTags corrects drift of the tags propagated from labels of vs on its AppMesh VirtualService.
+func (m *defaultResourceManager) updateSDKVirtualServiceTags(ctx context.Context, sdkVS *appmeshsdk.VirtualServiceData, vs *appmesh.VirtualService) error {
+	if !m.isSDKVirtualServiceControlledByCRDVirtualService(ctx, sdkVS, vs) {
+		return nil
+	}
+	return m.tagsManager.ReconcileSDKTags(ctx, aws.StringValue(sdkVS.Metadata.Arn), vs)
+}
+
 // isSDKVirtualServiceControlledByCRDVirtualService checks whether an AppMesh VirtualService is controlled by CRD VirtualService.
 // if it's controlled, CRD VirtualService update is responsible for updating the AppMesh VirtualService.
 func (m *defaultResourceManager) isSDKVirtualServiceControlledByCRDVirtualService(ctx context.Context, sdkVS *appmeshsdk.VirtualServiceData, vs *appmesh.VirtualService) bool {
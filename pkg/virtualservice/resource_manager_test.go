@@ -4,9 +4,14 @@ import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	mock_resolver "github.com/aws/aws-app-mesh-controller-for-k8s/mocks/aws-app-mesh-controller-for-k8s/pkg/references"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/aws/services"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/equality"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/k8s"
+	appmeshruntime "github.com/aws/aws-app-mesh-controller-for-k8s/pkg/runtime"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/tagging"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	appmeshsdk "github.com/aws/aws-sdk-go/service/appmesh"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
@@ -108,6 +113,55 @@ func Test_defaultResourceManager_updateCRDVirtualService(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "virtualService resumed after pause needs patch ReconciliationPaused condition",
+			args: args{
+				vs: &appmesh.VirtualService{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vs-1",
+					},
+					Status: appmesh.VirtualServiceStatus{
+						VirtualServiceARN: aws.String("arn-1"),
+						Conditions: []appmesh.VirtualServiceCondition{
+							{
+								Type:   appmesh.VirtualServiceActive,
+								Status: corev1.ConditionTrue,
+							},
+							{
+								Type:   appmesh.VirtualServiceReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+				sdkVS: &appmeshsdk.VirtualServiceData{
+					Metadata: &appmeshsdk.ResourceMetadata{
+						Arn: aws.String("arn-1"),
+					},
+					Status: &appmeshsdk.VirtualServiceStatus{
+						Status: aws.String(appmeshsdk.VirtualServiceStatusCodeActive),
+					},
+				},
+			},
+			wantVS: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vs-1",
+				},
+				Status: appmesh.VirtualServiceStatus{
+					VirtualServiceARN: aws.String("arn-1"),
+					Conditions: []appmesh.VirtualServiceCondition{
+						{
+							Type:   appmesh.VirtualServiceActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.VirtualServiceReconciliationPaused,
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -141,6 +195,136 @@ func Test_defaultResourceManager_updateCRDVirtualService(t *testing.T) {
 	}
 }
 
+func Test_defaultResourceManager_Reconcile_paused(t *testing.T) {
+	type args struct {
+		vs *appmesh.VirtualService
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantVS  *appmesh.VirtualService
+		wantErr error
+	}{
+		{
+			name: "paused virtualService records ReconciliationPaused condition",
+			args: args{
+				vs: &appmesh.VirtualService{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vs-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.VirtualServiceStatus{
+						VirtualServiceARN: aws.String("arn-1"),
+						Conditions: []appmesh.VirtualServiceCondition{
+							{
+								Type:   appmesh.VirtualServiceActive,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantVS: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vs-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.VirtualServiceStatus{
+					VirtualServiceARN: aws.String("arn-1"),
+					Conditions: []appmesh.VirtualServiceCondition{
+						{
+							Type:   appmesh.VirtualServiceActive,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   appmesh.VirtualServiceReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "paused virtualService already has ReconciliationPaused condition",
+			args: args{
+				vs: &appmesh.VirtualService{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vs-1",
+						Annotations: map[string]string{
+							"appmesh.k8s.aws/paused": "true",
+						},
+					},
+					Status: appmesh.VirtualServiceStatus{
+						VirtualServiceARN: aws.String("arn-1"),
+						Conditions: []appmesh.VirtualServiceCondition{
+							{
+								Type:   appmesh.VirtualServiceReconciliationPaused,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			wantVS: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vs-1",
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/paused": "true",
+					},
+				},
+				Status: appmesh.VirtualServiceStatus{
+					VirtualServiceARN: aws.String("arn-1"),
+					Conditions: []appmesh.VirtualServiceCondition{
+						{
+							Type:   appmesh.VirtualServiceReconciliationPaused,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			// neither referencesResolver nor appMeshSDK expect any calls while paused.
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			m := &defaultResourceManager{
+				k8sClient:          k8sClient,
+				referencesResolver: resolver,
+				log:                log.NullLogger{},
+			}
+
+			err := k8sClient.Create(ctx, tt.args.vs.DeepCopy())
+			assert.NoError(t, err)
+			err = m.Reconcile(ctx, tt.args.vs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				gotVS := &appmesh.VirtualService{}
+				err = k8sClient.Get(ctx, k8s.NamespacedName(tt.args.vs), gotVS)
+				assert.NoError(t, err)
+				opts := cmp.Options{
+					equality.IgnoreFakeClientPopulatedFields(),
+					cmpopts.IgnoreTypes((*metav1.Time)(nil)),
+				}
+				assert.True(t, cmp.Equal(tt.wantVS, gotVS, opts), "diff", cmp.Diff(tt.wantVS, gotVS, opts))
+			}
+		})
+	}
+}
+
 func Test_defaultResourceManager_isSDKVirtualServiceControlledByCRDVirtualService(t *testing.T) {
 	type fields struct {
 		accountID string
@@ -603,15 +787,38 @@ func Test_defaultResourceManager_validateMeshDependency(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
 			m := &defaultResourceManager{
-				log: log.NullLogger{},
+				k8sClient: k8sClient,
+				log:       log.NullLogger{},
+			}
+			vs := &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "my-ns",
+					Name:      "my-vs",
+				},
 			}
+			err := k8sClient.Create(ctx, vs.DeepCopy())
+			assert.NoError(t, err)
 
-			err := m.validateMeshDependencies(ctx, tt.args.mesh)
+			err = m.validateMeshDependencies(ctx, tt.args.mesh, vs)
+			gotVS := &appmesh.VirtualService{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(vs), gotVS))
+			waitingForMesh := getCondition(gotVS, appmesh.VirtualServiceWaitingForMesh)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
+				var requeueErr *appmeshruntime.RequeueError
+				assert.True(t, errors.As(err, &requeueErr), "vs must be requeued while the mesh isn't active")
+				if assert.NotNil(t, waitingForMesh) {
+					assert.Equal(t, corev1.ConditionTrue, waitingForMesh.Status)
+					assert.Equal(t, "mesh my-mesh is not active yet", aws.StringValue(waitingForMesh.Message))
+				}
 			} else {
 				assert.NoError(t, err)
+				assert.Nil(t, waitingForMesh)
 			}
 		})
 	}
@@ -882,3 +1089,369 @@ func Test_defaultResourceManager_findVirtualRouterDependencies(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultResourceManager_reconcileCanaryWeights(t *testing.T) {
+	vr := &appmesh.VirtualRouter{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "vr-1",
+		},
+		Spec: appmesh.VirtualRouterSpec{
+			Routes: []appmesh.Route{
+				{
+					Name: "route-1",
+					HTTPRoute: &appmesh.HTTPRoute{
+						Match: appmesh.HTTPRouteMatch{Prefix: "/"},
+						Action: appmesh.HTTPRouteAction{
+							WeightedTargets: []appmesh.WeightedTarget{
+								{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-stable"}, Weight: 100},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	vsWithAnnotations := func(annotations map[string]string) *appmesh.VirtualService {
+		return &appmesh.VirtualService{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns-1",
+				Name:        "vs-1",
+				Annotations: annotations,
+			},
+			Spec: appmesh.VirtualServiceSpec{
+				Provider: &appmesh.VirtualServiceProvider{
+					VirtualRouter: &appmesh.VirtualRouterServiceProvider{
+						VirtualRouterRef: &appmesh.VirtualRouterReference{Name: "vr-1"},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name                        string
+		vs                          *appmesh.VirtualService
+		resolveVirtualNodeReference func(ctx context.Context, obj metav1.Object, ref appmesh.VirtualNodeReference) (*appmesh.VirtualNode, error)
+		wantWeightedTargets         []appmesh.WeightedTarget
+		wantErr                     error
+	}{
+		{
+			name: "without canary weights",
+			vs:   vsWithAnnotations(nil),
+			wantWeightedTargets: []appmesh.WeightedTarget{
+				{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-stable"}, Weight: 100},
+			},
+		},
+		{
+			name: "canary weights are applied to virtualRouter",
+			vs: vsWithAnnotations(map[string]string{
+				"appmesh.k8s.aws/canaryWeights": "vn-stable=90,vn-canary=10",
+			}),
+			resolveVirtualNodeReference: func(ctx context.Context, obj metav1.Object, ref appmesh.VirtualNodeReference) (*appmesh.VirtualNode, error) {
+				return &appmesh.VirtualNode{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: ref.Name}}, nil
+			},
+			wantWeightedTargets: []appmesh.WeightedTarget{
+				{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-stable"}, Weight: 90},
+				{VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-canary"}, Weight: 10},
+			},
+		},
+		{
+			name: "canary virtualNode doesn't exist",
+			vs: vsWithAnnotations(map[string]string{
+				"appmesh.k8s.aws/canaryWeights": "vn-stable=90,vn-canary=10",
+			}),
+			resolveVirtualNodeReference: func(ctx context.Context, obj metav1.Object, ref appmesh.VirtualNodeReference) (*appmesh.VirtualNode, error) {
+				return nil, errors.New("virtual node not found")
+			},
+			wantErr: errors.New("failed to resolve canary virtualNode vn-stable: virtual node not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			m := &defaultResourceManager{
+				k8sClient:          k8sClient,
+				referencesResolver: resolver,
+				log:                log.NullLogger{},
+			}
+			if tt.resolveVirtualNodeReference != nil {
+				resolver.EXPECT().ResolveVirtualNodeReference(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(tt.resolveVirtualNodeReference).AnyTimes()
+			}
+
+			err := k8sClient.Create(ctx, vr.DeepCopy())
+			assert.NoError(t, err)
+			vrByKey := make(map[types.NamespacedName]*appmesh.VirtualRouter)
+			existingVR := &appmesh.VirtualRouter{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(vr), existingVR))
+			vrByKey[k8s.NamespacedName(vr)] = existingVR
+
+			err = m.reconcileCanaryWeights(ctx, tt.vs, vrByKey)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				gotVR := &appmesh.VirtualRouter{}
+				assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(vr), gotVR))
+				gotWeightedTargets := gotVR.Spec.Routes[0].HTTPRoute.Action.WeightedTargets
+				assert.True(t, cmp.Equal(tt.wantWeightedTargets, gotWeightedTargets), "diff", cmp.Diff(tt.wantWeightedTargets, gotWeightedTargets))
+			}
+		})
+	}
+}
+
+// fakeAppMesh serves a single AppMesh VirtualService and records the names it's described with, and fails deletions with deleteErr.
+type fakeAppMesh struct {
+	services.AppMesh
+	sdkVS                    *appmeshsdk.VirtualServiceData
+	describedVirtualServices []string
+	deleteErr                error
+}
+
+func (f *fakeAppMesh) DescribeVirtualServiceWithContext(_ context.Context, input *appmeshsdk.DescribeVirtualServiceInput, _ ...request.Option) (*appmeshsdk.DescribeVirtualServiceOutput, error) {
+	f.describedVirtualServices = append(f.describedVirtualServices, aws.StringValue(input.VirtualServiceName))
+	if f.sdkVS == nil || aws.StringValue(f.sdkVS.VirtualServiceName) != aws.StringValue(input.VirtualServiceName) {
+		return nil, awserr.New("NotFoundException", "virtualService not found", nil)
+	}
+	return &appmeshsdk.DescribeVirtualServiceOutput{VirtualService: f.sdkVS}, nil
+}
+
+func (f *fakeAppMesh) CreateVirtualServiceWithContext(_ context.Context, input *appmeshsdk.CreateVirtualServiceInput, _ ...request.Option) (*appmeshsdk.CreateVirtualServiceOutput, error) {
+	f.sdkVS = &appmeshsdk.VirtualServiceData{
+		MeshName:           input.MeshName,
+		VirtualServiceName: input.VirtualServiceName,
+		Spec:               input.Spec,
+		Metadata: &appmeshsdk.ResourceMetadata{
+			Arn:           aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualService/" + aws.StringValue(input.VirtualServiceName)),
+			MeshOwner:     aws.String("222222222"),
+			ResourceOwner: aws.String("222222222"),
+		},
+		Status: &appmeshsdk.VirtualServiceStatus{
+			Status: aws.String(appmeshsdk.VirtualServiceStatusCodeActive),
+		},
+	}
+	return &appmeshsdk.CreateVirtualServiceOutput{VirtualService: f.sdkVS}, nil
+}
+
+func (f *fakeAppMesh) DeleteVirtualServiceWithContext(_ context.Context, _ *appmeshsdk.DeleteVirtualServiceInput, _ ...request.Option) (*appmeshsdk.DeleteVirtualServiceOutput, error) {
+	if f.deleteErr != nil {
+		return nil, f.deleteErr
+	}
+	return &appmeshsdk.DeleteVirtualServiceOutput{}, nil
+}
+
+func Test_defaultResourceManager_Reconcile_awsNameDiffersFromName(t *testing.T) {
+	ms := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+		Status: appmesh.MeshStatus{
+			Conditions: []appmesh.MeshCondition{
+				{
+					Type:   appmesh.MeshActive,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	vs := &appmesh.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "payments",
+		},
+		Spec: appmesh.VirtualServiceSpec{
+			AWSName: aws.String("payments.internal.example.com"),
+			MeshRef: &appmesh.MeshReference{
+				Name: "my-mesh",
+			},
+		},
+	}
+
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	appmesh.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	resolver := mock_resolver.NewMockResolver(ctrl)
+	resolver.EXPECT().ResolveMeshReference(gomock.Any(), *vs.Spec.MeshRef).Return(ms, nil).Times(2)
+	appMeshSDK := &fakeAppMesh{}
+	m := NewDefaultResourceManager(k8sClient, appMeshSDK, resolver, "222222222", tagging.NewDefaultManager(appMeshSDK, tagging.Config{}, log.NullLogger{}), log.NullLogger{})
+	assert.NoError(t, k8sClient.Create(ctx, vs.DeepCopy()))
+
+	// the first reconcile creates AppMesh VirtualService named after awsName rather than the CR name.
+	assert.NoError(t, m.Reconcile(ctx, vs.DeepCopy()))
+	if assert.NotNil(t, appMeshSDK.sdkVS) {
+		assert.Equal(t, "payments.internal.example.com", aws.StringValue(appMeshSDK.sdkVS.VirtualServiceName))
+	}
+	gotVS := &appmesh.VirtualService{}
+	assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(vs), gotVS))
+	assert.Equal(t, aws.String("arn:aws:appmesh:us-west-2:222222222:mesh/my-mesh/virtualService/payments.internal.example.com"), gotVS.Status.VirtualServiceARN)
+
+	// the following reconcile finds the same AppMesh VirtualService and has nothing to change.
+	assert.NoError(t, m.Reconcile(ctx, gotVS.DeepCopy()))
+	reconciledVS := &appmesh.VirtualService{}
+	assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(vs), reconciledVS))
+	assert.Equal(t, gotVS.ResourceVersion, reconciledVS.ResourceVersion)
+	assert.Equal(t, []string{"payments.internal.example.com", "payments.internal.example.com"}, appMeshSDK.describedVirtualServices)
+}
+
+func Test_defaultResourceManager_referenceOnly(t *testing.T) {
+	ms := &appmesh.Mesh{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-mesh",
+		},
+		Spec: appmesh.MeshSpec{
+			AWSName: aws.String("my-mesh"),
+		},
+		Status: appmesh.MeshStatus{
+			Conditions: []appmesh.MeshCondition{
+				{
+					Type:   appmesh.MeshActive,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	vs := &appmesh.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "payments",
+			Annotations: map[string]string{
+				"appmesh.k8s.aws/referenceOnly": "true",
+			},
+		},
+		Spec: appmesh.VirtualServiceSpec{
+			AWSName: aws.String("payments.external.example.com"),
+			MeshRef: &appmesh.MeshReference{
+				Name: "my-mesh",
+			},
+		},
+	}
+	// externally managed AppMesh VirtualService owned by another account, with a provider the CR doesn't declare.
+	externalSDKVS := &appmeshsdk.VirtualServiceData{
+		MeshName:           aws.String("my-mesh"),
+		VirtualServiceName: aws.String("payments.external.example.com"),
+		Spec: &appmeshsdk.VirtualServiceSpec{
+			Provider: &appmeshsdk.VirtualServiceProvider{
+				VirtualNode: &appmeshsdk.VirtualNodeServiceProvider{
+					VirtualNodeName: aws.String("payments-v1"),
+				},
+			},
+		},
+		Metadata: &appmeshsdk.ResourceMetadata{
+			Arn:           aws.String("arn:aws:appmesh:us-west-2:333333333:mesh/my-mesh/virtualService/payments.external.example.com"),
+			MeshOwner:     aws.String("222222222"),
+			ResourceOwner: aws.String("333333333"),
+		},
+		Status: &appmeshsdk.VirtualServiceStatus{
+			Status: aws.String(appmeshsdk.VirtualServiceStatusCodeActive),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		sdkVS   *appmeshsdk.VirtualServiceData
+		wantARN *string
+		wantErr error
+	}{
+		{
+			name:    "referenced AppMesh virtualService exists",
+			sdkVS:   externalSDKVS,
+			wantARN: aws.String("arn:aws:appmesh:us-west-2:333333333:mesh/my-mesh/virtualService/payments.external.example.com"),
+		},
+		{
+			name:    "referenced AppMesh virtualService doesn't exist",
+			sdkVS:   nil,
+			wantErr: errors.New("AppMesh virtualService payments.external.example.com referenced by virtualService my-ns/payments doesn't exist"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			appmesh.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			resolver := mock_resolver.NewMockResolver(ctrl)
+			resolver.EXPECT().ResolveMeshReference(gomock.Any(), *vs.Spec.MeshRef).Return(ms, nil)
+			// create, update, tag and delete calls would panic on the nil embedded AppMesh of the fake.
+			appMeshSDK := &fakeAppMesh{sdkVS: tt.sdkVS}
+			m := NewDefaultResourceManager(k8sClient, appMeshSDK, resolver, "222222222", tagging.NewDefaultManager(appMeshSDK, tagging.Config{}, log.NullLogger{}), log.NullLogger{})
+			assert.NoError(t, k8sClient.Create(ctx, vs.DeepCopy()))
+
+			err := m.Reconcile(ctx, vs.DeepCopy())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, appMeshSDK.sdkVS)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.sdkVS, appMeshSDK.sdkVS)
+			}
+			gotVS := &appmesh.VirtualService{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(vs), gotVS))
+			assert.Equal(t, tt.wantARN, gotVS.Status.VirtualServiceARN)
+
+			// cleanup never deletes the referenced AppMesh virtualService, nor describes it.
+			appMeshSDK.describedVirtualServices = nil
+			assert.NoError(t, m.Cleanup(ctx, gotVS))
+			assert.Nil(t, appMeshSDK.describedVirtualServices)
+		})
+	}
+}
+
+func Test_defaultResourceManager_deleteSDKVirtualService(t *testing.T) {
+	sdkVirtualService := &appmeshsdk.VirtualServiceData{
+		MeshName:           aws.String("my-mesh"),
+		VirtualServiceName: aws.String("my-virtualservice"),
+		Metadata: &appmeshsdk.ResourceMetadata{
+			MeshOwner:     aws.String("222222222"),
+			ResourceOwner: aws.String("222222222"),
+		},
+	}
+	tests := []struct {
+		name      string
+		deleteErr error
+		wantErr   error
+	}{
+		{
+			name: "AppMesh virtualService deleted",
+		},
+		{
+			name:      "AppMesh virtualService deleted out-of-band",
+			deleteErr: awserr.New("NotFoundException", "virtualService not found", nil),
+		},
+		{
+			name:      "AppMesh virtualService deletion fails",
+			deleteErr: awserr.New("ResourceInUseException", "virtualService in use", nil),
+			wantErr:   errors.New("ResourceInUseException: virtualService in use"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				appMeshSDK: &fakeAppMesh{deleteErr: tt.deleteErr},
+				accountID:  "222222222",
+				log:        &log.NullLogger{},
+			}
+			err := m.deleteSDKVirtualService(context.Background(), sdkVirtualService, &appmesh.VirtualService{})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package webhook
+
+// RetriableError signals an admission request failed due to a transient error, so the request can be retried as is.
+type RetriableError struct {
+	err error
+}
+
+// NewRetriableError wraps err into a RetriableError.
+func NewRetriableError(err error) error {
+	return &RetriableError{err: err}
+}
+
+func (e *RetriableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *RetriableError) Unwrap() error {
+	return e.err
+}
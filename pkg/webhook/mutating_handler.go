@@ -3,6 +3,7 @@ package webhook
 import (
 	"context"
 	"encoding/json"
+	"github.com/pkg/errors"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	"net/http"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -52,7 +53,7 @@ func (h *mutatingHandler) handleCreate(ctx context.Context, req admission.Reques
 
 	mutatedObj, err := h.mutator.MutateCreate(ContextWithAdmissionRequest(ctx, req), obj)
 	if err != nil {
-		return admission.Denied(err.Error())
+		return mutationErrorResponse(err)
 	}
 	mutatedObjPayload, err := json.Marshal(mutatedObj)
 	if err != nil {
@@ -77,7 +78,7 @@ func (h *mutatingHandler) handleUpdate(ctx context.Context, req admission.Reques
 
 	mutatedObj, err := h.mutator.MutateUpdate(ContextWithAdmissionRequest(ctx, req), obj, oldObj)
 	if err != nil {
-		return admission.Denied(err.Error())
+		return mutationErrorResponse(err)
 	}
 	mutatedObjPayload, err := json.Marshal(mutatedObj)
 	if err != nil {
@@ -85,3 +86,13 @@ func (h *mutatingHandler) handleUpdate(ctx context.Context, req admission.Reques
 	}
 	return admission.PatchResponseFromRaw(req.Object.Raw, mutatedObjPayload)
 }
+
+// mutationErrorResponse denies the admission request, unless the mutation failed due to a transient error,
+// in which case the request is errored so that it can be retried.
+func mutationErrorResponse(err error) admission.Response {
+	var retriableErr *RetriableError
+	if errors.As(err, &retriableErr) {
+		return admission.Errored(http.StatusServiceUnavailable, errors.Errorf("%v, the request can be retried", err))
+	}
+	return admission.Denied(err.Error())
+}
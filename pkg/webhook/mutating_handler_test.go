@@ -181,6 +181,38 @@ func Test_mutatingHandler_Handle(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "[create] error request on retriable error",
+			fields: fields{
+				mutatorPrototype: func(req admission.Request) (runtime.Object, error) {
+					return &corev1.Pod{}, nil
+				},
+				mutatorMutateCreate: func(ctx context.Context, obj runtime.Object) (runtime.Object, error) {
+					return nil, errors.Wrap(NewRetriableError(errors.New("etcdserver: request timed out")), "failed to get namespace default")
+				},
+				decoder: decoder,
+			},
+			args: args{
+				req: admission.Request{
+					AdmissionRequest: admissionv1beta1.AdmissionRequest{
+						Operation: admissionv1beta1.Create,
+						Object: runtime.RawExtension{
+							Raw: initialPodRaw,
+						},
+					},
+				},
+			},
+			want: admission.Response{
+				Patches: nil,
+				AdmissionResponse: admissionv1beta1.AdmissionResponse{
+					Allowed: false,
+					Result: &metav1.Status{
+						Code:    http.StatusServiceUnavailable,
+						Message: "failed to get namespace default: etcdserver: request timed out, the request can be retried",
+					},
+				},
+			},
+		},
 		{
 			name: "[create] unexpected object type - prototype returns error ",
 			fields: fields{
@@ -3,6 +3,7 @@ package appmesh
 import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,13 +16,16 @@ import (
 const apiPathValidateAppMeshGatewayRoute = "/validate-appmesh-k8s-aws-v1beta2-gatewayroute"
 
 // NewGatewayRouteValidator returns a validator for GatewayRoute.
-func NewGatewayRouteValidator() *gatewayRouteValidator {
-	return &gatewayRouteValidator{}
+func NewGatewayRouteValidator(meshMembershipValidator mesh.MembershipValidator) *gatewayRouteValidator {
+	return &gatewayRouteValidator{
+		meshMembershipValidator: meshMembershipValidator,
+	}
 }
 
 var _ webhook.Validator = &gatewayRouteValidator{}
 
 type gatewayRouteValidator struct {
+	meshMembershipValidator mesh.MembershipValidator
 }
 
 func (v *gatewayRouteValidator) Prototype(req admission.Request) (runtime.Object, error) {
@@ -29,6 +33,10 @@ func (v *gatewayRouteValidator) Prototype(req admission.Request) (runtime.Object
 }
 
 func (v *gatewayRouteValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	gr := obj.(*appmesh.GatewayRoute)
+	if err := v.meshMembershipValidator.Validate(ctx, gr, gr.Spec.MeshRef); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -38,6 +46,9 @@ func (v *gatewayRouteValidator) ValidateUpdate(ctx context.Context, obj runtime.
 	if err := v.enforceFieldsImmutability(newGR, oldGR); err != nil {
 		return err
 	}
+	if err := v.meshMembershipValidator.Validate(ctx, newGR, newGR.Spec.MeshRef); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"reflect"
@@ -29,6 +30,13 @@ func (v *meshValidator) Prototype(req admission.Request) (runtime.Object, error)
 }
 
 func (v *meshValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	mesh := obj.(*appmesh.Mesh)
+	if err := v.checkBackendDefaults(mesh); err != nil {
+		return err
+	}
+	if err := v.checkVirtualGatewayDefaults(mesh); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -38,6 +46,12 @@ func (v *meshValidator) ValidateUpdate(ctx context.Context, obj runtime.Object,
 	if err := v.enforceFieldsImmutability(mesh, oldMesh); err != nil {
 		return err
 	}
+	if err := v.checkBackendDefaults(mesh); err != nil {
+		return err
+	}
+	if err := v.checkVirtualGatewayDefaults(mesh); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -57,6 +71,65 @@ func (v *meshValidator) enforceFieldsImmutability(mesh *appmesh.Mesh, oldMesh *a
 	return nil
 }
 
+// checkBackendDefaults checks the mesh's default clientPolicy is well-formed, since it's applied to virtualNodes in this mesh.
+func (v *meshValidator) checkBackendDefaults(mesh *appmesh.Mesh) error {
+	if mesh.Spec.BackendDefaults == nil || mesh.Spec.BackendDefaults.ClientPolicy == nil || mesh.Spec.BackendDefaults.ClientPolicy.TLS == nil {
+		return nil
+	}
+	tls := mesh.Spec.BackendDefaults.ClientPolicy.TLS
+	trust := tls.Validation.Trust
+	trustCount := 0
+	if trust.ACM != nil {
+		trustCount++
+	}
+	if trust.File != nil {
+		trustCount++
+	}
+	if trust.SDS != nil {
+		trustCount++
+	}
+	if trustCount != 1 {
+		return errors.New("Mesh BackendDefaults ClientPolicy TLS Validation Trust must specify exactly one of acm, file or sds")
+	}
+	if trust.SDS != nil && aws.StringValue(trust.SDS.SecretName) == "" {
+		return errors.New("Mesh BackendDefaults ClientPolicy TLS Validation Trust sds must specify secretName")
+	}
+	if tls.Certificate != nil && (tls.Certificate.File == nil) == (tls.Certificate.SDS == nil) {
+		return errors.New("Mesh BackendDefaults ClientPolicy TLS Certificate must specify exactly one of file or sds")
+	}
+	if err := validateClientPolicyTLSPorts(mesh.Spec.BackendDefaults.ClientPolicy); err != nil {
+		return errors.Wrap(err, "Mesh BackendDefaults")
+	}
+	return nil
+}
+
+// checkVirtualGatewayDefaults checks the mesh's default connectionPool is well-formed, since it's applied to virtualGateway listeners in this mesh.
+// unlike a listener's connectionPool, settings for multiple protocols are allowed.
+func (v *meshValidator) checkVirtualGatewayDefaults(mesh *appmesh.Mesh) error {
+	if mesh.Spec.VirtualGatewayDefaults == nil || mesh.Spec.VirtualGatewayDefaults.ConnectionPool == nil {
+		return nil
+	}
+	connectionPool := mesh.Spec.VirtualGatewayDefaults.ConnectionPool
+	if connectionPool.HTTP == nil && connectionPool.HTTP2 == nil && connectionPool.GRPC == nil {
+		return errors.New("Mesh VirtualGatewayDefaults ConnectionPool must specify at least one of http, http2 or grpc")
+	}
+	if connectionPool.HTTP != nil {
+		if connectionPool.HTTP.MaxConnections < 1 {
+			return errors.New("Mesh VirtualGatewayDefaults ConnectionPool http maxConnections must be at least 1")
+		}
+		if connectionPool.HTTP.MaxPendingRequests != nil && *connectionPool.HTTP.MaxPendingRequests < 1 {
+			return errors.New("Mesh VirtualGatewayDefaults ConnectionPool http maxPendingRequests must be at least 1")
+		}
+	}
+	if connectionPool.HTTP2 != nil && connectionPool.HTTP2.MaxRequests < 1 {
+		return errors.New("Mesh VirtualGatewayDefaults ConnectionPool http2 maxRequests must be at least 1")
+	}
+	if connectionPool.GRPC != nil && connectionPool.GRPC.MaxRequests < 1 {
+		return errors.New("Mesh VirtualGatewayDefaults ConnectionPool grpc maxRequests must be at least 1")
+	}
+	return nil
+}
+
 // +kubebuilder:webhook:path=/validate-appmesh-k8s-aws-v1beta2-mesh,mutating=false,failurePolicy=fail,groups=appmesh.k8s.aws,resources=meshes,verbs=create;update,versions=v1beta2,name=vmesh.appmesh.k8s.aws,sideEffects=None,webhookVersions=v1beta1
 
 func (v *meshValidator) SetupWithManager(mgr ctrl.Manager) {
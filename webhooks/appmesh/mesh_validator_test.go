@@ -76,3 +76,252 @@ func Test_meshValidator_enforceFieldsImmutability(t *testing.T) {
 		})
 	}
 }
+
+func Test_meshValidator_checkBackendDefaults(t *testing.T) {
+	fileTrust := appmesh.TLSValidationContextTrust{
+		File: &appmesh.TLSValidationContextFileTrust{
+			CertificateChain: "/certs/ca-chain.pem",
+		},
+	}
+	tests := []struct {
+		name    string
+		mesh    *appmesh.Mesh
+		wantErr error
+	}{
+		{
+			name: "Mesh without backendDefaults",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Mesh backendDefaults with file trust",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					BackendDefaults: &appmesh.BackendDefaults{
+						ClientPolicy: &appmesh.ClientPolicy{
+							TLS: &appmesh.ClientPolicyTLS{
+								Enforce: aws.Bool(true),
+								Validation: appmesh.TLSValidationContext{
+									Trust: fileTrust,
+								},
+								Certificate: &appmesh.ClientTLSCertificate{
+									SDS: &appmesh.ListenerTLSSDSCertificate{
+										SecretName: aws.String("spiffe://example.org/client"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Mesh backendDefaults without trust",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					BackendDefaults: &appmesh.BackendDefaults{
+						ClientPolicy: &appmesh.ClientPolicy{
+							TLS: &appmesh.ClientPolicyTLS{},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Mesh BackendDefaults ClientPolicy TLS Validation Trust must specify exactly one of acm, file or sds"),
+		},
+		{
+			name: "Mesh backendDefaults with multiple trusts",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					BackendDefaults: &appmesh.BackendDefaults{
+						ClientPolicy: &appmesh.ClientPolicy{
+							TLS: &appmesh.ClientPolicyTLS{
+								Validation: appmesh.TLSValidationContext{
+									Trust: appmesh.TLSValidationContextTrust{
+										ACM: &appmesh.TLSValidationContextACMTrust{
+											CertificateAuthorityARNs: []string{"arn:aws:acm-pca:us-west-2:000000000000:certificate-authority/ca"},
+										},
+										File: fileTrust.File,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Mesh BackendDefaults ClientPolicy TLS Validation Trust must specify exactly one of acm, file or sds"),
+		},
+		{
+			name: "Mesh backendDefaults with sds trust without secretName",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					BackendDefaults: &appmesh.BackendDefaults{
+						ClientPolicy: &appmesh.ClientPolicy{
+							TLS: &appmesh.ClientPolicyTLS{
+								Validation: appmesh.TLSValidationContext{
+									Trust: appmesh.TLSValidationContextTrust{
+										SDS: &appmesh.TLSValidationContextSDSTrust{},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Mesh BackendDefaults ClientPolicy TLS Validation Trust sds must specify secretName"),
+		},
+		{
+			name: "Mesh backendDefaults with empty certificate",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					BackendDefaults: &appmesh.BackendDefaults{
+						ClientPolicy: &appmesh.ClientPolicy{
+							TLS: &appmesh.ClientPolicyTLS{
+								Validation: appmesh.TLSValidationContext{
+									Trust: fileTrust,
+								},
+								Certificate: &appmesh.ClientTLSCertificate{},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Mesh BackendDefaults ClientPolicy TLS Certificate must specify exactly one of file or sds"),
+		},
+		{
+			name: "Mesh backendDefaults with duplicate ports",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					BackendDefaults: &appmesh.BackendDefaults{
+						ClientPolicy: &appmesh.ClientPolicy{
+							TLS: &appmesh.ClientPolicyTLS{
+								Ports: []appmesh.PortNumber{443, 443},
+								Validation: appmesh.TLSValidationContext{
+									Trust: fileTrust,
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Mesh BackendDefaults: ClientPolicy TLS has duplicate port 443"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &meshValidator{}
+			err := v.checkBackendDefaults(tt.mesh)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_meshValidator_checkVirtualGatewayDefaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		mesh    *appmesh.Mesh
+		wantErr error
+	}{
+		{
+			name: "Mesh without virtualGatewayDefaults",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Mesh virtualGatewayDefaults with multiple protocols",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					VirtualGatewayDefaults: &appmesh.VirtualGatewayDefaults{
+						ConnectionPool: &appmesh.VirtualGatewayConnectionPool{
+							HTTP: &appmesh.HTTPConnectionPool{
+								MaxConnections:     100,
+								MaxPendingRequests: aws.Int64(50),
+							},
+							HTTP2: &appmesh.HTTP2ConnectionPool{
+								MaxRequests: 100,
+							},
+							GRPC: &appmesh.GRPCConnectionPool{
+								MaxRequests: 100,
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Mesh virtualGatewayDefaults with empty connectionPool",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					VirtualGatewayDefaults: &appmesh.VirtualGatewayDefaults{
+						ConnectionPool: &appmesh.VirtualGatewayConnectionPool{},
+					},
+				},
+			},
+			wantErr: errors.New("Mesh VirtualGatewayDefaults ConnectionPool must specify at least one of http, http2 or grpc"),
+		},
+		{
+			name: "Mesh virtualGatewayDefaults with zero http maxConnections",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					VirtualGatewayDefaults: &appmesh.VirtualGatewayDefaults{
+						ConnectionPool: &appmesh.VirtualGatewayConnectionPool{
+							HTTP: &appmesh.HTTPConnectionPool{},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Mesh VirtualGatewayDefaults ConnectionPool http maxConnections must be at least 1"),
+		},
+		{
+			name: "Mesh virtualGatewayDefaults with zero http maxPendingRequests",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					VirtualGatewayDefaults: &appmesh.VirtualGatewayDefaults{
+						ConnectionPool: &appmesh.VirtualGatewayConnectionPool{
+							HTTP: &appmesh.HTTPConnectionPool{
+								MaxConnections:     100,
+								MaxPendingRequests: aws.Int64(0),
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Mesh VirtualGatewayDefaults ConnectionPool http maxPendingRequests must be at least 1"),
+		},
+		{
+			name: "Mesh virtualGatewayDefaults with negative grpc maxRequests",
+			mesh: &appmesh.Mesh{
+				Spec: appmesh.MeshSpec{
+					VirtualGatewayDefaults: &appmesh.VirtualGatewayDefaults{
+						ConnectionPool: &appmesh.VirtualGatewayConnectionPool{
+							GRPC: &appmesh.GRPCConnectionPool{
+								MaxRequests: -1,
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Mesh VirtualGatewayDefaults ConnectionPool grpc maxRequests must be at least 1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &meshValidator{}
+			err := v.checkVirtualGatewayDefaults(tt.mesh)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
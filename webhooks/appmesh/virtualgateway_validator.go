@@ -3,6 +3,7 @@ package appmesh
 import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,13 +16,16 @@ import (
 const apiPathValidateAppMeshVirtualGateway = "/validate-appmesh-k8s-aws-v1beta2-virtualgateway"
 
 // NewVirtualGatewayValidator returns a validator for VirtualGateway.
-func NewVirtualGatewayValidator() *virtualGatewayValidator {
-	return &virtualGatewayValidator{}
+func NewVirtualGatewayValidator(meshMembershipValidator mesh.MembershipValidator) *virtualGatewayValidator {
+	return &virtualGatewayValidator{
+		meshMembershipValidator: meshMembershipValidator,
+	}
 }
 
 var _ webhook.Validator = &virtualGatewayValidator{}
 
 type virtualGatewayValidator struct {
+	meshMembershipValidator mesh.MembershipValidator
 }
 
 func (v *virtualGatewayValidator) Prototype(req admission.Request) (runtime.Object, error) {
@@ -30,10 +34,18 @@ func (v *virtualGatewayValidator) Prototype(req admission.Request) (runtime.Obje
 
 func (v *virtualGatewayValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
 	vg := obj.(*appmesh.VirtualGateway)
-
+	if err := v.meshMembershipValidator.Validate(ctx, vg, vg.Spec.MeshRef); err != nil {
+		return err
+	}
 	if err := v.checkForConnectionPoolProtocols(vg); err != nil {
 		return err
 	}
+	if err := v.checkForHealthCheckPorts(vg); err != nil {
+		return err
+	}
+	if err := v.checkForHealthCheckProtocols(vg); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -44,10 +56,19 @@ func (v *virtualGatewayValidator) ValidateUpdate(ctx context.Context, obj runtim
 	if err := v.enforceFieldsImmutability(vg, oldVGateway); err != nil {
 		return err
 	}
+	if err := v.meshMembershipValidator.Validate(ctx, vg, vg.Spec.MeshRef); err != nil {
+		return err
+	}
 
 	if err := v.checkForConnectionPoolProtocols(vg); err != nil {
 		return err
 	}
+	if err := v.checkForHealthCheckPorts(vg); err != nil {
+		return err
+	}
+	if err := v.checkForHealthCheckProtocols(vg); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -112,6 +133,50 @@ func (v *virtualGatewayValidator) checkListenerMultipleConnectionPools(ln appmes
 	return nil
 }
 
+// checkForHealthCheckPorts checks the health check of each listener targets the listener port, as required by AppMesh.
+func (v *virtualGatewayValidator) checkForHealthCheckPorts(vg *appmesh.VirtualGateway) error {
+	for _, listener := range vg.Spec.Listeners {
+		if listener.HealthCheck == nil || listener.HealthCheck.Port == nil {
+			continue
+		}
+		if *listener.HealthCheck.Port != listener.PortMapping.Port {
+			return errors.Errorf("Virtual Gateway Listener Health Check port %d must match listener port %d", *listener.HealthCheck.Port, listener.PortMapping.Port)
+		}
+	}
+	return nil
+}
+
+var virtualGatewayHealthCheckProtocolsByListenerProtocol = map[appmesh.VirtualGatewayPortProtocol][]appmesh.VirtualGatewayPortProtocol{
+	appmesh.VirtualGatewayPortProtocolHTTP:  {appmesh.VirtualGatewayPortProtocolHTTP},
+	appmesh.VirtualGatewayPortProtocolHTTP2: {appmesh.VirtualGatewayPortProtocolHTTP2, appmesh.VirtualGatewayPortProtocolGRPC},
+	appmesh.VirtualGatewayPortProtocolGRPC:  {appmesh.VirtualGatewayPortProtocolHTTP2, appmesh.VirtualGatewayPortProtocolGRPC},
+}
+
+func (v *virtualGatewayValidator) checkForHealthCheckProtocols(vg *appmesh.VirtualGateway) error {
+	for _, listener := range vg.Spec.Listeners {
+		if err := v.checkListenerHealthCheckProtocol(listener); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *virtualGatewayValidator) checkListenerHealthCheckProtocol(ln appmesh.VirtualGatewayListener) error {
+	if ln.HealthCheck == nil {
+		return nil
+	}
+	allowedProtocols, ok := virtualGatewayHealthCheckProtocolsByListenerProtocol[ln.PortMapping.Protocol]
+	if !ok {
+		return nil
+	}
+	for _, protocol := range allowedProtocols {
+		if ln.HealthCheck.Protocol == protocol {
+			return nil
+		}
+	}
+	return errors.Errorf("Virtual Gateway Listener Health Check protocol %s isn't compatible with listener protocol %s on port %d", ln.HealthCheck.Protocol, ln.PortMapping.Protocol, ln.PortMapping.Port)
+}
+
 // +kubebuilder:webhook:path=/validate-appmesh-k8s-aws-v1beta2-virtualgateway,mutating=false,failurePolicy=fail,groups=appmesh.k8s.aws,resources=virtualgateways,verbs=create;update,versions=v1beta2,name=vvirtualgateway.appmesh.k8s.aws,sideEffects=None,webhookVersions=v1beta1
 
 func (v *virtualGatewayValidator) SetupWithManager(mgr ctrl.Manager) {
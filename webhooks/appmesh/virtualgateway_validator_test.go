@@ -319,3 +319,186 @@ func Test_virtualGatewayValidator_checkForConnectionPoolProtocols(t *testing.T)
 	}
 
 }
+
+func Test_virtualGatewayValidator_checkForHealthCheckProtocols(t *testing.T) {
+	port8080 := appmesh.PortNumber(8080)
+	type args struct {
+		listenerProtocol    appmesh.VirtualGatewayPortProtocol
+		healthCheckProtocol appmesh.VirtualGatewayPortProtocol
+		healthCheckPort     *appmesh.PortNumber
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "http listener with http health check",
+			args: args{
+				listenerProtocol:    appmesh.VirtualGatewayPortProtocolHTTP,
+				healthCheckProtocol: appmesh.VirtualGatewayPortProtocolHTTP,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "http listener with http2 health check",
+			args: args{
+				listenerProtocol:    appmesh.VirtualGatewayPortProtocolHTTP,
+				healthCheckProtocol: appmesh.VirtualGatewayPortProtocolHTTP2,
+			},
+			wantErr: errors.New("Virtual Gateway Listener Health Check protocol http2 isn't compatible with listener protocol http on port 8080"),
+		},
+		{
+			name: "http listener with grpc health check on listener port",
+			args: args{
+				listenerProtocol:    appmesh.VirtualGatewayPortProtocolHTTP,
+				healthCheckProtocol: appmesh.VirtualGatewayPortProtocolGRPC,
+				healthCheckPort:     &port8080,
+			},
+			wantErr: errors.New("Virtual Gateway Listener Health Check protocol grpc isn't compatible with listener protocol http on port 8080"),
+		},
+		{
+			name: "http2 listener with http2 health check",
+			args: args{
+				listenerProtocol:    appmesh.VirtualGatewayPortProtocolHTTP2,
+				healthCheckProtocol: appmesh.VirtualGatewayPortProtocolHTTP2,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "http2 listener with grpc health check",
+			args: args{
+				listenerProtocol:    appmesh.VirtualGatewayPortProtocolHTTP2,
+				healthCheckProtocol: appmesh.VirtualGatewayPortProtocolGRPC,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "http2 listener with http health check",
+			args: args{
+				listenerProtocol:    appmesh.VirtualGatewayPortProtocolHTTP2,
+				healthCheckProtocol: appmesh.VirtualGatewayPortProtocolHTTP,
+			},
+			wantErr: errors.New("Virtual Gateway Listener Health Check protocol http isn't compatible with listener protocol http2 on port 8080"),
+		},
+		{
+			name: "grpc listener with grpc health check",
+			args: args{
+				listenerProtocol:    appmesh.VirtualGatewayPortProtocolGRPC,
+				healthCheckProtocol: appmesh.VirtualGatewayPortProtocolGRPC,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "grpc listener with http2 health check",
+			args: args{
+				listenerProtocol:    appmesh.VirtualGatewayPortProtocolGRPC,
+				healthCheckProtocol: appmesh.VirtualGatewayPortProtocolHTTP2,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "grpc listener with http health check",
+			args: args{
+				listenerProtocol:    appmesh.VirtualGatewayPortProtocolGRPC,
+				healthCheckProtocol: appmesh.VirtualGatewayPortProtocolHTTP,
+			},
+			wantErr: errors.New("Virtual Gateway Listener Health Check protocol http isn't compatible with listener protocol grpc on port 8080"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualGatewayValidator{}
+			vg := &appmesh.VirtualGateway{
+				Spec: appmesh.VirtualGatewaySpec{
+					Listeners: []appmesh.VirtualGatewayListener{
+						{
+							PortMapping: appmesh.VirtualGatewayPortMapping{
+								Port:     8080,
+								Protocol: tt.args.listenerProtocol,
+							},
+							HealthCheck: &appmesh.VirtualGatewayHealthCheckPolicy{
+								HealthyThreshold:   2,
+								IntervalMillis:     5000,
+								Port:               tt.args.healthCheckPort,
+								Protocol:           tt.args.healthCheckProtocol,
+								TimeoutMillis:      2000,
+								UnhealthyThreshold: 2,
+							},
+						},
+					},
+				},
+			}
+			err := v.checkForHealthCheckProtocols(vg)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_virtualGatewayValidator_checkForHealthCheckPorts(t *testing.T) {
+	port8080 := appmesh.PortNumber(8080)
+	port8081 := appmesh.PortNumber(8081)
+	tests := []struct {
+		name    string
+		vg      *appmesh.VirtualGateway
+		wantErr error
+	}{
+		{
+			name: "health check without port",
+			vg: &appmesh.VirtualGateway{
+				Spec: appmesh.VirtualGatewaySpec{
+					Listeners: []appmesh.VirtualGatewayListener{
+						{
+							PortMapping: appmesh.VirtualGatewayPortMapping{Port: 8080, Protocol: appmesh.VirtualGatewayPortProtocolHTTP},
+							HealthCheck: &appmesh.VirtualGatewayHealthCheckPolicy{Protocol: appmesh.VirtualGatewayPortProtocolHTTP},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "health check on listener port",
+			vg: &appmesh.VirtualGateway{
+				Spec: appmesh.VirtualGatewaySpec{
+					Listeners: []appmesh.VirtualGatewayListener{
+						{
+							PortMapping: appmesh.VirtualGatewayPortMapping{Port: 8080, Protocol: appmesh.VirtualGatewayPortProtocolHTTP},
+							HealthCheck: &appmesh.VirtualGatewayHealthCheckPolicy{Port: &port8080, Protocol: appmesh.VirtualGatewayPortProtocolHTTP},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "health check on another port",
+			vg: &appmesh.VirtualGateway{
+				Spec: appmesh.VirtualGatewaySpec{
+					Listeners: []appmesh.VirtualGatewayListener{
+						{
+							PortMapping: appmesh.VirtualGatewayPortMapping{Port: 8080, Protocol: appmesh.VirtualGatewayPortProtocolHTTP},
+							HealthCheck: &appmesh.VirtualGatewayHealthCheckPolicy{Port: &port8081, Protocol: appmesh.VirtualGatewayPortProtocolHTTP},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Virtual Gateway Listener Health Check port 8081 must match listener port 8080"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualGatewayValidator{}
+			err := v.checkForHealthCheckPorts(tt.vg)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,9 +16,10 @@ import (
 const apiPathMutateAppMeshVirtualNode = "/mutate-appmesh-k8s-aws-v1beta2-virtualnode"
 
 // NewVirtualNodeMutator returns a mutator for VirtualNode.
-func NewVirtualNodeMutator(meshMembershipDesignator mesh.MembershipDesignator) *virtualNodeMutator {
+func NewVirtualNodeMutator(meshMembershipDesignator mesh.MembershipDesignator, vnConfig virtualnode.Config) *virtualNodeMutator {
 	return &virtualNodeMutator{
 		meshMembershipDesignator: meshMembershipDesignator,
+		vnConfig:                 vnConfig,
 	}
 }
 
@@ -25,6 +27,7 @@ var _ webhook.Mutator = &virtualNodeMutator{}
 
 type virtualNodeMutator struct {
 	meshMembershipDesignator mesh.MembershipDesignator
+	vnConfig                 virtualnode.Config
 }
 
 func (m *virtualNodeMutator) Prototype(req admission.Request) (runtime.Object, error) {
@@ -49,7 +52,9 @@ func (m *virtualNodeMutator) MutateUpdate(ctx context.Context, obj runtime.Objec
 
 func (m *virtualNodeMutator) defaultingAWSName(vn *appmesh.VirtualNode) error {
 	if vn.Spec.AWSName == nil || len(*vn.Spec.AWSName) == 0 {
-		awsName := fmt.Sprintf("%s_%s", vn.Name, vn.Namespace)
+		// prefix and suffix are only applied while defaulting, awsName is immutable afterwards so
+		// the AppMesh name stays stable across reconciles and flag changes.
+		awsName := m.vnConfig.BuildAWSName(fmt.Sprintf("%s_%s", vn.Name, vn.Namespace))
 		vn.Spec.AWSName = &awsName
 	}
 	return nil
@@ -70,7 +75,7 @@ func (m *virtualNodeMutator) designateMeshMembership(ctx context.Context, vn *ap
 	return nil
 }
 
-// +kubebuilder:webhook:path=/mutate-appmesh-k8s-aws-v1beta2-virtualnode,mutating=true,failurePolicy=fail,groups=appmesh.k8s.aws,resources=virtualnodes,verbs=create;update,versions=v1beta2,name=mvirtualnode.appmesh.k8s.aws,sideEffects=None,webhookVersions=v1beta1
+// +kubebuilder:webhook:path=/mutate-appmesh-k8s-aws-v1beta2-virtualnode,mutating=true,failurePolicy=fail,groups=appmesh.k8s.aws,resources=virtualnodes,verbs=create;update,versions=v1beta2,name=mvirtualnode.appmesh.k8s.aws,sideEffects=NoneOnDryRun,webhookVersions=v1beta1
 
 func (m *virtualNodeMutator) SetupWithManager(mgr ctrl.Manager) {
 	mgr.GetWebhookServer().Register(apiPathMutateAppMeshVirtualNode, webhook.MutatingWebhookForMutator(m))
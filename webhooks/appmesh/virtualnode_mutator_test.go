@@ -4,6 +4,7 @@ import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	mock_mesh "github.com/aws/aws-app-mesh-controller-for-k8s/mocks/aws-app-mesh-controller-for-k8s/pkg/mesh"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualnode"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
@@ -16,8 +17,12 @@ func Test_virtualNodeMutator_defaultingAWSName(t *testing.T) {
 	type args struct {
 		vn *appmesh.VirtualNode
 	}
+	type fields struct {
+		vnConfig virtualnode.Config
+	}
 	tests := []struct {
 		name    string
+		fields  fields
 		args    args
 		want    *appmesh.VirtualNode
 		wantErr error
@@ -89,10 +94,68 @@ func Test_virtualNodeMutator_defaultingAWSName(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "VirtualNode didn't specify awsName, with prefix and suffix",
+			fields: fields{
+				vnConfig: virtualnode.Config{
+					ResourceNamePrefix: "dev-",
+					ResourceNameSuffix: "_prod",
+				},
+			},
+			args: args{
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "my-vn",
+					},
+					Spec: appmesh.VirtualNodeSpec{},
+				},
+			},
+			want: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vn",
+				},
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("dev-my-vn_awesome-ns_prod"),
+				},
+			},
+		},
+		{
+			name: "VirtualNode specified non-empty awsName, with prefix and suffix",
+			fields: fields{
+				vnConfig: virtualnode.Config{
+					ResourceNamePrefix: "dev-",
+					ResourceNameSuffix: "_prod",
+				},
+			},
+			args: args{
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "my-vn",
+					},
+					Spec: appmesh.VirtualNodeSpec{
+						AWSName: aws.String("my-vn_awesome-ns_my-cluster"),
+					},
+				},
+			},
+			want: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vn",
+				},
+				Spec: appmesh.VirtualNodeSpec{
+					AWSName: aws.String("my-vn_awesome-ns_my-cluster"),
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := &virtualNodeMutator{}
+			m := &virtualNodeMutator{
+				vnConfig: tt.fields.vnConfig,
+			}
 			err := m.defaultingAWSName(tt.args.vn)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
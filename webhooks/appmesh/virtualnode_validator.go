@@ -3,6 +3,8 @@ package appmesh
 import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/conversions"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/references"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
 	"github.com/pkg/errors"
@@ -16,13 +18,16 @@ import (
 const apiPathValidateAppMeshVirtualNode = "/validate-appmesh-k8s-aws-v1beta2-virtualnode"
 
 // NewVirtualNodeValidator returns a validator for VirtualNode.
-func NewVirtualNodeValidator() *virtualNodeValidator {
-	return &virtualNodeValidator{}
+func NewVirtualNodeValidator(meshMembershipValidator mesh.MembershipValidator) *virtualNodeValidator {
+	return &virtualNodeValidator{
+		meshMembershipValidator: meshMembershipValidator,
+	}
 }
 
 var _ webhook.Validator = &virtualNodeValidator{}
 
 type virtualNodeValidator struct {
+	meshMembershipValidator mesh.MembershipValidator
 }
 
 func (v *virtualNodeValidator) Prototype(req admission.Request) (runtime.Object, error) {
@@ -31,15 +36,36 @@ func (v *virtualNodeValidator) Prototype(req admission.Request) (runtime.Object,
 
 func (v *virtualNodeValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
 	vn := obj.(*appmesh.VirtualNode)
+	if err := v.meshMembershipValidator.Validate(ctx, vn, vn.Spec.MeshRef); err != nil {
+		return err
+	}
 	if err := v.checkForRequiredFields(vn); err != nil {
 		return err
 	}
 	if err := v.checkVirtualNodeBackendsForDuplicates(vn); err != nil {
 		return err
 	}
+	if err := v.checkVirtualNodeBackendReferences(vn); err != nil {
+		return err
+	}
 	if err := v.checkForConnectionPoolProtocols(vn); err != nil {
 		return err
 	}
+	if err := v.checkForListenerTimeoutProtocols(vn); err != nil {
+		return err
+	}
+	if err := v.checkForHealthCheckPorts(vn); err != nil {
+		return err
+	}
+	if err := v.checkForClientPolicyTLSPorts(vn); err != nil {
+		return err
+	}
+	if err := v.checkForEnvoyLogLevel(vn); err != nil {
+		return err
+	}
+	if err := v.checkForHealthCheckProtocols(vn); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -52,12 +78,33 @@ func (v *virtualNodeValidator) ValidateUpdate(ctx context.Context, obj runtime.O
 	if err := v.enforceFieldsImmutability(vn, oldVN); err != nil {
 		return err
 	}
+	if err := v.meshMembershipValidator.Validate(ctx, vn, vn.Spec.MeshRef); err != nil {
+		return err
+	}
 	if err := v.checkVirtualNodeBackendsForDuplicates(vn); err != nil {
 		return err
 	}
+	if err := v.checkVirtualNodeBackendReferences(vn); err != nil {
+		return err
+	}
 	if err := v.checkForConnectionPoolProtocols(vn); err != nil {
 		return err
 	}
+	if err := v.checkForListenerTimeoutProtocols(vn); err != nil {
+		return err
+	}
+	if err := v.checkForHealthCheckPorts(vn); err != nil {
+		return err
+	}
+	if err := v.checkForClientPolicyTLSPorts(vn); err != nil {
+		return err
+	}
+	if err := v.checkForEnvoyLogLevel(vn); err != nil {
+		return err
+	}
+	if err := v.checkForHealthCheckProtocols(vn); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -108,11 +155,34 @@ func (v *virtualNodeValidator) checkVirtualNodeBackendsForDuplicates(vn *appmesh
 	return nil
 }
 
+func (v *virtualNodeValidator) checkVirtualNodeBackendReferences(vn *appmesh.VirtualNode) error {
+	//backends in a shared mesh can be referenced by ARN, which is passed to App Mesh without resolving a local VirtualService
+	for _, backend := range vn.Spec.Backends {
+		vsRef := backend.VirtualService.VirtualServiceRef
+		vsARN := backend.VirtualService.VirtualServiceARN
+		if (vsRef == nil) == (vsARN == nil) {
+			return errors.Errorf("%s-%s backend must specify exactly one of virtualServiceRef or virtualServiceARN", "VirtualNode", vn.Name)
+		}
+		if vsARN != nil {
+			var vsName string
+			if err := conversions.Convert_CRD_VirtualServiceARN_To_SDK_VirtualServiceName(vsARN, &vsName, nil); err != nil {
+				return errors.Wrapf(err, "%s-%s has invalid VirtualServiceARN %s", "VirtualNode", vn.Name, *vsARN)
+			}
+		}
+	}
+	return nil
+}
+
 func (v *virtualNodeValidator) checkForRequiredFields(vn *appmesh.VirtualNode) error {
 	//ServiceDiscovery is mandatory if a listener is specified
 	if vn.Spec.Listeners != nil && vn.Spec.ServiceDiscovery == nil {
 		return errors.Errorf("ServiceDiscovery missing for %s-%s. ServiceDiscovery must be specified when a listener is specified.", "VirtualNode", vn.Name)
 	}
+	//AWSCloudMap namespace is referenced by either name or ID
+	if vn.Spec.ServiceDiscovery != nil && vn.Spec.ServiceDiscovery.AWSCloudMap != nil &&
+		vn.Spec.ServiceDiscovery.AWSCloudMap.NamespaceName == "" && vn.Spec.ServiceDiscovery.AWSCloudMap.NamespaceID == "" {
+		return errors.Errorf("either namespaceName or namespaceID must be specified for awsCloudMap serviceDiscovery of %s-%s", "VirtualNode", vn.Name)
+	}
 	return nil
 }
 
@@ -124,6 +194,9 @@ func (v *virtualNodeValidator) checkForConnectionPoolProtocols(vn *appmesh.Virtu
 			if err != nil {
 				return err
 			}
+			if err := v.checkListenerConnectionPoolProtocol(listener); err != nil {
+				return err
+			}
 
 		}
 	}
@@ -162,6 +235,164 @@ func (v *virtualNodeValidator) checkListenerMultipleConnectionPools(ln appmesh.L
 	return nil
 }
 
+func (v *virtualNodeValidator) checkListenerConnectionPoolProtocol(ln appmesh.Listener) error {
+	//App Mesh only accepts the connection pool matching the listener protocol
+	if ln.ConnectionPool == nil {
+		return nil
+	}
+	var poolProtocol appmesh.PortProtocol
+	switch {
+	case ln.ConnectionPool.TCP != nil:
+		poolProtocol = appmesh.PortProtocolTCP
+	case ln.ConnectionPool.HTTP != nil:
+		poolProtocol = appmesh.PortProtocolHTTP
+	case ln.ConnectionPool.HTTP2 != nil:
+		poolProtocol = appmesh.PortProtocolHTTP2
+	case ln.ConnectionPool.GRPC != nil:
+		poolProtocol = appmesh.PortProtocolGRPC
+	default:
+		return nil
+	}
+	if poolProtocol != ln.PortMapping.Protocol {
+		return errors.Errorf("Virtual Node Listener Connection Pool %s doesn't match listener protocol %s", poolProtocol, ln.PortMapping.Protocol)
+	}
+	return nil
+}
+
+func (v *virtualNodeValidator) checkForListenerTimeoutProtocols(vn *appmesh.VirtualNode) error {
+	//App Mesh only applies the listener timeout matching the listener protocol
+	for _, listener := range vn.Spec.Listeners {
+		if err := v.checkListenerTimeoutProtocol(listener); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *virtualNodeValidator) checkListenerTimeoutProtocol(ln appmesh.Listener) error {
+	if ln.Timeout == nil {
+		return nil
+	}
+	var timeoutProtocols []appmesh.PortProtocol
+	if ln.Timeout.TCP != nil {
+		timeoutProtocols = append(timeoutProtocols, appmesh.PortProtocolTCP)
+	}
+	if ln.Timeout.HTTP != nil {
+		timeoutProtocols = append(timeoutProtocols, appmesh.PortProtocolHTTP)
+	}
+	if ln.Timeout.HTTP2 != nil {
+		timeoutProtocols = append(timeoutProtocols, appmesh.PortProtocolHTTP2)
+	}
+	if ln.Timeout.GRPC != nil {
+		timeoutProtocols = append(timeoutProtocols, appmesh.PortProtocolGRPC)
+	}
+
+	if len(timeoutProtocols) > 1 {
+		return errors.Errorf("Only one type of Virtual Node Listener Timeout is allowed")
+	}
+	if len(timeoutProtocols) == 1 && timeoutProtocols[0] != ln.PortMapping.Protocol {
+		return errors.Errorf("Virtual Node Listener Timeout %s doesn't match listener protocol %s", timeoutProtocols[0], ln.PortMapping.Protocol)
+	}
+	return nil
+}
+
+// checkForHealthCheckPorts checks the health check of each listener targets the listener port, as required by AppMesh.
+func (v *virtualNodeValidator) checkForHealthCheckPorts(vn *appmesh.VirtualNode) error {
+	for _, listener := range vn.Spec.Listeners {
+		if listener.HealthCheck == nil || listener.HealthCheck.Port == nil {
+			continue
+		}
+		if *listener.HealthCheck.Port != listener.PortMapping.Port {
+			return errors.Errorf("Virtual Node Listener Health Check port %d must match listener port %d", *listener.HealthCheck.Port, listener.PortMapping.Port)
+		}
+	}
+	return nil
+}
+
+// checkForClientPolicyTLSPorts checks the ports of the TLS client policies of backendDefaults and backends.
+func (v *virtualNodeValidator) checkForClientPolicyTLSPorts(vn *appmesh.VirtualNode) error {
+	if vn.Spec.BackendDefaults != nil {
+		if err := validateClientPolicyTLSPorts(vn.Spec.BackendDefaults.ClientPolicy); err != nil {
+			return errors.Wrapf(err, "%s-%s backendDefaults", "VirtualNode", vn.Name)
+		}
+	}
+	for i, backend := range vn.Spec.Backends {
+		if err := validateClientPolicyTLSPorts(backend.VirtualService.ClientPolicy); err != nil {
+			return errors.Wrapf(err, "%s-%s backends[%d]", "VirtualNode", vn.Name, i)
+		}
+	}
+	return nil
+}
+
+// validateClientPolicyTLSPorts checks the ports a TLS client policy is enforced for are valid and unique.
+// the policy is enforced for all ports when no port is specified.
+func validateClientPolicyTLSPorts(clientPolicy *appmesh.ClientPolicy) error {
+	if clientPolicy == nil || clientPolicy.TLS == nil {
+		return nil
+	}
+	seenPorts := make(map[appmesh.PortNumber]bool, len(clientPolicy.TLS.Ports))
+	for _, port := range clientPolicy.TLS.Ports {
+		if port < 1 || port > 65535 {
+			return errors.Errorf("ClientPolicy TLS port %d must be between 1 and 65535", port)
+		}
+		if seenPorts[port] {
+			return errors.Errorf("ClientPolicy TLS has duplicate port %d", port)
+		}
+		seenPorts[port] = true
+	}
+	return nil
+}
+
+var validEnvoyLogLevels = []appmesh.EnvoyLogLevel{"trace", "debug", "info", "warning", "error", "critical", "off"}
+
+// checkForEnvoyLogLevel checks the Envoy log level of logging is supported by Envoy.
+func (v *virtualNodeValidator) checkForEnvoyLogLevel(vn *appmesh.VirtualNode) error {
+	if vn.Spec.Logging == nil || vn.Spec.Logging.EnvoyLogLevel == nil {
+		return nil
+	}
+	logLevel := *vn.Spec.Logging.EnvoyLogLevel
+	for _, validLogLevel := range validEnvoyLogLevels {
+		if logLevel == validLogLevel {
+			return nil
+		}
+	}
+	return errors.Errorf("%s-%s has unsupported Envoy log level %s, supported log levels: %v", "VirtualNode", vn.Name, logLevel, validEnvoyLogLevels)
+}
+
+// healthCheckProtocolsByListenerProtocol lists the health check protocols that can probe a listener on its own port.
+// tcp listeners can carry any application protocol, so they accept any health check protocol.
+var healthCheckProtocolsByListenerProtocol = map[appmesh.PortProtocol][]appmesh.PortProtocol{
+	appmesh.PortProtocolTCP:   {appmesh.PortProtocolTCP, appmesh.PortProtocolHTTP, appmesh.PortProtocolHTTP2, appmesh.PortProtocolGRPC},
+	appmesh.PortProtocolHTTP:  {appmesh.PortProtocolTCP, appmesh.PortProtocolHTTP},
+	appmesh.PortProtocolHTTP2: {appmesh.PortProtocolTCP, appmesh.PortProtocolHTTP2, appmesh.PortProtocolGRPC},
+	appmesh.PortProtocolGRPC:  {appmesh.PortProtocolTCP, appmesh.PortProtocolHTTP2, appmesh.PortProtocolGRPC},
+}
+
+func (v *virtualNodeValidator) checkForHealthCheckProtocols(vn *appmesh.VirtualNode) error {
+	for _, listener := range vn.Spec.Listeners {
+		if err := v.checkListenerHealthCheckProtocol(listener); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *virtualNodeValidator) checkListenerHealthCheckProtocol(ln appmesh.Listener) error {
+	if ln.HealthCheck == nil {
+		return nil
+	}
+	allowedProtocols, ok := healthCheckProtocolsByListenerProtocol[ln.PortMapping.Protocol]
+	if !ok {
+		return nil
+	}
+	for _, protocol := range allowedProtocols {
+		if ln.HealthCheck.Protocol == protocol {
+			return nil
+		}
+	}
+	return errors.Errorf("Virtual Node Listener Health Check protocol %s isn't compatible with listener protocol %s on port %d", ln.HealthCheck.Protocol, ln.PortMapping.Protocol, ln.PortMapping.Port)
+}
+
 // +kubebuilder:webhook:path=/validate-appmesh-k8s-aws-v1beta2-virtualnode,mutating=false,failurePolicy=fail,groups=appmesh.k8s.aws,resources=virtualnodes,verbs=create;update,versions=v1beta2,name=vvirtualnode.appmesh.k8s.aws,sideEffects=None,webhookVersions=v1beta1
 
 func (v *virtualNodeValidator) SetupWithManager(mgr ctrl.Manager) {
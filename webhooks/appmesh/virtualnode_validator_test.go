@@ -1,6 +1,7 @@
 package appmesh
 
 import (
+	"fmt"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
@@ -608,6 +609,45 @@ func Test_virtualNodeValidator_checkRequiredFields(t *testing.T) {
 			},
 			wantErr: errors.New("ServiceDiscovery missing for VirtualNode-TestVN. ServiceDiscovery must be specified when a listener is specified."),
 		},
+		{
+			name: "AWSCloudMap namespace referenced by ID",
+			args: args{
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "TestVN",
+					},
+					Spec: appmesh.VirtualNodeSpec{
+						ServiceDiscovery: &appmesh.ServiceDiscovery{
+							AWSCloudMap: &appmesh.AWSCloudMapServiceDiscovery{
+								NamespaceID: "ns-1",
+								ServiceName: "my-svc",
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "AWSCloudMap namespace referenced by neither name nor ID",
+			args: args{
+				vn: &appmesh.VirtualNode{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "TestVN",
+					},
+					Spec: appmesh.VirtualNodeSpec{
+						ServiceDiscovery: &appmesh.ServiceDiscovery{
+							AWSCloudMap: &appmesh.AWSCloudMapServiceDiscovery{
+								ServiceName: "my-svc",
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("either namespaceName or namespaceID must be specified for awsCloudMap serviceDiscovery of VirtualNode-TestVN"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -890,3 +930,546 @@ func Test_virtualNodeValidator_checkForConnectionPoolProtocols(t *testing.T) {
 		})
 	}
 }
+
+func Test_virtualNodeValidator_checkListenerTimeoutProtocol(t *testing.T) {
+	type args struct {
+		ln appmesh.Listener
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "tcp listener without timeout",
+			args: args{
+				ln: appmesh.Listener{
+					PortMapping: appmesh.PortMapping{
+						Port:     8080,
+						Protocol: "tcp",
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "tcp listener with tcp idle timeout",
+			args: args{
+				ln: appmesh.Listener{
+					PortMapping: appmesh.PortMapping{
+						Port:     8080,
+						Protocol: "tcp",
+					},
+					Timeout: &appmesh.ListenerTimeout{
+						TCP: &appmesh.TCPTimeout{
+							Idle: &appmesh.Duration{
+								Unit:  appmesh.DurationUnitS,
+								Value: 600,
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "http listener with tcp idle timeout",
+			args: args{
+				ln: appmesh.Listener{
+					PortMapping: appmesh.PortMapping{
+						Port:     8080,
+						Protocol: "http",
+					},
+					Timeout: &appmesh.ListenerTimeout{
+						TCP: &appmesh.TCPTimeout{
+							Idle: &appmesh.Duration{
+								Unit:  appmesh.DurationUnitS,
+								Value: 600,
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Virtual Node Listener Timeout tcp doesn't match listener protocol http"),
+		},
+		{
+			name: "tcp listener with both tcp and http timeouts",
+			args: args{
+				ln: appmesh.Listener{
+					PortMapping: appmesh.PortMapping{
+						Port:     8080,
+						Protocol: "tcp",
+					},
+					Timeout: &appmesh.ListenerTimeout{
+						TCP: &appmesh.TCPTimeout{
+							Idle: &appmesh.Duration{
+								Unit:  appmesh.DurationUnitS,
+								Value: 600,
+							},
+						},
+						HTTP: &appmesh.HTTPTimeout{
+							Idle: &appmesh.Duration{
+								Unit:  appmesh.DurationUnitS,
+								Value: 600,
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Only one type of Virtual Node Listener Timeout is allowed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualNodeValidator{}
+			err := v.checkListenerTimeoutProtocol(tt.args.ln)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_virtualNodeValidator_checkVirtualNodeBackendReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		backends []appmesh.Backend
+		wantErr  error
+	}{
+		{
+			name: "backend by virtualServiceRef",
+			backends: []appmesh.Backend{
+				{
+					VirtualService: appmesh.VirtualServiceBackend{
+						VirtualServiceRef: &appmesh.VirtualServiceReference{
+							Name: "vs-1",
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "backend by virtualServiceARN",
+			backends: []appmesh.Backend{
+				{
+					VirtualService: appmesh.VirtualServiceBackend{
+						VirtualServiceARN: aws.String("arn:aws:appmesh:us-west-2:222222222222:mesh/shared-mesh/virtualService/vs-1.shared.local"),
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "backend with both virtualServiceRef and virtualServiceARN",
+			backends: []appmesh.Backend{
+				{
+					VirtualService: appmesh.VirtualServiceBackend{
+						VirtualServiceRef: &appmesh.VirtualServiceReference{
+							Name: "vs-1",
+						},
+						VirtualServiceARN: aws.String("arn:aws:appmesh:us-west-2:222222222222:mesh/shared-mesh/virtualService/vs-1.shared.local"),
+					},
+				},
+			},
+			wantErr: errors.New("VirtualNode-my-vn backend must specify exactly one of virtualServiceRef or virtualServiceARN"),
+		},
+		{
+			name: "backend without virtualServiceRef or virtualServiceARN",
+			backends: []appmesh.Backend{
+				{
+					VirtualService: appmesh.VirtualServiceBackend{},
+				},
+			},
+			wantErr: errors.New("VirtualNode-my-vn backend must specify exactly one of virtualServiceRef or virtualServiceARN"),
+		},
+		{
+			name: "backend with malformed virtualServiceARN",
+			backends: []appmesh.Backend{
+				{
+					VirtualService: appmesh.VirtualServiceBackend{
+						VirtualServiceARN: aws.String("vs-1.shared.local"),
+					},
+				},
+			},
+			wantErr: errors.New("VirtualNode-my-vn has invalid VirtualServiceARN vs-1.shared.local: invalid arn: arn: invalid prefix"),
+		},
+		{
+			name: "backend with virtualNode ARN",
+			backends: []appmesh.Backend{
+				{
+					VirtualService: appmesh.VirtualServiceBackend{
+						VirtualServiceARN: aws.String("arn:aws:appmesh:us-west-2:222222222222:mesh/shared-mesh/virtualNode/vn-1"),
+					},
+				},
+			},
+			wantErr: errors.New("VirtualNode-my-vn has invalid VirtualServiceARN arn:aws:appmesh:us-west-2:222222222222:mesh/shared-mesh/virtualNode/vn-1: expects virtualService ARN, got virtualNode"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualNodeValidator{}
+			vn := &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vn",
+				},
+				Spec: appmesh.VirtualNodeSpec{
+					Backends: tt.backends,
+				},
+			}
+			err := v.checkVirtualNodeBackendReferences(vn)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_virtualNodeValidator_checkListenerConnectionPoolProtocol(t *testing.T) {
+	connectionPools := map[appmesh.PortProtocol]*appmesh.VirtualNodeConnectionPool{
+		appmesh.PortProtocolTCP: {
+			TCP: &appmesh.TCPConnectionPool{MaxConnections: 10},
+		},
+		appmesh.PortProtocolHTTP: {
+			HTTP: &appmesh.HTTPConnectionPool{MaxConnections: 10},
+		},
+		appmesh.PortProtocolHTTP2: {
+			HTTP2: &appmesh.HTTP2ConnectionPool{MaxRequests: 10},
+		},
+		appmesh.PortProtocolGRPC: {
+			GRPC: &appmesh.GRPCConnectionPool{MaxRequests: 10},
+		},
+	}
+	protocols := []appmesh.PortProtocol{appmesh.PortProtocolTCP, appmesh.PortProtocolHTTP, appmesh.PortProtocolHTTP2, appmesh.PortProtocolGRPC}
+	for _, listenerProtocol := range protocols {
+		for _, poolProtocol := range protocols {
+			t.Run(fmt.Sprintf("%s listener with %s connection pool", listenerProtocol, poolProtocol), func(t *testing.T) {
+				v := &virtualNodeValidator{}
+				ln := appmesh.Listener{
+					PortMapping: appmesh.PortMapping{
+						Port:     8080,
+						Protocol: listenerProtocol,
+					},
+					ConnectionPool: connectionPools[poolProtocol],
+				}
+				err := v.checkListenerConnectionPoolProtocol(ln)
+				if listenerProtocol == poolProtocol {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, fmt.Sprintf("Virtual Node Listener Connection Pool %s doesn't match listener protocol %s", poolProtocol, listenerProtocol))
+				}
+			})
+		}
+	}
+	t.Run("listener without connection pool", func(t *testing.T) {
+		v := &virtualNodeValidator{}
+		ln := appmesh.Listener{
+			PortMapping: appmesh.PortMapping{
+				Port:     8080,
+				Protocol: appmesh.PortProtocolHTTP,
+			},
+		}
+		assert.NoError(t, v.checkListenerConnectionPoolProtocol(ln))
+	})
+}
+
+func Test_virtualNodeValidator_checkListenerHealthCheckProtocol(t *testing.T) {
+	compatible := map[appmesh.PortProtocol]map[appmesh.PortProtocol]bool{
+		appmesh.PortProtocolTCP: {
+			appmesh.PortProtocolTCP:   true,
+			appmesh.PortProtocolHTTP:  true,
+			appmesh.PortProtocolHTTP2: true,
+			appmesh.PortProtocolGRPC:  true,
+		},
+		appmesh.PortProtocolHTTP: {
+			appmesh.PortProtocolTCP:  true,
+			appmesh.PortProtocolHTTP: true,
+		},
+		appmesh.PortProtocolHTTP2: {
+			appmesh.PortProtocolTCP:   true,
+			appmesh.PortProtocolHTTP2: true,
+			appmesh.PortProtocolGRPC:  true,
+		},
+		appmesh.PortProtocolGRPC: {
+			appmesh.PortProtocolTCP:   true,
+			appmesh.PortProtocolHTTP2: true,
+			appmesh.PortProtocolGRPC:  true,
+		},
+	}
+	protocols := []appmesh.PortProtocol{appmesh.PortProtocolTCP, appmesh.PortProtocolHTTP, appmesh.PortProtocolHTTP2, appmesh.PortProtocolGRPC}
+	for _, listenerProtocol := range protocols {
+		for _, healthCheckProtocol := range protocols {
+			t.Run(fmt.Sprintf("%s listener with %s health check", listenerProtocol, healthCheckProtocol), func(t *testing.T) {
+				v := &virtualNodeValidator{}
+				ln := appmesh.Listener{
+					PortMapping: appmesh.PortMapping{
+						Port:     8080,
+						Protocol: listenerProtocol,
+					},
+					HealthCheck: &appmesh.HealthCheckPolicy{
+						Protocol: healthCheckProtocol,
+					},
+				}
+				err := v.checkListenerHealthCheckProtocol(ln)
+				if compatible[listenerProtocol][healthCheckProtocol] {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, fmt.Sprintf("Virtual Node Listener Health Check protocol %s isn't compatible with listener protocol %s on port 8080", healthCheckProtocol, listenerProtocol))
+				}
+			})
+		}
+	}
+	t.Run("http listener with grpc health check on listener port", func(t *testing.T) {
+		v := &virtualNodeValidator{}
+		port := appmesh.PortNumber(8080)
+		ln := appmesh.Listener{
+			PortMapping: appmesh.PortMapping{
+				Port:     8080,
+				Protocol: appmesh.PortProtocolHTTP,
+			},
+			HealthCheck: &appmesh.HealthCheckPolicy{
+				Port:     &port,
+				Protocol: appmesh.PortProtocolGRPC,
+			},
+		}
+		assert.EqualError(t, v.checkListenerHealthCheckProtocol(ln), "Virtual Node Listener Health Check protocol grpc isn't compatible with listener protocol http on port 8080")
+	})
+}
+
+func Test_virtualNodeValidator_checkForHealthCheckPorts(t *testing.T) {
+	port8080 := appmesh.PortNumber(8080)
+	port8081 := appmesh.PortNumber(8081)
+	tests := []struct {
+		name    string
+		vn      *appmesh.VirtualNode
+		wantErr error
+	}{
+		{
+			name: "health check without port",
+			vn: &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					Listeners: []appmesh.Listener{
+						{
+							PortMapping: appmesh.PortMapping{Port: 8080, Protocol: appmesh.PortProtocolHTTP},
+							HealthCheck: &appmesh.HealthCheckPolicy{Protocol: appmesh.PortProtocolHTTP},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "health check on listener port",
+			vn: &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					Listeners: []appmesh.Listener{
+						{
+							PortMapping: appmesh.PortMapping{Port: 8080, Protocol: appmesh.PortProtocolHTTP},
+							HealthCheck: &appmesh.HealthCheckPolicy{Port: &port8080, Protocol: appmesh.PortProtocolHTTP},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "health check on dedicated listener port",
+			vn: &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					Listeners: []appmesh.Listener{
+						{
+							PortMapping: appmesh.PortMapping{Port: 8080, Protocol: appmesh.PortProtocolHTTP},
+						},
+						{
+							PortMapping: appmesh.PortMapping{Port: 8081, Protocol: appmesh.PortProtocolHTTP},
+							HealthCheck: &appmesh.HealthCheckPolicy{Port: &port8081, Protocol: appmesh.PortProtocolHTTP},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "health check on another port",
+			vn: &appmesh.VirtualNode{
+				Spec: appmesh.VirtualNodeSpec{
+					Listeners: []appmesh.Listener{
+						{
+							PortMapping: appmesh.PortMapping{Port: 8080, Protocol: appmesh.PortProtocolHTTP},
+							HealthCheck: &appmesh.HealthCheckPolicy{Port: &port8081, Protocol: appmesh.PortProtocolHTTP},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Virtual Node Listener Health Check port 8081 must match listener port 8080"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualNodeValidator{}
+			err := v.checkForHealthCheckPorts(tt.vn)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_virtualNodeValidator_checkForClientPolicyTLSPorts(t *testing.T) {
+	clientPolicyWithPorts := func(ports ...appmesh.PortNumber) *appmesh.ClientPolicy {
+		return &appmesh.ClientPolicy{
+			TLS: &appmesh.ClientPolicyTLS{
+				Ports: ports,
+				Validation: appmesh.TLSValidationContext{
+					Trust: appmesh.TLSValidationContextTrust{
+						File: &appmesh.TLSValidationContextFileTrust{
+							CertificateChain: "/certs/ca-chain.pem",
+						},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		vn      *appmesh.VirtualNode
+		wantErr error
+	}{
+		{
+			name: "client policy for all ports",
+			vn: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "vn-1"},
+				Spec: appmesh.VirtualNodeSpec{
+					BackendDefaults: &appmesh.BackendDefaults{
+						ClientPolicy: clientPolicyWithPorts(),
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "client policy filtered by ports",
+			vn: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "vn-1"},
+				Spec: appmesh.VirtualNodeSpec{
+					Backends: []appmesh.Backend{
+						{
+							VirtualService: appmesh.VirtualServiceBackend{
+								VirtualServiceRef: &appmesh.VirtualServiceReference{Name: "vs-1"},
+								ClientPolicy:      clientPolicyWithPorts(8443, 443),
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "backendDefaults client policy with invalid port",
+			vn: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "vn-1"},
+				Spec: appmesh.VirtualNodeSpec{
+					BackendDefaults: &appmesh.BackendDefaults{
+						ClientPolicy: clientPolicyWithPorts(443, 0),
+					},
+				},
+			},
+			wantErr: errors.New("VirtualNode-vn-1 backendDefaults: ClientPolicy TLS port 0 must be between 1 and 65535"),
+		},
+		{
+			name: "backend client policy with duplicate ports",
+			vn: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "vn-1"},
+				Spec: appmesh.VirtualNodeSpec{
+					Backends: []appmesh.Backend{
+						{
+							VirtualService: appmesh.VirtualServiceBackend{
+								VirtualServiceRef: &appmesh.VirtualServiceReference{Name: "vs-1"},
+							},
+						},
+						{
+							VirtualService: appmesh.VirtualServiceBackend{
+								VirtualServiceRef: &appmesh.VirtualServiceReference{Name: "vs-2"},
+								ClientPolicy:      clientPolicyWithPorts(443, 8443, 443),
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("VirtualNode-vn-1 backends[1]: ClientPolicy TLS has duplicate port 443"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualNodeValidator{}
+			err := v.checkForClientPolicyTLSPorts(tt.vn)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_virtualNodeValidator_checkForEnvoyLogLevel(t *testing.T) {
+	envoyLogLevel := func(logLevel string) *appmesh.EnvoyLogLevel {
+		l := appmesh.EnvoyLogLevel(logLevel)
+		return &l
+	}
+	tests := []struct {
+		name    string
+		vn      *appmesh.VirtualNode
+		wantErr error
+	}{
+		{
+			name: "logging not specified",
+			vn: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "vn-1"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "supported Envoy log level",
+			vn: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "vn-1"},
+				Spec: appmesh.VirtualNodeSpec{
+					Logging: &appmesh.Logging{
+						EnvoyLogLevel: envoyLogLevel("warning"),
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unsupported Envoy log level",
+			vn: &appmesh.VirtualNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "vn-1"},
+				Spec: appmesh.VirtualNodeSpec{
+					Logging: &appmesh.Logging{
+						EnvoyLogLevel: envoyLogLevel("verbose"),
+					},
+				},
+			},
+			wantErr: errors.New("VirtualNode-vn-1 has unsupported Envoy log level verbose, supported log levels: [trace debug info warning error critical off]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualNodeValidator{}
+			err := v.checkForEnvoyLogLevel(tt.vn)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package appmesh
 import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,13 +16,16 @@ import (
 const apiPathValidateAppMeshVirtualRouter = "/validate-appmesh-k8s-aws-v1beta2-virtualrouter"
 
 // NewVirtualRouterValidator returns a validator for VirtualRouter.
-func NewVirtualRouterValidator() *virtualRouterValidator {
-	return &virtualRouterValidator{}
+func NewVirtualRouterValidator(meshMembershipValidator mesh.MembershipValidator) *virtualRouterValidator {
+	return &virtualRouterValidator{
+		meshMembershipValidator: meshMembershipValidator,
+	}
 }
 
 var _ webhook.Validator = &virtualRouterValidator{}
 
 type virtualRouterValidator struct {
+	meshMembershipValidator mesh.MembershipValidator
 }
 
 func (v *virtualRouterValidator) Prototype(req admission.Request) (runtime.Object, error) {
@@ -30,9 +34,21 @@ func (v *virtualRouterValidator) Prototype(req admission.Request) (runtime.Objec
 
 func (v *virtualRouterValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
 	vr := obj.(*appmesh.VirtualRouter)
+	if err := v.meshMembershipValidator.Validate(ctx, vr, vr.Spec.MeshRef); err != nil {
+		return err
+	}
 	if err := v.checkForDuplicateRouteEntries(vr); err != nil {
 		return err
 	}
+	if err := v.checkForRouteProtocols(vr); err != nil {
+		return err
+	}
+	if err := v.checkForGRPCRouteMatches(vr); err != nil {
+		return err
+	}
+	if err := v.checkForHTTPRetryPolicies(vr); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -42,9 +58,21 @@ func (v *virtualRouterValidator) ValidateUpdate(ctx context.Context, obj runtime
 	if err := v.enforceFieldsImmutability(vr, oldVR); err != nil {
 		return err
 	}
+	if err := v.meshMembershipValidator.Validate(ctx, vr, vr.Spec.MeshRef); err != nil {
+		return err
+	}
 	if err := v.checkForDuplicateRouteEntries(vr); err != nil {
 		return err
 	}
+	if err := v.checkForRouteProtocols(vr); err != nil {
+		return err
+	}
+	if err := v.checkForGRPCRouteMatches(vr); err != nil {
+		return err
+	}
+	if err := v.checkForHTTPRetryPolicies(vr); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -80,6 +108,78 @@ func (v *virtualRouterValidator) checkForDuplicateRouteEntries(vr *appmesh.Virtu
 	return nil
 }
 
+func (v *virtualRouterValidator) checkForRouteProtocols(vr *appmesh.VirtualRouter) error {
+	//Route level timeouts are only applied to routes matching the listener protocol
+	listenerProtocols := make(map[appmesh.PortProtocol]bool, len(vr.Spec.Listeners))
+	for _, listener := range vr.Spec.Listeners {
+		listenerProtocols[listener.PortMapping.Protocol] = true
+	}
+	for _, route := range vr.Spec.Routes {
+		if err := v.checkRouteProtocol(route, listenerProtocols); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *virtualRouterValidator) checkRouteProtocol(route appmesh.Route, listenerProtocols map[appmesh.PortProtocol]bool) error {
+	var routeProtocols []appmesh.PortProtocol
+	if route.GRPCRoute != nil {
+		routeProtocols = append(routeProtocols, appmesh.PortProtocolGRPC)
+	}
+	if route.HTTPRoute != nil {
+		routeProtocols = append(routeProtocols, appmesh.PortProtocolHTTP)
+	}
+	if route.HTTP2Route != nil {
+		routeProtocols = append(routeProtocols, appmesh.PortProtocolHTTP2)
+	}
+	if route.TCPRoute != nil {
+		routeProtocols = append(routeProtocols, appmesh.PortProtocolTCP)
+	}
+	if len(routeProtocols) > 1 {
+		return errors.Errorf("Only one type of Route is allowed, route %s", route.Name)
+	}
+	if len(routeProtocols) == 0 || len(listenerProtocols) == 0 {
+		return nil
+	}
+	if !listenerProtocols[routeProtocols[0]] {
+		return errors.Errorf("Route %s protocol %s doesn't match any VirtualRouter listener protocol", route.Name, routeProtocols[0])
+	}
+	return nil
+}
+
+func (v *virtualRouterValidator) checkForGRPCRouteMatches(vr *appmesh.VirtualRouter) error {
+	//serviceName without methodName matches all methods of the service, but methodName without serviceName is rejected by App Mesh
+	for _, route := range vr.Spec.Routes {
+		if route.GRPCRoute == nil {
+			continue
+		}
+		match := route.GRPCRoute.Match
+		if match.MethodName != nil && match.ServiceName == nil {
+			return errors.Errorf("Route %s gRPC match methodName requires serviceName", route.Name)
+		}
+	}
+	return nil
+}
+
+func (v *virtualRouterValidator) checkForHTTPRetryPolicies(vr *appmesh.VirtualRouter) error {
+	for _, route := range vr.Spec.Routes {
+		var retryPolicy *appmesh.HTTPRetryPolicy
+		if route.HTTPRoute != nil {
+			retryPolicy = route.HTTPRoute.RetryPolicy
+		} else if route.HTTP2Route != nil {
+			retryPolicy = route.HTTP2Route.RetryPolicy
+		}
+		if retryPolicy == nil {
+			continue
+		}
+		if len(retryPolicy.HTTPRetryEvents) == 0 && len(retryPolicy.TCPRetryEvents) == 0 {
+			return errors.Errorf("Route %s retryPolicy must specify at least one of httpRetryEvents or tcpRetryEvents", route.Name)
+		}
+	}
+	return nil
+}
+
 // +kubebuilder:webhook:path=/validate-appmesh-k8s-aws-v1beta2-virtualrouter,mutating=false,failurePolicy=fail,groups=appmesh.k8s.aws,resources=virtualrouters,verbs=create;update,versions=v1beta2,name=vvirtualrouter.appmesh.k8s.aws,sideEffects=None,webhookVersions=v1beta1
 
 func (v *virtualRouterValidator) SetupWithManager(mgr ctrl.Manager) {
@@ -290,3 +290,347 @@ func Test_virtualRouterValidator_checkForDuplicateRouteEntries(t *testing.T) {
 		})
 	}
 }
+
+func Test_virtualRouterValidator_checkForRouteProtocols(t *testing.T) {
+	httpRoute := &appmesh.HTTPRoute{
+		Match: appmesh.HTTPRouteMatch{
+			Prefix: "/",
+		},
+		Action: appmesh.HTTPRouteAction{
+			WeightedTargets: []appmesh.WeightedTarget{
+				{
+					VirtualNodeRef: &appmesh.VirtualNodeReference{
+						Name: "testVN",
+					},
+					Weight: 1,
+				},
+			},
+		},
+		Timeout: &appmesh.HTTPTimeout{
+			PerRequest: &appmesh.Duration{
+				Unit:  appmesh.DurationUnitS,
+				Value: 30,
+			},
+		},
+	}
+	tcpRoute := &appmesh.TCPRoute{
+		Action: appmesh.TCPRouteAction{
+			WeightedTargets: []appmesh.WeightedTarget{
+				{
+					VirtualNodeRef: &appmesh.VirtualNodeReference{
+						Name: "testVN",
+					},
+					Weight: 1,
+				},
+			},
+		},
+		Timeout: &appmesh.TCPTimeout{
+			Idle: &appmesh.Duration{
+				Unit:  appmesh.DurationUnitS,
+				Value: 60,
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		listeners []appmesh.VirtualRouterListener
+		routes    []appmesh.Route
+		wantErr   error
+	}{
+		{
+			name: "http route with timeout on http listener",
+			listeners: []appmesh.VirtualRouterListener{
+				{PortMapping: appmesh.PortMapping{Port: 8080, Protocol: appmesh.PortProtocolHTTP}},
+			},
+			routes: []appmesh.Route{
+				{Name: "route-1", HTTPRoute: httpRoute},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "tcp route with timeout on tcp listener",
+			listeners: []appmesh.VirtualRouterListener{
+				{PortMapping: appmesh.PortMapping{Port: 8080, Protocol: appmesh.PortProtocolTCP}},
+			},
+			routes: []appmesh.Route{
+				{Name: "route-1", TCPRoute: tcpRoute},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "http route with timeout on tcp listener",
+			listeners: []appmesh.VirtualRouterListener{
+				{PortMapping: appmesh.PortMapping{Port: 8080, Protocol: appmesh.PortProtocolTCP}},
+			},
+			routes: []appmesh.Route{
+				{Name: "route-1", HTTPRoute: httpRoute},
+			},
+			wantErr: errors.New("Route route-1 protocol http doesn't match any VirtualRouter listener protocol"),
+		},
+		{
+			name: "http2 route on http listener",
+			listeners: []appmesh.VirtualRouterListener{
+				{PortMapping: appmesh.PortMapping{Port: 8080, Protocol: appmesh.PortProtocolHTTP}},
+			},
+			routes: []appmesh.Route{
+				{Name: "route-1", HTTP2Route: httpRoute},
+			},
+			wantErr: errors.New("Route route-1 protocol http2 doesn't match any VirtualRouter listener protocol"),
+		},
+		{
+			name: "route with multiple route types",
+			listeners: []appmesh.VirtualRouterListener{
+				{PortMapping: appmesh.PortMapping{Port: 8080, Protocol: appmesh.PortProtocolHTTP}},
+			},
+			routes: []appmesh.Route{
+				{Name: "route-1", HTTPRoute: httpRoute, TCPRoute: tcpRoute},
+			},
+			wantErr: errors.New("Only one type of Route is allowed, route route-1"),
+		},
+		{
+			name: "routes matching multiple listeners",
+			listeners: []appmesh.VirtualRouterListener{
+				{PortMapping: appmesh.PortMapping{Port: 8080, Protocol: appmesh.PortProtocolHTTP}},
+				{PortMapping: appmesh.PortMapping{Port: 9090, Protocol: appmesh.PortProtocolTCP}},
+			},
+			routes: []appmesh.Route{
+				{Name: "route-1", HTTPRoute: httpRoute},
+				{Name: "route-2", TCPRoute: tcpRoute},
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualRouterValidator{}
+			vr := &appmesh.VirtualRouter{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vr",
+				},
+				Spec: appmesh.VirtualRouterSpec{
+					Listeners: tt.listeners,
+					Routes:    tt.routes,
+				},
+			}
+			err := v.checkForRouteProtocols(vr)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_virtualRouterValidator_checkForGRPCRouteMatches(t *testing.T) {
+	grpcAction := appmesh.GRPCRouteAction{
+		WeightedTargets: []appmesh.WeightedTarget{
+			{
+				VirtualNodeRef: &appmesh.VirtualNodeReference{
+					Name: "testVN",
+				},
+				Weight: 1,
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		routes  []appmesh.Route
+		wantErr error
+	}{
+		{
+			name: "grpc route matching service and method",
+			routes: []appmesh.Route{
+				{
+					Name: "route1",
+					GRPCRoute: &appmesh.GRPCRoute{
+						Match: appmesh.GRPCRouteMatch{
+							ServiceName: aws.String("foo.foodomain.local"),
+							MethodName:  aws.String("stream"),
+						},
+						Action: grpcAction,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "grpc route matching service only",
+			routes: []appmesh.Route{
+				{
+					Name: "route1",
+					GRPCRoute: &appmesh.GRPCRoute{
+						Match: appmesh.GRPCRouteMatch{
+							ServiceName: aws.String("foo.foodomain.local"),
+						},
+						Action: grpcAction,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "grpc route matching method only",
+			routes: []appmesh.Route{
+				{
+					Name: "route1",
+					GRPCRoute: &appmesh.GRPCRoute{
+						Match: appmesh.GRPCRouteMatch{
+							MethodName: aws.String("stream"),
+						},
+						Action: grpcAction,
+					},
+				},
+			},
+			wantErr: errors.New("Route route1 gRPC match methodName requires serviceName"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualRouterValidator{}
+			vr := &appmesh.VirtualRouter{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vr",
+				},
+				Spec: appmesh.VirtualRouterSpec{
+					Routes: tt.routes,
+				},
+			}
+			err := v.checkForGRPCRouteMatches(vr)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_virtualRouterValidator_checkForHTTPRetryPolicies(t *testing.T) {
+	httpAction := appmesh.HTTPRouteAction{
+		WeightedTargets: []appmesh.WeightedTarget{
+			{
+				VirtualNodeRef: &appmesh.VirtualNodeReference{
+					Name: "testVN",
+				},
+				Weight: 1,
+			},
+		},
+	}
+	perRetryTimeout := appmesh.Duration{
+		Unit:  appmesh.DurationUnitMS,
+		Value: 200,
+	}
+	tests := []struct {
+		name    string
+		routes  []appmesh.Route
+		wantErr error
+	}{
+		{
+			name: "http route without retry policy",
+			routes: []appmesh.Route{
+				{
+					Name: "route1",
+					HTTPRoute: &appmesh.HTTPRoute{
+						Match:  appmesh.HTTPRouteMatch{Prefix: "/"},
+						Action: httpAction,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "http route with http retry events",
+			routes: []appmesh.Route{
+				{
+					Name: "route1",
+					HTTPRoute: &appmesh.HTTPRoute{
+						Match:  appmesh.HTTPRouteMatch{Prefix: "/"},
+						Action: httpAction,
+						RetryPolicy: &appmesh.HTTPRetryPolicy{
+							HTTPRetryEvents: []appmesh.HTTPRetryPolicyEvent{"server-error", "gateway-error", "client-error", "stream-error"},
+							MaxRetries:      3,
+							PerRetryTimeout: perRetryTimeout,
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "http2 route with tcp retry events",
+			routes: []appmesh.Route{
+				{
+					Name: "route1",
+					HTTP2Route: &appmesh.HTTPRoute{
+						Match:  appmesh.HTTPRouteMatch{Prefix: "/"},
+						Action: httpAction,
+						RetryPolicy: &appmesh.HTTPRetryPolicy{
+							TCPRetryEvents:  []appmesh.TCPRetryPolicyEvent{"connection-error"},
+							MaxRetries:      3,
+							PerRetryTimeout: perRetryTimeout,
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "http route with retry policy without events",
+			routes: []appmesh.Route{
+				{
+					Name: "route1",
+					HTTPRoute: &appmesh.HTTPRoute{
+						Match:  appmesh.HTTPRouteMatch{Prefix: "/"},
+						Action: httpAction,
+						RetryPolicy: &appmesh.HTTPRetryPolicy{
+							MaxRetries:      3,
+							PerRetryTimeout: perRetryTimeout,
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Route route1 retryPolicy must specify at least one of httpRetryEvents or tcpRetryEvents"),
+		},
+		{
+			name: "http2 route with retry policy without events",
+			routes: []appmesh.Route{
+				{
+					Name: "route1",
+					HTTP2Route: &appmesh.HTTPRoute{
+						Match:  appmesh.HTTPRouteMatch{Prefix: "/"},
+						Action: httpAction,
+						RetryPolicy: &appmesh.HTTPRetryPolicy{
+							HTTPRetryEvents: []appmesh.HTTPRetryPolicyEvent{},
+							MaxRetries:      3,
+							PerRetryTimeout: perRetryTimeout,
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Route route1 retryPolicy must specify at least one of httpRetryEvents or tcpRetryEvents"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualRouterValidator{}
+			vr := &appmesh.VirtualRouter{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "my-vr",
+				},
+				Spec: appmesh.VirtualRouterSpec{
+					Routes: tt.routes,
+				},
+			}
+			err := v.checkForHTTPRetryPolicies(vr)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -70,7 +70,7 @@ func (m *virtualServiceMutator) designateMeshMembership(ctx context.Context, vs
 	return nil
 }
 
-// +kubebuilder:webhook:path=/mutate-appmesh-k8s-aws-v1beta2-virtualservice,mutating=true,failurePolicy=fail,groups=appmesh.k8s.aws,resources=virtualservices,verbs=create;update,versions=v1beta2,name=mvirtualservice.appmesh.k8s.aws,sideEffects=None,webhookVersions=v1beta1
+// +kubebuilder:webhook:path=/mutate-appmesh-k8s-aws-v1beta2-virtualservice,mutating=true,failurePolicy=fail,groups=appmesh.k8s.aws,resources=virtualservices,verbs=create;update,versions=v1beta2,name=mvirtualservice.appmesh.k8s.aws,sideEffects=NoneOnDryRun,webhookVersions=v1beta1
 
 func (m *virtualServiceMutator) SetupWithManager(mgr ctrl.Manager) {
 	mgr.GetWebhookServer().Register(apiPathMutateAppMeshVirtualService, webhook.MutatingWebhookForMutator(m))
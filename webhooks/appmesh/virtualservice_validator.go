@@ -3,9 +3,13 @@ package appmesh
 import (
 	"context"
 	appmesh "github.com/aws/aws-app-mesh-controller-for-k8s/apis/appmesh/v1beta2"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/mesh"
+	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/virtualservice"
 	"github.com/aws/aws-app-mesh-controller-for-k8s/pkg/webhook"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"reflect"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -15,13 +19,16 @@ import (
 const apiPathValidateAppMeshVirtualService = "/validate-appmesh-k8s-aws-v1beta2-virtualservice"
 
 // NewVirtualServiceValidator returns a validator for VirtualService.
-func NewVirtualServiceValidator() *virtualServiceValidator {
-	return &virtualServiceValidator{}
+func NewVirtualServiceValidator(meshMembershipValidator mesh.MembershipValidator) *virtualServiceValidator {
+	return &virtualServiceValidator{
+		meshMembershipValidator: meshMembershipValidator,
+	}
 }
 
 var _ webhook.Validator = &virtualServiceValidator{}
 
 type virtualServiceValidator struct {
+	meshMembershipValidator mesh.MembershipValidator
 }
 
 func (v *virtualServiceValidator) Prototype(req admission.Request) (runtime.Object, error) {
@@ -29,6 +36,19 @@ func (v *virtualServiceValidator) Prototype(req admission.Request) (runtime.Obje
 }
 
 func (v *virtualServiceValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	vs := obj.(*appmesh.VirtualService)
+	if err := v.checkForAWSName(vs); err != nil {
+		return err
+	}
+	if err := v.meshMembershipValidator.Validate(ctx, vs, vs.Spec.MeshRef); err != nil {
+		return err
+	}
+	if err := v.checkForCanaryWeights(vs); err != nil {
+		return err
+	}
+	if err := virtualservice.ValidateReferenceOnly(vs); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -38,6 +58,15 @@ func (v *virtualServiceValidator) ValidateUpdate(ctx context.Context, obj runtim
 	if err := v.enforceFieldsImmutability(vs, oldVS); err != nil {
 		return err
 	}
+	if err := v.meshMembershipValidator.Validate(ctx, vs, vs.Spec.MeshRef); err != nil {
+		return err
+	}
+	if err := v.checkForCanaryWeights(vs); err != nil {
+		return err
+	}
+	if err := virtualservice.ValidateReferenceOnly(vs); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -60,6 +89,31 @@ func (v *virtualServiceValidator) enforceFieldsImmutability(vs *appmesh.VirtualS
 	return nil
 }
 
+// checkForAWSName makes sure the AppMesh name of vs is a valid DNS name, since it's the hostname applications use to reach vs.
+// awsName is immutable, so it's only checked on create.
+func (v *virtualServiceValidator) checkForAWSName(vs *appmesh.VirtualService) error {
+	awsName := aws.StringValue(vs.Spec.AWSName)
+	if errs := validation.IsDNS1123Subdomain(awsName); len(errs) != 0 {
+		return errors.Errorf("%s-%s has invalid awsName %s: %s", "VirtualService", vs.Name, awsName, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// checkForCanaryWeights makes sure the canary weights annotation is well formed and used with a virtualRouter provider.
+func (v *virtualServiceValidator) checkForCanaryWeights(vs *appmesh.VirtualService) error {
+	targets, err := virtualservice.ParseCanaryWeights(vs)
+	if err != nil {
+		return err
+	}
+	if targets == nil {
+		return nil
+	}
+	if vs.Spec.Provider == nil || vs.Spec.Provider.VirtualRouter == nil || vs.Spec.Provider.VirtualRouter.VirtualRouterRef == nil {
+		return errors.Errorf("annotation %s requires a virtualRouterRef provider", virtualservice.AnnotationCanaryWeights)
+	}
+	return nil
+}
+
 // +kubebuilder:webhook:path=/validate-appmesh-k8s-aws-v1beta2-virtualservice,mutating=false,failurePolicy=fail,groups=appmesh.k8s.aws,resources=virtualservices,verbs=create;update,versions=v1beta2,name=vvirtualservice.appmesh.k8s.aws,sideEffects=None,webhookVersions=v1beta1
 
 func (v *virtualServiceValidator) SetupWithManager(mgr ctrl.Manager) {
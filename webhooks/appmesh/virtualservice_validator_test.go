@@ -160,3 +160,132 @@ func Test_virtualServiceValidator_enforceFieldsImmutability(t *testing.T) {
 		})
 	}
 }
+
+func Test_virtualServiceValidator_checkForCanaryWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		vs      *appmesh.VirtualService
+		wantErr error
+	}{
+		{
+			name: "without canary weights",
+			vs: &appmesh.VirtualService{
+				Spec: appmesh.VirtualServiceSpec{},
+			},
+		},
+		{
+			name: "canary weights with virtualRouter provider",
+			vs: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/canaryWeights": "vn-stable=90,vn-canary=10",
+					},
+				},
+				Spec: appmesh.VirtualServiceSpec{
+					Provider: &appmesh.VirtualServiceProvider{
+						VirtualRouter: &appmesh.VirtualRouterServiceProvider{
+							VirtualRouterRef: &appmesh.VirtualRouterReference{Name: "vr-1"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "canary weights with virtualNode provider",
+			vs: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/canaryWeights": "vn-stable=90,vn-canary=10",
+					},
+				},
+				Spec: appmesh.VirtualServiceSpec{
+					Provider: &appmesh.VirtualServiceProvider{
+						VirtualNode: &appmesh.VirtualNodeServiceProvider{
+							VirtualNodeRef: &appmesh.VirtualNodeReference{Name: "vn-stable"},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("annotation appmesh.k8s.aws/canaryWeights requires a virtualRouterRef provider"),
+		},
+		{
+			name: "malformed canary weights",
+			vs: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"appmesh.k8s.aws/canaryWeights": "vn-stable=90,vn-canary=120",
+					},
+				},
+				Spec: appmesh.VirtualServiceSpec{
+					Provider: &appmesh.VirtualServiceProvider{
+						VirtualRouter: &appmesh.VirtualRouterServiceProvider{
+							VirtualRouterRef: &appmesh.VirtualRouterReference{Name: "vr-1"},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid annotation appmesh.k8s.aws/canaryWeights: weight 120 of virtualNode vn-canary must be an integer between 0 and 100"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualServiceValidator{}
+			err := v.checkForCanaryWeights(tt.vs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_virtualServiceValidator_checkForAWSName(t *testing.T) {
+	tests := []struct {
+		name    string
+		vs      *appmesh.VirtualService
+		wantErr error
+	}{
+		{
+			name: "defaulted awsName",
+			vs: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-vs"},
+				Spec: appmesh.VirtualServiceSpec{
+					AWSName: aws.String("my-vs.awesome-ns"),
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "awsName differs from name",
+			vs: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-vs"},
+				Spec: appmesh.VirtualServiceSpec{
+					AWSName: aws.String("payments.internal.example.com"),
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "awsName isn't a DNS name",
+			vs: &appmesh.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-vs"},
+				Spec: appmesh.VirtualServiceSpec{
+					AWSName: aws.String("Payments_Service"),
+				},
+			},
+			wantErr: errors.New("VirtualService-my-vs has invalid awsName Payments_Service: a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &virtualServiceValidator{}
+			err := v.checkForAWSName(tt.vs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}